@@ -16,6 +16,7 @@ package zlint
 
 import (
 	"encoding/pem"
+	"runtime"
 	"testing"
 
 	"github.com/zmap/zcrypto/x509"
@@ -99,6 +100,7 @@ func BenchmarkZlint(b *testing.B) {
 		b.Fatalf("Error parsing certificate: %s", err.Error())
 	}
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	b.Run("All lints", func(b *testing.B) {
 		var lintResult *ResultSet
@@ -109,6 +111,16 @@ func BenchmarkZlint(b *testing.B) {
 		globalLintResult = lintResult
 	})
 
+	b.Run("All lints concurrently", func(b *testing.B) {
+		var lintResult *ResultSet
+		opts := Options{Concurrency: runtime.NumCPU()}
+		for i := 0; i < b.N; i++ {
+			lintResult = LintCertificateWithOptions(x509Cert, opts)
+		}
+
+		globalLintResult = lintResult
+	})
+
 	names := lint.GlobalRegistry().Names()
 
 	b.Run("Fast lints", func(b *testing.B) {
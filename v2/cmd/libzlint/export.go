@@ -0,0 +1,43 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+/*
+#include <stdlib.h>
+*/
+import "C"
+
+import "unsafe"
+
+// zlint_lint_der lints the DER encoded certificate of the given length at der with
+// all of the registered lints. It returns a NUL terminated JSON document: the
+// certificate's ResultSet, or an object with an "error" member if it couldn't
+// be parsed. The caller must release the document with zlint_free.
+//
+//export zlint_lint_der
+func zlint_lint_der(der *C.uchar, length C.int) *C.char {
+	var data []byte
+	if der != nil && length > 0 {
+		data = C.GoBytes(unsafe.Pointer(der), length)
+	}
+	return C.CString(string(lintDER(data)))
+}
+
+// zlint_free releases a document returned by zlint_lint_der.
+//
+//export zlint_free
+func zlint_free(doc *C.char) {
+	C.free(unsafe.Pointer(doc))
+}
@@ -0,0 +1,64 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+// libzlint is a C shared library exposing zlint to callers in other languages,
+// such as Python (via ctypes or cffi), Rust and Java (via JNA or the Foreign
+// Function API), without running the zlint command. Build it with:
+//
+//	go build -buildmode=c-shared -o libzlint.so ./cmd/libzlint
+//
+// which also writes libzlint.h declaring:
+//
+//	char *zlint_lint_der(unsigned char *der, int length);
+//	void zlint_free(char *doc);
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2"
+)
+
+// main is required by -buildmode=c-shared but never called.
+func main() {}
+
+// lintDER lints the DER encoded certificate in der, returning the JSON
+// encoding of its ResultSet, or of an error object if it couldn't be parsed.
+// A panic must not unwind into the C caller, so it gives an error object too.
+func lintDER(der []byte) (doc []byte) {
+	defer func() {
+		if r := recover(); r != nil {
+			doc = errorJSON(fmt.Sprintf("panic while linting certificate: %v", r))
+		}
+	}()
+	c, err := x509.ParseCertificate(der)
+	if err != nil {
+		return errorJSON("unable to parse certificate: " + err.Error())
+	}
+	doc, err = json.Marshal(zlint.LintCertificate(c))
+	if err != nil {
+		return errorJSON("unable to encode results: " + err.Error())
+	}
+	return doc
+}
+
+// errorJSON returns the JSON error object for message.
+func errorJSON(message string) []byte {
+	doc, _ := json.Marshal(struct {
+		Error string `json:"error"`
+	}{message})
+	return doc
+}
@@ -0,0 +1,53 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"testing"
+
+	"github.com/zmap/zlint/v2"
+	"github.com/zmap/zlint/v2/lint"
+)
+
+func TestLintDER(t *testing.T) {
+	pemBytes, err := ioutil.ReadFile("../../testdata/badRsaExp.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(pemBytes)
+
+	var rs zlint.ResultSet
+	if err := json.Unmarshal(lintDER(block.Bytes), &rs); err != nil {
+		t.Fatal(err)
+	}
+	if result := rs.Results["e_rsa_public_exponent_not_odd"]; result == nil || result.Status != lint.Error {
+		t.Errorf("expected e_rsa_public_exponent_not_odd to be an error, got %v", result)
+	}
+
+	var failure struct {
+		Error string `json:"error"`
+	}
+	for _, der := range [][]byte{nil, []byte("not a certificate")} {
+		if err := json.Unmarshal(lintDER(der), &failure); err != nil {
+			t.Fatal(err)
+		}
+		if failure.Error == "" {
+			t.Errorf("expected an error for %q", der)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"go/format"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/zmap/zlint/v2/util"
+)
+
+var (
+	// blocklistName matches the names of the RSA blocklist files shipped in the
+	// Debian openssl-blacklist and openssl-blacklist-extra packages, e.g.
+	// "blacklist.RSA-2048", and captures the modulus size.
+	blocklistName = regexp.MustCompile(`^blacklist\.RSA-([0-9]+)$`)
+
+	// weakKeysMapTemplate is a template that produces a Golang source code file
+	// in the "util" package containing a single member variable, a map of RSA
+	// modulus sizes to gzip compressed, sorted fingerprints called
+	// `debianWeakKeys`.
+	weakKeysMapTemplate = template.Must(template.New("weakKeysMapTemplate").Parse(
+		`// Code generated by go generate; DO NOT EDIT.
+// This file was generated by zlint-debian-weak-keys-update.
+
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package util
+
+var debianWeakKeys = map[int]string{
+{{- range $bits, $data := . }}
+	{{ $bits }}: {{ $data }},
+{{- end }}
+}
+`))
+)
+
+// readBlocklist reads the fingerprints from an openssl-blacklist file. Each
+// non-comment line of the file holds the last 20 hex characters of the SHA-1
+// hash of a weak key's modulus.
+func readBlocklist(r io.Reader) ([][]byte, error) {
+	var fingerprints [][]byte
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fp, err := hex.DecodeString(line)
+		if err != nil || len(fp) != util.DebianWeakKeyFingerprintLen {
+			return nil, fmt.Errorf("invalid fingerprint %q", line)
+		}
+		fingerprints = append(fingerprints, fp)
+	}
+	return fingerprints, scanner.Err()
+}
+
+// compress sorts and deduplicates the fingerprints and returns them
+// concatenated and gzip compressed.
+func compress(fingerprints [][]byte) ([]byte, error) {
+	sort.Slice(fingerprints, func(i, j int) bool {
+		return bytes.Compare(fingerprints[i], fingerprints[j]) < 0
+	})
+	var buf bytes.Buffer
+	w, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	for i, fp := range fingerprints {
+		if i > 0 && bytes.Equal(fp, fingerprints[i-1]) {
+			continue
+		}
+		if _, err := w.Write(fp); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// renderWeakKeysMap reads the given blocklist files, merging files for the same
+// modulus size (e.g. from different architectures), and renders the
+// weakKeysMapTemplate to the provided writer.
+func renderWeakKeysMap(writer io.Writer, paths []string) error {
+	bySize := make(map[int][][]byte)
+	for _, path := range paths {
+		match := blocklistName.FindStringSubmatch(filepath.Base(path))
+		if match == nil {
+			return fmt.Errorf("%q is not named like an RSA blocklist (blacklist.RSA-<bits>)", path)
+		}
+		bits, _ := strconv.Atoi(match[1])
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		fingerprints, err := readBlocklist(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("error reading %q: %s", path, err)
+		}
+		bySize[bits] = append(bySize[bits], fingerprints...)
+	}
+
+	templateData := make(map[int]template.HTML, len(bySize))
+	for bits, fingerprints := range bySize {
+		compressed, err := compress(fingerprints)
+		if err != nil {
+			return err
+		}
+		templateData[bits] = template.HTML(strconv.QuoteToASCII(string(compressed)))
+	}
+
+	var buf bytes.Buffer
+	if err := weakKeysMapTemplate.Execute(&buf, templateData); err != nil {
+		return err
+	}
+	// format the buffer so it won't trip up the `gofmt_test.go` checks
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(formatted)
+	return err
+}
+
+// init sets up command line flags
+func init() {
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s [flags] [output file] blacklist.RSA-<bits>...\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "\nThe blocklist files are found in /usr/share/openssl-blacklist/ after\n")
+		fmt.Fprintf(os.Stderr, "installing the Debian openssl-blacklist and openssl-blacklist-extra packages.\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+}
+
+// main handles rendering the weak key map to either standard out (when the
+// first argument is a blocklist) or to the filename given as the first
+// argument. If an error occurs it is printed to standard err and the program
+// terminates with a non-zero exit status.
+func main() {
+	errQuit := func(err error) {
+		fmt.Fprintf(os.Stderr, "error updating Debian weak key map: %s\n", err)
+		os.Exit(1)
+	}
+
+	args := flag.Args()
+	writer := os.Stdout
+	if len(args) > 0 && strings.HasSuffix(args[0], ".go") {
+		f, err := os.OpenFile(args[0], os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0664)
+		if err != nil {
+			errQuit(err)
+		}
+		defer f.Close()
+		writer = f
+		args = args[1:]
+	}
+
+	if err := renderWeakKeysMap(writer, args); err != nil {
+		errQuit(err)
+	}
+}
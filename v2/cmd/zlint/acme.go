@@ -0,0 +1,371 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	_ "crypto/sha256" // register crypto.SHA256 for signing
+	_ "crypto/sha512" // register crypto.SHA384 for signing
+	stdx509 "crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/zmap/zcrypto/x509"
+)
+
+// acmeClient is a minimal RFC 8555 client able to make POST-as-GET requests on
+// behalf of an existing account. It is only capable of what is needed to
+// download the certificate chain of a finalized order.
+type acmeClient struct {
+	http      *http.Client
+	key       crypto.Signer
+	directory struct {
+		NewNonce   string `json:"newNonce"`
+		NewAccount string `json:"newAccount"`
+	}
+	accountURL string
+	nonce      string
+}
+
+// acmeOrderObject is the subset of an RFC 8555 order object used by zlint.
+type acmeOrderObject struct {
+	Status      string `json:"status"`
+	Certificate string `json:"certificate"`
+}
+
+// acmeProblem is an RFC 7807 problem document returned by ACME servers.
+type acmeProblem struct {
+	Type   string `json:"type"`
+	Detail string `json:"detail"`
+}
+
+// fetchACMEChain downloads the certificate chain issued for the order at
+// orderURL (which may be relative to directoryURL) using the account
+// identified by the private key in keyFile. The chain is returned leaf first.
+func fetchACMEChain(directoryURL, orderURL, keyFile string) ([]*x509.Certificate, error) {
+	key, err := loadACMEAccountKey(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	client := &acmeClient{
+		http: &http.Client{Timeout: 30 * time.Second},
+		key:  key,
+	}
+	if err := client.loadDirectory(directoryURL); err != nil {
+		return nil, err
+	}
+	if err := client.lookupAccount(); err != nil {
+		return nil, err
+	}
+
+	base, err := url.Parse(directoryURL)
+	if err != nil {
+		return nil, err
+	}
+	ref, err := url.Parse(orderURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order %q: %v", orderURL, err)
+	}
+	orderBody, _, err := client.postAsGet(base.ResolveReference(ref).String(), "application/json")
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch order: %v", err)
+	}
+	var order acmeOrderObject
+	if err := json.Unmarshal(orderBody, &order); err != nil {
+		return nil, fmt.Errorf("unable to decode order: %v", err)
+	}
+	if order.Status != "valid" || order.Certificate == "" {
+		return nil, fmt.Errorf("order has status %q and no certificate to lint", order.Status)
+	}
+
+	chainPEM, _, err := client.postAsGet(order.Certificate, "application/pem-certificate-chain")
+	if err != nil {
+		return nil, fmt.Errorf("unable to download certificate: %v", err)
+	}
+	chain, err := parsePEMChain(chainPEM)
+	if err != nil {
+		return nil, fmt.Errorf("downloaded certificate chain: %v", err)
+	}
+	return chain, nil
+}
+
+// parsePEMChain parses the CERTIFICATE blocks in a PEM certificate chain such
+// as an application/pem-certificate-chain document, in the order they appear.
+// Other blocks are ignored.
+func parsePEMChain(chainPEM []byte) ([]*x509.Certificate, error) {
+	var chain []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, chainPEM = pem.Decode(chainPEM)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		c, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse certificate %d: %v", len(chain), err)
+		}
+		chain = append(chain, c)
+	}
+	if len(chain) == 0 {
+		return nil, errors.New("contained no certificates")
+	}
+	return chain, nil
+}
+
+// loadACMEAccountKey reads a PEM encoded ECDSA or RSA private key in PKCS#1,
+// SEC 1 or PKCS#8 form.
+func loadACMEAccountKey(keyFile string) (crypto.Signer, error) {
+	keyPEM, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read ACME account key: %v", err)
+	}
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, errors.New("unable to PEM decode ACME account key")
+	}
+	if key, err := stdx509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := stdx509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := stdx509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse ACME account key: %v", err)
+	}
+	switch k := key.(type) {
+	case *ecdsa.PrivateKey:
+		return k, nil
+	case *rsa.PrivateKey:
+		return k, nil
+	default:
+		return nil, fmt.Errorf("unsupported ACME account key type %T", key)
+	}
+}
+
+func (c *acmeClient) loadDirectory(directoryURL string) error {
+	resp, err := c.http.Get(directoryURL)
+	if err != nil {
+		return fmt.Errorf("unable to fetch ACME directory: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("ACME directory returned HTTP status %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&c.directory); err != nil {
+		return fmt.Errorf("unable to decode ACME directory: %v", err)
+	}
+	if c.directory.NewNonce == "" || c.directory.NewAccount == "" {
+		return errors.New("ACME directory is missing newNonce or newAccount")
+	}
+	return nil
+}
+
+// lookupAccount finds the URL of the existing account for the client's key
+// (RFC 8555 Section 7.3.1).
+func (c *acmeClient) lookupAccount() error {
+	_, headers, err := c.post(c.directory.NewAccount, []byte(`{"onlyReturnExisting":true}`), "application/json")
+	if err != nil {
+		return fmt.Errorf("unable to find ACME account for key: %v", err)
+	}
+	c.accountURL = headers.Get("Location")
+	if c.accountURL == "" {
+		return errors.New("ACME server did not return an account URL")
+	}
+	return nil
+}
+
+// postAsGet makes a POST-as-GET request (RFC 8555 Section 6.3) for the given
+// resource.
+func (c *acmeClient) postAsGet(resource, accept string) ([]byte, http.Header, error) {
+	return c.post(resource, []byte{}, accept)
+}
+
+// post sends a JWS signed request with the given payload, retrying once if the
+// server rejects the nonce.
+func (c *acmeClient) post(resource string, payload []byte, accept string) ([]byte, http.Header, error) {
+	body, headers, problem, err := c.postOnce(resource, payload, accept)
+	if problem != nil && problem.Type == "urn:ietf:params:acme:error:badNonce" {
+		body, headers, problem, err = c.postOnce(resource, payload, accept)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if problem != nil {
+		return nil, nil, fmt.Errorf("%s: %s", problem.Type, problem.Detail)
+	}
+	return body, headers, nil
+}
+
+func (c *acmeClient) postOnce(resource string, payload []byte, accept string) ([]byte, http.Header, *acmeProblem, error) {
+	if c.nonce == "" {
+		if err := c.newNonce(); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	jws, err := c.sign(resource, payload)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	c.nonce = ""
+
+	req, err := http.NewRequest(http.MethodPost, resource, bytes.NewReader(jws))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	req.Header.Set("Content-Type", "application/jose+json")
+	req.Header.Set("Accept", accept)
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	defer resp.Body.Close()
+	c.nonce = resp.Header.Get("Replay-Nonce")
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if resp.StatusCode >= 400 {
+		problem := &acmeProblem{}
+		if err := json.Unmarshal(body, problem); err != nil || problem.Type == "" {
+			return nil, nil, nil, fmt.Errorf("HTTP status %d from %s", resp.StatusCode, resource)
+		}
+		return nil, nil, problem, nil
+	}
+	return body, resp.Header, nil, nil
+}
+
+func (c *acmeClient) newNonce() error {
+	resp, err := c.http.Head(c.directory.NewNonce)
+	if err != nil {
+		return fmt.Errorf("unable to fetch ACME nonce: %v", err)
+	}
+	resp.Body.Close()
+	c.nonce = resp.Header.Get("Replay-Nonce")
+	if c.nonce == "" {
+		return errors.New("ACME server did not return a nonce")
+	}
+	return nil
+}
+
+// sign produces a JWS in flattened JSON serialization (RFC 8555 Section 6.2).
+// Requests are identified by the account URL once known and by the account's
+// JWK before that.
+func (c *acmeClient) sign(resource string, payload []byte) ([]byte, error) {
+	protected := map[string]interface{}{
+		"nonce": c.nonce,
+		"url":   resource,
+	}
+	var hash crypto.Hash
+	switch k := c.key.(type) {
+	case *ecdsa.PrivateKey:
+		switch k.Curve {
+		case elliptic.P256():
+			protected["alg"], hash = "ES256", crypto.SHA256
+		case elliptic.P384():
+			protected["alg"], hash = "ES384", crypto.SHA384
+		default:
+			return nil, errors.New("unsupported ACME account key curve")
+		}
+	case *rsa.PrivateKey:
+		protected["alg"], hash = "RS256", crypto.SHA256
+	}
+	if c.accountURL != "" {
+		protected["kid"] = c.accountURL
+	} else {
+		protected["jwk"] = jwkFor(c.key.Public())
+	}
+
+	protectedJSON, err := json.Marshal(protected)
+	if err != nil {
+		return nil, err
+	}
+	encProtected := base64.RawURLEncoding.EncodeToString(protectedJSON)
+	encPayload := base64.RawURLEncoding.EncodeToString(payload)
+
+	h := hash.New()
+	h.Write([]byte(encProtected + "." + encPayload))
+	digest := h.Sum(nil)
+
+	var sig []byte
+	switch k := c.key.(type) {
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest)
+		if err != nil {
+			return nil, err
+		}
+		size := (k.Curve.Params().BitSize + 7) / 8
+		sig = append(padBigInt(r, size), padBigInt(s, size)...)
+	case *rsa.PrivateKey:
+		sig, err = rsa.SignPKCS1v15(rand.Reader, k, hash, digest)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return json.Marshal(map[string]string{
+		"protected": encProtected,
+		"payload":   encPayload,
+		"signature": base64.RawURLEncoding.EncodeToString(sig),
+	})
+}
+
+// jwkFor returns the JSON Web Key (RFC 7517) representation of pub.
+func jwkFor(pub crypto.PublicKey) map[string]string {
+	b64 := func(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+	switch k := pub.(type) {
+	case *ecdsa.PublicKey:
+		size := (k.Curve.Params().BitSize + 7) / 8
+		return map[string]string{
+			"kty": "EC",
+			"crv": k.Curve.Params().Name,
+			"x":   b64(padBigInt(k.X, size)),
+			"y":   b64(padBigInt(k.Y, size)),
+		}
+	case *rsa.PublicKey:
+		return map[string]string{
+			"kty": "RSA",
+			"n":   b64(k.N.Bytes()),
+			"e":   b64(big.NewInt(int64(k.E)).Bytes()),
+		}
+	}
+	return nil
+}
+
+// padBigInt returns the big-endian bytes of n left padded with zeroes to size.
+func padBigInt(n *big.Int, size int) []byte {
+	b := n.Bytes()
+	if len(b) >= size {
+		return b
+	}
+	return append(make([]byte, size-len(b)), b...)
+}
@@ -0,0 +1,422 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	stdx509 "crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// testACMEServer is an RFC 8555 server that only supports looking up an
+// existing account and downloading the certificate of a single valid order.
+// Every request must carry a fresh nonce and be signed by the account key.
+type testACMEServer struct {
+	*httptest.Server
+	t          *testing.T
+	accountKey crypto.PublicKey
+	chainPEM   []byte
+	// orderStatus is the status of the order, "valid" unless set.
+	orderStatus string
+	// rejectNonce, if set, rejects the first signed request with a
+	// badNonce error.
+	rejectNonce bool
+
+	mu      sync.Mutex
+	nonces  map[string]bool
+	counter int
+}
+
+func newTestACMEServer(t *testing.T, accountKey crypto.PublicKey, chainPEM []byte) *testACMEServer {
+	s := &testACMEServer{t: t, accountKey: accountKey, chainPEM: chainPEM, nonces: make(map[string]bool)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/directory", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"newNonce":   s.URL + "/new-nonce",
+			"newAccount": s.URL + "/new-account",
+		})
+	})
+	mux.HandleFunc("/new-nonce", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Replay-Nonce", s.nonce())
+	})
+	mux.HandleFunc("/new-account", func(w http.ResponseWriter, r *http.Request) {
+		payload, ok := s.verify(w, r, true)
+		if !ok {
+			return
+		}
+		if string(payload) != `{"onlyReturnExisting":true}` {
+			s.problem(w, http.StatusBadRequest, "malformed", "expected an account lookup")
+			return
+		}
+		w.Header().Set("Location", s.URL+"/account/1")
+		_, _ = w.Write([]byte(`{"status":"valid"}`))
+	})
+	mux.HandleFunc("/order/1", func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := s.verify(w, r, false); !ok {
+			return
+		}
+		status := s.orderStatus
+		if status == "" {
+			status = "valid"
+		}
+		_ = json.NewEncoder(w).Encode(acmeOrderObject{Status: status, Certificate: s.URL + "/cert/1"})
+	})
+	mux.HandleFunc("/cert/1", func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := s.verify(w, r, false); !ok {
+			return
+		}
+		if accept := r.Header.Get("Accept"); accept != "application/pem-certificate-chain" {
+			s.t.Errorf("expected a PEM certificate chain to be requested, got %q", accept)
+		}
+		w.Header().Set("Content-Type", "application/pem-certificate-chain")
+		_, _ = w.Write(s.chainPEM)
+	})
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+// nonce returns a new nonce and adds it to the unused nonces.
+func (s *testACMEServer) nonce() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.counter++
+	nonce := fmt.Sprintf("nonce-%d", s.counter)
+	s.nonces[nonce] = true
+	return nonce
+}
+
+// useNonce reports whether nonce was issued and not yet used, marking it as
+// used.
+func (s *testACMEServer) useNonce(nonce string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.nonces[nonce] {
+		return false
+	}
+	delete(s.nonces, nonce)
+	return true
+}
+
+func (s *testACMEServer) problem(w http.ResponseWriter, status int, problemType, detail string) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Replay-Nonce", s.nonce())
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(acmeProblem{Type: "urn:ietf:params:acme:error:" + problemType, Detail: detail})
+}
+
+// verify checks the JWS in the body of r, replying with a problem document if
+// it is invalid. Requests must identify the account by JWK if byJWK is set,
+// and by the account URL otherwise. It returns the payload of a valid request.
+func (s *testACMEServer) verify(w http.ResponseWriter, r *http.Request, byJWK bool) ([]byte, bool) {
+	if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/jose+json" {
+		s.problem(w, http.StatusMethodNotAllowed, "malformed", "expected a JWS POST")
+		return nil, false
+	}
+	var jws struct {
+		Protected string `json:"protected"`
+		Payload   string `json:"payload"`
+		Signature string `json:"signature"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&jws); err != nil {
+		s.problem(w, http.StatusBadRequest, "malformed", err.Error())
+		return nil, false
+	}
+	protectedJSON, err := base64.RawURLEncoding.DecodeString(jws.Protected)
+	if err != nil {
+		s.problem(w, http.StatusBadRequest, "malformed", err.Error())
+		return nil, false
+	}
+	var protected struct {
+		Alg   string            `json:"alg"`
+		Nonce string            `json:"nonce"`
+		URL   string            `json:"url"`
+		KID   string            `json:"kid"`
+		JWK   map[string]string `json:"jwk"`
+	}
+	if err := json.Unmarshal(protectedJSON, &protected); err != nil {
+		s.problem(w, http.StatusBadRequest, "malformed", err.Error())
+		return nil, false
+	}
+
+	s.mu.Lock()
+	reject := s.rejectNonce
+	s.rejectNonce = false
+	s.mu.Unlock()
+	if reject || !s.useNonce(protected.Nonce) {
+		s.problem(w, http.StatusBadRequest, "badNonce", "nonce "+protected.Nonce+" is not valid")
+		return nil, false
+	}
+	if protected.URL != s.URL+r.URL.Path {
+		s.problem(w, http.StatusUnauthorized, "unauthorized", "JWS url does not match the request")
+		return nil, false
+	}
+
+	key := s.accountKey
+	if byJWK {
+		if protected.KID != "" {
+			s.problem(w, http.StatusBadRequest, "malformed", "expected a jwk rather than a kid")
+			return nil, false
+		}
+		if key, err = parseTestJWK(protected.JWK); err != nil {
+			s.problem(w, http.StatusBadRequest, "malformed", err.Error())
+			return nil, false
+		}
+	} else if protected.KID != s.URL+"/account/1" || protected.JWK != nil {
+		s.problem(w, http.StatusUnauthorized, "unauthorized", "expected the account URL as the kid")
+		return nil, false
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(jws.Signature)
+	if err != nil {
+		s.problem(w, http.StatusBadRequest, "malformed", err.Error())
+		return nil, false
+	}
+	if err := verifyTestJWS(protected.Alg, key, []byte(jws.Protected+"."+jws.Payload), signature); err != nil {
+		s.problem(w, http.StatusUnauthorized, "unauthorized", err.Error())
+		return nil, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(jws.Payload)
+	if err != nil {
+		s.problem(w, http.StatusBadRequest, "malformed", err.Error())
+		return nil, false
+	}
+	w.Header().Set("Replay-Nonce", s.nonce())
+	return payload, true
+}
+
+// parseTestJWK parses the public key in an EC or RSA JWK, requiring EC
+// coordinates to be padded to the size of the curve.
+func parseTestJWK(jwk map[string]string) (crypto.PublicKey, error) {
+	b64 := func(name string) (*big.Int, int, error) {
+		b, err := base64.RawURLEncoding.DecodeString(jwk[name])
+		return new(big.Int).SetBytes(b), len(b), err
+	}
+	switch jwk["kty"] {
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk["crv"] {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", jwk["crv"])
+		}
+		size := (curve.Params().BitSize + 7) / 8
+		x, xLen, err := b64("x")
+		if err != nil {
+			return nil, err
+		}
+		y, yLen, err := b64("y")
+		if err != nil {
+			return nil, err
+		}
+		if xLen != size || yLen != size {
+			return nil, fmt.Errorf("coordinates of %d and %d bytes, expected %d", xLen, yLen, size)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "RSA":
+		n, _, err := b64("n")
+		if err != nil {
+			return nil, err
+		}
+		e, _, err := b64("e")
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	}
+	return nil, fmt.Errorf("unsupported key type %q", jwk["kty"])
+}
+
+// verifyTestJWS verifies a JWS signature made with alg.
+func verifyTestJWS(alg string, key crypto.PublicKey, signed, signature []byte) error {
+	switch alg {
+	case "ES256", "ES384":
+		k, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return errors.New("algorithm does not match the key")
+		}
+		var digest []byte
+		if alg == "ES256" {
+			sum := sha256.Sum256(signed)
+			digest = sum[:]
+		} else {
+			sum := sha512.Sum384(signed)
+			digest = sum[:]
+		}
+		size := (k.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return fmt.Errorf("signature of %d bytes, expected %d", len(signature), 2*size)
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(k, digest, r, s) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	case "RS256":
+		k, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return errors.New("algorithm does not match the key")
+		}
+		digest := sha256.Sum256(signed)
+		return rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], signature)
+	}
+	return fmt.Errorf("unsupported algorithm %q", alg)
+}
+
+// writeTestKey writes key to a PEM file in dir and returns its path.
+func writeTestKey(t *testing.T, dir, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(dir, strings.ReplaceAll(blockType, " ", "_")+".pem")
+	if err := ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestFetchACMEChain(t *testing.T) {
+	chain := newStapleTestChain(t, false)
+	chainPEM := append(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: chain.leaf.Raw}),
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: chain.issuer.Raw})...)
+
+	p256, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sec1, err := stdx509.MarshalECPrivateKey(p256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkcs8, err := stdx509.MarshalPKCS8PrivateKey(p384)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	testCases := []struct {
+		name    string
+		keyFile string
+		key     crypto.PublicKey
+	}{
+		{"ES256 SEC 1", writeTestKey(t, dir, "EC PRIVATE KEY", sec1), p256.Public()},
+		{"ES384 PKCS#8", writeTestKey(t, dir, "PRIVATE KEY", pkcs8), p384.Public()},
+		{"RS256 PKCS#1", writeTestKey(t, dir, "RSA PRIVATE KEY", stdx509.MarshalPKCS1PrivateKey(rsaKey)), rsaKey.Public()},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestACMEServer(t, tc.key, chainPEM)
+			certs, err := fetchACMEChain(server.URL+"/directory", "/order/1", tc.keyFile)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(certs) != 2 || !bytes.Equal(certs[0].Raw, chain.leaf.Raw) || !bytes.Equal(certs[1].Raw, chain.issuer.Raw) {
+				t.Errorf("expected the leaf and issuer, got %d certificates", len(certs))
+			}
+		})
+	}
+
+	t.Run("bad nonce retried", func(t *testing.T) {
+		server := newTestACMEServer(t, p256.Public(), chainPEM)
+		server.rejectNonce = true
+		if _, err := fetchACMEChain(server.URL+"/directory", server.URL+"/order/1", testCases[0].keyFile); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	t.Run("wrong account key", func(t *testing.T) {
+		server := newTestACMEServer(t, p384.Public(), chainPEM)
+		_, err := fetchACMEChain(server.URL+"/directory", "/order/1", testCases[0].keyFile)
+		if err == nil || !strings.Contains(err.Error(), "unauthorized") {
+			t.Errorf("expected an unauthorized error, got %v", err)
+		}
+	})
+	t.Run("order not valid", func(t *testing.T) {
+		server := newTestACMEServer(t, p256.Public(), chainPEM)
+		server.orderStatus = "processing"
+		_, err := fetchACMEChain(server.URL+"/directory", "/order/1", testCases[0].keyFile)
+		if err == nil || !strings.Contains(err.Error(), `"processing"`) {
+			t.Errorf("expected an error for the processing order, got %v", err)
+		}
+	})
+}
+
+func TestPadBigInt(t *testing.T) {
+	testCases := []struct {
+		n        *big.Int
+		size     int
+		expected []byte
+	}{
+		{big.NewInt(1), 4, []byte{0, 0, 0, 1}},
+		{big.NewInt(0x0102), 2, []byte{1, 2}},
+		{big.NewInt(0x010203), 2, []byte{1, 2, 3}},
+		{big.NewInt(0), 2, []byte{0, 0}},
+	}
+	for _, tc := range testCases {
+		if actual := padBigInt(tc.n, tc.size); !bytes.Equal(actual, tc.expected) {
+			t.Errorf("padBigInt(%v, %d): expected %x, got %x", tc.n, tc.size, tc.expected, actual)
+		}
+	}
+}
+
+func TestJWKForPadsCoordinates(t *testing.T) {
+	// Find a key with a short coordinate, which must still be encoded with
+	// the full size of the curve.
+	for i := 0; i < 1000; i++ {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(key.X.Bytes()) == 32 && len(key.Y.Bytes()) == 32 {
+			continue
+		}
+		pub, err := parseTestJWK(jwkFor(key.Public()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if k := pub.(*ecdsa.PublicKey); k.X.Cmp(key.X) != 0 || k.Y.Cmp(key.Y) != 0 {
+			t.Error("JWK does not round trip")
+		}
+		return
+	}
+	t.Skip("no key with a short coordinate generated")
+}
@@ -0,0 +1,234 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+)
+
+// maxAdmissionReviewSize bounds the size of an AdmissionReview request body.
+// The API server itself limits objects to a little over 1.5MB.
+const maxAdmissionReviewSize = 3 << 20
+
+// admissionReview is the subset of a Kubernetes admission.k8s.io/v1 (or
+// v1beta1) AdmissionReview used by the admission webhook.
+type admissionReview struct {
+	APIVersion string             `json:"apiVersion"`
+	Kind       string             `json:"kind"`
+	Request    *admissionRequest  `json:"request,omitempty"`
+	Response   *admissionResponse `json:"response,omitempty"`
+}
+
+type admissionRequest struct {
+	UID  string `json:"uid"`
+	Kind struct {
+		Group string `json:"group"`
+		Kind  string `json:"kind"`
+	} `json:"kind"`
+	Namespace string          `json:"namespace"`
+	Name      string          `json:"name"`
+	Object    json.RawMessage `json:"object"`
+}
+
+type admissionResponse struct {
+	UID      string           `json:"uid"`
+	Allowed  bool             `json:"allowed"`
+	Status   *admissionStatus `json:"status,omitempty"`
+	Warnings []string         `json:"warnings,omitempty"`
+}
+
+type admissionStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// admissionWebhook reviews the certificates in Kubernetes objects, denying
+// objects with results at least as severe as deny and warning about those
+// with results at least as severe as warn. A zero threshold disables denying
+// or warning.
+type admissionWebhook struct {
+	registry lint.Registry
+	deny     lint.LintStatus
+	warn     lint.LintStatus
+}
+
+// doAdmissionWebhook runs a Kubernetes validating admission webhook, configured
+// by the flags in args (the arguments following "admission-webhook").
+func doAdmissionWebhook(args []string, registry lint.Registry) {
+	webhookFlags := flag.NewFlagSet("admission-webhook", flag.ExitOnError)
+	listen := webhookFlags.String("listen", ":8443", "Address to listen on")
+	certFile := webhookFlags.String("tls-cert", "", "PEM file containing the webhook's serving certificate (required, the API server only calls webhooks over HTTPS)")
+	keyFile := webhookFlags.String("tls-key", "", "PEM file containing the private key for -tls-cert")
+	deny := webhookFlags.String("deny-threshold", "error", "Least severe result that denies the object: one of info, warn, error or fatal, or none to never deny")
+	warn := webhookFlags.String("warn-threshold", "warn", "Least severe result that returns a warning to the client: one of info, warn, error or fatal, or none")
+	_ = webhookFlags.Parse(args)
+	if *certFile == "" || *keyFile == "" {
+		log.Fatal("admission-webhook requires -tls-cert and -tls-key")
+	}
+	h := &admissionWebhook{registry: registry}
+	var err error
+	if h.deny, err = parseOptionalThreshold("deny-threshold", *deny); err != nil {
+		log.Fatal(err)
+	}
+	if h.warn, err = parseOptionalThreshold("warn-threshold", *warn); err != nil {
+		log.Fatal(err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/validate", h)
+	server := &http.Server{
+		Addr:         *listen,
+		Handler:      mux,
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 10 * time.Second,
+	}
+	log.Infof("listening on %s", *listen)
+	log.Fatal(server.ListenAndServeTLS(*certFile, *keyFile))
+}
+
+// parseOptionalThreshold parses a threshold like parseThreshold, except that
+// none is also accepted and parsed as zero.
+func parseOptionalThreshold(flagName, value string) (lint.LintStatus, error) {
+	if value == "none" {
+		return 0, nil
+	}
+	return parseThreshold(flagName, value)
+}
+
+// ServeHTTP answers an AdmissionReview request.
+func (h *admissionWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeHTTPError(w, http.StatusMethodNotAllowed, "use POST to review an object")
+		return
+	}
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxAdmissionReviewSize))
+	if err != nil {
+		writeHTTPError(w, http.StatusRequestEntityTooLarge, "unable to read AdmissionReview: "+err.Error())
+		return
+	}
+	var review admissionReview
+	if err := json.Unmarshal(body, &review); err != nil || review.Request == nil {
+		writeHTTPError(w, http.StatusBadRequest, "request body is not an AdmissionReview request")
+		return
+	}
+	writeHTTPJSON(w, admissionReview{
+		APIVersion: review.APIVersion,
+		Kind:       "AdmissionReview",
+		Response:   h.review(review.Request),
+	})
+}
+
+// review lints the certificates in the object of req. Objects that aren't
+// TLS Secrets or issued cert-manager CertificateRequests are allowed.
+func (h *admissionWebhook) review(req *admissionRequest) *admissionResponse {
+	resp := &admissionResponse{UID: req.UID, Allowed: true}
+	field, chainPEM, err := admissionCertificates(req)
+	if err != nil {
+		resp.Warnings = []string{"zlint: " + err.Error()}
+		return resp
+	}
+	if len(chainPEM) == 0 {
+		return resp
+	}
+	chain, err := parsePEMChain(chainPEM)
+	if err != nil {
+		resp.Warnings = []string{fmt.Sprintf("zlint: %s: %s", field, err)}
+		return resp
+	}
+	var denied []string
+	for i, c := range chain {
+		rs := lintCertificate(c, h.registry)
+		alerts.check(c, fmt.Sprintf("%s/%s#%d", req.Namespace, req.Name, i), rs)
+		if h.deny != 0 {
+			if failing := rs.Failing(h.deny); failing != nil {
+				denied = append(denied, describeFailing(field, i, c, failing))
+				continue
+			}
+		}
+		if h.warn != 0 {
+			if failing := rs.Failing(h.warn); failing != nil {
+				resp.Warnings = append(resp.Warnings, "zlint: "+describeFailing(field, i, c, failing))
+			}
+		}
+	}
+	if denied != nil {
+		resp.Allowed = false
+		resp.Status = &admissionStatus{
+			Code:    http.StatusForbidden,
+			Message: "zlint: " + strings.Join(denied, "; "),
+		}
+	}
+	return resp
+}
+
+// describeFailing summarizes the failing results of the i'th certificate in
+// the named field.
+func describeFailing(field string, i int, c *x509.Certificate, failing map[string]*lint.LintResult) string {
+	names := make([]string, 0, len(failing))
+	for name := range failing {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return fmt.Sprintf("%s certificate %d (%s) failed %s", field, i, c.Subject.CommonName, strings.Join(names, ", "))
+}
+
+// admissionCertificates returns the PEM certificate chain in the object of
+// req, and the name of the field it was found in, or no chain if the object
+// doesn't hold one. The chain is taken from the tls.crt key of
+// kubernetes.io/tls Secrets and the status.certificate field of cert-manager
+// CertificateRequests. The certificates cert-manager issues for Certificate
+// resources are reviewed when it stores them in Secrets.
+func admissionCertificates(req *admissionRequest) (string, []byte, error) {
+	switch {
+	case req.Kind.Group == "" && req.Kind.Kind == "Secret":
+		var secret struct {
+			Type       string            `json:"type"`
+			Data       map[string][]byte `json:"data"`
+			StringData map[string]string `json:"stringData"`
+		}
+		if err := json.Unmarshal(req.Object, &secret); err != nil {
+			return "", nil, fmt.Errorf("unable to decode Secret: %v", err)
+		}
+		if secret.Type != "kubernetes.io/tls" {
+			return "", nil, nil
+		}
+		if s, ok := secret.StringData["tls.crt"]; ok {
+			return "stringData[tls.crt]", []byte(s), nil
+		}
+		return "data[tls.crt]", secret.Data["tls.crt"], nil
+	case req.Kind.Group == "cert-manager.io" && req.Kind.Kind == "CertificateRequest":
+		var cr struct {
+			Status struct {
+				Certificate []byte `json:"certificate"`
+			} `json:"status"`
+		}
+		if err := json.Unmarshal(req.Object, &cr); err != nil {
+			return "", nil, fmt.Errorf("unable to decode CertificateRequest: %v", err)
+		}
+		return "status.certificate", cr.Status.Certificate, nil
+	}
+	return "", nil, nil
+}
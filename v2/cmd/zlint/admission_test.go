@@ -0,0 +1,144 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zmap/zlint/v2/lint"
+)
+
+func TestAdmissionWebhook(t *testing.T) {
+	pemBytes, err := ioutil.ReadFile("../../testdata/badRsaExp.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	crt := base64.StdEncoding.EncodeToString(pemBytes)
+	registry, err := lint.GlobalRegistry().Filter(lint.FilterOptions{IncludeNames: []string{"e_rsa_public_exponent_not_odd"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name     string
+		deny     lint.LintStatus
+		warn     lint.LintStatus
+		kind     string
+		object   string
+		allowed  bool
+		message  string
+		warnings int
+	}{
+		{
+			name:    "TLS Secret denied",
+			deny:    lint.Error,
+			kind:    `{"kind":"Secret"}`,
+			object:  fmt.Sprintf(`{"type":"kubernetes.io/tls","data":{"tls.crt":%q}}`, crt),
+			message: "zlint: data[tls.crt] certificate 0 (gov.us) failed e_rsa_public_exponent_not_odd",
+		},
+		{
+			name:     "TLS Secret warned",
+			warn:     lint.Warn,
+			kind:     `{"kind":"Secret"}`,
+			object:   fmt.Sprintf(`{"type":"kubernetes.io/tls","data":{"tls.crt":%q}}`, crt),
+			allowed:  true,
+			warnings: 1,
+		},
+		{
+			name:    "TLS Secret below threshold",
+			deny:    lint.Fatal,
+			warn:    lint.Fatal,
+			kind:    `{"kind":"Secret"}`,
+			object:  fmt.Sprintf(`{"type":"kubernetes.io/tls","data":{"tls.crt":%q}}`, crt),
+			allowed: true,
+		},
+		{
+			name:    "opaque Secret",
+			deny:    lint.Error,
+			kind:    `{"kind":"Secret"}`,
+			object:  fmt.Sprintf(`{"type":"Opaque","data":{"tls.crt":%q}}`, crt),
+			allowed: true,
+		},
+		{
+			name:    "pending CertificateRequest",
+			deny:    lint.Error,
+			kind:    `{"group":"cert-manager.io","kind":"CertificateRequest"}`,
+			object:  `{"status":{}}`,
+			allowed: true,
+		},
+		{
+			name:    "issued CertificateRequest",
+			deny:    lint.Error,
+			kind:    `{"group":"cert-manager.io","kind":"CertificateRequest"}`,
+			object:  fmt.Sprintf(`{"status":{"certificate":%q}}`, crt),
+			message: "zlint: status.certificate certificate 0 (gov.us) failed e_rsa_public_exponent_not_odd",
+		},
+		{
+			name:     "unparseable certificate",
+			deny:     lint.Error,
+			kind:     `{"kind":"Secret"}`,
+			object:   `{"type":"kubernetes.io/tls","stringData":{"tls.crt":"not a certificate"}}`,
+			allowed:  true,
+			warnings: 1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(&admissionWebhook{registry: registry, deny: tc.deny, warn: tc.warn})
+			defer server.Close()
+			body := fmt.Sprintf(`{"apiVersion":"admission.k8s.io/v1","kind":"AdmissionReview","request":{"uid":"1234","kind":%s,"namespace":"default","name":"example-tls","object":%s}}`, tc.kind, tc.object)
+			resp, err := http.Post(server.URL, "application/json", strings.NewReader(body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			var review admissionReview
+			if err := json.NewDecoder(resp.Body).Decode(&review); err != nil {
+				t.Fatal(err)
+			}
+			if review.APIVersion != "admission.k8s.io/v1" || review.Response == nil || review.Response.UID != "1234" {
+				t.Fatalf("unexpected AdmissionReview response %+v", review)
+			}
+			if review.Response.Allowed != tc.allowed {
+				t.Errorf("expected allowed %v, got %v", tc.allowed, review.Response.Allowed)
+			}
+			if tc.message != "" && (review.Response.Status == nil || review.Response.Status.Message != tc.message) {
+				t.Errorf("expected message %q, got %+v", tc.message, review.Response.Status)
+			}
+			if len(review.Response.Warnings) != tc.warnings {
+				t.Errorf("expected %d warnings, got %q", tc.warnings, review.Response.Warnings)
+			}
+		})
+	}
+
+	server := httptest.NewServer(&admissionWebhook{registry: registry})
+	defer server.Close()
+	resp, err := http.Post(server.URL, "application/json", bytes.NewReader([]byte(`{"kind":"AdmissionReview"}`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected status 400 for a review without a request, got %d", resp.StatusCode)
+	}
+}
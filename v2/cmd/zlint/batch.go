@@ -0,0 +1,320 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// maxCertificateSize is the largest encoded certificate that will be read.
+// Batch files are read one certificate at a time, so this also bounds the
+// memory used to read each certificate regardless of the size of the file.
+const maxCertificateSize = 1 << 20
+
+// certificateReader reads the DER encoding of one certificate at a time from a
+// batch file.
+type certificateReader interface {
+	// Next returns the DER encoding of the next certificate, or io.EOF if
+	// there are no more certificates. The returned slice is not reused.
+	Next() ([]byte, error)
+}
+
+// newCertificateReader returns a certificateReader for a batch file in the
+// given format:
+//
+//	der:    concatenated DER certificates
+//	pem:    concatenated PEM certificates
+//	base64: one base64 encoded DER certificate per line
+//	ndjson: one JSON object per line with the base64 encoded DER certificate
+//	        in its "raw" field (e.g. the output of zcertificate or ZGrab)
+func newCertificateReader(r io.Reader, inform string) (certificateReader, error) {
+	switch inform {
+	case "der":
+		return &derReader{r: bufio.NewReader(r)}, nil
+	case "pem", "base64", "ndjson":
+		scanner := bufio.NewScanner(r)
+		// A base64 encoded line is a third larger than the certificate it
+		// encodes and a JSON line may have other fields too.
+		scanner.Buffer(nil, 2*maxCertificateSize)
+		return &lineReader{scanner: scanner, inform: inform}, nil
+	}
+	return nil, fmt.Errorf("unknown input format %s", inform)
+}
+
+// derReader reads concatenated DER certificates, using the length in each
+// certificate's header to read exactly one certificate at a time.
+type derReader struct {
+	r *bufio.Reader
+}
+
+func (d *derReader) Next() ([]byte, error) {
+	header, err := d.r.Peek(2)
+	if len(header) == 0 && err == io.EOF {
+		return nil, io.EOF
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read certificate header: %s", err)
+	}
+	if header[0] != 0x30 {
+		return nil, errors.New("certificate does not start with a SEQUENCE")
+	}
+	size, headerSize := int(header[1]), 2
+	if size&0x80 != 0 {
+		lengthBytes := size & 0x7f
+		if lengthBytes == 0 || lengthBytes > 4 {
+			return nil, errors.New("unsupported certificate length encoding")
+		}
+		header, err = d.r.Peek(2 + lengthBytes)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read certificate header: %s", err)
+		}
+		size, headerSize = 0, 2+lengthBytes
+		for _, b := range header[2:] {
+			size = size<<8 | int(b)
+		}
+	}
+	if size > maxCertificateSize-headerSize {
+		return nil, fmt.Errorf("certificate is larger than %d bytes", maxCertificateSize)
+	}
+	der := make([]byte, headerSize+size)
+	if _, err := io.ReadFull(d.r, der); err != nil {
+		return nil, fmt.Errorf("unable to read certificate: %s", err)
+	}
+	return der, nil
+}
+
+// lineReader reads line based batch files: PEM, base64 or NDJSON. Each
+// certificate is decoded straight from the scanner's buffer into the slice
+// that is returned, without intermediate copies.
+type lineReader struct {
+	scanner *bufio.Scanner
+	inform  string
+	// inBlock is true while reading the lines of a PEM block, whose base64
+	// encoded body is accumulated in body.
+	inBlock bool
+	isCert  bool
+	body    []byte
+}
+
+func (l *lineReader) Next() ([]byte, error) {
+	for l.scanner.Scan() {
+		line := bytes.TrimSpace(l.scanner.Bytes())
+		switch {
+		case l.inform == "pem":
+			if der, ok, err := l.addPEMLine(line); ok {
+				return der, err
+			}
+		case len(line) == 0:
+			continue
+		case l.inform == "base64":
+			der, err := decodeBase64(line)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse base64: %s", err)
+			}
+			return der, nil
+		case l.inform == "ndjson":
+			return decodeNDJSONRecord(line)
+		}
+	}
+	if err := l.scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read line: %s", err)
+	}
+	if l.inBlock {
+		return nil, errors.New("unterminated PEM block")
+	}
+	return nil, io.EOF
+}
+
+// addPEMLine adds line to the PEM block being read. Once the end of the block
+// is reached it returns the decoded certificate and true.
+func (l *lineReader) addPEMLine(line []byte) ([]byte, bool, error) {
+	switch {
+	case !l.inBlock:
+		if bytes.HasPrefix(line, []byte("-----BEGIN ")) {
+			l.inBlock = true
+			l.isCert = string(line) == "-----BEGIN CERTIFICATE-----"
+			l.body = l.body[:0]
+		}
+		return nil, false, nil
+	case bytes.HasPrefix(line, []byte("-----END ")):
+		l.inBlock = false
+		if !l.isCert {
+			return nil, true, errors.New("unable to parse PEM")
+		}
+		der, err := decodeBase64(l.body)
+		if err != nil {
+			return nil, true, errors.New("unable to parse PEM")
+		}
+		return der, true, nil
+	case bytes.IndexByte(line, ':') >= 0:
+		// Skip PEM headers.
+		return nil, false, nil
+	}
+	if len(l.body)+len(line) > 2*maxCertificateSize {
+		return nil, true, fmt.Errorf("PEM block is larger than %d bytes", 2*maxCertificateSize)
+	}
+	l.body = append(l.body, line...)
+	return nil, false, nil
+}
+
+// decodeBase64 decodes the standard base64 encoding in encoded into a new
+// slice.
+func decodeBase64(encoded []byte) ([]byte, error) {
+	der := make([]byte, base64.StdEncoding.DecodedLen(len(encoded)))
+	n, err := base64.StdEncoding.Decode(der, encoded)
+	if err != nil {
+		return nil, err
+	}
+	return der[:n], nil
+}
+
+// decodeNDJSONRecord returns the certificate in the "raw" field of the JSON
+// object in line. The field is found by skipping over the other fields of the
+// object rather than decoding them, which matters for records that include a
+// large parsed form of the certificate.
+func decodeNDJSONRecord(line []byte) ([]byte, error) {
+	raw, err := jsonObjectField(line, "raw")
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse JSON: %s", err)
+	}
+	if len(raw) < 2 || raw[0] != '"' {
+		return nil, errors.New(`JSON record has no "raw" certificate`)
+	}
+	encoded := raw[1 : len(raw)-1]
+	if bytes.IndexByte(encoded, '\\') >= 0 {
+		// Let encoding/json deal with escape sequences.
+		var der []byte
+		if err := json.Unmarshal(raw, &der); err != nil {
+			return nil, fmt.Errorf("unable to parse JSON: %s", err)
+		}
+		return der, nil
+	}
+	der, err := decodeBase64(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse JSON: %s", err)
+	}
+	return der, nil
+}
+
+// jsonObjectField returns the encoded value of the field called name in the
+// JSON object in data, or nil if the object has no such field. The rest of
+// the object is checked only as far as is needed to find the field.
+func jsonObjectField(data []byte, name string) ([]byte, error) {
+	i := skipJSONSpace(data, 0)
+	if i == len(data) || data[i] != '{' {
+		return nil, errors.New("not a JSON object")
+	}
+	i = skipJSONSpace(data, i+1)
+	if i < len(data) && data[i] == '}' {
+		return nil, nil
+	}
+	for {
+		end, err := skipJSONValue(data, i)
+		if err != nil {
+			return nil, err
+		}
+		if data[i] != '"' {
+			return nil, errors.New("object key is not a string")
+		}
+		key := data[i+1 : end-1]
+		i = skipJSONSpace(data, end)
+		if i == len(data) || data[i] != ':' {
+			return nil, errors.New("missing colon after object key")
+		}
+		i = skipJSONSpace(data, i+1)
+		if end, err = skipJSONValue(data, i); err != nil {
+			return nil, err
+		}
+		if string(key) == name {
+			return data[i:end], nil
+		}
+		i = skipJSONSpace(data, end)
+		if i == len(data) {
+			return nil, errors.New("unexpected end of JSON object")
+		}
+		switch data[i] {
+		case '}':
+			return nil, nil
+		case ',':
+			i = skipJSONSpace(data, i+1)
+		default:
+			return nil, fmt.Errorf("unexpected %q in JSON object", data[i])
+		}
+	}
+}
+
+// skipJSONSpace returns the index of the first non-whitespace byte in data at
+// or after i.
+func skipJSONSpace(data []byte, i int) int {
+	for i < len(data) && (data[i] == ' ' || data[i] == '\t' || data[i] == '\n' || data[i] == '\r') {
+		i++
+	}
+	return i
+}
+
+// skipJSONValue returns the index just past the JSON value starting at data[i].
+// Objects and arrays are skipped by matching brackets outside of strings.
+func skipJSONValue(data []byte, i int) (int, error) {
+	if i == len(data) {
+		return 0, errors.New("unexpected end of JSON value")
+	}
+	switch data[i] {
+	case '"':
+		for j := i + 1; j < len(data); j++ {
+			switch data[j] {
+			case '\\':
+				j++
+			case '"':
+				return j + 1, nil
+			}
+		}
+		return 0, errors.New("unterminated JSON string")
+	case '{', '[':
+		depth := 0
+		for j := i; j < len(data); j++ {
+			switch data[j] {
+			case '"':
+				end, err := skipJSONValue(data, j)
+				if err != nil {
+					return 0, err
+				}
+				j = end - 1
+			case '{', '[':
+				depth++
+			case '}', ']':
+				if depth--; depth == 0 {
+					return j + 1, nil
+				}
+			}
+		}
+		return 0, errors.New("unterminated JSON object or array")
+	}
+	// Numbers, booleans and null run until the next delimiter.
+	j := i
+	for j < len(data) && !strings.ContainsRune(",}] \t\r\n", rune(data[j])) {
+		j++
+	}
+	if j == i {
+		return 0, fmt.Errorf("unexpected %q in JSON value", data[i])
+	}
+	return j, nil
+}
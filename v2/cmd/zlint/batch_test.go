@@ -0,0 +1,152 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/zmap/zcrypto/x509"
+)
+
+// testBatches returns the certificates in ../../testdata encoded as batch
+// files in each format. The NDJSON records resemble a CT log export, with a
+// parsed certificate ahead of the raw certificate.
+func testBatches(tb testing.TB) (map[string][]byte, [][]byte) {
+	paths, err := filepath.Glob("../../testdata/*.pem")
+	if err != nil {
+		tb.Fatal(err)
+	}
+	parsed, err := parsedTestCertificate()
+	if err != nil {
+		tb.Fatal(err)
+	}
+	batches := make(map[string][]byte)
+	var certs [][]byte
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		block, _ := pem.Decode(data)
+		if block == nil || block.Type != "CERTIFICATE" {
+			continue
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			continue
+		}
+		certs = append(certs, block.Bytes)
+		batches["der"] = append(batches["der"], block.Bytes...)
+		batches["pem"] = append(batches["pem"], pem.EncodeToMemory(block)...)
+		encoded := base64.StdEncoding.EncodeToString(block.Bytes)
+		batches["base64"] = append(batches["base64"], encoded+"\n"...)
+		batches["ndjson"] = append(batches["ndjson"], `{"parsed":`+string(parsed)+`,"raw":"`+encoded+`"}`+"\n"...)
+	}
+	return batches, certs
+}
+
+// parsedTestCertificate returns the JSON encoding of a parsed certificate.
+func parsedTestCertificate() ([]byte, error) {
+	data, err := ioutil.ReadFile("../../testdata/evAllGood.pem")
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	c, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(c)
+}
+
+func TestCertificateReader(t *testing.T) {
+	batches, certs := testBatches(t)
+	// Escaped slashes and the raw certificate appearing first in NDJSON
+	// records must be handled too.
+	var escaped []byte
+	for _, der := range certs {
+		encoded := bytes.ReplaceAll([]byte(base64.StdEncoding.EncodeToString(der)), []byte("/"), []byte(`\/`))
+		escaped = append(escaped, `{"raw":"`+string(encoded)+`","ct":{"index":[1,{"a":"}"}]}}`+"\n"...)
+	}
+	batches["ndjson escaped"] = escaped
+
+	for name, batch := range batches {
+		t.Run(name, func(t *testing.T) {
+			inform := name
+			if name == "ndjson escaped" {
+				inform = "ndjson"
+			}
+			r, err := newCertificateReader(bytes.NewReader(batch), inform)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, expected := range certs {
+				der, err := r.Next()
+				if err != nil {
+					t.Fatalf("certificate %d: unexpected error: %v", i, err)
+				}
+				if !bytes.Equal(der, expected) {
+					t.Fatalf("certificate %d: read the wrong certificate", i)
+				}
+			}
+			if _, err := r.Next(); err != io.EOF {
+				t.Errorf("expected io.EOF after the last certificate, got %v", err)
+			}
+		})
+	}
+}
+
+func BenchmarkCertificateReader(b *testing.B) {
+	batches, certs := testBatches(b)
+	for _, format := range []string{"der", "pem", "base64", "ndjson"} {
+		batch := batches[format]
+		b.Run(format, func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(batch)))
+			for i := 0; i < b.N; i++ {
+				r, err := newCertificateReader(bytes.NewReader(batch), format)
+				if err != nil {
+					b.Fatal(err)
+				}
+				for range certs {
+					if _, err := r.Next(); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeNDJSONRecordErrors(t *testing.T) {
+	for _, record := range []string{
+		`[]`,
+		`{}`,
+		`{"raw": 1}`,
+		`{"parsed": {"raw": "AQID"}}`,
+		`{"parsed": "}", "raw"}`,
+		`{"raw": "not base64!"}`,
+	} {
+		if _, err := decodeNDJSONRecord([]byte(record)); err == nil {
+			t.Errorf("%s: expected an error", record)
+		}
+	}
+}
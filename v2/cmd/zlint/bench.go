@@ -0,0 +1,151 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+)
+
+// benchSamples is the number of latency samples kept per lint. Larger corpora
+// are reservoir sampled, so percentiles are estimates but memory use doesn't
+// grow with the size of the corpus.
+const benchSamples = 10000
+
+// benchReport is the output of the bench subcommand.
+type benchReport struct {
+	Certificates   int                         `json:"certificates"`
+	ParseFailures  int                         `json:"parse_failures"`
+	DurationNanos  int64                       `json:"duration_ns"`
+	CertsPerSecond float64                     `json:"certs_per_second"`
+	AllocsPerCert  float64                     `json:"allocs_per_cert"`
+	BytesPerCert   float64                     `json:"bytes_per_cert"`
+	Lints          map[string]*lintBenchResult `json:"lints"`
+}
+
+// lintBenchResult summarizes the latency of a single lint across the corpus.
+type lintBenchResult struct {
+	MeanNanos int64 `json:"mean_ns"`
+	P50Nanos  int64 `json:"p50_ns"`
+	P90Nanos  int64 `json:"p90_ns"`
+	P99Nanos  int64 `json:"p99_ns"`
+	MaxNanos  int64 `json:"max_ns"`
+
+	count   int
+	total   time.Duration
+	samples []time.Duration
+}
+
+// add records one execution of the lint taking d.
+func (r *lintBenchResult) add(d time.Duration) {
+	r.count++
+	r.total += d
+	if d > time.Duration(r.MaxNanos) {
+		r.MaxNanos = int64(d)
+	}
+	if len(r.samples) < benchSamples {
+		r.samples = append(r.samples, d)
+	} else if i := rand.Intn(r.count); i < benchSamples {
+		r.samples[i] = d
+	}
+}
+
+// summarize fills in the mean and percentile latencies.
+func (r *lintBenchResult) summarize() {
+	if r.count == 0 {
+		return
+	}
+	r.MeanNanos = int64(r.total) / int64(r.count)
+	sort.Slice(r.samples, func(i, j int) bool { return r.samples[i] < r.samples[j] })
+	percentile := func(p float64) int64 {
+		return int64(r.samples[int(p*float64(len(r.samples)-1))])
+	}
+	r.P50Nanos = percentile(0.50)
+	r.P90Nanos = percentile(0.90)
+	r.P99Nanos = percentile(0.99)
+}
+
+// doBench lints every certificate found under dir with each lint in the
+// registry and writes a report of the throughput, the latency of each lint and
+// the memory allocated per certificate. Files that can't be parsed as
+// certificates are counted and skipped.
+func doBench(dir, inform string, registry lint.Registry) {
+	names := registry.Names()
+	report := benchReport{Lints: make(map[string]*lintBenchResult, len(names))}
+	for _, name := range names {
+		report.Lints[name] = &lintBenchResult{}
+	}
+
+	var elapsed time.Duration
+	var before, after runtime.MemStats
+	var mallocs, allocBytes uint64
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		c, err := readBenchCertificate(path, inform)
+		if err != nil {
+			report.ParseFailures++
+			return nil
+		}
+		report.Certificates++
+
+		runtime.ReadMemStats(&before)
+		for _, name := range names {
+			l := registry.ByName(name)
+			start := time.Now()
+			l.ExecuteWithConfig(c, lintConfig)
+			d := time.Since(start)
+			elapsed += d
+			report.Lints[name].add(d)
+		}
+		runtime.ReadMemStats(&after)
+		mallocs += after.Mallocs - before.Mallocs
+		allocBytes += after.TotalAlloc - before.TotalAlloc
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("unable to read corpus %s: %s", dir, err)
+	}
+
+	for _, r := range report.Lints {
+		r.summarize()
+	}
+	report.DurationNanos = int64(elapsed)
+	if report.Certificates > 0 {
+		report.CertsPerSecond = float64(report.Certificates) / elapsed.Seconds()
+		report.AllocsPerCert = float64(mallocs) / float64(report.Certificates)
+		report.BytesPerCert = float64(allocBytes) / float64(report.Certificates)
+	}
+	writeJSON(report)
+}
+
+// readBenchCertificate reads and parses the certificate at path.
+func readBenchCertificate(path, inform string) (*x509.Certificate, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parseCertificateFile(f, fileFormat(path, inform))
+}
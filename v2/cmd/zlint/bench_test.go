@@ -0,0 +1,118 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"math/rand"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/zmap/zlint/v2/lint"
+)
+
+func TestLintBenchResult(t *testing.T) {
+	r := &lintBenchResult{}
+	for _, i := range rand.Perm(100) {
+		r.add(time.Duration(i+1) * time.Millisecond)
+	}
+	r.summarize()
+	expected := lintBenchResult{
+		MeanNanos: int64(50500 * time.Microsecond),
+		P50Nanos:  int64(50 * time.Millisecond),
+		P90Nanos:  int64(90 * time.Millisecond),
+		P99Nanos:  int64(99 * time.Millisecond),
+		MaxNanos:  int64(100 * time.Millisecond),
+	}
+	if r.MeanNanos != expected.MeanNanos || r.P50Nanos != expected.P50Nanos || r.P90Nanos != expected.P90Nanos ||
+		r.P99Nanos != expected.P99Nanos || r.MaxNanos != expected.MaxNanos {
+		t.Errorf("expected %+v, got %+v", expected, *r)
+	}
+
+	// Beyond benchSamples executions the samples are a fixed size reservoir,
+	// but the mean and maximum still cover every execution.
+	r = &lintBenchResult{}
+	for i := 0; i < benchSamples+1000; i++ {
+		r.add(time.Millisecond)
+	}
+	r.add(time.Second)
+	r.summarize()
+	if len(r.samples) != benchSamples {
+		t.Errorf("expected %d samples, got %d", benchSamples, len(r.samples))
+	}
+	if r.count != benchSamples+1001 || r.MaxNanos != int64(time.Second) {
+		t.Errorf("expected %d executions with a maximum of 1s, got %d with %v", benchSamples+1001, r.count, time.Duration(r.MaxNanos))
+	}
+
+	// A lint that never ran has no latencies.
+	r = &lintBenchResult{}
+	r.summarize()
+	if r.MeanNanos != 0 || r.P99Nanos != 0 {
+		t.Errorf("expected no latencies, got %+v", *r)
+	}
+}
+
+func TestDoBench(t *testing.T) {
+	dir := t.TempDir()
+	certs := []string{"evAllGood.pem", "badRsaExp.pem"}
+	for _, name := range certs {
+		data, err := ioutil.ReadFile(filepath.Join("../../testdata", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, name), data, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "notes.pem"), []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	registry, err := lint.GlobalRegistry().Filter(lint.FilterOptions{
+		IncludeSources: lint.SourceList{lint.CABFBaselineRequirements},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	setOutput(t, &out)
+	doBench(dir, "pem", registry)
+	var report benchReport
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	if report.Certificates != len(certs) || report.ParseFailures != 1 {
+		t.Errorf("expected %d certificates and 1 parse failure, got %d and %d", len(certs), report.Certificates, report.ParseFailures)
+	}
+	if report.DurationNanos <= 0 || report.CertsPerSecond <= 0 || report.AllocsPerCert <= 0 || report.BytesPerCert <= 0 {
+		t.Errorf("expected positive throughput and allocations, got %+v", report)
+	}
+	if len(report.Lints) != len(registry.Names()) {
+		t.Fatalf("expected a result for each of the %d lints, got %d", len(registry.Names()), len(report.Lints))
+	}
+	for _, name := range registry.Names() {
+		r := report.Lints[name]
+		if r == nil {
+			t.Errorf("%s: missing from the report", name)
+			continue
+		}
+		if r.MaxNanos < r.P99Nanos || r.P99Nanos < r.P90Nanos || r.P90Nanos < r.P50Nanos {
+			t.Errorf("%s: latencies out of order: %+v", name, *r)
+		}
+	}
+}
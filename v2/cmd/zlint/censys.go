@@ -0,0 +1,229 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+)
+
+// censysAttempts is the number of times a Censys API request is made before
+// giving up. Requests are retried when rate limited or on server errors.
+const censysAttempts = 4
+
+// censysResult is the line of output written for each certificate found.
+type censysResult struct {
+	Fingerprint string `json:"fingerprint_sha256"`
+	// Error is set instead of Results if the certificate couldn't be
+	// downloaded or parsed.
+	Error   string      `json:"error,omitempty"`
+	Results interface{} `json:"results,omitempty"`
+}
+
+// censysClient searches certificates with the Censys Search API (v2).
+type censysClient struct {
+	baseURL string
+	apiID   string
+	secret  string
+	client  *http.Client
+	backoff time.Duration
+}
+
+// doCensysSearch lints the certificates matching the Censys search query
+// given by the flags in args (the arguments following "censys-search"),
+// writing a line of output for each as soon as it and those before it have
+// been linted. The API credentials are read from the CENSYS_API_ID and
+// CENSYS_API_SECRET environment variables.
+func doCensysSearch(args []string, registry lint.Registry) {
+	searchFlags := flag.NewFlagSet("censys-search", flag.ExitOnError)
+	max := searchFlags.Int("max", 1000, "Maximum number of certificates to lint (each costs API quota), or 0 for all matches")
+	parallel := searchFlags.Int("parallel", 4, "Number of certificates to download and lint at a time")
+	baseURL := searchFlags.String("censys-url", "https://search.censys.io/api", "URL of the Censys Search API")
+	_ = searchFlags.Parse(args)
+	if searchFlags.NArg() != 1 {
+		log.Fatal("censys-search requires a single search query, e.g. 'names: example.com'")
+	}
+	c := &censysClient{
+		baseURL: strings.TrimSuffix(*baseURL, "/"),
+		apiID:   os.Getenv("CENSYS_API_ID"),
+		secret:  os.Getenv("CENSYS_API_SECRET"),
+		client:  &http.Client{Timeout: time.Minute},
+		backoff: 5 * time.Second,
+	}
+	if c.apiID == "" || c.secret == "" {
+		log.Fatal("censys-search requires the CENSYS_API_ID and CENSYS_API_SECRET environment variables")
+	}
+	if err := c.lintSearch(searchFlags.Arg(0), *max, *parallel, registry, writeJSON); err != nil {
+		log.Fatalf("unable to search Censys: %s", err)
+	}
+}
+
+// lintSearch pages through the certificates matching query, up to max of
+// them if max is positive, downloading and linting parallel at a time and
+// passing the results to write in search order.
+func (c *censysClient) lintSearch(query string, max, parallel int, registry lint.Registry, write func(interface{})) error {
+	if parallel < 1 {
+		parallel = 1
+	}
+	// pending holds the result channels in search order. Its capacity bounds
+	// the number of downloads in flight.
+	pending := make(chan chan censysResult, parallel)
+	searchErr := make(chan error, 1)
+	go func() {
+		defer close(pending)
+		count := 0
+		for cursor := ""; ; {
+			fingerprints, next, err := c.search(query, cursor)
+			if err != nil {
+				searchErr <- err
+				return
+			}
+			for _, fp := range fingerprints {
+				if max > 0 && count == max {
+					return
+				}
+				count++
+				result := make(chan censysResult, 1)
+				pending <- result
+				go func(fp string) { result <- c.lintCertificate(fp, registry) }(fp)
+			}
+			if next == "" || len(fingerprints) == 0 {
+				return
+			}
+			cursor = next
+		}
+	}()
+	for result := range pending {
+		write(<-result)
+	}
+	select {
+	case err := <-searchErr:
+		return err
+	default:
+		return nil
+	}
+}
+
+// search returns the fingerprints of a page of certificates matching query,
+// and the cursor of the next page, which is empty after the last page.
+func (c *censysClient) search(query, cursor string) ([]string, string, error) {
+	params := url.Values{"q": {query}, "per_page": {"100"}}
+	if cursor != "" {
+		params.Set("cursor", cursor)
+	}
+	var resp struct {
+		Result struct {
+			Hits []struct {
+				Fingerprint string `json:"fingerprint_sha256"`
+			} `json:"hits"`
+			Links struct {
+				Next string `json:"next"`
+			} `json:"links"`
+		} `json:"result"`
+	}
+	if err := c.get("/v2/certificates/search?"+params.Encode(), &resp); err != nil {
+		return nil, "", err
+	}
+	fingerprints := make([]string, len(resp.Result.Hits))
+	for i, hit := range resp.Result.Hits {
+		fingerprints[i] = hit.Fingerprint
+	}
+	return fingerprints, resp.Result.Links.Next, nil
+}
+
+// lintCertificate downloads and lints the certificate with the given
+// SHA-256 fingerprint.
+func (c *censysClient) lintCertificate(fingerprint string, registry lint.Registry) censysResult {
+	result := censysResult{Fingerprint: fingerprint}
+	var resp struct {
+		Result struct {
+			Raw string `json:"raw"`
+		} `json:"result"`
+	}
+	if err := c.get("/v2/certificates/"+url.PathEscape(fingerprint), &resp); err != nil {
+		result.Error = fmt.Sprintf("unable to download certificate: %s", err)
+		return result
+	}
+	der, err := base64.StdEncoding.DecodeString(resp.Result.Raw)
+	if err != nil || len(der) == 0 {
+		result.Error = "certificate has no base64 raw encoding"
+		return result
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		result.Error = fmt.Sprintf("unable to parse certificate: %s", err)
+		return result
+	}
+	rs := lintCertificate(cert, registry)
+	alerts.check(cert, "censys", rs)
+	result.Results = results(rs, registry)
+	return result
+}
+
+// get decodes the JSON response of the API endpoint at path into v, retrying
+// when rate limited or on server errors.
+func (c *censysClient) get(path string, v interface{}) error {
+	for attempt := 1; ; attempt++ {
+		retry, err := c.getOnce(path, v)
+		if err == nil || !retry || attempt == censysAttempts {
+			return err
+		}
+		time.Sleep(time.Duration(attempt) * c.backoff)
+	}
+}
+
+// getOnce makes a single request for get, returning whether a failed request
+// should be retried.
+func (c *censysClient) getOnce(path string, v interface{}) (bool, error) {
+	req, err := http.NewRequest(http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return false, err
+	}
+	req.SetBasicAuth(c.apiID, c.secret)
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Error string `json:"error"`
+		}
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 4096))
+		_ = json.Unmarshal(body, &apiErr)
+		msg := "Censys returned " + resp.Status
+		if apiErr.Error != "" {
+			msg += ": " + apiErr.Error
+		}
+		retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		return retry, errors.New(msg)
+	}
+	return false, json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,122 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zmap/zlint/v2/lint"
+)
+
+func TestCensysSearch(t *testing.T) {
+	pemBytes, err := ioutil.ReadFile("../../testdata/badRsaExp.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(pemBytes)
+	raw := base64.StdEncoding.EncodeToString(block.Bytes)
+	var searches, flaky int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id, secret, ok := r.BasicAuth(); !ok || id != "id" || secret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/v2/certificates/search":
+			searches++
+			q := r.URL.Query()
+			if q.Get("q") != "names: example.com" {
+				t.Errorf("unexpected search query %s", r.URL.RawQuery)
+			}
+			if q.Get("cursor") == "" {
+				fmt.Fprint(w, `{"result":{"hits":[{"fingerprint_sha256":"a"},{"fingerprint_sha256":"b"}],"links":{"next":"page2"}}}`)
+			} else {
+				fmt.Fprint(w, `{"result":{"hits":[{"fingerprint_sha256":"c"},{"fingerprint_sha256":"d"},{"fingerprint_sha256":"e"}],"links":{"next":"page3"}}}`)
+			}
+		case "/v2/certificates/b":
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":"certificate not found"}`)
+		case "/v2/certificates/c":
+			// Rate limit the first attempt to check that requests are retried.
+			if flaky++; flaky == 1 {
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			fmt.Fprintf(w, `{"result":{"raw":%q}}`, raw)
+		default:
+			fmt.Fprintf(w, `{"result":{"raw":%q}}`, raw)
+		}
+	}))
+	defer server.Close()
+
+	c := &censysClient{baseURL: server.URL, apiID: "id", secret: "secret", client: server.Client()}
+	registry, err := lint.GlobalRegistry().Filter(lint.FilterOptions{IncludeNames: []string{"e_rsa_public_exponent_not_odd"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out []censysResult
+	err = c.lintSearch("names: example.com", 4, 2, registry, func(v interface{}) {
+		// Round trip through JSON like the output written by the command.
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var result censysResult
+		if err := json.Unmarshal(data, &result); err != nil {
+			t.Fatal(err)
+		}
+		out = append(out, result)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if searches != 2 {
+		t.Errorf("expected 2 searches, got %d", searches)
+	}
+	if len(out) != 4 {
+		t.Fatalf("expected -max 4 results, got %d", len(out))
+	}
+	for i, result := range out {
+		if expected := string(rune('a' + i)); result.Fingerprint != expected {
+			t.Errorf("expected result %d to be for %s, got %s", i, expected, result.Fingerprint)
+		}
+		if result.Fingerprint == "b" {
+			if !strings.Contains(result.Error, "certificate not found") {
+				t.Errorf("expected the API error for b, got %q", result.Error)
+			}
+			continue
+		}
+		if result.Error != "" {
+			t.Errorf("%s: unexpected error %q", result.Fingerprint, result.Error)
+		}
+		if fmt.Sprint(result.Results) != "map[e_rsa_public_exponent_not_odd:map[result:error]]" {
+			t.Errorf("%s: unexpected results %v", result.Fingerprint, result.Results)
+		}
+	}
+
+	c.apiID = "wrong"
+	if err := c.lintSearch("names: example.com", 0, 1, registry, func(interface{}) {}); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("expected an authentication error, got %v", err)
+	}
+}
@@ -0,0 +1,141 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zcrypto/x509/revocation/ocsp"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+// connectTimeout bounds how long establishing a TLS connection with -connect
+// may take.
+const connectTimeout = 10 * time.Second
+
+// liveEndpoint holds what was learned from a TLS handshake with a live server.
+type liveEndpoint struct {
+	// chain is the certificate chain presented by the server, leaf first.
+	chain []*x509.Certificate
+	// stapledOCSP is the OCSP response stapled by the server, if any.
+	stapledOCSP []byte
+}
+
+// fetchLiveEndpoint performs a TLS handshake with addr (host:port) and returns
+// the certificate chain and any stapled OCSP response presented by the server.
+// The chain is not verified: zlint is equally interested in broken chains.
+//
+// If starttls names an application protocol the connection is upgraded to TLS
+// using the protocol's STARTTLS mechanism (see startTLS) rather than starting
+// with the handshake.
+func fetchLiveEndpoint(addr, starttls string) (*liveEndpoint, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := net.DialTimeout("tcp", addr, connectTimeout)
+	if err != nil {
+		return nil, err
+	}
+	defer raw.Close()
+	if err := raw.SetDeadline(time.Now().Add(connectTimeout)); err != nil {
+		return nil, err
+	}
+	if starttls != "" {
+		if err := startTLS(raw, starttls); err != nil {
+			return nil, err
+		}
+	}
+	conn := tls.Client(raw, &tls.Config{
+		ServerName:         host,
+		InsecureSkipVerify: true,
+	})
+	if err := conn.Handshake(); err != nil {
+		return nil, err
+	}
+
+	state := conn.ConnectionState()
+	if len(state.PeerCertificates) == 0 {
+		return nil, errors.New("server presented no certificates")
+	}
+	endpoint := &liveEndpoint{stapledOCSP: state.OCSPResponse}
+	for _, peer := range state.PeerCertificates {
+		c, err := x509.ParseCertificate(peer.Raw)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse certificate presented by server: %v", err)
+		}
+		endpoint.chain = append(endpoint.chain, c)
+	}
+	return endpoint, nil
+}
+
+// checkStapledOCSP lints the OCSP response stapled by a live endpoint together
+// with the leaf certificate. Certificates asserting the TLS Feature
+// status_request ("OCSP Must-Staple") that were served without a staple, and
+// stapled responses that are unusable, result in an Error.
+func (e *liveEndpoint) checkStapledOCSP() *lint.LintResult {
+	leaf := e.chain[0]
+	if len(e.stapledOCSP) == 0 {
+		if util.IsMustStaple(leaf) {
+			return &lint.LintResult{
+				Status:  lint.Error,
+				Details: "certificate asserts OCSP Must-Staple but the server did not staple an OCSP response",
+			}
+		}
+		return &lint.LintResult{
+			Status:  lint.Notice,
+			Details: "server did not staple an OCSP response",
+		}
+	}
+
+	var issuer *x509.Certificate
+	if len(e.chain) > 1 {
+		issuer = e.chain[1]
+	}
+	resp, err := ocsp.ParseResponseForCert(e.stapledOCSP, leaf, issuer)
+	if err != nil {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: fmt.Sprintf("unable to parse stapled OCSP response: %v", err),
+		}
+	}
+	if issuer != nil && !resp.IsValidSignature {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: "stapled OCSP response signature is not valid for the presented issuer",
+		}
+	}
+	now := time.Now()
+	if !resp.NextUpdate.IsZero() && resp.NextUpdate.Before(now) {
+		return &lint.LintResult{
+			Status: lint.Error,
+			Details: fmt.Sprintf("stapled OCSP response has nextUpdate %s in the past",
+				resp.NextUpdate.UTC().Format(time.RFC3339)),
+		}
+	}
+	if resp.IsRevoked {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: "stapled OCSP response reports the certificate as revoked",
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
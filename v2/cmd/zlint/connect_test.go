@@ -0,0 +1,241 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	stdx509 "crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+	"golang.org/x/crypto/ocsp"
+)
+
+// stapleTestChain is a leaf certificate and the CA that issued it, for
+// creating OCSP responses to staple.
+type stapleTestChain struct {
+	leaf, issuer       *stdx509.Certificate
+	leafKey, issuerKey crypto.Signer
+}
+
+// newStapleTestChain returns a freshly issued leaf certificate, asserting
+// OCSP Must-Staple if mustStaple is set, and its issuer.
+func newStapleTestChain(t *testing.T, mustStaple bool) *stapleTestChain {
+	t.Helper()
+	issuerKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuerTemplate := &stdx509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "staple test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              stdx509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	issuerDER, err := stdx509.CreateCertificate(rand.Reader, issuerTemplate, issuerTemplate, issuerKey.Public(), issuerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuer, err := stdx509.ParseCertificate(issuerDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	leafKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leafTemplate := &stdx509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "staple.example.com"},
+		DNSNames:     []string{"staple.example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+	}
+	if mustStaple {
+		features, err := asn1.Marshal([]int{util.TLSFeatureStatusRequest})
+		if err != nil {
+			t.Fatal(err)
+		}
+		leafTemplate.ExtraExtensions = []pkix.Extension{{Id: util.TLSFeatureOID, Value: features}}
+	}
+	leafDER, err := stdx509.CreateCertificate(rand.Reader, leafTemplate, issuer, leafKey.Public(), issuerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := stdx509.ParseCertificate(leafDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &stapleTestChain{leaf: leaf, issuer: issuer, leafKey: leafKey, issuerKey: issuerKey}
+}
+
+// ocspResponse returns an OCSP response for the leaf with the given status and
+// nextUpdate, signed by signer.
+func (c *stapleTestChain) ocspResponse(t *testing.T, status int, nextUpdate time.Time, signer crypto.Signer) []byte {
+	t.Helper()
+	template := ocsp.Response{
+		Status:       status,
+		SerialNumber: c.leaf.SerialNumber,
+		ThisUpdate:   time.Now().Add(-time.Hour),
+		NextUpdate:   nextUpdate,
+	}
+	if status == ocsp.Revoked {
+		template.RevokedAt = time.Now().Add(-time.Hour)
+	}
+	resp, err := ocsp.CreateResponse(c.issuer, c.issuer, template, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+// endpoint returns a liveEndpoint presenting the chain and staple, with the
+// issuer omitted if leafOnly is set.
+func (c *stapleTestChain) endpoint(t *testing.T, staple []byte, leafOnly bool) *liveEndpoint {
+	t.Helper()
+	endpoint := &liveEndpoint{stapledOCSP: staple}
+	certs := []*stdx509.Certificate{c.leaf, c.issuer}
+	if leafOnly {
+		certs = certs[:1]
+	}
+	for _, std := range certs {
+		zc, err := x509.ParseCertificate(std.Raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		endpoint.chain = append(endpoint.chain, zc)
+	}
+	return endpoint
+}
+
+func TestCheckStapledOCSP(t *testing.T) {
+	chain := newStapleTestChain(t, false)
+	mustStaple := newStapleTestChain(t, true)
+	fresh := time.Now().Add(24 * time.Hour)
+	stale := time.Now().Add(-time.Minute)
+
+	testCases := []struct {
+		name     string
+		endpoint *liveEndpoint
+		expected lint.LintStatus
+	}{
+		{
+			name:     "no staple",
+			endpoint: chain.endpoint(t, nil, false),
+			expected: lint.Notice,
+		},
+		{
+			name:     "no staple for must-staple certificate",
+			endpoint: mustStaple.endpoint(t, nil, false),
+			expected: lint.Error,
+		},
+		{
+			name:     "good staple",
+			endpoint: chain.endpoint(t, chain.ocspResponse(t, ocsp.Good, fresh, chain.issuerKey), false),
+			expected: lint.Pass,
+		},
+		{
+			name:     "good staple for must-staple certificate",
+			endpoint: mustStaple.endpoint(t, mustStaple.ocspResponse(t, ocsp.Good, fresh, mustStaple.issuerKey), false),
+			expected: lint.Pass,
+		},
+		{
+			name:     "good staple without issuer",
+			endpoint: chain.endpoint(t, chain.ocspResponse(t, ocsp.Good, fresh, chain.issuerKey), true),
+			expected: lint.Pass,
+		},
+		{
+			name:     "unparsable staple",
+			endpoint: chain.endpoint(t, []byte("not an OCSP response"), false),
+			expected: lint.Error,
+		},
+		{
+			name:     "staple signed by the wrong key",
+			endpoint: chain.endpoint(t, chain.ocspResponse(t, ocsp.Good, fresh, chain.leafKey), false),
+			expected: lint.Error,
+		},
+		{
+			name:     "stale staple",
+			endpoint: chain.endpoint(t, chain.ocspResponse(t, ocsp.Good, stale, chain.issuerKey), false),
+			expected: lint.Error,
+		},
+		{
+			name:     "revoked",
+			endpoint: chain.endpoint(t, chain.ocspResponse(t, ocsp.Revoked, fresh, chain.issuerKey), false),
+			expected: lint.Error,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if result := tc.endpoint.checkStapledOCSP(); result.Status != tc.expected {
+				t.Errorf("expected %v, got %v (%s)", tc.expected, result.Status, result.Details)
+			}
+		})
+	}
+}
+
+func TestFetchLiveEndpointStaple(t *testing.T) {
+	chain := newStapleTestChain(t, true)
+	staple := chain.ocspResponse(t, ocsp.Good, time.Now().Add(24*time.Hour), chain.issuerKey)
+	config := &tls.Config{Certificates: []tls.Certificate{{
+		Certificate: [][]byte{chain.leaf.Raw, chain.issuer.Raw},
+		PrivateKey:  chain.leafKey,
+		OCSPStaple:  staple,
+	}}}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_ = tls.Server(conn, config).Handshake()
+	}()
+
+	endpoint, err := fetchLiveEndpoint(ln.Addr().String(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(endpoint.chain) != 2 {
+		t.Fatalf("expected the leaf and issuer, got %d certificates", len(endpoint.chain))
+	}
+	if string(endpoint.stapledOCSP) != string(staple) {
+		t.Error("expected the stapled OCSP response")
+	}
+	if result := endpoint.checkStapledOCSP(); result.Status != lint.Pass {
+		t.Errorf("expected the staple to pass, got %v (%s)", result.Status, result.Details)
+	}
+}
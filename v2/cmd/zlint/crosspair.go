@@ -0,0 +1,132 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+)
+
+// checkCrossPair compares two certificates that are expected to certify the
+// same key, such as a root certificate and a cross-signed certificate for the
+// same CA. Path building relies on these certificates being interchangeable so
+// a divergence in the fields checked here can cause clients to build (or fail
+// to build) unexpected paths. The result is keyed by the name of each check.
+func checkCrossPair(a, b *x509.Certificate) map[string]*lint.LintResult {
+	return map[string]*lint.LintResult{
+		"subject":                checkCrossPairSubject(a, b),
+		"public_key":             checkCrossPairKey(a, b),
+		"subject_key_identifier": checkCrossPairSKI(a, b),
+		"policies":               checkCrossPairPolicies(a, b),
+	}
+}
+
+// checkCrossPairSubject returns an Error if the subjects are not byte for byte
+// identical. Many path builders match issuers to subjects by comparing the
+// encoded names, so even a difference in string type is significant.
+func checkCrossPairSubject(a, b *x509.Certificate) *lint.LintResult {
+	if bytes.Equal(a.RawSubject, b.RawSubject) {
+		return &lint.LintResult{Status: lint.Pass}
+	}
+	if a.Subject.String() == b.Subject.String() {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: "subjects are equivalent but not identically encoded",
+		}
+	}
+	return &lint.LintResult{
+		Status:  lint.Error,
+		Details: fmt.Sprintf("subjects differ: %q and %q", a.Subject.String(), b.Subject.String()),
+	}
+}
+
+// checkCrossPairKey returns an Error if the certificates don't contain the
+// same subject public key info.
+func checkCrossPairKey(a, b *x509.Certificate) *lint.LintResult {
+	if bytes.Equal(a.RawSubjectPublicKeyInfo, b.RawSubjectPublicKeyInfo) {
+		return &lint.LintResult{Status: lint.Pass}
+	}
+	return &lint.LintResult{
+		Status:  lint.Error,
+		Details: "certificates do not contain the same subject public key",
+	}
+}
+
+// checkCrossPairSKI returns an Error if the subject key identifiers differ and
+// a Warning if only one certificate has one, since a certificate issued by the
+// CA can only carry one authority key identifier.
+func checkCrossPairSKI(a, b *x509.Certificate) *lint.LintResult {
+	switch {
+	case len(a.SubjectKeyId) == 0 && len(b.SubjectKeyId) == 0:
+		return &lint.LintResult{Status: lint.NA}
+	case len(a.SubjectKeyId) == 0 || len(b.SubjectKeyId) == 0:
+		return &lint.LintResult{
+			Status:  lint.Warn,
+			Details: "only one certificate has a subject key identifier",
+		}
+	case !bytes.Equal(a.SubjectKeyId, b.SubjectKeyId):
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: fmt.Sprintf("subject key identifiers differ: %X and %X", a.SubjectKeyId, b.SubjectKeyId),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+// checkCrossPairPolicies returns a Warning if the certificates assert
+// different policy OIDs. This is sometimes intended, but results in the
+// policies that are valid for a subordinate certificate depending on which
+// path a client builds.
+func checkCrossPairPolicies(a, b *x509.Certificate) *lint.LintResult {
+	policies := func(c *x509.Certificate) map[string]bool {
+		set := make(map[string]bool)
+		for _, oid := range c.PolicyIdentifiers {
+			set[oid.String()] = true
+		}
+		return set
+	}
+	difference := func(x, y map[string]bool) []string {
+		var diff []string
+		for oid := range x {
+			if !y[oid] {
+				diff = append(diff, oid)
+			}
+		}
+		sort.Strings(diff)
+		return diff
+	}
+
+	aPolicies, bPolicies := policies(a), policies(b)
+	onlyA, onlyB := difference(aPolicies, bPolicies), difference(bPolicies, aPolicies)
+	if len(onlyA) == 0 && len(onlyB) == 0 {
+		return &lint.LintResult{Status: lint.Pass}
+	}
+	var details []string
+	if len(onlyA) > 0 {
+		details = append(details, "only in first certificate: "+strings.Join(onlyA, ", "))
+	}
+	if len(onlyB) > 0 {
+		details = append(details, "only in second certificate: "+strings.Join(onlyB, ", "))
+	}
+	return &lint.LintResult{
+		Status:  lint.Warn,
+		Details: "certificate policies differ; " + strings.Join(details, "; "),
+	}
+}
@@ -0,0 +1,168 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	stdx509 "crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+)
+
+// crossPairSubject is the common name of the CA certified by the test
+// certificates.
+const crossPairSubject = "Cross Pair Test Root"
+
+// newCrossPairCert returns a CA certificate for key signed by signer. The
+// certificate has a fixed subject, subject key identifier and policy, which
+// modify can change before it is issued.
+func newCrossPairCert(t *testing.T, key, signer crypto.Signer, modify func(*stdx509.Certificate)) *x509.Certificate {
+	t.Helper()
+	template := &stdx509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: crossPairSubject},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              stdx509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		SubjectKeyId:          []byte{1, 2, 3, 4},
+		PolicyIdentifiers:     []asn1.ObjectIdentifier{{2, 23, 140, 1, 2, 1}},
+	}
+	if modify != nil {
+		modify(template)
+	}
+	parent := template
+	if signer != key {
+		parent = &stdx509.Certificate{Subject: pkix.Name{CommonName: "Cross Pair Test Other Root"}}
+	}
+	der, err := stdx509.CreateCertificate(rand.Reader, template, parent, key.Public(), signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestCheckCrossPair(t *testing.T) {
+	newKey := func() crypto.Signer {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return key
+	}
+	key, otherKey := newKey(), newKey()
+	root := newCrossPairCert(t, key, key, nil)
+
+	utf8CN, err := asn1.MarshalWithParams(crossPairSubject, "utf8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	utf8Subject, err := asn1.Marshal(pkix.RDNSequence{{{Type: asn1.ObjectIdentifier{2, 5, 4, 3}, Value: asn1.RawValue{FullBytes: utf8CN}}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name     string
+		cross    *x509.Certificate
+		expected map[string]lint.LintStatus
+	}{
+		{
+			name:  "consistent cross-sign",
+			cross: newCrossPairCert(t, key, otherKey, nil),
+			expected: map[string]lint.LintStatus{
+				"subject":                lint.Pass,
+				"public_key":             lint.Pass,
+				"subject_key_identifier": lint.Pass,
+				"policies":               lint.Pass,
+			},
+		},
+		{
+			name: "different subject",
+			cross: newCrossPairCert(t, key, otherKey, func(c *stdx509.Certificate) {
+				c.Subject.CommonName = "Cross Pair Test Root 2"
+			}),
+			expected: map[string]lint.LintStatus{"subject": lint.Error},
+		},
+		{
+			name: "differently encoded subject",
+			cross: newCrossPairCert(t, key, otherKey, func(c *stdx509.Certificate) {
+				c.RawSubject = utf8Subject
+			}),
+			expected: map[string]lint.LintStatus{"subject": lint.Error},
+		},
+		{
+			name:     "different key",
+			cross:    newCrossPairCert(t, otherKey, otherKey, nil),
+			expected: map[string]lint.LintStatus{"public_key": lint.Error},
+		},
+		{
+			name: "different subject key identifier",
+			cross: newCrossPairCert(t, key, otherKey, func(c *stdx509.Certificate) {
+				c.SubjectKeyId = []byte{5, 6, 7, 8}
+			}),
+			expected: map[string]lint.LintStatus{"subject_key_identifier": lint.Error},
+		},
+		{
+			name: "policies differ",
+			cross: newCrossPairCert(t, key, otherKey, func(c *stdx509.Certificate) {
+				c.PolicyIdentifiers = []asn1.ObjectIdentifier{{2, 23, 140, 1, 2, 2}}
+			}),
+			expected: map[string]lint.LintStatus{"policies": lint.Warn},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			results := checkCrossPair(root, tc.cross)
+			for check, expected := range tc.expected {
+				if result := results[check]; result.Status != expected {
+					t.Errorf("%s: expected %v, got %v (%s)", check, expected, result.Status, result.Details)
+				}
+			}
+			// Checks not named in the test case are expected to pass.
+			for check, result := range results {
+				if _, ok := tc.expected[check]; !ok && result.Status != lint.Pass {
+					t.Errorf("%s: expected pass, got %v (%s)", check, result.Status, result.Details)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckCrossPairSKI(t *testing.T) {
+	withSKI := &x509.Certificate{SubjectKeyId: []byte{1, 2, 3, 4}}
+	withoutSKI := &x509.Certificate{}
+	if result := checkCrossPairSKI(withoutSKI, withoutSKI); result.Status != lint.NA {
+		t.Errorf("expected NA without subject key identifiers, got %v", result.Status)
+	}
+	if result := checkCrossPairSKI(withSKI, withoutSKI); result.Status != lint.Warn {
+		t.Errorf("expected a warning with one subject key identifier, got %v", result.Status)
+	}
+}
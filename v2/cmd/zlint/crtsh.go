@@ -0,0 +1,189 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/zmap/zlint/v2"
+	"github.com/zmap/zlint/v2/lint"
+)
+
+// crtshAttempts is the number of times a crt.sh request is made before giving
+// up. crt.sh is a shared service that often fails requests under load.
+const crtshAttempts = 3
+
+// crtshEntry is a certificate in crt.sh's JSON search results.
+type crtshEntry struct {
+	ID         int64  `json:"id"`
+	IssuerName string `json:"issuer_name"`
+	CommonName string `json:"common_name"`
+	NameValue  string `json:"name_value"`
+	NotBefore  string `json:"not_before"`
+	NotAfter   string `json:"not_after"`
+}
+
+// crtshResult is the line of output written for each certificate found.
+type crtshResult struct {
+	ID         int64  `json:"crtsh_id"`
+	IssuerName string `json:"issuer_name"`
+	CommonName string `json:"common_name"`
+	NotBefore  string `json:"not_before"`
+	NotAfter   string `json:"not_after"`
+	// Error is set instead of Results if the certificate couldn't be
+	// downloaded or parsed.
+	Error   string      `json:"error,omitempty"`
+	Results interface{} `json:"results,omitempty"`
+}
+
+// crtshClient searches crt.sh and downloads certificates from it.
+type crtshClient struct {
+	baseURL string
+	client  *http.Client
+	// backoff is the delay before retrying a failed request, multiplied by
+	// the number of attempts so far.
+	backoff time.Duration
+}
+
+// doCrtshSearch lints the certificates crt.sh has for the domain given by the
+// flags in args (the arguments following "crtsh-search"), writing a line of
+// output for each.
+func doCrtshSearch(args []string, registry lint.Registry) {
+	searchFlags := flag.NewFlagSet("crtsh-search", flag.ExitOnError)
+	subdomains := searchFlags.Bool("subdomains", false, "Include certificates for subdomains of the domain")
+	excludeExpired := searchFlags.Bool("exclude-expired", false, "Only lint certificates that haven't expired")
+	parallel := searchFlags.Int("parallel", 4, "Number of certificates to download and lint at a time (please be gentle with crt.sh)")
+	baseURL := searchFlags.String("crtsh-url", "https://crt.sh/", "URL of crt.sh, or a compatible service")
+	_ = searchFlags.Parse(args)
+	if searchFlags.NArg() != 1 {
+		log.Fatal("crtsh-search requires a single domain")
+	}
+	domain := searchFlags.Arg(0)
+	if *subdomains {
+		domain = "%." + domain
+	}
+
+	c := &crtshClient{baseURL: *baseURL, client: &http.Client{Timeout: time.Minute}, backoff: 2 * time.Second}
+	entries, err := c.search(domain, *excludeExpired)
+	if err != nil {
+		log.Fatalf("unable to search crt.sh: %s", err)
+	}
+	log.Infof("linting %d certificates found for %s", len(entries), domain)
+	c.lintEntries(entries, registry, *parallel, writeJSON)
+}
+
+// search returns the certificates crt.sh has for identity, deduplicating
+// precertificates and the certificates issued for them.
+func (c *crtshClient) search(identity string, excludeExpired bool) ([]crtshEntry, error) {
+	query := url.Values{"q": {identity}, "output": {"json"}, "deduplicate": {"Y"}}
+	if excludeExpired {
+		query.Set("exclude", "expired")
+	}
+	body, err := c.get(query)
+	if err != nil {
+		return nil, err
+	}
+	var entries []crtshEntry
+	if err := json.Unmarshal(body, &entries); err != nil {
+		return nil, fmt.Errorf("unable to decode search results: %s", err)
+	}
+	return entries, nil
+}
+
+// lintEntries downloads and lints the certificates in entries, parallel at a
+// time, passing the results to write in the order of entries.
+func (c *crtshClient) lintEntries(entries []crtshEntry, registry lint.Registry, parallel int, write func(interface{})) {
+	if parallel < 1 {
+		parallel = 1
+	}
+	// pending holds the result channels in the order of entries. Its
+	// capacity bounds the number of requests to crt.sh in flight.
+	pending := make(chan chan crtshResult, parallel)
+	go func() {
+		for _, entry := range entries {
+			result := make(chan crtshResult, 1)
+			pending <- result
+			go func(entry crtshEntry) { result <- c.lintEntry(entry, registry) }(entry)
+		}
+		close(pending)
+	}()
+	for result := range pending {
+		write(<-result)
+	}
+}
+
+// lintEntry downloads and lints the certificate for entry.
+func (c *crtshClient) lintEntry(entry crtshEntry, registry lint.Registry) crtshResult {
+	result := crtshResult{
+		ID:         entry.ID,
+		IssuerName: entry.IssuerName,
+		CommonName: entry.CommonName,
+		NotBefore:  entry.NotBefore,
+		NotAfter:   entry.NotAfter,
+	}
+	body, err := c.get(url.Values{"d": {strconv.FormatInt(entry.ID, 10)}})
+	if err != nil {
+		result.Error = fmt.Sprintf("unable to download certificate: %s", err)
+		return result
+	}
+	cert, err := zlint.ParseCertificate(body, "pem")
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	rs := lintCertificate(cert, registry)
+	alerts.check(cert, fmt.Sprintf("crt.sh ID %d", entry.ID), rs)
+	result.Results = results(rs, registry)
+	return result
+}
+
+// get returns the body of the crt.sh page with the given query, retrying
+// failed requests.
+func (c *crtshClient) get(query url.Values) ([]byte, error) {
+	u := strings.TrimSuffix(c.baseURL, "/") + "/?" + query.Encode()
+	var err error
+	for attempt := 1; attempt <= crtshAttempts; attempt++ {
+		if attempt > 1 {
+			time.Sleep(time.Duration(attempt-1) * c.backoff)
+		}
+		var body []byte
+		if body, err = c.getOnce(u); err == nil {
+			return body, nil
+		}
+	}
+	return nil, err
+}
+
+func (c *crtshClient) getOnce(u string) ([]byte, error) {
+	resp, err := c.client.Get(u)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("crt.sh returned %s", resp.Status)
+	}
+	return ioutil.ReadAll(io.LimitReader(resp.Body, 64<<20))
+}
@@ -0,0 +1,104 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/zmap/zlint/v2/lint"
+)
+
+func TestCrtshSearch(t *testing.T) {
+	pemBytes, err := ioutil.ReadFile("../../testdata/badRsaExp.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var searches, flaky int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch {
+		case q.Get("q") != "":
+			searches++
+			if q.Get("q") != "%.example.com" || q.Get("output") != "json" || q.Get("exclude") != "expired" {
+				t.Errorf("unexpected search query %s", r.URL.RawQuery)
+			}
+			fmt.Fprint(w, `[{"id":1,"common_name":"a.example.com"},{"id":2},{"id":3},{"id":4}]`)
+		case q.Get("d") == "2":
+			w.WriteHeader(http.StatusNotFound)
+		case q.Get("d") == "3":
+			// Fail the first attempt to check that requests are retried.
+			if flaky++; flaky == 1 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			_, _ = w.Write(pemBytes)
+		case q.Get("d") == "4":
+			fmt.Fprint(w, "not a certificate")
+		default:
+			_, _ = w.Write(pemBytes)
+		}
+	}))
+	defer server.Close()
+
+	c := &crtshClient{baseURL: server.URL, client: server.Client()}
+	entries, err := c.search("%.example.com", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry, err := lint.GlobalRegistry().Filter(lint.FilterOptions{IncludeNames: []string{"e_rsa_public_exponent_not_odd"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out []crtshResult
+	c.lintEntries(entries, registry, 2, func(v interface{}) {
+		// Round trip through JSON like the output written by the command.
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var result crtshResult
+		if err := json.Unmarshal(data, &result); err != nil {
+			t.Fatal(err)
+		}
+		out = append(out, result)
+	})
+
+	if searches != 1 {
+		t.Errorf("expected 1 search, got %d", searches)
+	}
+	if len(out) != 4 {
+		t.Fatalf("expected 4 results, got %d", len(out))
+	}
+	for i, result := range out {
+		if result.ID != int64(i+1) {
+			t.Errorf("expected result %d to be for crt.sh ID %d, got %d", i, i+1, result.ID)
+		}
+		linted := result.ID == 1 || result.ID == 3
+		if linted != (result.Error == "") {
+			t.Errorf("crt.sh ID %d: unexpected error %q", result.ID, result.Error)
+		}
+		if linted && fmt.Sprint(result.Results) != "map[e_rsa_public_exponent_not_odd:map[result:error]]" {
+			t.Errorf("crt.sh ID %d: unexpected results %v", result.ID, result.Results)
+		}
+	}
+	if out[0].CommonName != "a.example.com" {
+		t.Errorf("expected the search result's details in the output, got %+v", out[0])
+	}
+}
@@ -0,0 +1,357 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/metrics"
+)
+
+// Entry types of RFC 6962 timestamped entries.
+const (
+	ctX509Entry    = 0
+	ctPrecertEntry = 1
+)
+
+// ctFinding is the line of output written for a log entry with findings, i.e.
+// lint results with the notice, warn, error or fatal status, or for an entry
+// that couldn't be linted.
+type ctFinding struct {
+	Index     uint64 `json:"index"`
+	EntryType string `json:"entry_type,omitempty"`
+	// Timestamp is the entry's timestamp in milliseconds since the Unix epoch.
+	Timestamp uint64                      `json:"timestamp,omitempty"`
+	Error     string                      `json:"error,omitempty"`
+	Findings  map[string]*lint.LintResult `json:"findings,omitempty"`
+}
+
+// ctTailState is the position in a log persisted between runs of ct-tail.
+type ctTailState struct {
+	Log       string `json:"log"`
+	NextIndex uint64 `json:"next_index"`
+}
+
+// ctTailer lints the entries of a CT log from a position onwards.
+type ctTailer struct {
+	logURL    string
+	client    *http.Client
+	registry  lint.Registry
+	batchSize uint64
+	workers   int
+	statePath string
+	state     ctTailState
+	out       *json.Encoder
+	metrics   *metrics.Metrics
+}
+
+// doCTTail tails the CT log given by the flags in args (the arguments
+// following "ct-tail"), writing the findings for each new entry to stdout.
+func doCTTail(args []string, registry lint.Registry) {
+	ctFlags := flag.NewFlagSet("ct-tail", flag.ExitOnError)
+	logURL := ctFlags.String("log", "", "URL of the CT log to tail, e.g. https://ct.example.com/2020/")
+	statePath := ctFlags.String("state", "", "File recording the next entry to lint, read at startup and updated after each batch of entries is written")
+	start := ctFlags.Int64("start", -1, "Index of the first entry to lint if there is no -state file (default the current tree size, i.e. only new entries)")
+	batchSize := ctFlags.Uint64("batch-size", 256, "Maximum number of entries to request from the log at a time")
+	poll := ctFlags.Duration("poll", time.Minute, "How often to check the log for new entries once caught up")
+	once := ctFlags.Bool("once", false, "Exit once caught up with the log rather than polling for new entries")
+	metricsListen := ctFlags.String("metrics-listen", "", "Address to serve Prometheus metrics on at /metrics, e.g. :9100")
+	_ = ctFlags.Parse(args)
+	if *logURL == "" {
+		log.Fatal("ct-tail requires -log")
+	}
+	if *batchSize == 0 || workers < 1 {
+		log.Fatal("-batch-size and -workers must be positive")
+	}
+
+	t := &ctTailer{
+		logURL:    strings.TrimSuffix(*logURL, "/") + "/ct/v1/",
+		client:    &http.Client{Timeout: time.Minute},
+		registry:  registry,
+		batchSize: *batchSize,
+		workers:   workers,
+		statePath: *statePath,
+		state:     ctTailState{Log: *logURL},
+		out:       output,
+		metrics:   metrics.New(),
+	}
+	if *metricsListen != "" {
+		go serveMetrics(*metricsListen, t.metrics)
+	}
+	resumed, err := t.loadState()
+	if err != nil {
+		log.Fatalf("unable to read -state: %s", err)
+	}
+	if !resumed {
+		if *start >= 0 {
+			t.state.NextIndex = uint64(*start)
+		} else if t.state.NextIndex, err = t.treeSize(); err != nil {
+			log.Fatalf("unable to get the log's tree size: %s", err)
+		}
+	}
+	log.Infof("tailing %s from entry %d", *logURL, t.state.NextIndex)
+
+	for {
+		caughtUp, err := t.poll()
+		if err != nil {
+			// The position is only advanced once a batch has been written, so
+			// the batch is simply tried again.
+			log.Warnf("unable to lint entries from %d: %s", t.state.NextIndex, err)
+		}
+		if caughtUp && *once {
+			return
+		}
+		if caughtUp || err != nil {
+			time.Sleep(*poll)
+		}
+	}
+}
+
+// serveMetrics serves m at /metrics on addr, exiting if that isn't possible.
+func serveMetrics(addr string, m *metrics.Metrics) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", m)
+	server := &http.Server{Addr: addr, Handler: mux, ReadTimeout: 30 * time.Second, WriteTimeout: 30 * time.Second}
+	log.Fatal(server.ListenAndServe())
+}
+
+// poll lints the next batch of entries, if any, and records the new position.
+// It returns true if there were no new entries in the log.
+func (t *ctTailer) poll() (bool, error) {
+	size, err := t.treeSize()
+	if err != nil {
+		return false, err
+	}
+	if t.state.NextIndex >= size {
+		return true, nil
+	}
+	end := t.state.NextIndex + t.batchSize - 1
+	if end >= size {
+		end = size - 1
+	}
+	entries, err := t.entries(t.state.NextIndex, end)
+	if err != nil {
+		return false, err
+	}
+	if len(entries) == 0 {
+		return false, errors.New("log returned no entries")
+	}
+	for _, finding := range t.lintEntries(t.state.NextIndex, entries) {
+		if finding.Error != "" || len(finding.Findings) > 0 {
+			if err := t.out.Encode(finding); err != nil {
+				return false, err
+			}
+		}
+	}
+	// Logs may return fewer entries than requested, so the position advances
+	// by the number actually linted.
+	t.state.NextIndex += uint64(len(entries))
+	return false, t.saveState()
+}
+
+// lintEntries lints entries, the first of which is at index first, returning
+// a ctFinding for each in order.
+func (t *ctTailer) lintEntries(first uint64, entries []ctEntry) []ctFinding {
+	findings := make([]ctFinding, len(entries))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < t.workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				findings[i] = t.lintEntry(first+uint64(i), entries[i])
+			}
+		}()
+	}
+	for i := range entries {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return findings
+}
+
+// lintEntry lints the certificate or precertificate in entry.
+func (t *ctTailer) lintEntry(index uint64, entry ctEntry) ctFinding {
+	finding := ctFinding{Index: index}
+	der, entryType, timestamp, err := entry.certificate()
+	if err != nil {
+		t.metrics.ObserveFailure()
+		finding.Error = err.Error()
+		return finding
+	}
+	finding.EntryType = entryType
+	finding.Timestamp = timestamp
+	c, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.metrics.ObserveFailure()
+		finding.Error = fmt.Sprintf("unable to parse %s: %s", entryType, err)
+		return finding
+	}
+	start := time.Now()
+	rs := lintCertificate(c, t.registry)
+	t.metrics.Observe(rs, time.Since(start))
+	alerts.check(c, fmt.Sprintf("%s entry %d", t.state.Log, index), rs)
+	for name, result := range rs.Results {
+		if result.Status >= lint.Notice && result.Status <= lint.Fatal {
+			if finding.Findings == nil {
+				finding.Findings = make(map[string]*lint.LintResult)
+			}
+			finding.Findings[name] = result
+		}
+	}
+	return finding
+}
+
+// ctEntry is an entry returned by the get-entries method of RFC 6962.
+type ctEntry struct {
+	LeafInput []byte `json:"leaf_input"`
+	ExtraData []byte `json:"extra_data"`
+}
+
+// certificate returns the DER certificate or precertificate logged in e,
+// along with its entry type and timestamp.
+func (e ctEntry) certificate() ([]byte, string, uint64, error) {
+	// MerkleTreeLeaf: version (1 byte), leaf_type (1 byte), then a
+	// TimestampedEntry: timestamp (8 bytes), entry_type (2 bytes) and the
+	// entry itself.
+	leaf := e.LeafInput
+	if len(leaf) < 12 || leaf[0] != 0 || leaf[1] != 0 {
+		return nil, "", 0, errors.New("unsupported Merkle tree leaf")
+	}
+	timestamp := binary.BigEndian.Uint64(leaf[2:10])
+	switch binary.BigEndian.Uint16(leaf[10:12]) {
+	case ctX509Entry:
+		der, _, err := readCTCertificate(leaf[12:])
+		return der, "x509", timestamp, err
+	case ctPrecertEntry:
+		// The leaf only holds the precertificate's TBSCertificate. The
+		// precertificate itself starts the PrecertChainEntry in extra_data.
+		der, _, err := readCTCertificate(e.ExtraData)
+		return der, "precert", timestamp, err
+	}
+	return nil, "", 0, errors.New("unsupported log entry type")
+}
+
+// readCTCertificate reads an ASN.1Cert, a certificate with a 3 byte length
+// prefix, from the start of data and returns it and the rest of data.
+func readCTCertificate(data []byte) ([]byte, []byte, error) {
+	if len(data) < 3 {
+		return nil, nil, errors.New("truncated log entry")
+	}
+	n := int(data[0])<<16 | int(data[1])<<8 | int(data[2])
+	if len(data)-3 < n {
+		return nil, nil, errors.New("truncated log entry")
+	}
+	return data[3 : 3+n], data[3+n:], nil
+}
+
+// treeSize returns the size of the log's latest signed tree head.
+func (t *ctTailer) treeSize() (uint64, error) {
+	var sth struct {
+		TreeSize uint64 `json:"tree_size"`
+	}
+	err := t.get("get-sth", &sth)
+	return sth.TreeSize, err
+}
+
+// entries returns the log's entries from start to end inclusive, or fewer if
+// the log limits how many are returned at once.
+func (t *ctTailer) entries(start, end uint64) ([]ctEntry, error) {
+	var resp struct {
+		Entries []ctEntry `json:"entries"`
+	}
+	err := t.get(fmt.Sprintf("get-entries?start=%d&end=%d", start, end), &resp)
+	return resp.Entries, err
+}
+
+// get decodes the JSON response to the RFC 6962 method into v.
+func (t *ctTailer) get(method string, v interface{}) error {
+	resp, err := t.client.Get(t.logURL + method)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s: %s: %s", method, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// loadState reads the position saved in the state file, if there is one,
+// returning false if there was no saved position.
+func (t *ctTailer) loadState() (bool, error) {
+	if t.statePath == "" {
+		return false, nil
+	}
+	data, err := ioutil.ReadFile(t.statePath)
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	var state ctTailState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return false, err
+	}
+	if state.Log != t.state.Log {
+		return false, fmt.Errorf("%s records the position in %s, not %s", t.statePath, state.Log, t.state.Log)
+	}
+	t.state = state
+	return true, nil
+}
+
+// saveState writes the current position to the state file, if any. The file
+// is replaced atomically so a crash never leaves a partially written state.
+func (t *ctTailer) saveState() error {
+	if t.statePath == "" {
+		return nil
+	}
+	data, err := json.Marshal(t.state)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(t.statePath), filepath.Base(t.statePath)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), t.statePath)
+}
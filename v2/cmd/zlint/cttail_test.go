@@ -0,0 +1,172 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/metrics"
+)
+
+// testCTEntry returns a log entry for der, logged as a precertificate if
+// precert is true.
+func testCTEntry(der []byte, precert bool) ctEntry {
+	asn1Cert := append([]byte{byte(len(der) >> 16), byte(len(der) >> 8), byte(len(der))}, der...)
+	leaf := make([]byte, 12)
+	binary.BigEndian.PutUint64(leaf[2:], 1588000000000)
+	if !precert {
+		return ctEntry{LeafInput: append(append(leaf, asn1Cert...), 0, 0)}
+	}
+	binary.BigEndian.PutUint16(leaf[10:], ctPrecertEntry)
+	// The TBSCertificate in the leaf isn't used, so any bytes will do.
+	leaf = append(leaf, make([]byte, 32)...)
+	leaf = append(leaf, 0, 0, 1, 0, 0, 0)
+	return ctEntry{LeafInput: leaf, ExtraData: append(asn1Cert, 0, 0, 0)}
+}
+
+func testCertificateDER(t *testing.T, name string) []byte {
+	data, err := ioutil.ReadFile(filepath.Join("../../testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(data)
+	return block.Bytes
+}
+
+// newTestCTLog returns a CT log serving entries, returning at most two
+// entries at a time from get-entries.
+func newTestCTLog(entries []ctEntry) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ct/v1/get-sth", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"tree_size":%d}`, len(entries))
+	})
+	mux.HandleFunc("/ct/v1/get-entries", func(w http.ResponseWriter, r *http.Request) {
+		start, _ := strconv.Atoi(r.URL.Query().Get("start"))
+		end, _ := strconv.Atoi(r.URL.Query().Get("end"))
+		if end > start+1 {
+			end = start + 1
+		}
+		_ = json.NewEncoder(w).Encode(map[string][]ctEntry{"entries": entries[start : end+1]})
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestCTTail(t *testing.T) {
+	bad := testCertificateDER(t, "badRsaExp.pem")
+	good := testCertificateDER(t, "goodRsaExp.pem")
+	server := newTestCTLog([]ctEntry{
+		testCTEntry(bad, false),
+		testCTEntry(good, false),
+		testCTEntry(bad, true),
+		{LeafInput: []byte("not a leaf")},
+		testCTEntry(good, true),
+	})
+	defer server.Close()
+	registry, err := lint.GlobalRegistry().Filter(lint.FilterOptions{IncludeNames: []string{"e_rsa_public_exponent_not_odd"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := ioutil.TempDir("", "zlint-ct-tail")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	statePath := filepath.Join(dir, "state.json")
+	newTailer := func(out *bytes.Buffer) *ctTailer {
+		return &ctTailer{
+			logURL:    server.URL + "/ct/v1/",
+			client:    server.Client(),
+			registry:  registry,
+			batchSize: 3,
+			workers:   2,
+			statePath: statePath,
+			state:     ctTailState{Log: server.URL},
+			out:       json.NewEncoder(out),
+			metrics:   metrics.New(),
+		}
+	}
+	var out bytes.Buffer
+	tailer := newTailer(&out)
+	for caughtUp := false; !caughtUp; {
+		if caughtUp, err = tailer.poll(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var findings []ctFinding
+	dec := json.NewDecoder(&out)
+	for dec.More() {
+		var f ctFinding
+		if err := dec.Decode(&f); err != nil {
+			t.Fatal(err)
+		}
+		findings = append(findings, f)
+	}
+	if len(findings) != 3 {
+		t.Fatalf("expected 3 findings, got %d: %+v", len(findings), findings)
+	}
+	for i, expected := range []struct {
+		index     uint64
+		entryType string
+		err       bool
+	}{{0, "x509", false}, {2, "precert", false}, {3, "", true}} {
+		f := findings[i]
+		if f.Index != expected.index || f.EntryType != expected.entryType || (f.Error != "") != expected.err {
+			t.Errorf("unexpected finding %d: %+v", i, f)
+		}
+		if !expected.err && f.Findings["e_rsa_public_exponent_not_odd"].Status != lint.Error {
+			t.Errorf("expected an error for entry %d, got %+v", f.Index, f.Findings)
+		}
+	}
+
+	var metricsOut bytes.Buffer
+	if err := tailer.metrics.Write(&metricsOut); err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{"zlint_certificates_total 4\n", "zlint_certificate_failures_total 1\n"} {
+		if !strings.Contains(metricsOut.String(), expected) {
+			t.Errorf("expected metrics to contain %q, got:\n%s", expected, metricsOut.String())
+		}
+	}
+
+	// A new tailer resumes from the saved position.
+	tailer = newTailer(&out)
+	if resumed, err := tailer.loadState(); err != nil || !resumed {
+		t.Fatalf("expected to resume from the saved state, got %v, %v", resumed, err)
+	}
+	if tailer.state.NextIndex != 5 {
+		t.Errorf("expected to resume at entry 5, got %d", tailer.state.NextIndex)
+	}
+
+	// The state can't be used for another log.
+	tailer.state.Log = "https://other.example.com"
+	if _, err := tailer.loadState(); err == nil {
+		t.Error("expected an error loading the state of another log")
+	}
+}
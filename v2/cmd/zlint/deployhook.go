@@ -0,0 +1,97 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+)
+
+// deployHookFailed is the exit status of deploy-hook when a certificate in
+// the chain has results meeting -fail-on. Other failures exit with status 1.
+const deployHookFailed = 2
+
+// doDeployHook lints a newly issued certificate chain for use as an ACME
+// client's deploy hook, given the flags in args (the arguments following
+// "deploy-hook"). It writes a line of results for each certificate, leaf
+// first, and returns the status zlint should exit with.
+//
+// Without -chain the chain is read from the fullchain.pem file in the
+// directory named by the RENEWED_LINEAGE environment variable, which is set
+// by certbot when it runs a --deploy-hook.
+func doDeployHook(args []string, registry lint.Registry) int {
+	hookFlags := flag.NewFlagSet("deploy-hook", flag.ExitOnError)
+	chainPath := hookFlags.String("chain", "", "PEM file containing the certificate chain to lint, leaf first (default $RENEWED_LINEAGE/fullchain.pem)")
+	failOn := hookFlags.String("fail-on", "error", fmt.Sprintf("Least severe result that makes the hook exit with status %d: one of info, warn, error or fatal", deployHookFailed))
+	_ = hookFlags.Parse(args)
+	threshold, err := parseThreshold("fail-on", *failOn)
+	if err != nil {
+		log.Fatal(err)
+	}
+	path := *chainPath
+	if path == "" {
+		lineage := os.Getenv("RENEWED_LINEAGE")
+		if lineage == "" {
+			log.Fatal("deploy-hook requires -chain or the RENEWED_LINEAGE environment variable")
+		}
+		path = filepath.Join(lineage, "fullchain.pem")
+	}
+	chainPEM, err := ioutil.ReadFile(path)
+	if err != nil {
+		log.Fatalf("unable to read certificate chain: %s", err)
+	}
+	chain, err := parsePEMChain(chainPEM)
+	if err != nil {
+		log.Fatalf("certificate chain %s: %s", path, err)
+	}
+	if lintChain(path, chain, threshold, registry, writeJSON) {
+		return deployHookFailed
+	}
+	return 0
+}
+
+// lintChain lints each certificate in the chain read from path, passing the
+// results to write, and returns whether any of them has results at least as
+// severe as threshold. Those results are also logged, so they show up in the
+// ACME client's log.
+func lintChain(path string, chain []*x509.Certificate, threshold lint.LintStatus, registry lint.Registry, write func(interface{})) bool {
+	failed := false
+	for i, c := range chain {
+		rs := lintCertificate(c, registry)
+		alerts.check(c, fmt.Sprintf("%s#%d", path, i), rs)
+		write(results(rs, registry))
+		failing := rs.Failing(threshold)
+		if failing == nil {
+			continue
+		}
+		failed = true
+		names := make([]string, 0, len(failing))
+		for name := range failing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		log.Errorf("%s: certificate %d (%s) failed %s", path, i, c.Subject.String(), strings.Join(names, ", "))
+	}
+	return failed
+}
@@ -0,0 +1,69 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+)
+
+func TestLintChain(t *testing.T) {
+	var chainPEM []byte
+	for _, file := range []string{"evAllGood.pem", "badRsaExp.pem"} {
+		data, err := ioutil.ReadFile("../../testdata/" + file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		chainPEM = append(chainPEM, data...)
+	}
+	chain, err := parsePEMChain(chainPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chain) != 2 {
+		t.Fatalf("expected 2 certificates in the chain, got %d", len(chain))
+	}
+	registry, err := lint.GlobalRegistry().Filter(lint.FilterOptions{IncludeNames: []string{"e_rsa_public_exponent_not_odd"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		threshold lint.LintStatus
+		chain     []*x509.Certificate
+		failed    bool
+	}{
+		{lint.Error, chain, true},
+		{lint.Fatal, chain, false},
+		{lint.Notice, chain[:1], false},
+	}
+	for _, tc := range testCases {
+		written := 0
+		failed := lintChain("fullchain.pem", tc.chain, tc.threshold, registry, func(interface{}) { written++ })
+		if failed != tc.failed {
+			t.Errorf("threshold %s, %d certificates: expected failed %v, got %v", tc.threshold, len(tc.chain), tc.failed, failed)
+		}
+		if written != len(tc.chain) {
+			t.Errorf("threshold %s: expected results for %d certificates, got %d", tc.threshold, len(tc.chain), written)
+		}
+	}
+
+	if _, err := parsePEMChain([]byte("not a chain")); err == nil {
+		t.Error("expected an error parsing a chain with no certificates")
+	}
+}
@@ -15,22 +15,25 @@
 package main
 
 import (
-	"bytes"
-	"encoding/base64"
 	"encoding/json"
-	"encoding/pem"
 	"flag"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"regexp"
+	"runtime"
 	"sort"
 	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zcrypto/x509/ct"
 	"github.com/zmap/zlint/v2"
 	"github.com/zmap/zlint/v2/lint"
+	_ "github.com/zmap/zlint/v2/lints/online"
+	"github.com/zmap/zlint/v2/util"
 )
 
 var ( // flags
@@ -43,32 +46,100 @@ var ( // flags
 	excludeNames    string
 	includeSources  string
 	excludeSources  string
+	includeTags     string
+	excludeTags     string
+	online          bool
+	connect         string
+	starttls        string
+	checkStaple     bool
+	acmeDirectory   string
+	acmeOrder       string
+	acmeAccountKey  string
+	crossPair       bool
+	strength        int
+	underscoreWarn  bool
+	maxBackdate     time.Duration
+	ctLogList       string
+	workers         int
+	batch           bool
+	fast            bool
+	maxPending      int
+	compact         bool
+	parseWorkers    int
+	timing          bool
+	webhookURL      string
+	webhookLevel    string
+	webhookFormat   string
 
 	// version is replaced by GoReleaser using an LDFlags option at release time.
 	version = "dev"
+
+	// lintConfig holds the lint settings given by flags (e.g.
+	// -security-strength), and is passed to every lint run.
+	lintConfig = &lint.Config{}
 )
 
 func init() {
 	flag.BoolVar(&listLintsJSON, "list-lints-json", false, "Print lints in JSON format, one per line")
 	flag.BoolVar(&listLintSources, "list-lints-source", false, "Print list of lint sources, one per line")
-	flag.StringVar(&format, "format", "pem", "One of {pem, der, base64}")
+	flag.StringVar(&format, "format", "pem", "One of {pem, der, base64}, or ndjson with -batch")
 	flag.StringVar(&nameFilter, "nameFilter", "", "Only run lints with a name matching the provided regex. (Can not be used with -includeNames/-excludeNames)")
 	flag.StringVar(&includeNames, "includeNames", "", "Comma-separated list of lints to include by name")
 	flag.StringVar(&excludeNames, "excludeNames", "", "Comma-separated list of lints to exclude by name")
-	flag.StringVar(&includeSources, "includeSources", "", "Comma-separated list of lint sources to include")
+	flag.StringVar(&includeSources, "includeSources", "", "Comma-separated list of lint sources to include (BSI lints only run when included)")
 	flag.StringVar(&excludeSources, "excludeSources", "", "Comma-separated list of lint sources to exclude")
+	flag.StringVar(&includeTags, "includeTags", "", "Comma-separated list of lint tags to include (e.g. "+lint.TagWeakCrypto+")")
+	flag.StringVar(&excludeTags, "excludeTags", "", "Comma-separated list of lint tags to exclude")
+	flag.StringVar(&connect, "connect", "", "Lint the certificate presented by the TLS server at the given host:port instead of reading files")
+	flag.StringVar(&starttls, "starttls", "", "With -connect, upgrade the connection to TLS using the STARTTLS mechanism of the given protocol: smtp, imap, pop3, ftp, postgres or ldap")
+	flag.BoolVar(&checkStaple, "staple", false, "With -connect, also check the OCSP response stapled by the server (required for OCSP Must-Staple certificates)")
+	flag.StringVar(&acmeDirectory, "acme-directory", "", "Lint the certificate chain issued for -acme-order by the ACME server with the given directory URL")
+	flag.StringVar(&acmeOrder, "acme-order", "", "With -acme-directory, the URL (absolute or relative to the directory URL) of a valid order")
+	flag.StringVar(&acmeAccountKey, "acme-account-key", "", "With -acme-directory, a PEM file containing the private key of the account that owns -acme-order")
+	flag.BoolVar(&crossPair, "cross-pair", false, "Check that the two certificate files given are consistent certificates for the same key (e.g. a root and its cross-sign) instead of linting them")
+	flag.IntVar(&strength, "security-strength", util.DefaultTargetSecurityStrength, "Target security strength in bits for w_security_strength_below_target")
+	flag.BoolVar(&underscoreWarn, "underscore-warn-only", false, "Report underscores in dNSNames as a warning rather than an error in e_dnsname_underscore_in_sld")
+	flag.DurationVar(&maxBackdate, "max-backdate", util.DefaultMaxNotBeforeBackdate, "Longest period notBefore may precede the earliest embedded SCT for w_not_before_backdated_beyond_window")
+	flag.StringVar(&ctLogList, "ct-log-list", "", "A CT log list JSON file (v3 format) identifying the operator of each log for n_chrome_ct_sct_log_operator_diversity")
+	flag.IntVar(&workers, "workers", runtime.GOMAXPROCS(0), "Number of certificate files (or certificates with -batch) to lint in parallel. Results are still written in the order the files were given")
+	flag.IntVar(&parseWorkers, "parse-workers", (runtime.GOMAXPROCS(0)+7)/8, "With -batch, the number of certificates to parse in parallel")
+	flag.BoolVar(&timing, "timing", false, "With -batch, write how busy each stage of the pipeline (decode, parse, lint and encode) was to stderr at the end, to show which is the bottleneck")
+	flag.BoolVar(&batch, "batch", false, "Treat each file (or standard input) as a batch of certificates: concatenated DER or PEM, one base64 certificate per line, or one JSON object per line with a base64 \"raw\" certificate for -format ndjson. Batches are read one certificate at a time")
+	flag.IntVar(&maxPending, "max-pending", 0, "Maximum number of linted certificates waiting to be written before reading more input pauses (default the number of -workers). Bounds memory use when the output is consumed slowly")
+	flag.BoolVar(&compact, "compact", false, "Write results in the compact form of zlint.CompactResultSet, which omits pass and NA results and uses short status codes")
+	flag.BoolVar(&fast, "fast", false, "Skip expensive lints (tagged "+lint.TagExpensive+"), reporting them as skipped")
+	flag.BoolVar(&online, "online", false, "Run lints that make network requests (e.g. to fetch CRLs and OCSP responses)")
 
+	flag.StringVar(&webhookURL, "webhook", "", "POST a JSON alert with the certificate's identity and failing lints to this URL for each certificate with a result at or above -webhook-threshold")
+	flag.StringVar(&webhookLevel, "webhook-threshold", "error", "Least severe result that triggers a -webhook alert: one of info, warn, error or fatal")
+	flag.StringVar(&webhookFormat, "webhook-format", "json", "Format of -webhook alerts: json, or slack or teams to post a message summarizing the failing lints to a Slack or Teams webhook")
 	flag.BoolVar(&prettyprint, "pretty", false, "Pretty-print output")
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "ZLint version %s\n\n", version)
 		fmt.Fprintf(os.Stderr, "Usage: %s [flags] file...\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "       %s [flags] bench corpus-dir\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "       %s [flags] serve [-listen addr]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "       %s [flags] ct-tail -log url [-state file] [-once]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "       %s [flags] crtsh-search [-subdomains] [-exclude-expired] domain\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "       %s [flags] censys-search [-max n] query\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "       %s [flags] vault-audit [-mount path]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "       %s [flags] system-roots\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "       %s [flags] deploy-hook [-chain file] [-fail-on level]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "       %s [flags] admission-webhook -tls-cert file -tls-key file\n", os.Args[0])
 		flag.PrintDefaults()
 	}
-	flag.Parse()
 	log.SetLevel(log.InfoLevel)
 }
 
 func main() {
+	flag.Parse()
+	lintConfig.TargetSecurityStrength = strength
+	lintConfig.UnderscoreDNSNamesWarnOnly = underscoreWarn
+	lintConfig.MaxNotBeforeBackdate = maxBackdate
+	if prettyprint {
+		output.SetIndent("", " ")
+	}
+
 	// Build a registry of lints using the include/exclude lint name and source
 	// flags.
 	registry, err := setLints()
@@ -76,6 +147,21 @@ func main() {
 		log.Fatalf("unable to configure included/exclude lints: %v\n", err)
 	}
 
+	if ctLogList != "" {
+		operators, err := loadCTLogList(ctLogList)
+		if err != nil {
+			log.Fatalf("unable to load CT log list: %v", err)
+		}
+		lintConfig.CTLogOperators = operators
+	}
+
+	if webhookURL != "" {
+		if alerts, err = newWebhookSink(webhookURL, webhookFormat, webhookLevel); err != nil {
+			log.Fatal(err)
+		}
+		defer alerts.close()
+	}
+
 	if listLintsJSON {
 		registry.WriteJSON(os.Stdout)
 		return
@@ -90,77 +176,228 @@ func main() {
 		return
 	}
 
+	if connect != "" {
+		doConnect(connect, registry)
+		return
+	}
+
+	if acmeDirectory != "" {
+		doACME(acmeDirectory, acmeOrder, acmeAccountKey, registry)
+		return
+	}
+
 	var inform = strings.ToLower(format)
+	if crossPair {
+		doCrossPair(flag.Args(), inform)
+		return
+	}
+
+	if flag.NArg() >= 1 && flag.Arg(0) == "serve" {
+		doServe(flag.Args()[1:], registry)
+		return
+	}
+
+	if flag.NArg() >= 1 && flag.Arg(0) == "admission-webhook" {
+		doAdmissionWebhook(flag.Args()[1:], registry)
+		return
+	}
+
+	if flag.NArg() >= 1 && flag.Arg(0) == "ct-tail" {
+		doCTTail(flag.Args()[1:], registry)
+		return
+	}
+
+	if flag.NArg() >= 1 && flag.Arg(0) == "crtsh-search" {
+		doCrtshSearch(flag.Args()[1:], registry)
+		return
+	}
+
+	if flag.NArg() >= 1 && flag.Arg(0) == "censys-search" {
+		doCensysSearch(flag.Args()[1:], registry)
+		return
+	}
+
+	if flag.NArg() >= 1 && flag.Arg(0) == "vault-audit" {
+		doVaultAudit(flag.Args()[1:], registry)
+		return
+	}
+
+	if flag.NArg() == 1 && flag.Arg(0) == "system-roots" {
+		doSystemRoots(registry)
+		return
+	}
+
+	if flag.NArg() >= 1 && flag.Arg(0) == "deploy-hook" {
+		status := doDeployHook(flag.Args()[1:], registry)
+		// os.Exit skips deferred calls, so send any alerts first.
+		alerts.close()
+		os.Exit(status)
+	}
+
+	if flag.NArg() == 2 && flag.Arg(0) == "bench" {
+		doBench(flag.Arg(1), inform, registry)
+		return
+	}
+
+	if batch {
+		filePaths := flag.Args()
+		if len(filePaths) == 0 {
+			filePaths = []string{"-"}
+		}
+		lintBatches(filePaths, inform, registry, parseWorkers, workers)
+		return
+	}
+
 	if flag.NArg() < 1 || flag.Arg(0) == "-" {
-		doLint(os.Stdin, inform, registry)
-	} else {
-		for _, filePath := range flag.Args() {
-			var inputFile *os.File
-			var err error
-			inputFile, err = os.Open(filePath)
-			if err != nil {
-				log.Fatalf("unable to open file %s: %s", filePath, err)
-			}
-			var fileInform = inform
-			switch {
-			case strings.HasSuffix(filePath, ".der"):
-				fileInform = "der"
-			case strings.HasSuffix(filePath, ".pem"):
-				fileInform = "pem"
-			}
-
-			doLint(inputFile, fileInform, registry)
-			inputFile.Close()
+		if err := doLint(os.Stdin, inform, registry); err != nil {
+			log.Fatal(err)
 		}
+	} else {
+		lintFiles(flag.Args(), inform, registry, workers)
+	}
+}
+
+// loadCTLogList returns the operators of the logs in the CT log list at path.
+func loadCTLogList(path string) (map[ct.SHA256Hash]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return util.ParseCTLogList(f)
+}
+
+// fileFormat returns the input format to use for filePath, which is inferred
+// from the file extension if possible and inform otherwise.
+func fileFormat(filePath, inform string) string {
+	switch {
+	case strings.HasSuffix(filePath, ".der"):
+		return "der"
+	case strings.HasSuffix(filePath, ".pem"):
+		return "pem"
+	}
+	return inform
+}
+
+func doLint(inputFile *os.File, inform string, registry lint.Registry) error {
+	c, err := parseCertificateFile(inputFile, inform)
+	if err != nil {
+		return err
+	}
+	zlintResult := lintCertificate(c, registry)
+	alerts.check(c, inputFile.Name(), zlintResult)
+	writeJSON(results(zlintResult, registry))
+	return nil
+}
+
+// lintCertificate lints c with the lints in registry and the settings in
+// lintConfig, skipping expensive lints if the -fast flag was provided. Any opts
+// are applied after these settings.
+func lintCertificate(c *x509.Certificate, registry lint.Registry, opts ...zlint.Option) *zlint.ResultSet {
+	options := []zlint.Option{zlint.WithConfig(lintConfig)}
+	if fast {
+		options = append(options, zlint.WithFast())
+	}
+	return zlint.LintCertificateEx(c, registry, append(options, opts...)...)
+}
+
+// results returns the results in rs to write, in compact form if the -compact
+// flag was provided.
+func results(rs *zlint.ResultSet, registry lint.Registry) interface{} {
+	if compact {
+		return rs.Compact(registry)
 	}
+	return rs.Results
 }
 
-func doLint(inputFile *os.File, inform string, registry lint.Registry) {
-	fileBytes, err := ioutil.ReadAll(inputFile)
+// parseCertificateFile reads and parses the certificate in inputFile,
+// returning an error rather than exiting if that isn't possible.
+func parseCertificateFile(inputFile *os.File, inform string) (*x509.Certificate, error) {
+	// Reading one byte more than the largest certificate allowed detects a
+	// batch file given without -batch without reading all of it.
+	fileBytes, err := ioutil.ReadAll(io.LimitReader(inputFile, maxCertificateSize*2+1))
 	if err != nil {
-		log.Fatalf("unable to read file %s: %s", inputFile.Name(), err)
+		return nil, fmt.Errorf("unable to read file %s: %s", inputFile.Name(), err)
+	}
+	if len(fileBytes) > maxCertificateSize*2 {
+		return nil, fmt.Errorf("file %s is too large to contain a single certificate, use -batch for files with more than one", inputFile.Name())
 	}
+	return zlint.ParseCertificate(fileBytes, inform)
+}
 
-	var asn1Data []byte
-	switch inform {
-	case "pem":
-		p, _ := pem.Decode(fileBytes)
-		if p == nil || p.Type != "CERTIFICATE" {
-			log.Fatal("unable to parse PEM")
+// doCrossPair checks that the two certificates in filePaths are consistent
+// certificates for the same key.
+func doCrossPair(filePaths []string, inform string) {
+	if len(filePaths) != 2 {
+		log.Fatal("-cross-pair requires exactly two certificate files")
+	}
+	var certs []*x509.Certificate
+	for _, filePath := range filePaths {
+		inputFile, err := os.Open(filePath)
+		if err != nil {
+			log.Fatalf("unable to open file %s: %s", filePath, err)
 		}
-		asn1Data = p.Bytes
-	case "der":
-		asn1Data = fileBytes
-	case "base64":
-		asn1Data, err = base64.StdEncoding.DecodeString(string(fileBytes))
+		c, err := parseCertificateFile(inputFile, fileFormat(filePath, inform))
+		inputFile.Close()
 		if err != nil {
-			log.Fatalf("unable to parse base64: %s", err)
+			log.Fatal(err)
 		}
-	default:
-		log.Fatalf("unknown input format %s", format)
+		certs = append(certs, c)
 	}
+	writeJSON(checkCrossPair(certs[0], certs[1]))
+}
 
-	c, err := x509.ParseCertificate(asn1Data)
+// doConnect lints the leaf certificate presented by the TLS server at addr. If
+// the -staple flag was provided the OCSP response stapled by the server is
+// checked as well and the output includes its result alongside the lint
+// results.
+func doConnect(addr string, registry lint.Registry) {
+	endpoint, err := fetchLiveEndpoint(addr, starttls)
 	if err != nil {
-		log.Fatalf("unable to parse certificate: %s", err)
+		log.Fatalf("unable to fetch certificate from %s: %s", addr, err)
 	}
 
-	zlintResult := zlint.LintCertificateEx(c, registry)
-	jsonBytes, err := json.Marshal(zlintResult.Results)
+	zlintResult := lintCertificate(endpoint.chain[0], registry)
+	if !checkStaple {
+		writeJSON(results(zlintResult, registry))
+		return
+	}
+	writeJSON(struct {
+		Results     interface{}      `json:"lints"`
+		StapledOCSP *lint.LintResult `json:"stapled_ocsp"`
+	}{
+		Results:     results(zlintResult, registry),
+		StapledOCSP: endpoint.checkStapledOCSP(),
+	})
+}
+
+// doACME lints every certificate in the chain issued for an ACME order, leaf
+// first, writing one line of results per certificate.
+func doACME(directoryURL, orderURL, keyFile string, registry lint.Registry) {
+	if orderURL == "" || keyFile == "" {
+		log.Fatal("-acme-directory requires -acme-order and -acme-account-key")
+	}
+	chain, err := fetchACMEChain(directoryURL, orderURL, keyFile)
 	if err != nil {
-		log.Fatalf("unable to encode lints JSON: %s", err)
+		log.Fatalf("unable to fetch ACME certificate chain: %s", err)
 	}
-	if prettyprint {
-		var out bytes.Buffer
-		if err := json.Indent(&out, jsonBytes, "", " "); err != nil {
-			log.Fatalf("can't format output: %s", err)
-		}
-		os.Stdout.Write(out.Bytes())
-	} else {
-		os.Stdout.Write(jsonBytes)
+	for _, c := range chain {
+		zlintResult := lintCertificate(c, registry)
+		writeJSON(results(zlintResult, registry))
+	}
+}
+
+// output encodes results directly to stdout. Encoding each value as it is
+// produced, rather than marshalling it to an intermediate buffer first, keeps
+// memory use flat when linting a large number of certificates.
+var output = json.NewEncoder(os.Stdout)
+
+// writeJSON writes v to stdout as a line of JSON, pretty-printed if requested
+// with the -pretty flag.
+func writeJSON(v interface{}) {
+	if err := output.Encode(v); err != nil {
+		log.Fatalf("unable to encode lints JSON: %s", err)
 	}
-	os.Stdout.Write([]byte{'\n'})
-	os.Stdout.Sync()
 }
 
 // trimmedList takes a comma separated string argument in raw, splits it by
@@ -175,15 +412,17 @@ func trimmedList(raw string) []string {
 }
 
 // setLints returns a filtered registry to use based on the nameFilter,
-// includeNames, excludeNames, includeSources, and excludeSources flag values in
-// use.
+// includeNames, excludeNames, includeSources, excludeSources, includeTags,
+// excludeTags and online flag values in use.
 func setLints() (lint.Registry, error) {
 	// If there's no filter options set, use the global registry as-is
-	if nameFilter == "" && includeNames == "" && excludeNames == "" && includeSources == "" && excludeSources == "" {
+	if nameFilter == "" && includeNames == "" && excludeNames == "" && includeSources == "" && excludeSources == "" && includeTags == "" && excludeTags == "" && online {
 		return lint.GlobalRegistry(), nil
 	}
 
-	filterOpts := lint.FilterOptions{}
+	filterOpts := lint.FilterOptions{
+		ExcludeOnline: !online,
+	}
 	if nameFilter != "" {
 		r, err := regexp.Compile(nameFilter)
 		if err != nil {
@@ -207,6 +446,12 @@ func setLints() (lint.Registry, error) {
 	if includeNames != "" {
 		filterOpts.IncludeNames = trimmedList(includeNames)
 	}
+	if excludeTags != "" {
+		filterOpts.ExcludeTags = trimmedList(excludeTags)
+	}
+	if includeTags != "" {
+		filterOpts.IncludeTags = trimmedList(includeTags)
+	}
 
 	return lint.GlobalRegistry().Filter(filterOpts)
 }
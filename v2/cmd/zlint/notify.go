@@ -0,0 +1,129 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// maxNotifiedLints is the number of failing lints listed in a Slack or Teams
+// message. Chat messages are read by people, and both services limit their
+// size.
+const maxNotifiedLints = 20
+
+// webhookPayload returns the body to POST for alert in the given format: the
+// alert itself for "json", or a chat message for "slack" and "teams".
+func webhookPayload(format string, alert *webhookAlert) (interface{}, error) {
+	switch format {
+	case "json":
+		return alert, nil
+	case "slack":
+		return slackMessage(alert), nil
+	case "teams":
+		return teamsMessage(alert), nil
+	}
+	return nil, fmt.Errorf("unknown webhook format %q", format)
+}
+
+// crtshURL returns the crt.sh page for the certificate in alert.
+func crtshURL(alert *webhookAlert) string {
+	return "https://crt.sh/?q=" + alert.Certificate.SHA256
+}
+
+// failingLints returns a line describing each failing lint in alert, sorted
+// by name and limited to maxNotifiedLints lines plus a line counting the
+// rest.
+func failingLints(alert *webhookAlert) []string {
+	names := make([]string, 0, len(alert.Lints))
+	for name := range alert.Lints {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var lines []string
+	for i, name := range names {
+		if i == maxNotifiedLints {
+			lines = append(lines, fmt.Sprintf("and %d more", len(names)-i))
+			break
+		}
+		result := alert.Lints[name]
+		line := fmt.Sprintf("%s (%s)", name, result.Status)
+		if result.Details != "" {
+			line += ": " + result.Details
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// slackMessage returns an incoming webhook message for alert.
+func slackMessage(alert *webhookAlert) map[string]interface{} {
+	escape := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace
+	var b strings.Builder
+	fmt.Fprintf(&b, ":rotating_light: *ZLint found %d failing lint(s)*\n", len(alert.Lints))
+	fmt.Fprintf(&b, "*Subject:* %s\n", escape(alert.Certificate.Subject))
+	fmt.Fprintf(&b, "*Issuer:* %s\n", escape(alert.Certificate.Issuer))
+	fmt.Fprintf(&b, "*Serial:* %s\n", alert.Certificate.SerialNumber)
+	if alert.Source != "" {
+		fmt.Fprintf(&b, "*Source:* %s\n", escape(alert.Source))
+	}
+	for _, line := range failingLints(alert) {
+		fmt.Fprintf(&b, "• %s\n", escape(line))
+	}
+	fmt.Fprintf(&b, "<%s|View on crt.sh>", crtshURL(alert))
+	return map[string]interface{}{"text": b.String()}
+}
+
+// teamsMessage returns a Teams workflow webhook message with an Adaptive
+// Card for alert.
+func teamsMessage(alert *webhookAlert) map[string]interface{} {
+	facts := []map[string]string{
+		{"title": "Subject", "value": alert.Certificate.Subject},
+		{"title": "Issuer", "value": alert.Certificate.Issuer},
+		{"title": "Serial", "value": alert.Certificate.SerialNumber},
+	}
+	if alert.Source != "" {
+		facts = append(facts, map[string]string{"title": "Source", "value": alert.Source})
+	}
+	body := []map[string]interface{}{
+		{
+			"type":   "TextBlock",
+			"text":   fmt.Sprintf("ZLint found %d failing lint(s)", len(alert.Lints)),
+			"weight": "bolder",
+			"size":   "medium",
+			"color":  "attention",
+		},
+		{"type": "FactSet", "facts": facts},
+	}
+	for _, line := range failingLints(alert) {
+		body = append(body, map[string]interface{}{"type": "TextBlock", "text": "- " + line, "wrap": true})
+	}
+	return map[string]interface{}{
+		"type": "message",
+		"attachments": []map[string]interface{}{{
+			"contentType": "application/vnd.microsoft.card.adaptive",
+			"content": map[string]interface{}{
+				"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+				"type":    "AdaptiveCard",
+				"version": "1.4",
+				"body":    body,
+				"actions": []map[string]string{
+					{"type": "Action.OpenUrl", "title": "View on crt.sh", "url": crtshURL(alert)},
+				},
+			},
+		}},
+	}
+}
@@ -0,0 +1,107 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/zmap/zlint/v2/lint"
+)
+
+func testAlert(lints int) *webhookAlert {
+	alert := &webhookAlert{
+		Source: "certs.der#3",
+		Certificate: alertCertificate{
+			SHA256:       "d68e163f",
+			Subject:      "CN=<script>, O=A & B",
+			Issuer:       "CN=Test CA",
+			SerialNumber: "1234",
+		},
+		Lints: make(map[string]*lint.LintResult),
+	}
+	for i := 0; i < lints; i++ {
+		alert.Lints[fmt.Sprintf("e_lint_%02d", i)] = &lint.LintResult{Status: lint.Error, Details: "bad"}
+	}
+	return alert
+}
+
+func TestSlackMessage(t *testing.T) {
+	text := slackMessage(testAlert(2))["text"].(string)
+	for _, expected := range []string{
+		"CN=&lt;script&gt;, O=A &amp; B",
+		"CN=Test CA",
+		"e_lint_00 (error): bad",
+		"e_lint_01 (error): bad",
+		"<https://crt.sh/?q=d68e163f|View on crt.sh>",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("expected message to contain %q, got:\n%s", expected, text)
+		}
+	}
+
+	text = slackMessage(testAlert(maxNotifiedLints + 5))["text"].(string)
+	if !strings.Contains(text, "and 5 more") || strings.Contains(text, fmt.Sprintf("e_lint_%02d", maxNotifiedLints)) {
+		t.Errorf("expected the lints listed to be limited to %d, got:\n%s", maxNotifiedLints, text)
+	}
+}
+
+func TestTeamsMessage(t *testing.T) {
+	data, err := json.Marshal(teamsMessage(testAlert(2)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var msg struct {
+		Type        string `json:"type"`
+		Attachments []struct {
+			ContentType string `json:"contentType"`
+			Content     struct {
+				Type string `json:"type"`
+				Body []struct {
+					Type  string `json:"type"`
+					Text  string `json:"text"`
+					Facts []struct {
+						Title string `json:"title"`
+						Value string `json:"value"`
+					} `json:"facts"`
+				} `json:"body"`
+				Actions []struct {
+					URL string `json:"url"`
+				} `json:"actions"`
+			} `json:"content"`
+		} `json:"attachments"`
+	}
+	if err := json.Unmarshal(data, &msg); err != nil {
+		t.Fatal(err)
+	}
+	if msg.Type != "message" || len(msg.Attachments) != 1 || msg.Attachments[0].Content.Type != "AdaptiveCard" {
+		t.Fatalf("expected a message with an Adaptive Card, got %s", data)
+	}
+	card := msg.Attachments[0].Content
+	if len(card.Body) != 4 || card.Body[1].Facts[0].Value != "CN=<script>, O=A & B" || card.Body[2].Text != "- e_lint_00 (error): bad" {
+		t.Errorf("unexpected card body %s", data)
+	}
+	if len(card.Actions) != 1 || card.Actions[0].URL != "https://crt.sh/?q=d68e163f" {
+		t.Errorf("expected a crt.sh link, got %s", data)
+	}
+}
+
+func TestWebhookPayloadFormat(t *testing.T) {
+	if _, err := newWebhookSink("http://localhost", "irc", "error"); err == nil {
+		t.Error("expected an error for an unknown webhook format")
+	}
+}
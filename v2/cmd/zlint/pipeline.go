@@ -0,0 +1,230 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"text/tabwriter"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+)
+
+// stageMetrics records how the goroutines of one pipeline stage spent their
+// time. A stage that is busy most of the time is the bottleneck; stages before
+// it spend time blocked on output and stages after it wait for input.
+type stageMetrics struct {
+	name    string
+	workers int
+	items   int64
+	// busy, idle and blocked are nanoseconds summed over the stage's workers.
+	busy    int64
+	idle    int64
+	blocked int64
+}
+
+// record adds the time spent from start to received waiting for input, from
+// received to done doing the stage's work and from done to handed off
+// blocked on the next stage to m.
+func (m *stageMetrics) record(start, received, done, handedOff time.Time) {
+	atomic.AddInt64(&m.items, 1)
+	atomic.AddInt64(&m.idle, int64(received.Sub(start)))
+	atomic.AddInt64(&m.busy, int64(done.Sub(received)))
+	atomic.AddInt64(&m.blocked, int64(handedOff.Sub(done)))
+}
+
+// writeStageMetrics writes a table of the metrics for stages over a run
+// lasting elapsed to w.
+func writeStageMetrics(w io.Writer, stages []*stageMetrics, elapsed time.Duration) {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "stage\tworkers\titems\tbusy\twaiting for input\tblocked on output\tutilization")
+	for _, m := range stages {
+		var utilization float64
+		if elapsed > 0 {
+			utilization = float64(m.busy) / float64(elapsed) / float64(m.workers)
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t%s\t%s\t%.0f%%\n",
+			m.name, m.workers, m.items,
+			time.Duration(m.busy).Round(time.Millisecond),
+			time.Duration(m.idle).Round(time.Millisecond),
+			time.Duration(m.blocked).Round(time.Millisecond),
+			100*utilization)
+	}
+	tw.Flush()
+}
+
+// batchItem is a certificate moving through the batch pipeline.
+type batchItem struct {
+	// index is the position of the certificate in its file, starting at 1.
+	index    int
+	fileName string
+	der      []byte
+	cert     *x509.Certificate
+	result   chan lintResult
+}
+
+// lintBatches lints every certificate in the batch files at filePaths, or
+// standard input if filePath is "-". Files are read one certificate at a time
+// so memory use doesn't depend on their size. Results are written in the
+// order the certificates appear in the files. If a certificate can't be read
+// or parsed the results for the certificates before it are written and the
+// program exits.
+//
+// The work is split into four stages connected by channels: decode reads each
+// certificate's DER encoding from the input, parseWorkers goroutines parse
+// them, lintWorkers goroutines lint them and encode writes the results. If
+// the -timing flag was provided metrics for each stage are written to stderr
+// at the end so the worker counts can be tuned.
+func lintBatches(filePaths []string, inform string, registry lint.Registry, parseWorkers, lintWorkers int) {
+	if parseWorkers < 1 {
+		parseWorkers = 1
+	}
+	if lintWorkers < 1 {
+		lintWorkers = 1
+	}
+	bound := maxPending
+	if bound < 1 {
+		bound = lintWorkers
+	}
+	decodeStage := &stageMetrics{name: "decode", workers: 1}
+	parseStage := &stageMetrics{name: "parse", workers: parseWorkers}
+	lintStage := &stageMetrics{name: "lint", workers: lintWorkers}
+	encodeStage := &stageMetrics{name: "encode", workers: 1}
+	begin := time.Now()
+
+	// pending holds the result channels in input order. Its capacity bounds
+	// the number of certificates in the pipeline.
+	pending := make(chan chan lintResult, bound)
+	parseQueue := make(chan *batchItem, parseWorkers)
+	lintQueue := make(chan *batchItem, lintWorkers)
+
+	go func() {
+		for _, filePath := range filePaths {
+			if err := decodeBatch(filePath, fileFormat(filePath, inform), decodeStage, pending, parseQueue); err != nil {
+				result := make(chan lintResult, 1)
+				result <- lintResult{err: err}
+				pending <- result
+				break
+			}
+		}
+		close(parseQueue)
+		close(pending)
+	}()
+
+	var parsers sync.WaitGroup
+	for i := 0; i < parseWorkers; i++ {
+		parsers.Add(1)
+		go func() {
+			defer parsers.Done()
+			for start := time.Now(); ; start = time.Now() {
+				item, ok := <-parseQueue
+				if !ok {
+					return
+				}
+				received := time.Now()
+				c, err := x509.ParseCertificate(item.der)
+				done := time.Now()
+				if err != nil {
+					item.result <- lintResult{err: fmt.Errorf("unable to parse certificate %d of %s: %s", item.index, item.fileName, err)}
+				} else {
+					item.cert, item.der = c, nil
+					lintQueue <- item
+				}
+				parseStage.record(start, received, done, time.Now())
+			}
+		}()
+	}
+	go func() {
+		parsers.Wait()
+		close(lintQueue)
+	}()
+
+	for i := 0; i < lintWorkers; i++ {
+		go func() {
+			for start := time.Now(); ; start = time.Now() {
+				item, ok := <-lintQueue
+				if !ok {
+					return
+				}
+				received := time.Now()
+				resultSet := lintCertificate(item.cert, registry)
+				done := time.Now()
+				item.result <- lintResult{
+					resultSet: resultSet,
+					cert:      item.cert,
+					source:    fmt.Sprintf("%s#%d", item.fileName, item.index),
+				}
+				lintStage.record(start, received, done, time.Now())
+			}
+		}()
+	}
+
+	for result := range pending {
+		start := time.Now()
+		r := <-result
+		if r.err != nil {
+			log.Fatal(r.err)
+		}
+		received := time.Now()
+		alerts.check(r.cert, r.source, r.resultSet)
+		writeJSON(results(r.resultSet, registry))
+		done := time.Now()
+		encodeStage.record(start, received, done, done)
+	}
+
+	if timing {
+		writeStageMetrics(os.Stderr, []*stageMetrics{decodeStage, parseStage, lintStage, encodeStage}, time.Since(begin))
+	}
+}
+
+// decodeBatch reads the certificates in the batch file at filePath, adding
+// each to pending and queueing it to be parsed.
+func decodeBatch(filePath, inform string, metrics *stageMetrics, pending chan<- chan lintResult, parseQueue chan<- *batchItem) error {
+	inputFile := os.Stdin
+	if filePath != "-" {
+		var err error
+		if inputFile, err = os.Open(filePath); err != nil {
+			return fmt.Errorf("unable to open file %s: %s", filePath, err)
+		}
+		defer inputFile.Close()
+	}
+	certs, err := newCertificateReader(inputFile, inform)
+	if err != nil {
+		return err
+	}
+	for i := 1; ; i++ {
+		start := time.Now()
+		der, err := certs.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("unable to read certificate %d of %s: %s", i, inputFile.Name(), err)
+		}
+		done := time.Now()
+		item := &batchItem{index: i, fileName: inputFile.Name(), der: der, result: make(chan lintResult, 1)}
+		pending <- item.result
+		parseQueue <- item
+		// Reading from the input is the decode stage's work, so it is never
+		// recorded as waiting for input.
+		metrics.record(start, start, done, time.Now())
+	}
+}
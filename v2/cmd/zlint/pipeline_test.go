@@ -0,0 +1,94 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+)
+
+func TestLintBatches(t *testing.T) {
+	batches, certs := testBatches(t)
+	registry, err := lint.GlobalRegistry().Filter(lint.FilterOptions{
+		IncludeNames: []string{"e_rsa_public_exponent_not_odd", "e_sub_cert_aia_missing", "w_sub_cert_aia_does_not_contain_issuing_ca_url"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Each batch file is linted twice to check results follow the order of
+	// the files as well as the certificates within them.
+	var expected bytes.Buffer
+	encoder := json.NewEncoder(&expected)
+	for i := 0; i < 2; i++ {
+		for _, der := range certs {
+			c, err := x509.ParseCertificate(der)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := encoder.Encode(lintCertificate(c, registry).Results); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	saved := maxPending
+	defer func() { maxPending = saved }()
+	dir := t.TempDir()
+	// The batch formats themselves are covered by TestCertificateReader.
+	for _, format := range []string{"der", "ndjson"} {
+		path := filepath.Join(dir, "batch."+format)
+		if err := ioutil.WriteFile(path, batches[format], 0600); err != nil {
+			t.Fatal(err)
+		}
+		for _, workers := range []struct{ parse, lint, maxPending int }{{1, 1, 0}, {2, 8, 0}, {4, 4, 1}} {
+			maxPending = workers.maxPending
+			var out bytes.Buffer
+			setOutput(t, &out)
+			lintBatches([]string{path, path}, format, registry, workers.parse, workers.lint)
+			if !bytes.Equal(out.Bytes(), expected.Bytes()) {
+				t.Errorf("%s with %d parse and %d lint workers: output differs from linting the certificates in order",
+					format, workers.parse, workers.lint)
+			}
+		}
+	}
+}
+
+func TestWriteStageMetrics(t *testing.T) {
+	m := &stageMetrics{name: "lint", workers: 2}
+	start := time.Now()
+	m.record(start, start.Add(time.Second), start.Add(3*time.Second), start.Add(4*time.Second))
+	m.record(start, start, start.Add(time.Second), start.Add(time.Second))
+
+	var out bytes.Buffer
+	writeStageMetrics(&out, []*stageMetrics{m}, 3*time.Second)
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected a header and one stage, got %q", out.String())
+	}
+	// Three seconds busy over two workers for three seconds is 50%.
+	expected := []string{"lint", "2", "2", "3s", "1s", "1s", "50%"}
+	if fields := strings.Fields(lines[1]); strings.Join(fields, " ") != strings.Join(expected, " ") {
+		t.Errorf("expected %v, got %v", expected, fields)
+	}
+}
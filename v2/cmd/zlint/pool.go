@@ -0,0 +1,118 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2"
+	"github.com/zmap/zlint/v2/lint"
+)
+
+// job is a unit of work waiting to be run by a worker, and the channel its
+// outcome is delivered on.
+type job struct {
+	run    func() lintResult
+	result chan lintResult
+}
+
+// lintResult is the outcome of reading and linting a single certificate.
+type lintResult struct {
+	resultSet *zlint.ResultSet
+	err       error
+	// cert and source identify the certificate in -webhook alerts.
+	cert   *x509.Certificate
+	source string
+}
+
+// lintFiles lints the certificates in filePaths using up to workers
+// goroutines. Results are written in the order of filePaths regardless of the
+// order in which they complete, so the output is the same as linting the files
+// one at a time. If a file can't be read or parsed the results for the files
+// before it are written and the program exits.
+func lintFiles(filePaths []string, inform string, registry lint.Registry, workers int) {
+	runOrdered(func(submit func(func() lintResult)) {
+		for _, filePath := range filePaths {
+			filePath, inform := filePath, fileFormat(filePath, inform)
+			submit(func() lintResult { return lintFile(filePath, inform, registry) })
+		}
+	}, registry, workers)
+}
+
+// runOrdered calls produce, which submits work by calling submit, and runs the
+// submitted work using up to workers goroutines. Results are written in the
+// order they were submitted, each as soon as it and the results before it are
+// ready. If a result has an error the results before it are written and the
+// program exits.
+//
+// Writing a result blocks until stdout accepts it. Once -max-pending results
+// are waiting to be written submit blocks too, so a slow consumer of the
+// output slows down reading the input rather than causing results to
+// accumulate in memory.
+func runOrdered(produce func(submit func(func() lintResult)), registry lint.Registry, workers int) {
+	if workers < 1 {
+		workers = 1
+	}
+	bound := maxPending
+	if bound < 1 {
+		bound = workers
+	}
+	jobs := make(chan job)
+	// pending holds the result channels in submission order. Its capacity
+	// bounds how far the workers can get ahead of the result due next.
+	pending := make(chan chan lintResult, bound)
+	go func() {
+		produce(func(run func() lintResult) {
+			j := job{run: run, result: make(chan lintResult, 1)}
+			pending <- j.result
+			jobs <- j
+		})
+		close(jobs)
+		close(pending)
+	}()
+	for i := 0; i < workers; i++ {
+		go func() {
+			for j := range jobs {
+				j.result <- j.run()
+			}
+		}()
+	}
+
+	for result := range pending {
+		r := <-result
+		if r.err != nil {
+			log.Fatal(r.err)
+		}
+		alerts.check(r.cert, r.source, r.resultSet)
+		writeJSON(results(r.resultSet, registry))
+	}
+}
+
+// lintFile reads, parses and lints the certificate in filePath.
+func lintFile(filePath, inform string, registry lint.Registry) lintResult {
+	inputFile, err := os.Open(filePath)
+	if err != nil {
+		return lintResult{err: fmt.Errorf("unable to open file %s: %s", filePath, err)}
+	}
+	defer inputFile.Close()
+	c, err := parseCertificateFile(inputFile, inform)
+	if err != nil {
+		return lintResult{err: err}
+	}
+	return lintResult{resultSet: lintCertificate(c, registry), cert: c, source: filePath}
+}
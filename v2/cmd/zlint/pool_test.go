@@ -0,0 +1,160 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zmap/zlint/v2"
+	"github.com/zmap/zlint/v2/lint"
+)
+
+// indexedResult returns a lintResult identifying the i-th submitted job.
+func indexedResult(i int) lintResult {
+	return lintResult{resultSet: &zlint.ResultSet{Results: map[string]*lint.LintResult{
+		"index": {Status: lint.Pass, Details: strconv.Itoa(i)},
+	}}}
+}
+
+// writtenIndexes decodes the indexes of the results written to out.
+func writtenIndexes(t *testing.T, out io.Reader) []int {
+	t.Helper()
+	var indexes []int
+	decoder := json.NewDecoder(out)
+	for decoder.More() {
+		var results map[string]*lint.LintResult
+		if err := decoder.Decode(&results); err != nil {
+			t.Fatal(err)
+		}
+		i, err := strconv.Atoi(results["index"].Details)
+		if err != nil {
+			t.Fatal(err)
+		}
+		indexes = append(indexes, i)
+	}
+	return indexes
+}
+
+// setOutput redirects writeJSON to w for the rest of the test.
+func setOutput(t *testing.T, w io.Writer) {
+	saved := output
+	output = json.NewEncoder(w)
+	t.Cleanup(func() { output = saved })
+}
+
+func TestRunOrdered(t *testing.T) {
+	const jobs = 20
+	for _, workers := range []int{1, 4, 32} {
+		var out bytes.Buffer
+		setOutput(t, &out)
+		runOrdered(func(submit func(func() lintResult)) {
+			for i := 0; i < jobs; i++ {
+				i := i
+				submit(func() lintResult {
+					// Later jobs finish first.
+					time.Sleep(time.Duration(jobs-i) * time.Millisecond)
+					return indexedResult(i)
+				})
+			}
+		}, nil, workers)
+
+		indexes := writtenIndexes(t, &out)
+		if len(indexes) != jobs {
+			t.Fatalf("workers %d: expected %d results, got %d", workers, jobs, len(indexes))
+		}
+		for i, index := range indexes {
+			if index != i {
+				t.Errorf("workers %d: expected result %d to be written in position %d, got %v", workers, i, i, indexes)
+				break
+			}
+		}
+	}
+}
+
+// blockingWriter blocks the first write until release is closed.
+type blockingWriter struct {
+	bytes.Buffer
+	once    sync.Once
+	started chan struct{}
+	release chan struct{}
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	w.once.Do(func() {
+		close(w.started)
+		<-w.release
+	})
+	return w.Buffer.Write(p)
+}
+
+func TestRunOrderedMaxPending(t *testing.T) {
+	const jobs, workers, bound = 20, 4, 2
+	saved := maxPending
+	maxPending = bound
+	defer func() { maxPending = saved }()
+	out := &blockingWriter{started: make(chan struct{}), release: make(chan struct{})}
+	setOutput(t, out)
+
+	var submitted, run int32
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		runOrdered(func(submit func(func() lintResult)) {
+			for i := 0; i < jobs; i++ {
+				i := i
+				submit(func() lintResult {
+					atomic.AddInt32(&run, 1)
+					return indexedResult(i)
+				})
+				atomic.AddInt32(&submitted, 1)
+			}
+		}, nil, workers)
+	}()
+
+	<-out.started
+	// While the first result is being written, one job has been handed to
+	// the writer and at most bound more can be waiting, so submitting the
+	// next one must block however long the writer takes.
+	deadline := time.Now().Add(5 * time.Second)
+	for atomic.LoadInt32(&submitted) < bound+1 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if n := atomic.LoadInt32(&submitted); n != bound+1 {
+		t.Errorf("expected %d jobs to be submitted while the output is blocked, got %d", bound+1, n)
+	}
+	if n := atomic.LoadInt32(&run); n > bound+1 {
+		t.Errorf("expected at most %d jobs to run while the output is blocked, got %d", bound+1, n)
+	}
+
+	close(out.release)
+	<-done
+	indexes := writtenIndexes(t, &out.Buffer)
+	if len(indexes) != jobs {
+		t.Fatalf("expected %d results, got %d", jobs, len(indexes))
+	}
+	for i, index := range indexes {
+		if index != i {
+			t.Fatalf("expected results in submission order, got %v", indexes)
+		}
+	}
+}
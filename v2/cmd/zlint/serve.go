@@ -0,0 +1,171 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/zmap/zlint/v2"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/metrics"
+)
+
+// doServe runs an HTTP server exposing the lints in registry, configured by
+// the flags in args (the arguments following "serve").
+func doServe(args []string, registry lint.Registry) {
+	serveFlags := flag.NewFlagSet("serve", flag.ExitOnError)
+	listen := serveFlags.String("listen", ":8080", "Address to listen on")
+	_ = serveFlags.Parse(args)
+
+	server := &http.Server{
+		Addr:         *listen,
+		Handler:      newServeMux(registry),
+		ReadTimeout:  30 * time.Second,
+		WriteTimeout: 30 * time.Second,
+	}
+	log.Infof("listening on %s", *listen)
+	log.Fatal(server.ListenAndServe())
+}
+
+// newServeMux returns the handler for the HTTP API:
+//
+//	POST /v1/lint     lint the certificate in the request body
+//	GET  /v1/lints    list the lints that would be run
+//	GET  /v1/sources  list the lint sources
+//	GET  /metrics     counters for the certificates linted, for Prometheus
+//
+// The certificate may be PEM, DER or base64 encoded. Its format is detected
+// unless given with the format query parameter. The includeNames,
+// excludeNames, includeSources, excludeSources, includeTags, excludeTags and
+// nameFilter query parameters select lints from registry like the command
+// line flags of the same names.
+func newServeMux(registry lint.Registry) *http.ServeMux {
+	mux := http.NewServeMux()
+	m := metrics.New()
+	mux.Handle("/metrics", m)
+	mux.HandleFunc("/v1/lint", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeHTTPError(w, http.StatusMethodNotAllowed, "use POST to lint a certificate")
+			return
+		}
+		filtered, err := filterFromQuery(registry, r.URL.Query())
+		if err != nil {
+			writeHTTPError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxCertificateSize*2))
+		if err != nil {
+			writeHTTPError(w, http.StatusRequestEntityTooLarge, "unable to read certificate: "+err.Error())
+			return
+		}
+		inform := r.URL.Query().Get("format")
+		if inform == "" {
+			inform = zlint.DetectFormat(body)
+		}
+		c, err := zlint.ParseCertificate(body, inform)
+		if err != nil {
+			m.ObserveFailure()
+			writeHTTPError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		start := time.Now()
+		rs := lintCertificate(c, filtered, zlint.WithContext(r.Context()))
+		m.Observe(rs, time.Since(start))
+		writeHTTPJSON(w, rs)
+	})
+	mux.HandleFunc("/v1/lints", func(w http.ResponseWriter, r *http.Request) {
+		filtered, err := filterFromQuery(registry, r.URL.Query())
+		if err != nil {
+			writeHTTPError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		lints := make([]*lint.Lint, 0, len(filtered.Names()))
+		for _, name := range filtered.Names() {
+			lints = append(lints, filtered.ByName(name))
+		}
+		writeHTTPJSON(w, lints)
+	})
+	mux.HandleFunc("/v1/sources", func(w http.ResponseWriter, r *http.Request) {
+		sources := registry.Sources()
+		sort.Sort(sources)
+		writeHTTPJSON(w, sources)
+	})
+	return mux
+}
+
+// filterFromQuery returns registry filtered with the lint selection query
+// parameters in query, or registry itself if there are none.
+func filterFromQuery(registry lint.Registry, query url.Values) (lint.Registry, error) {
+	var opts lint.FilterOptions
+	if v := query.Get("nameFilter"); v != "" {
+		r, err := regexp.Compile(v)
+		if err != nil {
+			return nil, fmt.Errorf("bad nameFilter: %v", err)
+		}
+		opts.NameFilter = r
+	}
+	if v := query.Get("excludeSources"); v != "" {
+		if err := opts.ExcludeSources.FromString(v); err != nil {
+			return nil, fmt.Errorf("invalid excludeSources: %v", err)
+		}
+	}
+	if v := query.Get("includeSources"); v != "" {
+		if err := opts.IncludeSources.FromString(v); err != nil {
+			return nil, fmt.Errorf("invalid includeSources: %v", err)
+		}
+	}
+	if v := query.Get("excludeNames"); v != "" {
+		opts.ExcludeNames = trimmedList(v)
+	}
+	if v := query.Get("includeNames"); v != "" {
+		opts.IncludeNames = trimmedList(v)
+	}
+	if v := query.Get("excludeTags"); v != "" {
+		opts.ExcludeTags = trimmedList(v)
+	}
+	if v := query.Get("includeTags"); v != "" {
+		opts.IncludeTags = trimmedList(v)
+	}
+	if opts.Empty() {
+		return registry, nil
+	}
+	return registry.Filter(opts)
+}
+
+// writeHTTPJSON writes v as the JSON response body.
+func writeHTTPJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Errorf("unable to write response: %s", err)
+	}
+}
+
+// writeHTTPError writes a JSON error response with the given status code.
+func writeHTTPError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(struct {
+		Error string `json:"error"`
+	}{message})
+}
@@ -0,0 +1,174 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zmap/zlint/v2"
+	"github.com/zmap/zlint/v2/lint"
+)
+
+func TestServeLint(t *testing.T) {
+	server := httptest.NewServer(newServeMux(lint.GlobalRegistry()))
+	defer server.Close()
+
+	pemBytes, err := ioutil.ReadFile("../../testdata/evAllGood.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(pemBytes)
+	encodings := map[string][]byte{
+		"pem":    pemBytes,
+		"der":    block.Bytes,
+		"base64": []byte(base64.StdEncoding.EncodeToString(block.Bytes)),
+	}
+	for name, body := range encodings {
+		t.Run(name, func(t *testing.T) {
+			resp, err := http.Post(server.URL+"/v1/lint?includeSources=CABF_EV", "application/octet-stream", bytes.NewReader(body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("expected status 200, got %d", resp.StatusCode)
+			}
+			var results zlint.ResultSet
+			if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := results.Results["e_ev_jurisdiction_country_missing"]; !ok {
+				t.Error("expected results for CABF EV lints")
+			}
+			if _, ok := results.Results["e_sub_cert_aia_missing"]; ok {
+				t.Error("expected no results for lints from other sources")
+			}
+		})
+	}
+
+	resp, err := http.Get(server.URL + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := fmt.Sprintf("zlint_certificates_total %d\n", len(encodings)); !strings.Contains(string(body), expected) {
+		t.Errorf("expected metrics to contain %q, got:\n%s", expected, body)
+	}
+}
+
+func TestServeLintCanceled(t *testing.T) {
+	pemBytes, err := ioutil.ReadFile("../../testdata/evAllGood.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := httptest.NewRequest(http.MethodPost, "/v1/lint?includeSources=CABF_EV", bytes.NewReader(pemBytes)).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	newServeMux(lint.GlobalRegistry()).ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	var results zlint.ResultSet
+	if err := json.NewDecoder(rec.Body).Decode(&results); err != nil {
+		t.Fatal(err)
+	}
+	if len(results.Results) == 0 {
+		t.Fatal("expected results for CABF EV lints")
+	}
+	for name, result := range results.Results {
+		if result.Status != lint.Canceled {
+			t.Errorf("expected %s to be canceled, got %s", name, result.Status)
+		}
+	}
+}
+
+func TestServeErrors(t *testing.T) {
+	server := httptest.NewServer(newServeMux(lint.GlobalRegistry()))
+	defer server.Close()
+
+	testCases := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		expected int
+	}{
+		{"lint with GET", http.MethodGet, "/v1/lint", "", http.StatusMethodNotAllowed},
+		{"not a certificate", http.MethodPost, "/v1/lint", "AQID", http.StatusBadRequest},
+		{"unknown source", http.MethodPost, "/v1/lint?includeSources=nope", "", http.StatusBadRequest},
+		{"bad name filter", http.MethodGet, "/v1/lints?nameFilter=(", "", http.StatusBadRequest},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(tc.method, server.URL+tc.path, bytes.NewBufferString(tc.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tc.expected {
+				t.Errorf("expected status %d, got %d", tc.expected, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestServeListLints(t *testing.T) {
+	server := httptest.NewServer(newServeMux(lint.GlobalRegistry()))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/v1/lints?includeTags=" + lint.TagExpensive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var lints []struct {
+		Name string   `json:"name"`
+		Tags []string `json:"tags"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&lints); err != nil {
+		t.Fatal(err)
+	}
+	if len(lints) == 0 {
+		t.Fatal("expected expensive lints to be listed")
+	}
+	for _, l := range lints {
+		var tagged bool
+		for _, tag := range l.Tags {
+			tagged = tagged || tag == lint.TagExpensive
+		}
+		if !tagged {
+			t.Errorf("%s: listed without the %s tag", l.Name, lint.TagExpensive)
+		}
+	}
+}
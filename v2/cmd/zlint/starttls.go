@@ -0,0 +1,273 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"bufio"
+	"encoding/asn1"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+)
+
+// startTLSProtocols are the protocols supported by -starttls.
+var startTLSProtocols = map[string]func(conn net.Conn) error{
+	"smtp":     startTLSSMTP,
+	"imap":     startTLSIMAP,
+	"pop3":     startTLSPOP3,
+	"ftp":      startTLSFTP,
+	"postgres": startTLSPostgres,
+	"ldap":     startTLSLDAP,
+}
+
+// startTLS asks the server on conn to switch to TLS using the given
+// application protocol's STARTTLS mechanism. On success the next bytes
+// exchanged on conn are the TLS handshake.
+func startTLS(conn net.Conn, protocol string) error {
+	negotiate, ok := startTLSProtocols[protocol]
+	if !ok {
+		return fmt.Errorf("unsupported -starttls protocol %q: must be one of smtp, imap, pop3, ftp, postgres or ldap", protocol)
+	}
+	if err := negotiate(conn); err != nil {
+		return fmt.Errorf("%s STARTTLS failed: %v", protocol, err)
+	}
+	return nil
+}
+
+// lineConn reads and writes the lines of a text protocol such as SMTP.
+type lineConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+func newLineConn(conn net.Conn) *lineConn {
+	return &lineConn{conn: conn, r: bufio.NewReader(conn)}
+}
+
+// readLine reads a line without its line ending.
+func (c *lineConn) readLine() (string, error) {
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// writeLine writes a command terminated by CRLF.
+func (c *lineConn) writeLine(command string) error {
+	_, err := io.WriteString(c.conn, command+"\r\n")
+	return err
+}
+
+// readReply reads an SMTP or FTP style reply, which may span several lines
+// ("250-..." followed by "250 ..."), and checks that it has the given code.
+func (c *lineConn) readReply(code string) error {
+	for {
+		line, err := c.readLine()
+		if err != nil {
+			return err
+		}
+		if !strings.HasPrefix(line, code) {
+			return fmt.Errorf("unexpected reply %q", line)
+		}
+		if len(line) == len(code) || line[len(code)] == ' ' {
+			return nil
+		}
+	}
+}
+
+// ready checks that the server hasn't sent anything after agreeing to start
+// TLS, which would otherwise be lost or, worse, be taken to have been sent
+// over TLS.
+func (c *lineConn) ready() error {
+	if c.r.Buffered() > 0 {
+		return errors.New("server sent unexpected data before the TLS handshake")
+	}
+	return nil
+}
+
+// startTLSSMTP negotiates TLS as described in RFC 3207.
+func startTLSSMTP(conn net.Conn) error {
+	c := newLineConn(conn)
+	if err := c.readReply("220"); err != nil {
+		return err
+	}
+	if err := c.writeLine("EHLO zlint"); err != nil {
+		return err
+	}
+	if err := c.readReply("250"); err != nil {
+		return err
+	}
+	if err := c.writeLine("STARTTLS"); err != nil {
+		return err
+	}
+	if err := c.readReply("220"); err != nil {
+		return err
+	}
+	return c.ready()
+}
+
+// startTLSIMAP negotiates TLS as described in RFC 2595.
+func startTLSIMAP(conn net.Conn) error {
+	c := newLineConn(conn)
+	greeting, err := c.readLine()
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(greeting, "* OK") {
+		return fmt.Errorf("unexpected greeting %q", greeting)
+	}
+	if err := c.writeLine("a1 STARTTLS"); err != nil {
+		return err
+	}
+	for {
+		line, err := c.readLine()
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(line, "* ") {
+			// Untagged responses may precede the command's result.
+			continue
+		}
+		if !strings.HasPrefix(line, "a1 OK") {
+			return fmt.Errorf("unexpected response %q", line)
+		}
+		return c.ready()
+	}
+}
+
+// startTLSPOP3 negotiates TLS as described in RFC 2595.
+func startTLSPOP3(conn net.Conn) error {
+	c := newLineConn(conn)
+	for _, command := range []string{"", "STLS"} {
+		if command != "" {
+			if err := c.writeLine(command); err != nil {
+				return err
+			}
+		}
+		line, err := c.readLine()
+		if err != nil {
+			return err
+		}
+		if !strings.HasPrefix(line, "+OK") {
+			return fmt.Errorf("unexpected response %q", line)
+		}
+	}
+	return c.ready()
+}
+
+// startTLSFTP negotiates TLS as described in RFC 4217.
+func startTLSFTP(conn net.Conn) error {
+	c := newLineConn(conn)
+	if err := c.readReply("220"); err != nil {
+		return err
+	}
+	if err := c.writeLine("AUTH TLS"); err != nil {
+		return err
+	}
+	if err := c.readReply("234"); err != nil {
+		return err
+	}
+	return c.ready()
+}
+
+// postgresSSLRequest is the code of the PostgreSQL SSLRequest message.
+const postgresSSLRequest = 80877103
+
+// startTLSPostgres sends a PostgreSQL SSLRequest message, which the server
+// answers with S if it is willing to perform a TLS handshake.
+func startTLSPostgres(conn net.Conn) error {
+	var request [8]byte
+	binary.BigEndian.PutUint32(request[0:], 8)
+	binary.BigEndian.PutUint32(request[4:], postgresSSLRequest)
+	if _, err := conn.Write(request[:]); err != nil {
+		return err
+	}
+	var response [1]byte
+	if _, err := io.ReadFull(conn, response[:]); err != nil {
+		return err
+	}
+	if response[0] != 'S' {
+		return fmt.Errorf("server does not support TLS (responded %q)", response[0])
+	}
+	return nil
+}
+
+// ldapStartTLSRequest is an LDAPMessage with message ID 1 holding the
+// StartTLS ExtendedRequest of RFC 4511 section 4.14.1.
+var ldapStartTLSRequest = []byte("\x30\x1d\x02\x01\x01\x77\x18\x80\x16" + "1.3.6.1.4.1.1466.20037")
+
+// startTLSLDAP sends an LDAP StartTLS extended operation and checks the
+// resultCode of the server's response.
+func startTLSLDAP(conn net.Conn) error {
+	if _, err := conn.Write(ldapStartTLSRequest); err != nil {
+		return err
+	}
+	msg, err := readBERElement(conn)
+	if err != nil {
+		return err
+	}
+	var response struct {
+		MessageID int
+		Response  asn1.RawValue
+	}
+	if _, err := asn1.Unmarshal(msg, &response); err != nil {
+		return fmt.Errorf("unable to parse response: %v", err)
+	}
+	if response.Response.Class != asn1.ClassApplication || response.Response.Tag != 24 {
+		return fmt.Errorf("unexpected response with tag %d", response.Response.Tag)
+	}
+	var resultCode asn1.Enumerated
+	if _, err := asn1.Unmarshal(response.Response.Bytes, &resultCode); err != nil {
+		return fmt.Errorf("unable to parse resultCode: %v", err)
+	}
+	if resultCode != 0 {
+		return fmt.Errorf("server returned resultCode %d", resultCode)
+	}
+	return nil
+}
+
+// readBERElement reads a single BER element with a definite length from r.
+func readBERElement(r io.Reader) ([]byte, error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+	length := int(header[1])
+	if length&0x80 != 0 {
+		n := length & 0x7f
+		if n == 0 || n > 3 {
+			return nil, errors.New("unsupported BER length")
+		}
+		lengthBytes := make([]byte, n)
+		if _, err := io.ReadFull(r, lengthBytes); err != nil {
+			return nil, err
+		}
+		header = append(header, lengthBytes...)
+		length = 0
+		for _, b := range lengthBytes {
+			length = length<<8 | int(b)
+		}
+	}
+	msg := make([]byte, len(header)+length)
+	copy(msg, header)
+	if _, err := io.ReadFull(r, msg[len(header):]); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
@@ -0,0 +1,151 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	stdx509 "crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"math/big"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// startTLSServers are the server sides of the STARTTLS exchanges, up to the
+// point the TLS handshake starts.
+var startTLSServers = map[string]func(conn net.Conn, r *bufio.Reader) error{
+	"smtp": func(conn net.Conn, r *bufio.Reader) error {
+		return converse(conn, r, "220-mail.example.com ESMTP\r\n220 ready\r\n",
+			"EHLO zlint", "250-mail.example.com\r\n250-PIPELINING\r\n250 STARTTLS\r\n",
+			"STARTTLS", "220 go ahead\r\n")
+	},
+	"imap": func(conn net.Conn, r *bufio.Reader) error {
+		return converse(conn, r, "* OK IMAP4rev1 ready\r\n",
+			"a1 STARTTLS", "* CAPABILITY IMAP4rev1\r\na1 OK begin TLS\r\n")
+	},
+	"pop3": func(conn net.Conn, r *bufio.Reader) error {
+		return converse(conn, r, "+OK POP3 ready\r\n", "STLS", "+OK begin TLS\r\n")
+	},
+	"ftp": func(conn net.Conn, r *bufio.Reader) error {
+		return converse(conn, r, "220 FTP ready\r\n", "AUTH TLS", "234 AUTH TLS OK\r\n")
+	},
+	"postgres": func(conn net.Conn, r *bufio.Reader) error {
+		request := make([]byte, 8)
+		if _, err := io.ReadFull(r, request); err != nil {
+			return err
+		}
+		if !bytes.Equal(request, []byte{0, 0, 0, 8, 0x04, 0xd2, 0x16, 0x2f}) {
+			return io.ErrUnexpectedEOF
+		}
+		_, err := conn.Write([]byte("S"))
+		return err
+	},
+	"ldap": func(conn net.Conn, r *bufio.Reader) error {
+		request, err := readBERElement(r)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(request, ldapStartTLSRequest) {
+			return io.ErrUnexpectedEOF
+		}
+		// ExtendedResponse with resultCode success and empty matchedDN and
+		// diagnosticMessage.
+		_, err = conn.Write([]byte{0x30, 0x0c, 0x02, 0x01, 0x01, 0x78, 0x07, 0x0a, 0x01, 0x00, 0x04, 0x00, 0x04, 0x00})
+		return err
+	},
+}
+
+// converse writes greeting and then, for each pair of the exchange, expects
+// a command and writes the reply.
+func converse(conn net.Conn, r *bufio.Reader, greeting string, exchange ...string) error {
+	if _, err := io.WriteString(conn, greeting); err != nil {
+		return err
+	}
+	for i := 0; i < len(exchange); i += 2 {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		if strings.TrimRight(line, "\r\n") != exchange[i] {
+			return io.ErrUnexpectedEOF
+		}
+		if _, err := io.WriteString(conn, exchange[i+1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestFetchLiveEndpointStartTLS(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := stdx509.CreateCertificate(rand.Reader, &stdx509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "mail.example.com"},
+		DNSNames:     []string{"mail.example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}, &stdx509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "mail.example.com"}}, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}}}
+
+	for protocol, serve := range startTLSServers {
+		t.Run(protocol, func(t *testing.T) {
+			ln, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer ln.Close()
+			go func() {
+				conn, err := ln.Accept()
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+				// The reader must not consume any of the TLS handshake,
+				// which is only sent once the server has replied.
+				if err := serve(conn, bufio.NewReader(conn)); err != nil {
+					t.Errorf("STARTTLS exchange failed: %v", err)
+					return
+				}
+				_ = tls.Server(conn, config).Handshake()
+			}()
+
+			endpoint, err := fetchLiveEndpoint(ln.Addr().String(), protocol)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(endpoint.chain) != 1 || !bytes.Equal(endpoint.chain[0].Raw, der) {
+				t.Errorf("expected the server's certificate, got %d certificates", len(endpoint.chain))
+			}
+		})
+	}
+
+	if err := startTLS(nil, "gopher"); err == nil {
+		t.Error("expected an error for an unsupported protocol")
+	}
+}
@@ -0,0 +1,99 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+)
+
+// systemCertificate is a certificate found in the platform trust store.
+type systemCertificate struct {
+	// location is where the certificate was found, e.g. a file or a Windows
+	// system store.
+	location string
+	der      []byte
+}
+
+// systemRootResult is the line of output written for each certificate in the
+// trust store.
+type systemRootResult struct {
+	Location string `json:"location"`
+	SHA256   string `json:"sha256"`
+	Subject  string `json:"subject,omitempty"`
+	// Error is set instead of Results if the certificate couldn't be parsed.
+	Error   string      `json:"error,omitempty"`
+	Results interface{} `json:"results,omitempty"`
+}
+
+// doSystemRoots lints the root and intermediate certificates in the platform
+// trust store, writing a line of output for each. See loadSystemRoots for
+// where they are found on each platform.
+func doSystemRoots(registry lint.Registry) {
+	certs, err := loadSystemRoots()
+	if err != nil {
+		log.Fatalf("unable to load the system trust store: %s", err)
+	}
+	if len(certs) == 0 {
+		log.Fatal("no certificates found in the system trust store")
+	}
+	lintSystemCertificates(certs, registry, writeJSON)
+}
+
+// lintSystemCertificates lints each of certs, passing the results to write.
+// A certificate found in several locations is only linted the first time.
+func lintSystemCertificates(certs []systemCertificate, registry lint.Registry, write func(interface{})) {
+	seen := make(map[[sha256.Size]byte]bool)
+	for _, sc := range certs {
+		fingerprint := sha256.Sum256(sc.der)
+		if seen[fingerprint] {
+			continue
+		}
+		seen[fingerprint] = true
+		result := systemRootResult{Location: sc.location, SHA256: hex.EncodeToString(fingerprint[:])}
+		c, err := x509.ParseCertificate(sc.der)
+		if err != nil {
+			result.Error = fmt.Sprintf("unable to parse certificate: %s", err)
+			write(result)
+			continue
+		}
+		result.Subject = c.Subject.String()
+		rs := lintCertificate(c, registry)
+		alerts.check(c, sc.location, rs)
+		result.Results = results(rs, registry)
+		write(result)
+	}
+}
+
+// pemCertificates returns the CERTIFICATE blocks in data, found at location.
+func pemCertificates(location string, data []byte) []systemCertificate {
+	var certs []systemCertificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return certs
+		}
+		if block.Type == "CERTIFICATE" {
+			certs = append(certs, systemCertificate{location: location, der: block.Bytes})
+		}
+	}
+}
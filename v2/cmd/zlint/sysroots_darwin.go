@@ -0,0 +1,42 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// systemKeychains are the macOS keychains holding the system's root and
+// administrator installed certificates.
+var systemKeychains = []string{
+	"/System/Library/Keychains/SystemRootCertificates.keychain",
+	"/Library/Keychains/System.keychain",
+}
+
+// loadSystemRoots exports the certificates in the system keychains with the
+// security command. Trust settings, which may distrust some of them, are not
+// taken into account.
+func loadSystemRoots() ([]systemCertificate, error) {
+	var certs []systemCertificate
+	for _, keychain := range systemKeychains {
+		out, err := exec.Command("/usr/bin/security", "find-certificate", "-a", "-p", keychain).Output()
+		if err != nil {
+			return nil, fmt.Errorf("unable to export certificates from %s: %v", keychain, err)
+		}
+		certs = append(certs, pemCertificates(keychain, out)...)
+	}
+	return certs, nil
+}
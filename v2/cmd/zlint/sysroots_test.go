@@ -0,0 +1,84 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/zmap/zlint/v2/lint"
+)
+
+func TestLintSystemCertificates(t *testing.T) {
+	var certs []systemCertificate
+	for _, name := range []string{"badRsaExp.pem", "evAllGood.pem"} {
+		data, err := ioutil.ReadFile(filepath.Join("../../testdata", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		certs = append(certs, pemCertificates(name, data)...)
+	}
+	// A duplicate found elsewhere in the trust store, and garbage.
+	certs = append(certs,
+		systemCertificate{location: "duplicate", der: certs[0].der},
+		systemCertificate{location: "garbage", der: []byte("not a certificate")})
+
+	registry, err := lint.GlobalRegistry().Filter(lint.FilterOptions{IncludeNames: []string{"e_rsa_public_exponent_not_odd"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out []systemRootResult
+	lintSystemCertificates(certs, registry, func(v interface{}) {
+		// Round trip through JSON like the output written by the command.
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var result systemRootResult
+		if err := json.Unmarshal(data, &result); err != nil {
+			t.Fatal(err)
+		}
+		out = append(out, result)
+	})
+
+	if len(out) != 3 {
+		t.Fatalf("expected 3 results, got %d: %+v", len(out), out)
+	}
+	expected := []struct {
+		location string
+		results  string
+	}{
+		{"badRsaExp.pem", "map[e_rsa_public_exponent_not_odd:map[result:error]]"},
+		{"evAllGood.pem", "map[e_rsa_public_exponent_not_odd:map[result:pass]]"},
+		{"garbage", "<nil>"},
+	}
+	for i, e := range expected {
+		if out[i].Location != e.location {
+			t.Errorf("result %d: expected location %q, got %q", i, e.location, out[i].Location)
+		}
+		if fmt.Sprint(out[i].Results) != e.results {
+			t.Errorf("%s: unexpected results %v", e.location, out[i].Results)
+		}
+		if (out[i].Error != "") != (e.location == "garbage") {
+			t.Errorf("%s: unexpected error %q", e.location, out[i].Error)
+		}
+		if len(out[i].SHA256) != 64 {
+			t.Errorf("%s: unexpected fingerprint %q", e.location, out[i].SHA256)
+		}
+	}
+}
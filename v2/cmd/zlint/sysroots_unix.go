@@ -0,0 +1,92 @@
+//go:build !windows && !darwin
+// +build !windows,!darwin
+
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// systemRootFiles are the CA bundle files of the various Linux distributions
+// and BSDs, as searched by crypto/x509. Only the first that exists is read.
+var systemRootFiles = []string{
+	"/etc/ssl/certs/ca-certificates.crt",                // Debian, Ubuntu, Gentoo, Arch
+	"/etc/pki/tls/certs/ca-bundle.crt",                  // Fedora, RHEL 6
+	"/etc/ssl/ca-bundle.pem",                            // OpenSUSE
+	"/etc/pki/tls/cacert.pem",                           // OpenELEC
+	"/etc/pki/ca-trust/extracted/pem/tls-ca-bundle.pem", // CentOS, RHEL 7
+	"/etc/ssl/cert.pem",                                 // Alpine, OpenBSD
+	"/usr/local/etc/ssl/cert.pem",                       // FreeBSD
+	"/usr/local/share/certs/ca-root-nss.crt",            // DragonFly
+	"/etc/openssl/certs/ca-certificates.crt",            // NetBSD
+}
+
+// systemRootDirs are the directories of individual CA certificates, all of
+// which are read.
+var systemRootDirs = []string{
+	"/etc/ssl/certs",     // SLES, Android
+	"/etc/pki/tls/certs", // Fedora, RHEL
+}
+
+// loadSystemRoots reads the certificates in the CA bundle file and the
+// certificate directories used by OpenSSL and crypto/x509. Like them, it
+// reads the file named by $SSL_CERT_FILE and the directories listed in
+// $SSL_CERT_DIR instead if they are set.
+func loadSystemRoots() ([]systemCertificate, error) {
+	files, dirs := systemRootFiles, systemRootDirs
+	if f := os.Getenv("SSL_CERT_FILE"); f != "" {
+		files = []string{f}
+	}
+	if d := os.Getenv("SSL_CERT_DIR"); d != "" {
+		dirs = strings.Split(d, ":")
+	}
+
+	var certs []systemCertificate
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		certs = append(certs, pemCertificates(file, data)...)
+		break
+	}
+	for _, dir := range dirs {
+		entries, err := ioutil.ReadDir(dir)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			path := filepath.Join(dir, entry.Name())
+			// Follow symlinks, which is how the certificates in
+			// /etc/ssl/certs usually are installed, but skip directories
+			// and unreadable files.
+			data, err := ioutil.ReadFile(path)
+			if err != nil {
+				continue
+			}
+			certs = append(certs, pemCertificates(path, data)...)
+		}
+	}
+	return certs, nil
+}
@@ -0,0 +1,83 @@
+//go:build !windows && !darwin
+// +build !windows,!darwin
+
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadSystemRoots(t *testing.T) {
+	dir, err := ioutil.TempDir("", "zlint-system-roots")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	bundle, err := ioutil.ReadFile("../../testdata/rootCAValid.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	intermediate, err := ioutil.ReadFile("../../testdata/badRsaExp.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bundlePath := filepath.Join(dir, "bundle.pem")
+	certsDir := filepath.Join(dir, "certs")
+	if err := os.Mkdir(certsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(bundlePath, bundle, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(certsDir, "intermediate.pem"), intermediate, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(certsDir, "README"), []byte("not a certificate"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// Directories in the certificate directory are skipped.
+	if err := os.Mkdir(filepath.Join(certsDir, "subdir"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, value := range map[string]string{
+		"SSL_CERT_FILE": bundlePath,
+		"SSL_CERT_DIR":  filepath.Join(dir, "missing") + ":" + certsDir,
+	} {
+		old, ok := os.LookupEnv(name)
+		os.Setenv(name, value)
+		if ok {
+			defer os.Setenv(name, old)
+		} else {
+			defer os.Unsetenv(name)
+		}
+	}
+
+	certs, err := loadSystemRoots()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(certs) != 2 {
+		t.Fatalf("expected 2 certificates, got %d", len(certs))
+	}
+	if certs[0].location != bundlePath || certs[1].location != filepath.Join(certsDir, "intermediate.pem") {
+		t.Errorf("unexpected locations %q and %q", certs[0].location, certs[1].location)
+	}
+}
@@ -0,0 +1,57 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+// systemStores are the Windows system certificate stores holding root and
+// intermediate certificates.
+var systemStores = []string{"ROOT", "CA"}
+
+// loadSystemRoots enumerates the certificates in the current user's view of
+// the ROOT and CA system stores, which includes the local machine's
+// certificates.
+func loadSystemRoots() ([]systemCertificate, error) {
+	var certs []systemCertificate
+	for _, name := range systemStores {
+		storeName, err := syscall.UTF16PtrFromString(name)
+		if err != nil {
+			return nil, err
+		}
+		store, err := syscall.CertOpenSystemStore(0, storeName)
+		if err != nil {
+			return nil, fmt.Errorf("unable to open the %s store: %v", name, err)
+		}
+		var ctx *syscall.CertContext
+		for {
+			// The previous context is freed by the next call, so the
+			// encoded certificate must be copied.
+			ctx, _ = syscall.CertEnumCertificatesInStore(store, ctx)
+			if ctx == nil {
+				break
+			}
+			encoded := (*[1 << 20]byte)(unsafe.Pointer(ctx.EncodedCert))[:ctx.Length:ctx.Length]
+			der := make([]byte, len(encoded))
+			copy(der, encoded)
+			certs = append(certs, systemCertificate{location: "windows:" + name, der: der})
+		}
+		_ = syscall.CertCloseStore(store, 0)
+	}
+	return certs, nil
+}
@@ -0,0 +1,340 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2"
+	"github.com/zmap/zlint/v2/lint"
+)
+
+const (
+	// vaultIssuerRole is the role key of the CA certificates in a mount.
+	vaultIssuerRole = "(issuer)"
+	// vaultUnknownRole is the role key of certificates no role allows.
+	vaultUnknownRole = "(unknown)"
+)
+
+// vaultReport is the output of vault-audit: the certificates issued by a PKI
+// mount grouped by the role that could have issued them.
+type vaultReport struct {
+	Mount string                      `json:"mount"`
+	Roles map[string]*vaultRoleReport `json:"roles"`
+}
+
+// vaultRoleReport describes the certificates attributed to a role.
+type vaultRoleReport struct {
+	// Counts is the number of certificates by their worst lint status, e.g.
+	// "error" or "pass".
+	Counts       map[string]int            `json:"counts"`
+	Certificates []*vaultCertificateReport `json:"certificates"`
+}
+
+// vaultCertificateReport describes a certificate stored by the mount. Its
+// Findings are the lint results with a status of info or worse.
+type vaultCertificateReport struct {
+	Serial      string                      `json:"serial"`
+	Subject     string                      `json:"subject,omitempty"`
+	NotAfter    time.Time                   `json:"not_after"`
+	Revoked     bool                        `json:"revoked,omitempty"`
+	Error       string                      `json:"error,omitempty"`
+	WorstStatus lint.LintStatus             `json:"worst_status,omitempty"`
+	Findings    map[string]*lint.LintResult `json:"findings,omitempty"`
+	certificate *x509.Certificate
+}
+
+// vaultRole is the part of a PKI role's configuration that decides which
+// names it may issue certificates for.
+type vaultRole struct {
+	name             string
+	AllowedDomains   []string `json:"allowed_domains"`
+	AllowBareDomains bool     `json:"allow_bare_domains"`
+	AllowSubdomains  bool     `json:"allow_subdomains"`
+	AllowGlobDomains bool     `json:"allow_glob_domains"`
+	AllowAnyName     bool     `json:"allow_any_name"`
+	AllowLocalhost   bool     `json:"allow_localhost"`
+}
+
+// vaultClient reads a PKI secrets engine mount with the Vault HTTP API.
+type vaultClient struct {
+	addr      string
+	token     string
+	namespace string
+	mount     string
+	client    *http.Client
+}
+
+// doVaultAudit lints the certificates stored by a Vault PKI mount, configured
+// by the flags in args (the arguments following "vault-audit"), and writes a
+// report of the findings by role. The Vault address, token and namespace are
+// read from the VAULT_ADDR, VAULT_TOKEN and VAULT_NAMESPACE environment
+// variables, like the vault command.
+func doVaultAudit(args []string, registry lint.Registry) {
+	auditFlags := flag.NewFlagSet("vault-audit", flag.ExitOnError)
+	mount := auditFlags.String("mount", "pki", "Path of the PKI secrets engine mount")
+	parallel := auditFlags.Int("parallel", 8, "Number of certificates to read and lint at a time")
+	_ = auditFlags.Parse(args)
+	c := &vaultClient{
+		addr:      strings.TrimSuffix(os.Getenv("VAULT_ADDR"), "/"),
+		token:     os.Getenv("VAULT_TOKEN"),
+		namespace: os.Getenv("VAULT_NAMESPACE"),
+		mount:     strings.Trim(*mount, "/"),
+		client:    &http.Client{Timeout: 30 * time.Second},
+	}
+	if c.addr == "" || c.token == "" {
+		log.Fatal("vault-audit requires the VAULT_ADDR and VAULT_TOKEN environment variables")
+	}
+	report, err := c.audit(registry, *parallel)
+	if err != nil {
+		log.Fatalf("unable to audit Vault mount %s: %s", c.mount, err)
+	}
+	writeJSON(report)
+}
+
+// audit reads the mount's roles and certificates and lints the certificates,
+// parallel at a time.
+func (c *vaultClient) audit(registry lint.Registry, parallel int) (*vaultReport, error) {
+	roles, err := c.roles()
+	if err != nil {
+		return nil, err
+	}
+	serials, err := c.list("certs")
+	if err != nil {
+		return nil, err
+	}
+	if parallel < 1 {
+		parallel = 1
+	}
+	certs := make([]*vaultCertificateReport, len(serials))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < parallel; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				certs[i] = c.lintCertificate(serials[i], registry)
+			}
+		}()
+	}
+	for i := range serials {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	report := &vaultReport{Mount: c.mount, Roles: make(map[string]*vaultRoleReport)}
+	for _, cert := range certs {
+		for _, role := range attributeRoles(cert.certificate, roles) {
+			rr := report.Roles[role]
+			if rr == nil {
+				rr = &vaultRoleReport{Counts: make(map[string]int)}
+				report.Roles[role] = rr
+			}
+			rr.Certificates = append(rr.Certificates, cert)
+			if cert.Error == "" {
+				rr.Counts[cert.WorstStatus.String()]++
+			}
+		}
+	}
+	return report, nil
+}
+
+// lintCertificate reads and lints the certificate with the given serial.
+func (c *vaultClient) lintCertificate(serial string, registry lint.Registry) *vaultCertificateReport {
+	report := &vaultCertificateReport{Serial: serial}
+	var resp struct {
+		Data struct {
+			Certificate    string `json:"certificate"`
+			RevocationTime int64  `json:"revocation_time"`
+		} `json:"data"`
+	}
+	if err := c.get("cert/"+url.PathEscape(serial), nil, &resp); err != nil {
+		report.Error = fmt.Sprintf("unable to read certificate: %s", err)
+		return report
+	}
+	cert, err := zlint.ParseCertificate([]byte(resp.Data.Certificate), "pem")
+	if err != nil {
+		report.Error = fmt.Sprintf("unable to parse certificate: %s", err)
+		return report
+	}
+	report.certificate = cert
+	report.Subject = cert.Subject.String()
+	report.NotAfter = cert.NotAfter
+	report.Revoked = resp.Data.RevocationTime != 0
+	rs := lintCertificate(cert, registry)
+	alerts.check(cert, "vault:"+c.mount+"/cert/"+serial, rs)
+	report.WorstStatus = lint.Pass
+	report.Findings = rs.Failing(lint.Notice)
+	if worst := rs.MaxStatus(); worst > lint.Pass {
+		report.WorstStatus = worst
+	}
+	return report
+}
+
+// attributeRoles returns the names of the roles that allow every DNS name in
+// c. Vault doesn't record which role issued a certificate, so this can only
+// be inferred from the roles' configuration, and a certificate may be
+// attributed to several roles. CA certificates are attributed to
+// vaultIssuerRole, and certificates no role allows (including those that
+// couldn't be read) to vaultUnknownRole.
+func attributeRoles(c *x509.Certificate, roles []*vaultRole) []string {
+	if c == nil {
+		return []string{vaultUnknownRole}
+	}
+	if c.IsCA {
+		return []string{vaultIssuerRole}
+	}
+	names := c.DNSNames
+	if len(names) == 0 && c.Subject.CommonName != "" {
+		names = []string{c.Subject.CommonName}
+	}
+	var matched []string
+	for _, role := range roles {
+		allowed := len(names) > 0 || role.AllowAnyName
+		for _, name := range names {
+			if !role.allows(name) {
+				allowed = false
+				break
+			}
+		}
+		if allowed {
+			matched = append(matched, role.name)
+		}
+	}
+	if matched == nil {
+		return []string{vaultUnknownRole}
+	}
+	return matched
+}
+
+// allows returns whether the role permits issuing for name.
+func (r *vaultRole) allows(name string) bool {
+	name = strings.ToLower(name)
+	if r.AllowAnyName || (r.AllowLocalhost && name == "localhost") {
+		return true
+	}
+	for _, domain := range r.AllowedDomains {
+		domain = strings.ToLower(domain)
+		switch {
+		case r.AllowBareDomains && name == domain:
+			return true
+		case r.AllowSubdomains && strings.HasSuffix(name, "."+strings.TrimPrefix(domain, "*.")):
+			return true
+		case r.AllowGlobDomains && strings.Contains(domain, "*"):
+			if ok, _ := path.Match(domain, name); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// roles reads the configuration of the mount's roles, sorted by name.
+func (c *vaultClient) roles() ([]*vaultRole, error) {
+	names, err := c.list("roles")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	roles := make([]*vaultRole, 0, len(names))
+	for _, name := range names {
+		var resp struct {
+			Data *vaultRole `json:"data"`
+		}
+		if err := c.get("roles/"+url.PathEscape(name), nil, &resp); err != nil {
+			return nil, fmt.Errorf("unable to read role %s: %v", name, err)
+		}
+		if resp.Data == nil {
+			return nil, fmt.Errorf("role %s has no data", name)
+		}
+		resp.Data.name = name
+		roles = append(roles, resp.Data)
+	}
+	return roles, nil
+}
+
+// errVaultNotFound is returned by get for a 404 response, which Vault also
+// uses for listing an empty path.
+var errVaultNotFound = errors.New("not found")
+
+// list returns the keys under the given path of the mount.
+func (c *vaultClient) list(p string) ([]string, error) {
+	var resp struct {
+		Data struct {
+			Keys []string `json:"keys"`
+		} `json:"data"`
+	}
+	err := c.get(p, url.Values{"list": {"true"}}, &resp)
+	if err == errVaultNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to list %s: %v", p, err)
+	}
+	return resp.Data.Keys, nil
+}
+
+// get decodes the JSON response for the given path of the mount into v.
+func (c *vaultClient) get(p string, query url.Values, v interface{}) error {
+	u := c.addr + "/v1/" + c.mount + "/" + p
+	if query != nil {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Vault-Token", c.token)
+	if c.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", c.namespace)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return errVaultNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		var vaultErr struct {
+			Errors []string `json:"errors"`
+		}
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 4096))
+		_ = json.Unmarshal(body, &vaultErr)
+		msg := "Vault returned " + resp.Status
+		if len(vaultErr.Errors) > 0 {
+			msg += ": " + strings.Join(vaultErr.Errors, "; ")
+		}
+		return errors.New(msg)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
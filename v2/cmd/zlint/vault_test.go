@@ -0,0 +1,120 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+)
+
+func TestVaultAudit(t *testing.T) {
+	certs := make(map[string]string)
+	for serial, file := range map[string]string{"01": "badRsaExp.pem", "02": "evAllGood.pem"} {
+		data, err := ioutil.ReadFile("../../testdata/" + file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		certs[serial] = string(data)
+	}
+	certs["03"] = "not a certificate"
+	roles := map[string]string{
+		"gov": `{"allowed_domains":["gov.us"],"allow_bare_domains":true,"allow_subdomains":true}`,
+		"any": `{"allow_any_name":true}`,
+		"tr":  `{"allowed_domains":["kepkur.com.tr"],"allow_subdomains":true}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"errors":["permission denied"]}`)
+			return
+		}
+		switch p := r.URL.Path; {
+		case p == "/v1/pki/roles" && r.URL.Query().Get("list") == "true":
+			fmt.Fprint(w, `{"data":{"keys":["gov","any","tr"]}}`)
+		case p == "/v1/pki/certs" && r.URL.Query().Get("list") == "true":
+			fmt.Fprint(w, `{"data":{"keys":["01","02","03"]}}`)
+		case strings.HasPrefix(p, "/v1/pki/roles/"):
+			fmt.Fprintf(w, `{"data":%s}`, roles[strings.TrimPrefix(p, "/v1/pki/roles/")])
+		case strings.HasPrefix(p, "/v1/pki/cert/"):
+			serial := strings.TrimPrefix(p, "/v1/pki/cert/")
+			revocationTime := 0
+			if serial == "02" {
+				revocationTime = 1600000000
+			}
+			data, _ := json.Marshal(certs[serial])
+			fmt.Fprintf(w, `{"data":{"certificate":%s,"revocation_time":%d}}`, data, revocationTime)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	registry, err := lint.GlobalRegistry().Filter(lint.FilterOptions{IncludeNames: []string{"e_rsa_public_exponent_not_odd"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &vaultClient{addr: server.URL, token: "token", mount: "pki", client: server.Client()}
+	report, err := c.audit(registry, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serials := func(role string) []string {
+		var s []string
+		if rr := report.Roles[role]; rr != nil {
+			for _, cert := range rr.Certificates {
+				s = append(s, cert.Serial)
+			}
+		}
+		return s
+	}
+	for role, expected := range map[string][]string{
+		"any":            {"01", "02"},
+		"gov":            {"01"},
+		"tr":             nil,
+		vaultUnknownRole: {"03"},
+	} {
+		if got := serials(role); !reflect.DeepEqual(got, expected) {
+			t.Errorf("role %s: expected certificates %v, got %v", role, expected, got)
+		}
+	}
+	gov := report.Roles["gov"]
+	if gov.Counts["error"] != 1 || gov.Certificates[0].Findings["e_rsa_public_exponent_not_odd"] == nil {
+		t.Errorf("expected an error finding for the gov role, got %+v", gov)
+	}
+	if any := report.Roles["any"]; !any.Certificates[1].Revoked || any.Certificates[1].WorstStatus != lint.Pass {
+		t.Errorf("expected serial 02 to be revoked and pass, got %+v", any.Certificates[1])
+	}
+	if report.Roles[vaultUnknownRole].Certificates[0].Error == "" {
+		t.Error("expected an error for serial 03")
+	}
+
+	c.token = "wrong"
+	if _, err := c.audit(registry, 1); err == nil {
+		t.Error("expected an error with the wrong token")
+	}
+	if roles := attributeRoles(&x509.Certificate{IsCA: true}, nil); !reflect.DeepEqual(roles, []string{vaultIssuerRole}) {
+		t.Errorf("expected a CA certificate to be attributed to %s, got %v", vaultIssuerRole, roles)
+	}
+}
@@ -0,0 +1,175 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2"
+	"github.com/zmap/zlint/v2/lint"
+)
+
+// webhookAttempts is the number of times an alert is POSTed before giving up.
+const webhookAttempts = 3
+
+// webhookAlert is the JSON body POSTed to the -webhook URL for a certificate
+// with results meeting the -webhook-threshold.
+type webhookAlert struct {
+	// Source identifies where the certificate was read from, e.g. a file name
+	// or a CT log entry.
+	Source      string                      `json:"source,omitempty"`
+	Certificate alertCertificate            `json:"certificate"`
+	Lints       map[string]*lint.LintResult `json:"lints"`
+}
+
+// alertCertificate identifies the certificate an alert is about.
+type alertCertificate struct {
+	SHA256       string    `json:"sha256"`
+	Subject      string    `json:"subject"`
+	Issuer       string    `json:"issuer"`
+	SerialNumber string    `json:"serial_number"`
+	NotBefore    time.Time `json:"not_before"`
+}
+
+// webhookSink POSTs alerts to a webhook from a background goroutine, so a slow
+// webhook doesn't hold up linting until its queue fills up.
+type webhookSink struct {
+	url       string
+	format    string
+	threshold lint.LintStatus
+	client    *http.Client
+	queue     chan *webhookAlert
+	done      chan struct{}
+}
+
+// alerts is the sink configured with the -webhook flags, or nil if there is
+// none.
+var alerts *webhookSink
+
+// newWebhookSink returns a sink POSTing alerts to url for certificates with
+// results at least as severe as threshold (one of info, warn, error or fatal).
+// The alerts are formatted as described by webhookPayload.
+func newWebhookSink(url, format, threshold string) (*webhookSink, error) {
+	if _, err := webhookPayload(format, &webhookAlert{}); err != nil {
+		return nil, err
+	}
+	status, err := parseThreshold("webhook-threshold", threshold)
+	if err != nil {
+		return nil, err
+	}
+	s := &webhookSink{
+		url:       url,
+		format:    format,
+		threshold: status,
+		client:    &http.Client{Timeout: 10 * time.Second},
+		queue:     make(chan *webhookAlert, 100),
+		done:      make(chan struct{}),
+	}
+	go s.run()
+	return s, nil
+}
+
+// check queues an alert for c if any of its results in rs meets the
+// threshold. It is a no-op on a nil sink.
+func (s *webhookSink) check(c *x509.Certificate, source string, rs *zlint.ResultSet) {
+	if s == nil || c == nil || rs == nil {
+		return
+	}
+	failing := rs.Failing(s.threshold)
+	if failing == nil {
+		return
+	}
+	fingerprint := sha256.Sum256(c.Raw)
+	s.queue <- &webhookAlert{
+		Source: source,
+		Certificate: alertCertificate{
+			SHA256:       hex.EncodeToString(fingerprint[:]),
+			Subject:      c.Subject.String(),
+			Issuer:       c.Issuer.String(),
+			SerialNumber: c.SerialNumber.String(),
+			NotBefore:    c.NotBefore,
+		},
+		Lints: failing,
+	}
+}
+
+// parseThreshold parses the value of the named severity threshold flag, one
+// of info, warn, error or fatal.
+func parseThreshold(flagName, value string) (lint.LintStatus, error) {
+	var status lint.LintStatus
+	if err := status.UnmarshalJSON([]byte(strconv.Quote(value))); err != nil || status < lint.Notice || status > lint.Fatal {
+		return 0, fmt.Errorf("invalid -%s %q: must be one of info, warn, error or fatal", flagName, value)
+	}
+	return status, nil
+}
+
+// close waits for the queued alerts to be sent. It is a no-op on a nil sink.
+func (s *webhookSink) close() {
+	if s == nil {
+		return
+	}
+	close(s.queue)
+	<-s.done
+}
+
+// run sends the queued alerts until the queue is closed.
+func (s *webhookSink) run() {
+	defer close(s.done)
+	for alert := range s.queue {
+		if err := s.send(alert); err != nil {
+			log.Errorf("unable to send alert for %s to webhook: %s", alert.Certificate.SHA256, err)
+		}
+	}
+}
+
+// send POSTs alert to the webhook, retrying failures with a backoff.
+func (s *webhookSink) send(alert *webhookAlert) error {
+	payload, err := webhookPayload(s.format, alert)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	for attempt := 1; ; attempt++ {
+		err = s.post(body)
+		if err == nil || attempt == webhookAttempts {
+			return err
+		}
+		time.Sleep(time.Duration(attempt) * time.Second)
+	}
+}
+
+func (s *webhookSink) post(body []byte) error {
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
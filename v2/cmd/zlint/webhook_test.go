@@ -0,0 +1,98 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2"
+	"github.com/zmap/zlint/v2/lint"
+)
+
+func TestWebhookSink(t *testing.T) {
+	var mu sync.Mutex
+	var received []webhookAlert
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var alert webhookAlert
+		if err := json.NewDecoder(r.Body).Decode(&alert); err != nil {
+			t.Error(err)
+		}
+		mu.Lock()
+		received = append(received, alert)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	c, err := x509.ParseCertificate(testCertificateDER(t, "badRsaExp.pem"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry, err := lint.GlobalRegistry().Filter(lint.FilterOptions{ExcludeOnline: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rs := zlint.LintCertificateEx(c, registry)
+	if rs.Results["e_rsa_public_exponent_not_odd"].Status != lint.Error {
+		t.Fatal("expected badRsaExp.pem to have an error")
+	}
+
+	for _, tc := range []struct {
+		threshold string
+		alerted   bool
+	}{{"warn", true}, {"error", true}, {"fatal", false}} {
+		t.Run(tc.threshold, func(t *testing.T) {
+			received = nil
+			sink, err := newWebhookSink(server.URL, "json", tc.threshold)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sink.check(c, "badRsaExp.pem", rs)
+			sink.close()
+
+			if !tc.alerted {
+				if len(received) != 0 {
+					t.Errorf("expected no alerts, got %d", len(received))
+				}
+				return
+			}
+			if len(received) != 1 {
+				t.Fatalf("expected 1 alert, got %d", len(received))
+			}
+			alert := received[0]
+			if alert.Source != "badRsaExp.pem" || alert.Certificate.SHA256 == "" || alert.Certificate.Subject == "" {
+				t.Errorf("expected the alert to identify the certificate, got %+v", alert)
+			}
+			if alert.Lints["e_rsa_public_exponent_not_odd"] == nil {
+				t.Errorf("expected e_rsa_public_exponent_not_odd in the alert, got %v", alert.Lints)
+			}
+			for name, result := range alert.Lints {
+				if result.Status < sink.threshold {
+					t.Errorf("expected only lints meeting the threshold, got %s with %s", name, result.Status)
+				}
+			}
+		})
+	}
+
+	for _, threshold := range []string{"pass", "NA", "loud"} {
+		if _, err := newWebhookSink(server.URL, "json", threshold); err == nil {
+			t.Errorf("expected an error for threshold %q", threshold)
+		}
+	}
+}
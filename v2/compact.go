@@ -0,0 +1,159 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package zlint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/zmap/zlint/v2/lint"
+)
+
+// compactStatusCodes are the short codes used for lint statuses in a
+// CompactResultSet.
+var compactStatusCodes = map[lint.LintStatus]string{
+	lint.Reserved: "r",
+	lint.NA:       "na",
+	lint.Pass:     "p",
+	lint.NE:       "ne",
+	lint.Notice:   "n",
+	lint.Warn:     "w",
+	lint.Error:    "e",
+	lint.Fatal:    "f",
+	lint.Skipped:  "s",
+	lint.Canceled: "c",
+}
+
+// CompactResultSet is a compact encoding of a ResultSet for storing the
+// results of linting very large numbers of certificates. Pass and NA results,
+// which are the vast majority, are recorded in a bitmap rather than by name,
+// and the remaining results use short status codes. Given the registry used
+// to produce it, a CompactResultSet can be expanded back into an identical
+// ResultSet.
+type CompactResultSet struct {
+	Version   int64 `json:"v"`
+	Timestamp int64 `json:"t"`
+	// Registry is a digest of the sorted lint names of the registry used to
+	// produce the CompactResultSet (see registryDigest). Expand rejects
+	// a different registry, since the Applied bitmap would be misread.
+	Registry string `json:"g"`
+	// Applied is a bitmap over the registry's lint names, in sorted order,
+	// with the bit for each lint that didn't have an NA result set. Bit i is
+	// the (i%8)th least significant bit of byte i/8.
+	Applied []byte `json:"a"`
+	// Results maps the names of lints with results other than pass and NA,
+	// or with details, to their short status code followed by a colon and the
+	// details if there are any.
+	Results map[string]string `json:"r,omitempty"`
+}
+
+// Compact returns the compact encoding of z, which must have been produced
+// using registry. If registry is nil then the global registry of all lints is
+// used.
+func (z *ResultSet) Compact(registry lint.Registry) *CompactResultSet {
+	if registry == nil {
+		registry = lint.GlobalRegistry()
+	}
+	names := registry.Names()
+	compact := &CompactResultSet{
+		Version:   z.Version,
+		Timestamp: z.Timestamp,
+		Registry:  registryDigest(names),
+		Applied:   make([]byte, (len(names)+7)/8),
+	}
+	for i, name := range names {
+		result, ok := z.Results[name]
+		if !ok {
+			continue
+		}
+		if result.Status != lint.NA {
+			compact.Applied[i/8] |= 1 << (i % 8)
+		}
+		if (result.Status == lint.Pass || result.Status == lint.NA) && result.Details == "" {
+			continue
+		}
+		code := compactStatusCodes[result.Status]
+		if result.Details != "" {
+			code += ":" + result.Details
+		}
+		if compact.Results == nil {
+			compact.Results = make(map[string]string)
+		}
+		compact.Results[name] = code
+	}
+	return compact
+}
+
+// Expand returns the ResultSet that c is the compact encoding of. registry
+// must be the registry c was produced with, or nil for the global registry of
+// all lints.
+func (c *CompactResultSet) Expand(registry lint.Registry) (*ResultSet, error) {
+	if registry == nil {
+		registry = lint.GlobalRegistry()
+	}
+	names := registry.Names()
+	if digest := registryDigest(names); c.Registry != digest {
+		return nil, fmt.Errorf("results were produced with registry %q, not %q", c.Registry, digest)
+	}
+	if len(c.Applied) != (len(names)+7)/8 {
+		return nil, fmt.Errorf("bitmap covers %d lints but the registry has %d", len(c.Applied)*8, len(names))
+	}
+	statuses := make(map[string]lint.LintStatus, len(compactStatusCodes))
+	for status, code := range compactStatusCodes {
+		statuses[code] = status
+	}
+
+	z := &ResultSet{
+		Version:   c.Version,
+		Timestamp: c.Timestamp,
+		Results:   make(map[string]*lint.LintResult, len(names)),
+	}
+	for i, name := range names {
+		result := &lint.LintResult{Status: lint.NA}
+		if c.Applied[i/8]&(1<<(i%8)) != 0 {
+			result.Status = lint.Pass
+		}
+		if encoded, ok := c.Results[name]; ok {
+			parts := strings.SplitN(encoded, ":", 2)
+			status, ok := statuses[parts[0]]
+			if !ok {
+				return nil, fmt.Errorf("unknown status code %q for lint %s", parts[0], name)
+			}
+			result.Status = status
+			if len(parts) == 2 {
+				result.Details = parts[1]
+			}
+		}
+		z.Results[name] = result
+		z.updateErrorStatePresent(result)
+	}
+	for name := range c.Results {
+		if registry.ByName(name) == nil {
+			return nil, fmt.Errorf("result for unknown lint %s", name)
+		}
+	}
+	return z, nil
+}
+
+// registryDigest returns the hex encoding of the first 8 bytes of the SHA-256
+// hash of the sorted lint names of a registry, separated by newlines. Any
+// change to the lints in a registry, even one that keeps their number the
+// same, changes its digest.
+func registryDigest(names []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(names, "\n")))
+	return hex.EncodeToString(sum[:8])
+}
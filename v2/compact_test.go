@@ -0,0 +1,113 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package zlint
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+)
+
+func TestCompactResultSetRoundTrip(t *testing.T) {
+	paths, err := filepath.Glob("testdata/*.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fullSize, compactSize int
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		block, _ := pem.Decode(data)
+		if block == nil {
+			continue
+		}
+		c, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			continue
+		}
+
+		expected := LintCertificate(c)
+		full, err := json.Marshal(expected.Results)
+		if err != nil {
+			t.Fatal(err)
+		}
+		encoded, err := json.Marshal(expected.Compact(nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		fullSize += len(full)
+		compactSize += len(encoded)
+
+		var compact CompactResultSet
+		if err := json.Unmarshal(encoded, &compact); err != nil {
+			t.Fatalf("%s: unable to unmarshal compact results: %v", path, err)
+		}
+		actual, err := compact.Expand(nil)
+		if err != nil {
+			t.Fatalf("%s: unable to expand compact results: %v", path, err)
+		}
+		// Compare against the full results as they would be stored, since
+		// encoding details as JSON replaces any invalid UTF-8 in them.
+		var stored map[string]*lint.LintResult
+		if err := json.Unmarshal(full, &stored); err != nil {
+			t.Fatal(err)
+		}
+		expected.Results = stored
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("%s: expanded results differ from the original results", path)
+		}
+	}
+	if compactSize*10 > fullSize {
+		t.Errorf("expected compact results to be at least 10x smaller, got %d bytes for %d bytes of full results", compactSize, fullSize)
+	}
+}
+
+func TestCompactResultSetExpandErrors(t *testing.T) {
+	registry, err := lint.GlobalRegistry().Filter(lint.FilterOptions{
+		IncludeNames: []string{"e_sub_cert_aia_missing", "w_sub_cert_aia_does_not_contain_issuing_ca_url"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := lint.GlobalRegistry().Filter(lint.FilterOptions{
+		IncludeNames: []string{"e_sub_cert_aia_missing", "e_sub_cert_crl_distribution_points_marked_critical"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := registryDigest(registry.Names())
+	testCases := map[string]CompactResultSet{
+		"wrong registry":    {Registry: registryDigest(other.Names()), Applied: []byte{3}},
+		"missing registry":  {Applied: []byte{3}},
+		"wrong bitmap size": {Registry: digest, Applied: make([]byte, 2)},
+		"unknown code":      {Registry: digest, Applied: []byte{3}, Results: map[string]string{"e_sub_cert_aia_missing": "x"}},
+		"unknown lint":      {Registry: digest, Applied: []byte{3}, Results: map[string]string{"e_unknown": "e"}},
+	}
+	for name, compact := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := compact.Expand(registry); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
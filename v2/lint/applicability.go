@@ -0,0 +1,97 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package lint
+
+import (
+	"encoding/asn1"
+	"fmt"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/util"
+)
+
+// CertificateKind is a coarse classification of certificates used to describe
+// the Applicability of a lint.
+type CertificateKind int
+
+const (
+	// AnyCertificate is the zero CertificateKind, matching every certificate.
+	AnyCertificate CertificateKind = iota
+	// CACertificate matches certificates with the CA bit set (see
+	// util.IsCACert).
+	CACertificate
+	// SubscriberCertificate matches subscriber certificates (see
+	// util.IsSubscriberCert).
+	SubscriberCertificate
+)
+
+// Applicability describes coarse conditions that a certificate must meet for
+// a lint's CheckApplies to return true. Registries group lints with the same
+// Applicability so that a certificate that doesn't meet the conditions skips
+// the whole group with a single check, rather than calling each lint's
+// CheckApplies.
+//
+// An Applicability must never be stricter than the lint's CheckApplies: for
+// any certificate it doesn't match, CheckApplies must return false. The zero
+// value matches every certificate.
+type Applicability struct {
+	// Kind restricts the lint to CA or subscriber certificates.
+	Kind CertificateKind
+	// Extension, if not nil, is an extension the certificate must contain.
+	Extension asn1.ObjectIdentifier
+}
+
+// Matches returns true if c meets the conditions of the Applicability.
+func (a Applicability) Matches(c *x509.Certificate) bool {
+	switch a.Kind {
+	case CACertificate:
+		if !util.IsCACert(c) {
+			return false
+		}
+	case SubscriberCertificate:
+		if !util.IsSubscriberCert(c) {
+			return false
+		}
+	}
+	return a.Extension == nil || util.IsExtInCert(c, a.Extension)
+}
+
+// ApplicabilityGroup is a set of registered lints that share the same coarse
+// applicability conditions. Every lint in a group that doesn't match
+// a certificate is NA for that certificate.
+type ApplicabilityGroup struct {
+	Applicability
+	// ServerAuth is true for groups of CA/B Forum Baseline Requirements lints,
+	// which only apply to certificates that can be used for server
+	// authentication.
+	ServerAuth bool
+	// Names are the names of the lints in the group in string sorted order.
+	Names []string
+
+	key string
+}
+
+// Matches returns true if the lints in the group may apply to c.
+func (g *ApplicabilityGroup) Matches(c *x509.Certificate) bool {
+	if g.ServerAuth && !util.IsServerAuthCert(c) {
+		return false
+	}
+	return g.Applicability.Matches(c)
+}
+
+// applicabilityKey returns the key of the ApplicabilityGroup that l belongs to.
+func applicabilityKey(l *Lint) string {
+	return fmt.Sprintf("%t/%d/%s", l.Source == CABFBaselineRequirements, l.Applicability.Kind, l.Applicability.Extension)
+}
@@ -24,6 +24,9 @@ import (
 // LintInterface is implemented by each Lint.
 type LintInterface interface {
 	// Initialize runs once per-lint. It is called during RegisterLint().
+	// Setup that doesn't depend on the certificate, e.g. compiling regular
+	// expressions or building lookup tables, belongs here (or in package level
+	// variables) rather than in Execute, which runs for every certificate.
 	Initialize() error
 
 	// CheckApplies runs once per certificate. It returns true if the Lint should
@@ -61,10 +64,36 @@ type Lint struct {
 	// EffectiveDate is zero.
 	EffectiveDate time.Time `json:"-"`
 
+	// Online lints make network requests (e.g. to fetch CRLs or OCSP responses)
+	// while executing. They are excluded by callers that set
+	// FilterOptions.ExcludeOnline and are never run by the zlint command unless
+	// explicitly requested.
+	Online bool `json:"online,omitempty"`
+
+	// Tags group related lints from different sources so they can be selected
+	// together with FilterOptions.IncludeTags and FilterOptions.ExcludeTags,
+	// e.g. TagWeakCrypto.
+	Tags []string `json:"tags,omitempty"`
+
+	// Applicability optionally describes coarse conditions that CheckApplies
+	// requires, letting registries skip the lint along with others sharing the
+	// same conditions without calling CheckApplies. See Applicability.
+	Applicability Applicability `json:"-"`
+
 	// The implementation of the lint logic.
 	Lint LintInterface `json:"-"`
 }
 
+// HasTag returns true if the lint has the given tag.
+func (l *Lint) HasTag(tag string) bool {
+	for _, t := range l.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
 // CheckEffective returns true if c was issued on or after the EffectiveDate. If
 // EffectiveDate is zero, CheckEffective always returns true.
 func (l *Lint) CheckEffective(c *x509.Certificate) bool {
@@ -83,6 +112,12 @@ func (l *Lint) CheckEffective(c *x509.Certificate) bool {
 // CheckEffective()
 // Execute()
 func (l *Lint) Execute(cert *x509.Certificate) *LintResult {
+	return l.ExecuteWithConfig(cert, nil)
+}
+
+// ExecuteWithConfig is like Execute, but lints implementing ConfigurableLint
+// lint with the settings in config. A nil config lints with the defaults.
+func (l *Lint) ExecuteWithConfig(cert *x509.Certificate, config *Config) *LintResult {
 	if l.Source == CABFBaselineRequirements && !util.IsServerAuthCert(cert) {
 		return &LintResult{Status: NA}
 	}
@@ -91,6 +126,12 @@ func (l *Lint) Execute(cert *x509.Certificate) *LintResult {
 	} else if !l.CheckEffective(cert) {
 		return &LintResult{Status: NE}
 	}
+	if configurable, ok := l.Lint.(ConfigurableLint); ok {
+		if config == nil {
+			config = &Config{}
+		}
+		return configurable.ExecuteWithConfig(cert, config)
+	}
 	res := l.Lint.Execute(cert)
 	return res
 }
@@ -0,0 +1,78 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package lint
+
+import (
+	"time"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zcrypto/x509/ct"
+)
+
+// Config holds the settings of a lint run that the results of some lints
+// depend on. It is passed to lints implementing ConfigurableLint, so that
+// callers linting concurrently with different settings don't interfere with
+// each other. The zero value of each field selects its default, so a nil or
+// empty *Config lints with the defaults.
+type Config struct {
+	// ReferenceTime is the time that lints comparing against the current time,
+	// such as w_not_before_in_future, evaluate certificates at. If zero the time
+	// each lint is run is used.
+	ReferenceTime time.Time
+	// TargetSecurityStrength is the minimum security strength in bits, as
+	// defined in NIST SP 800-57 Part 1, that w_security_strength_below_target
+	// expects a certificate to provide. If zero
+	// util.DefaultTargetSecurityStrength is used.
+	TargetSecurityStrength int
+	// UnderscoreDNSNamesWarnOnly downgrades underscores in the second level
+	// domain of dNSNames from an Error to a Warning in
+	// e_dnsname_underscore_in_sld. CA/Browser Forum ballots on the topic have
+	// tolerated, limited and then prohibited underscores, so callers linting
+	// under an older policy may set it.
+	UnderscoreDNSNamesWarnOnly bool
+	// MaxNotBeforeBackdate is the longest period by which the notBefore of
+	// a certificate with embedded SCTs may precede the earliest SCT timestamp
+	// before w_not_before_backdated_beyond_window reports it. CAs commonly
+	// backdate notBefore slightly to accommodate clock skew on relying
+	// parties, so callers with a stricter or more lenient policy may set it.
+	// If zero util.DefaultMaxNotBeforeBackdate is used.
+	MaxNotBeforeBackdate time.Duration
+	// CTLogOperators maps the log ID (the SHA-256 hash of the log's public
+	// key) of known Certificate Transparency logs to the name of the
+	// organization that operates them, e.g. as returned by
+	// util.ParseCTLogList. Lints that depend on the operator of a log are NA
+	// if it is empty.
+	CTLogOperators map[ct.SHA256Hash]string
+}
+
+// Now returns config's ReferenceTime, or the current time if config is nil or
+// its ReferenceTime is zero.
+func (config *Config) Now() time.Time {
+	if config == nil || config.ReferenceTime.IsZero() {
+		return time.Now()
+	}
+	return config.ReferenceTime
+}
+
+// ConfigurableLint is implemented by lints whose result depends on the Config
+// of the lint run. Lint.ExecuteWithConfig calls ExecuteWithConfig rather than
+// Execute for them; their Execute should lint with an empty Config.
+type ConfigurableLint interface {
+	LintInterface
+
+	// ExecuteWithConfig is like Execute, but lints with the settings in config,
+	// which is never nil.
+	ExecuteWithConfig(c *x509.Certificate, config *Config) *LintResult
+}
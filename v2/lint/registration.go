@@ -49,6 +49,15 @@ type FilterOptions struct {
 	// ExcludeSources is a SourceList of LintSources's to be excluded in the
 	// registry being filtered.
 	ExcludeSources SourceList
+	// ExcludeOnline controls whether lints that make network requests (see
+	// Lint.Online) are excluded from the registry being filtered.
+	ExcludeOnline bool
+	// IncludeTags is a list of tags (see Lint.Tags). Only lints with at least
+	// one of the tags are included in the registry being filtered.
+	IncludeTags []string
+	// ExcludeTags is a list of tags (see Lint.Tags). Lints with any of the tags
+	// are excluded from the registry being filtered.
+	ExcludeTags []string
 }
 
 // Empty returns true if the FilterOptions is empty and does not specify any
@@ -58,7 +67,10 @@ func (opts FilterOptions) Empty() bool {
 		len(opts.IncludeNames) == 0 &&
 		len(opts.ExcludeNames) == 0 &&
 		len(opts.IncludeSources) == 0 &&
-		len(opts.ExcludeSources) == 0
+		len(opts.ExcludeSources) == 0 &&
+		!opts.ExcludeOnline &&
+		len(opts.IncludeTags) == 0 &&
+		len(opts.ExcludeTags) == 0
 }
 
 // Registry is an interface describing a collection of registered lints.
@@ -81,6 +93,10 @@ type Registry interface {
 	// BySource returns a list of registered lints that have the same LintSource as
 	// provided (or nil if there were no such lints in the registry).
 	BySource(s LintSource) []*Lint
+	// ApplicabilityGroups returns the registered lints grouped by their coarse
+	// applicability conditions (see Applicability), indexed when the lints were
+	// registered. The returned groups must not be modified.
+	ApplicabilityGroups() []*ApplicabilityGroup
 	// Filter returns a new Registry containing only lints that match the
 	// FilterOptions criteria.
 	Filter(opts FilterOptions) (Registry, error)
@@ -102,6 +118,18 @@ type registryImpl struct {
 	// lintsBySource is a map of all registered lints by source category. Lints
 	// are added to the lintsBySource map by RegisterLint.
 	lintsBySource map[LintSource][]*Lint
+	// groupsByKey is a map of the registered lints grouped by their
+	// applicability. Lints are added to the groupsByKey map by RegisterLint.
+	groupsByKey map[string]*ApplicabilityGroup
+	// groups is a list of the values of groupsByKey sorted by key.
+	groups []*ApplicabilityGroup
+	// withholdOptIn controls whether lints from opt-in sources (see
+	// LintSource.OptIn) are kept in optInByName instead of being registered.
+	withholdOptIn bool
+	// optInByName is a map of the lints from opt-in sources that were withheld
+	// from the registry by name. They are only added to a registry created by
+	// Filter when their source or name is explicitly included.
+	optInByName map[string]*Lint
 }
 
 var (
@@ -165,19 +193,55 @@ func (r *registryImpl) register(l *Lint, initialize bool) error {
 	}
 	r.Lock()
 	defer r.Unlock()
+	if r.withholdOptIn && l.Source.OptIn() {
+		r.optInByName[l.Name] = l
+		return nil
+	}
 	r.lintNames = append(r.lintNames, l.Name)
 	r.lintsByName[l.Name] = l
 	r.lintsBySource[l.Source] = append(r.lintsBySource[l.Source], l)
 	sort.Strings(r.lintNames)
+	r.addToGroup(l)
 	return nil
 }
 
+// addToGroup adds l to the ApplicabilityGroup matching its applicability,
+// creating the group if required. The caller must hold the registry's lock.
+func (r *registryImpl) addToGroup(l *Lint) {
+	key := applicabilityKey(l)
+	group, ok := r.groupsByKey[key]
+	if !ok {
+		group = &ApplicabilityGroup{
+			Applicability: l.Applicability,
+			ServerAuth:    l.Source == CABFBaselineRequirements,
+			key:           key,
+		}
+		r.groupsByKey[key] = group
+		r.groups = append(r.groups, group)
+		sort.Slice(r.groups, func(i, j int) bool { return r.groups[i].key < r.groups[j].key })
+	}
+	group.Names = append(group.Names, l.Name)
+	sort.Strings(group.Names)
+}
+
+// ApplicabilityGroups returns the registered lints grouped by their coarse
+// applicability conditions.
+func (r *registryImpl) ApplicabilityGroups() []*ApplicabilityGroup {
+	r.RLock()
+	defer r.RUnlock()
+	return r.groups
+}
+
 // ByName returns the Lint previously registered under the given name with
-// Register, or nil if no matching lint name has been registered.
+// Register, or nil if no matching lint name has been registered. Lints from
+// opt-in sources are returned even if the registry withholds them.
 func (r *registryImpl) ByName(name string) *Lint {
 	r.RLock()
 	defer r.RUnlock()
-	return r.lintsByName[name]
+	if l, ok := r.lintsByName[name]; ok {
+		return l
+	}
+	return r.optInByName[name]
 }
 
 // Names returns a list of all of the lint names that have been registered
@@ -238,11 +302,25 @@ func sourceListToMap(sources SourceList) map[LintSource]bool {
 	return sourceMap
 }
 
+// hasAnyTag returns true if the lint has at least one of the given tags.
+func hasAnyTag(l *Lint, tags []string) bool {
+	for _, t := range tags {
+		if l.HasTag(t) {
+			return true
+		}
+	}
+	return false
+}
+
 // Filter creates a new Registry with only the lints that meet the FilterOptions
 // criteria included.
 //
 // FilterOptions are applied in the following order of precedence:
-//   ExcludeSources > IncludeSources > NameFilter > ExcludeNames > IncludeNames
+//   ExcludeOnline > ExcludeSources > IncludeSources > ExcludeTags > IncludeTags > NameFilter > ExcludeNames > IncludeNames
+//
+// Lints from opt-in sources (see LintSource.OptIn) withheld from r are only
+// included if their source is in IncludeSources or their name is in
+// IncludeNames.
 func (r *registryImpl) Filter(opts FilterOptions) (Registry, error) {
 	// If there's no filtering to be done, return the existing Registry.
 	if opts.Empty() {
@@ -269,15 +347,24 @@ func (r *registryImpl) Filter(opts FilterOptions) (Registry, error) {
 				"FilterOptions.ExcludeNames or FilterOptions.IncludeNames")
 	}
 
-	for _, name := range r.Names() {
+	for _, name := range r.candidateNames(sourceIncludes, nameIncludes) {
 		l := r.ByName(name)
 
+		if opts.ExcludeOnline && l.Online {
+			continue
+		}
 		if sourceExcludes != nil && sourceExcludes[l.Source] {
 			continue
 		}
 		if sourceIncludes != nil && !sourceIncludes[l.Source] {
 			continue
 		}
+		if len(opts.ExcludeTags) > 0 && hasAnyTag(l, opts.ExcludeTags) {
+			continue
+		}
+		if len(opts.IncludeTags) > 0 && !hasAnyTag(l, opts.IncludeTags) {
+			continue
+		}
 		if opts.NameFilter != nil && !opts.NameFilter.MatchString(name) {
 			continue
 		}
@@ -298,6 +385,25 @@ func (r *registryImpl) Filter(opts FilterOptions) (Registry, error) {
 	return filteredRegistry, nil
 }
 
+// candidateNames returns the sorted names of the lints Filter considers: the
+// registered lints, plus any withheld opt-in lints whose source is in
+// sourceIncludes or whose name is in nameIncludes.
+func (r *registryImpl) candidateNames(sourceIncludes map[LintSource]bool, nameIncludes map[string]bool) []string {
+	r.RLock()
+	defer r.RUnlock()
+	if len(r.optInByName) == 0 {
+		return r.lintNames
+	}
+	names := append([]string(nil), r.lintNames...)
+	for name, l := range r.optInByName {
+		if sourceIncludes[l.Source] || nameIncludes[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
 // WriteJSON writes a description of each registered lint as
 // a JSON object, one object per line, to the provided writer.
 func (r *registryImpl) WriteJSON(w io.Writer) {
@@ -314,12 +420,22 @@ func NewRegistry() *registryImpl {
 	return &registryImpl{
 		lintsByName:   make(map[string]*Lint),
 		lintsBySource: make(map[LintSource][]*Lint),
+		groupsByKey:   make(map[string]*ApplicabilityGroup),
+		optInByName:   make(map[string]*Lint),
 	}
 }
 
 // globalRegistry is the Registry used by all loaded lints that call
 // RegisterLint().
-var globalRegistry *registryImpl = NewRegistry()
+var globalRegistry *registryImpl = newGlobalRegistry()
+
+// newGlobalRegistry constructs the Registry used by RegisterLint, which withholds
+// lints from opt-in sources until they are included with Filter.
+func newGlobalRegistry() *registryImpl {
+	r := NewRegistry()
+	r.withholdOptIn = true
+	return r
+}
 
 // RegisterLint must be called once for each lint to be executed. Normally,
 // RegisterLint is called from the Go init() function of a lint implementation.
@@ -346,6 +462,10 @@ func RegisterLint(l *Lint) {
 // If you want to run only a subset of the globally registered lints use
 // GloablRegistry().Filter with FilterOptions to create a filtered
 // Registry.
+//
+// Lints from opt-in sources (see LintSource.OptIn) are not part of the global
+// registry's Names, and are only run when a filtered Registry includes them
+// with FilterOptions.IncludeSources or FilterOptions.IncludeNames.
 func GlobalRegistry() Registry {
 	return globalRegistry
 }
@@ -15,6 +15,7 @@ package lint
  */
 
 import (
+	"encoding/asn1"
 	"errors"
 	"reflect"
 	"regexp"
@@ -387,3 +388,162 @@ func TestRegistryFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestRegistryFilterExcludeOnline(t *testing.T) {
+	registry := NewRegistry()
+	for _, l := range []*Lint{
+		{Name: "e_offline_example", Source: ZLint, Lint: &mockLint{}},
+		{Name: "e_online_example", Source: ZLint, Lint: &mockLint{}, Online: true},
+	} {
+		if err := registry.register(l, true); err != nil {
+			t.Fatalf("failed to register %v", err)
+		}
+	}
+
+	result, err := registry.Filter(FilterOptions{ExcludeOnline: true})
+	if err != nil {
+		t.Fatalf("Filter returned err: %v", err)
+	}
+	expected := []string{"e_offline_example"}
+	if !reflect.DeepEqual(result.Names(), expected) {
+		t.Errorf("expected post-Filter Names %v got %v", expected, result.Names())
+	}
+}
+
+func TestRegistryFilterOptInSources(t *testing.T) {
+	registry := newGlobalRegistry()
+	for _, l := range []*Lint{
+		{Name: "e_bsi_example", Source: BSI, Lint: &mockLint{}},
+		{Name: "e_z_example", Source: ZLint, Lint: &mockLint{}},
+	} {
+		if err := registry.register(l, true); err != nil {
+			t.Fatalf("failed to register %v", err)
+		}
+	}
+
+	if expected := []string{"e_z_example"}; !reflect.DeepEqual(registry.Names(), expected) {
+		t.Errorf("expected Names %v got %v", expected, registry.Names())
+	}
+	if registry.ByName("e_bsi_example") == nil {
+		t.Errorf("expected ByName to find the withheld opt-in lint")
+	}
+
+	testCases := []struct {
+		name              string
+		opts              FilterOptions
+		expectedLintNames []string
+	}{
+		{
+			name:              "Opt-in source not included",
+			opts:              FilterOptions{ExcludeOnline: true},
+			expectedLintNames: []string{"e_z_example"},
+		},
+		{
+			name:              "Opt-in source included",
+			opts:              FilterOptions{IncludeSources: SourceList{BSI, ZLint}},
+			expectedLintNames: []string{"e_bsi_example", "e_z_example"},
+		},
+		{
+			name:              "Opt-in lint included by name",
+			opts:              FilterOptions{IncludeNames: []string{"e_bsi_example"}},
+			expectedLintNames: []string{"e_bsi_example"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := registry.Filter(tc.opts)
+			if err != nil {
+				t.Fatalf("Filter returned err: %v", err)
+			}
+			if !reflect.DeepEqual(result.Names(), tc.expectedLintNames) {
+				t.Errorf("expected post-Filter Names %v got %v", tc.expectedLintNames, result.Names())
+			}
+		})
+	}
+}
+
+func TestRegistryFilterTags(t *testing.T) {
+	registry := NewRegistry()
+	for _, l := range []*Lint{
+		{Name: "e_untagged_example", Source: ZLint, Lint: &mockLint{}},
+		{Name: "e_weak_example", Source: ZLint, Lint: &mockLint{}, Tags: []string{TagWeakCrypto}},
+		{Name: "e_other_example", Source: ZLint, Lint: &mockLint{}, Tags: []string{"other"}},
+	} {
+		if err := registry.register(l, true); err != nil {
+			t.Fatalf("failed to register %v", err)
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		opts          FilterOptions
+		expectedNames []string
+	}{
+		{
+			name:          "include tag",
+			opts:          FilterOptions{IncludeTags: []string{TagWeakCrypto}},
+			expectedNames: []string{"e_weak_example"},
+		},
+		{
+			name:          "include multiple tags",
+			opts:          FilterOptions{IncludeTags: []string{TagWeakCrypto, "other"}},
+			expectedNames: []string{"e_other_example", "e_weak_example"},
+		},
+		{
+			name:          "exclude tag",
+			opts:          FilterOptions{ExcludeTags: []string{TagWeakCrypto}},
+			expectedNames: []string{"e_other_example", "e_untagged_example"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := registry.Filter(tc.opts)
+			if err != nil {
+				t.Fatalf("Filter returned err: %v", err)
+			}
+			if !reflect.DeepEqual(result.Names(), tc.expectedNames) {
+				t.Errorf("expected post-Filter Names %v got %v", tc.expectedNames, result.Names())
+			}
+		})
+	}
+}
+
+func TestRegistryApplicabilityGroups(t *testing.T) {
+	sanOID := asn1.ObjectIdentifier{2, 5, 29, 17}
+	registry := NewRegistry()
+	for _, l := range []*Lint{
+		{Name: "e_any_example", Source: ZLint, Lint: &mockLint{}},
+		{Name: "e_other_any_example", Source: ZLint, Lint: &mockLint{}},
+		{Name: "e_br_example", Source: CABFBaselineRequirements, Lint: &mockLint{}},
+		{Name: "e_ca_example", Source: ZLint, Lint: &mockLint{}, Applicability: Applicability{Kind: CACertificate}},
+		{Name: "e_san_example", Source: ZLint, Lint: &mockLint{}, Applicability: Applicability{Extension: sanOID}},
+	} {
+		if err := registry.register(l, false); err != nil {
+			t.Fatalf("failed to register %q: %v", l.Name, err)
+		}
+	}
+
+	groups := registry.ApplicabilityGroups()
+	var names [][]string
+	for _, g := range groups {
+		names = append(names, g.Names)
+	}
+	expected := [][]string{
+		{"e_any_example", "e_other_any_example"},
+		{"e_san_example"},
+		{"e_ca_example"},
+		{"e_br_example"},
+	}
+	if !reflect.DeepEqual(names, expected) {
+		t.Fatalf("expected groups %v, got %v", expected, names)
+	}
+
+	subscriber := &x509.Certificate{ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}}
+	for i, matches := range []bool{true, false, false, false} {
+		if groups[i].Matches(subscriber) != matches {
+			t.Errorf("expected group %v to match %v", groups[i].Names, matches)
+		}
+	}
+}
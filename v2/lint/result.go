@@ -39,6 +39,14 @@ const (
 	Warn   LintStatus = 5
 	Error  LintStatus = 6
 	Fatal  LintStatus = 7
+
+	// Skipped is the status of lints that were deliberately not run, e.g.
+	// expensive lints when linting with zlint.Options.Fast.
+	Skipped LintStatus = 8
+
+	// Canceled is the status of lints that weren't run because linting was
+	// canceled, e.g. by the context given with zlint.WithContext.
+	Canceled LintStatus = 9
 )
 
 var (
@@ -54,6 +62,8 @@ var (
 		Warn.String():     Warn,
 		Error.String():    Error,
 		Fatal.String():    Fatal,
+		Skipped.String():  Skipped,
+		Canceled.String(): Canceled,
 	}
 )
 
@@ -100,6 +110,10 @@ func (e LintStatus) String() string {
 		return "error"
 	case Fatal:
 		return "fatal"
+	case Skipped:
+		return "skipped"
+	case Canceled:
+		return "canceled"
 	default:
 		return ""
 	}
@@ -42,6 +42,14 @@ func TestMarshalingLintStatus(t *testing.T) {
 			result:       Fatal,
 			expectedJSON: `"fatal"`,
 		},
+		{
+			result:       Skipped,
+			expectedJSON: `"skipped"`,
+		},
+		{
+			result:       Canceled,
+			expectedJSON: `"canceled"`,
+		},
 	}
 
 	for _, tc := range testCases {
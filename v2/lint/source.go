@@ -32,10 +32,22 @@ const (
 	CABFBaselineRequirements LintSource = "CABF_BR"
 	CABFEVGuidelines         LintSource = "CABF_EV"
 	MozillaRootStorePolicy   LintSource = "Mozilla"
-	AppleCTPolicy            LintSource = "Apple"
+	ApplePolicy              LintSource = "Apple"
+	ChromePolicy             LintSource = "Chrome"
 	ZLint                    LintSource = "ZLint"
 	AWSLabs                  LintSource = "AWSLabs"
 	EtsiEsi                  LintSource = "ETSI_ESI"
+	MicrosoftRootProgram     LintSource = "Microsoft"
+	FederalPKI               LintSource = "FPKI"
+	ICAO                     LintSource = "ICAO"
+	BSI                      LintSource = "BSI"
+	IGTF                     LintSource = "IGTF"
+
+	// AppleCTPolicy is the previous name of ApplePolicy, from when the only
+	// Apple requirements linted were its CT policy.
+	//
+	// Deprecated: use ApplePolicy.
+	AppleCTPolicy = ApplePolicy
 )
 
 // UnmarshalJSON implements the json.Unmarshaler interface. It ensures that the
@@ -47,7 +59,7 @@ func (s *LintSource) UnmarshalJSON(data []byte) error {
 	}
 
 	switch LintSource(throwAway) {
-	case RFC5280, RFC5480, RFC5891, CABFBaselineRequirements, CABFEVGuidelines, MozillaRootStorePolicy, AppleCTPolicy, ZLint, AWSLabs, EtsiEsi:
+	case RFC5280, RFC5480, RFC5891, CABFBaselineRequirements, CABFEVGuidelines, MozillaRootStorePolicy, ApplePolicy, ChromePolicy, ZLint, AWSLabs, EtsiEsi, MicrosoftRootProgram, FederalPKI, ICAO, BSI, IGTF:
 		*s = LintSource(throwAway)
 		return nil
 	default:
@@ -77,17 +89,38 @@ func (s *LintSource) FromString(src string) {
 		*s = CABFEVGuidelines
 	case MozillaRootStorePolicy:
 		*s = MozillaRootStorePolicy
-	case AppleCTPolicy:
-		*s = AppleCTPolicy
+	case ApplePolicy:
+		*s = ApplePolicy
+	case ChromePolicy:
+		*s = ChromePolicy
 	case ZLint:
 		*s = ZLint
 	case AWSLabs:
 		*s = AWSLabs
 	case EtsiEsi:
 		*s = EtsiEsi
+	case MicrosoftRootProgram:
+		*s = MicrosoftRootProgram
+	case FederalPKI:
+		*s = FederalPKI
+	case ICAO:
+		*s = ICAO
+	case BSI:
+		*s = BSI
+	case IGTF:
+		*s = IGTF
 	}
 }
 
+// OptIn returns true if lints from the source are not run by default, and must
+// be included explicitly with FilterOptions.IncludeSources or
+// FilterOptions.IncludeNames. The BSI technical guidelines only bind the
+// German federal administration and the CAs that issue for it, so the BSI
+// lints would otherwise report errors on certificates they don't apply to.
+func (s LintSource) OptIn() bool {
+	return s == BSI
+}
+
 // SourceList is a slice of LintSources that can be sorted.
 type SourceList []LintSource
 
@@ -0,0 +1,26 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package lint
+
+// TagWeakCrypto is the tag of lints that flag deprecated or broken
+// cryptographic algorithms and parameters, e.g. MD5 signatures, DSA keys or
+// RSA keys shorter than 2048 bits. Each lint carries the effective date of the
+// source that deprecated the algorithm.
+const TagWeakCrypto = "weak-crypto"
+
+// TagExpensive is the tag of lints that are much slower than the rest, e.g.
+// searching for factors of RSA moduli or looking keys up in large blocklists.
+// They are skipped when linting with zlint.Options.Fast.
+const TagExpensive = "expensive"
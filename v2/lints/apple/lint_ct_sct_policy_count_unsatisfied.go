@@ -35,7 +35,7 @@ func (l *sctPolicyCount) Initialize() error {
 // precertificates (e.g. that do not have the CT poison extension defined in RFC
 // 6962.
 func (l *sctPolicyCount) CheckApplies(c *x509.Certificate) bool {
-	return util.IsSubscriberCert(c) && !util.IsExtInCert(c, util.CtPoisonOID)
+	return util.IsSubscriberCert(c) && !util.IsPrecert(c)
 }
 
 // Execute checks if the provided certificate has embedded SCTs from
@@ -150,8 +150,9 @@ func init() {
 		Name:          "w_ct_sct_policy_count_unsatisfied",
 		Description:   "Check if certificate has enough embedded SCTs to meet Apple CT Policy",
 		Citation:      "https://support.apple.com/en-us/HT205280",
-		Source:        lint.AppleCTPolicy,
+		Source:        lint.ApplePolicy,
 		EffectiveDate: util.AppleCTPolicyDate,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
 		Lint:          &sctPolicyCount{},
 	})
 }
@@ -67,12 +67,8 @@ func init() {
 		Name: "e_tls_server_cert_valid_time_longer_than_398_days",
 		Description: "TLS server certificates issued on or after September 1, 2020 " +
 			"00:00 GMT/UTC must not have a validity period greater than 398 days",
-		Citation: "https://support.apple.com/en-us/HT211025",
-		// TODO(@cpu): The Source should be `lint.ApplePolicy` or something similar.
-		// The "CT" bit is too specific. Unfortunately since the constant is
-		// exported by the `util` package we can't change it without bumping the
-		// major version. See https://github.com/zmap/zlint/issues/418
-		Source:        lint.AppleCTPolicy,
+		Citation:      "https://support.apple.com/en-us/HT211025",
+		Source:        lint.ApplePolicy,
 		EffectiveDate: util.AppleReducedLifetimeDate,
 		Lint:          &serverCertValidityTooLong{},
 	})
@@ -0,0 +1,68 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package apple
+
+import (
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type serverCertEKUServerAuthMissing struct{}
+
+func (l *serverCertEKUServerAuthMissing) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for subscriber certificates that can be used for
+// TLS server authentication, including those without an EKU extension.
+func (l *serverCertEKUServerAuthMissing) CheckApplies(c *x509.Certificate) bool {
+	return util.IsSubscriberCert(c) && util.IsServerAuthCert(c)
+}
+
+// Execute returns an Error if the certificate doesn't have an EKU extension
+// that explicitly includes id-kp-serverAuth. Apple platforms reject TLS server
+// certificates that only assert anyExtendedKeyUsage, or that have no EKU
+// extension at all, even though RFC 5280 would allow either.
+func (l *serverCertEKUServerAuthMissing) Execute(c *x509.Certificate) *lint.LintResult {
+	for _, eku := range c.ExtKeyUsage {
+		if eku == x509.ExtKeyUsageServerAuth {
+			return &lint.LintResult{Status: lint.Pass}
+		}
+	}
+	if len(c.ExtKeyUsage) == 0 {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: "certificate has no EKU extension",
+		}
+	}
+	return &lint.LintResult{
+		Status:  lint.Error,
+		Details: "certificate EKU extension has anyExtendedKeyUsage but not id-kp-serverAuth",
+	}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name: "e_tls_server_cert_eku_server_auth_missing",
+		Description: "TLS server certificates issued on or after July 1, 2019 must " +
+			"contain an ExtendedKeyUsage extension containing the id-kp-serverAuth OID",
+		Citation:      "https://support.apple.com/en-us/HT210176",
+		Source:        lint.ApplePolicy,
+		EffectiveDate: util.AppleTLSRequirementsDate,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
+		Lint:          &serverCertEKUServerAuthMissing{},
+	})
+}
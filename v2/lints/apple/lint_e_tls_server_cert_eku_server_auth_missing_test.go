@@ -0,0 +1,72 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package apple
+
+import (
+	"testing"
+
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/test"
+)
+
+// Cert issued before July 1, 2019 without an EKU extension.
+func TestServerCertEKUServerAuthMissingServerCertNoEKUBefore2019(t *testing.T) {
+	inputPath := "appleServerCertNoEKUBefore2019.pem"
+	expected := lint.NE
+	out := test.TestLint("e_tls_server_cert_eku_server_auth_missing", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+// Cert issued after July 1, 2019 without an EKU extension.
+func TestServerCertEKUServerAuthMissingServerCertNoEKU(t *testing.T) {
+	inputPath := "appleServerCertNoEKU.pem"
+	expected := lint.Error
+	out := test.TestLint("e_tls_server_cert_eku_server_auth_missing", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+// Cert issued after July 1, 2019 with only anyExtendedKeyUsage.
+func TestServerCertEKUServerAuthMissingServerCertAnyEKU(t *testing.T) {
+	inputPath := "appleServerCertAnyEKU.pem"
+	expected := lint.Error
+	out := test.TestLint("e_tls_server_cert_eku_server_auth_missing", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+// Cert issued after July 1, 2019 with id-kp-serverAuth.
+func TestServerCertEKUServerAuthMissingServerCertServerAuthEKU(t *testing.T) {
+	inputPath := "appleServerCertServerAuthEKU.pem"
+	expected := lint.Pass
+	out := test.TestLint("e_tls_server_cert_eku_server_auth_missing", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+// Cert issued after July 1, 2019 that is only for client auth.
+func TestServerCertEKUServerAuthMissingClientCertClientAuthEKU(t *testing.T) {
+	inputPath := "appleClientCertClientAuthEKU.pem"
+	expected := lint.NA
+	out := test.TestLint("e_tls_server_cert_eku_server_auth_missing", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
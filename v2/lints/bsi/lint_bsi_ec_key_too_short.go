@@ -0,0 +1,59 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package bsi
+
+import (
+	"fmt"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type ecKeyTooShort struct{}
+
+func (l *ecKeyTooShort) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for certificates with an ECDSA public key.
+func (l *ecKeyTooShort) CheckApplies(c *x509.Certificate) bool {
+	return c.PublicKeyAlgorithm == x509.ECDSA && util.GetECDSAPublicKey(c) != nil
+}
+
+// Execute returns an Error if the order of the curve's base point is shorter
+// than 250 bits, e.g. for P-224.
+func (l *ecKeyTooShort) Execute(c *x509.Certificate) *lint.LintResult {
+	key := util.GetECDSAPublicKey(c)
+	if bits := key.Curve.Params().N.BitLen(); bits < 250 {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: fmt.Sprintf("curve %s has a %d bit order", key.Curve.Params().Name, bits),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_bsi_ec_key_too_short",
+		Description:   "Elliptic curve keys must use a curve whose base point order is at least 250 bits",
+		Citation:      "BSI TR-02102-1: 3.6",
+		Source:        lint.BSI,
+		EffectiveDate: util.ZeroDate,
+		Tags:          []string{lint.TagWeakCrypto},
+		Lint:          &ecKeyTooShort{},
+	})
+}
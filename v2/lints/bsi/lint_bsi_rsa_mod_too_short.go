@@ -0,0 +1,68 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package bsi
+
+import (
+	"crypto/rsa"
+	"fmt"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type rsaModTooShort struct{}
+
+func (l *rsaModTooShort) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for certificates with an RSA public key.
+func (l *rsaModTooShort) CheckApplies(c *x509.Certificate) bool {
+	_, ok := c.PublicKey.(*rsa.PublicKey)
+	return ok && c.PublicKeyAlgorithm == x509.RSA
+}
+
+// Execute returns an Error if the RSA modulus is shorter than 2000 bits, or
+// shorter than 3000 bits and the certificate is valid after the end of 2023,
+// when TR-02102-1 stops accepting 2000 bit keys.
+func (l *rsaModTooShort) Execute(c *x509.Certificate) *lint.LintResult {
+	bits := c.PublicKey.(*rsa.PublicKey).N.BitLen()
+	if bits < 2000 {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: fmt.Sprintf("RSA modulus is %d bits", bits),
+		}
+	}
+	if bits < 3000 && !c.NotAfter.Before(util.BSIRSA3000BitDate) {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: fmt.Sprintf("RSA modulus is %d bits and the certificate is valid after 2023", bits),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_bsi_rsa_mod_too_short",
+		Description:   "RSA moduli must be at least 2000 bits, and at least 3000 bits for use beyond 2023",
+		Citation:      "BSI TR-02102-1: 3.6",
+		Source:        lint.BSI,
+		EffectiveDate: util.ZeroDate,
+		Tags:          []string{lint.TagWeakCrypto},
+		Lint:          &rsaModTooShort{},
+	})
+}
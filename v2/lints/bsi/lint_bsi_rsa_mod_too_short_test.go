@@ -0,0 +1,67 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package bsi
+
+import (
+	"testing"
+
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/test"
+)
+
+func TestRSAModTooShortRSA2048ExpiresBefore2024(t *testing.T) {
+	inputPath := "bsiRSA2048ExpiresBefore2024.pem"
+	expected := lint.Pass
+	out := test.TestLint("e_bsi_rsa_mod_too_short", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+func TestRSAModTooShortRSA2048ExpiresAfter2023(t *testing.T) {
+	inputPath := "bsiRSA2048ExpiresAfter2023.pem"
+	expected := lint.Error
+	out := test.TestLint("e_bsi_rsa_mod_too_short", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+func TestRSAModTooShortRSA3072(t *testing.T) {
+	inputPath := "bsiRSA3072.pem"
+	expected := lint.Pass
+	out := test.TestLint("e_bsi_rsa_mod_too_short", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+func TestRSAModTooShortRSA1536(t *testing.T) {
+	inputPath := "bsiRSA1536.pem"
+	expected := lint.Error
+	out := test.TestLint("e_bsi_rsa_mod_too_short", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+func TestRSAModTooShortECP256(t *testing.T) {
+	inputPath := "bsiECP256.pem"
+	expected := lint.NA
+	out := test.TestLint("e_bsi_rsa_mod_too_short", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
@@ -0,0 +1,72 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package bsi
+
+import (
+	"fmt"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type signatureHashNotApproved struct{}
+
+// approvedSignatureAlgorithms are the signature algorithms known to zcrypto
+// that use a hash function from the SHA-2 family approved by TR-02102-1.
+var approvedSignatureAlgorithms = map[x509.SignatureAlgorithm]bool{
+	x509.SHA256WithRSA:    true,
+	x509.SHA384WithRSA:    true,
+	x509.SHA512WithRSA:    true,
+	x509.DSAWithSHA256:    true,
+	x509.ECDSAWithSHA256:  true,
+	x509.ECDSAWithSHA384:  true,
+	x509.ECDSAWithSHA512:  true,
+	x509.SHA256WithRSAPSS: true,
+	x509.SHA384WithRSAPSS: true,
+	x509.SHA512WithRSAPSS: true,
+}
+
+func (l *signatureHashNotApproved) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for certificates with a signature algorithm
+// zcrypto recognizes.
+func (l *signatureHashNotApproved) CheckApplies(c *x509.Certificate) bool {
+	return c.SignatureAlgorithm != x509.UnknownSignatureAlgorithm
+}
+
+func (l *signatureHashNotApproved) Execute(c *x509.Certificate) *lint.LintResult {
+	if !approvedSignatureAlgorithms[c.SignatureAlgorithm] {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: fmt.Sprintf("signature algorithm %s does not use an approved hash function", c.SignatureAlgorithm),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_bsi_signature_hash_not_approved",
+		Description:   "Certificates must be signed using an approved hash function (SHA-256, SHA-384 or SHA-512)",
+		Citation:      "BSI TR-02102-1: 4",
+		Source:        lint.BSI,
+		EffectiveDate: util.ZeroDate,
+		Tags:          []string{lint.TagWeakCrypto},
+		Lint:          &signatureHashNotApproved{},
+	})
+}
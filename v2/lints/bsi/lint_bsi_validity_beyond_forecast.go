@@ -0,0 +1,56 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package bsi
+
+import (
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type validityBeyondForecast struct{}
+
+func (l *validityBeyondForecast) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for all certificates.
+func (l *validityBeyondForecast) CheckApplies(c *x509.Certificate) bool {
+	return true
+}
+
+// Execute returns a Warn if the certificate is valid after the end of the
+// period TR-02102-1 forecasts the security of its recommended algorithms and
+// key lengths for, since nothing in the certificate is known to be secure then.
+func (l *validityBeyondForecast) Execute(c *x509.Certificate) *lint.LintResult {
+	if !c.NotAfter.Before(util.BSIForecastEndDate) {
+		return &lint.LintResult{
+			Status:  lint.Warn,
+			Details: "certificate is valid after 2029, the end of the TR-02102-1 forecast period",
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "w_bsi_validity_beyond_forecast",
+		Description:   "Certificates should not be valid beyond the period the TR-02102-1 algorithm and key length recommendations are forecast for",
+		Citation:      "BSI TR-02102-1 (2023-01): 1.1",
+		Source:        lint.BSI,
+		EffectiveDate: util.ZeroDate,
+		Lint:          &validityBeyondForecast{},
+	})
+}
@@ -0,0 +1,79 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package cabf_br
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type aiaDuplicateAccessDescription struct{}
+
+func (l *aiaDuplicateAccessDescription) Initialize() error {
+	return nil
+}
+
+func (l *aiaDuplicateAccessDescription) CheckApplies(c *x509.Certificate) bool {
+	return util.IsExtInCert(c, util.AiaOID)
+}
+
+// Execute returns an Error if two access descriptions have the same
+// accessMethod and the same accessLocation.
+func (l *aiaDuplicateAccessDescription) Execute(c *x509.Certificate) *lint.LintResult {
+	ads, err := util.GetAccessDescriptions(c)
+	if err != nil {
+		return &lint.LintResult{
+			Status:  lint.Fatal,
+			Details: fmt.Sprintf("error unmarshalling authority information access extension: %v", err),
+		}
+	}
+	var duplicates []string
+	for i, ad := range ads {
+		for _, prev := range ads[:i] {
+			if ad.AccessMethod.Equal(prev.AccessMethod) && bytes.Equal(ad.AccessLocation.FullBytes, prev.AccessLocation.FullBytes) {
+				location := fmt.Sprintf("%x", ad.AccessLocation.FullBytes)
+				if uri, ok := ad.URI(); ok {
+					location = uri
+				}
+				duplicates = append(duplicates, fmt.Sprintf("%s %s", ad.AccessMethod, location))
+				break
+			}
+		}
+	}
+	if len(duplicates) > 0 {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: fmt.Sprintf("duplicated access descriptions: %s", strings.Join(duplicates, ", ")),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_aia_duplicate_access_description",
+		Description:   "Access descriptions in the authorityInformationAccess extension with the same accessMethod MUST have unique accessLocations",
+		Citation:      "BRs: 7.1.2.7.7",
+		Source:        lint.CABFBaselineRequirements,
+		EffectiveDate: util.SC62EffectiveDate,
+		Applicability: lint.Applicability{Extension: util.AiaOID},
+		Lint:          &aiaDuplicateAccessDescription{},
+	})
+}
@@ -0,0 +1,74 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package cabf_br
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type aiaURINotHTTP struct{}
+
+func (l *aiaURINotHTTP) Initialize() error {
+	return nil
+}
+
+func (l *aiaURINotHTTP) CheckApplies(c *x509.Certificate) bool {
+	return util.IsExtInCert(c, util.AiaOID)
+}
+
+// Execute returns an Error for id-ad-ocsp and id-ad-caIssuers access
+// descriptions whose accessLocation isn't an http URI.
+func (l *aiaURINotHTTP) Execute(c *x509.Certificate) *lint.LintResult {
+	ads, err := util.GetAccessDescriptions(c)
+	if err != nil {
+		return &lint.LintResult{
+			Status:  lint.Fatal,
+			Details: fmt.Sprintf("error unmarshalling authority information access extension: %v", err),
+		}
+	}
+	var problems []string
+	for _, ad := range ads {
+		if !ad.AccessMethod.Equal(util.OidAccessMethodOCSP) && !ad.AccessMethod.Equal(util.OidAccessMethodCAIssuers) {
+			continue
+		}
+		uri, ok := ad.URI()
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s accessLocation is not a URI", ad.AccessMethod))
+		} else if !strings.HasPrefix(strings.ToLower(uri), "http://") {
+			problems = append(problems, fmt.Sprintf("%s accessLocation %q does not use the http scheme", ad.AccessMethod, uri))
+		}
+	}
+	if len(problems) > 0 {
+		return &lint.LintResult{Status: lint.Error, Details: strings.Join(problems, "; ")}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_aia_uri_not_http",
+		Description:   "The accessLocation of id-ad-ocsp and id-ad-caIssuers access descriptions MUST be a uniformResourceIdentifier using the http scheme",
+		Citation:      "BRs: 7.1.2.7.7",
+		Source:        lint.CABFBaselineRequirements,
+		EffectiveDate: util.SC62EffectiveDate,
+		Applicability: lint.Applicability{Extension: util.AiaOID},
+		Lint:          &aiaURINotHTTP{},
+	})
+}
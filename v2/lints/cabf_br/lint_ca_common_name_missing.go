@@ -45,6 +45,7 @@ func init() {
 		Citation:      "BRs: 7.1.4.3.1",
 		Source:        lint.CABFBaselineRequirements,
 		EffectiveDate: util.CABV148Date,
+		Applicability: lint.Applicability{Kind: lint.CACertificate},
 		Lint:          &caCommonNameMissing{},
 	})
 }
@@ -58,6 +58,7 @@ func init() {
 		Citation:      "BRs: 7.1.2.1, BRs: 7.1.2.2",
 		Source:        lint.CABFBaselineRequirements,
 		EffectiveDate: util.CABEffectiveDate,
+		Applicability: lint.Applicability{Extension: util.KeyUsageOID},
 		Lint:          &caIsCA{},
 	})
 }
@@ -51,6 +51,7 @@ func init() {
 		Citation:      "BRs: 7.1.2.1",
 		Source:        lint.CABFBaselineRequirements,
 		EffectiveDate: util.CABEffectiveDate,
+		Applicability: lint.Applicability{Kind: lint.CACertificate, Extension: util.KeyUsageOID},
 		Lint:          &caKeyCertSignNotSet{},
 	})
 }
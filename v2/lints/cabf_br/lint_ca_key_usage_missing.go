@@ -53,6 +53,7 @@ func init() {
 		Citation:      "BRs: 7.1.2.1, RFC 5280: 4.2.1.3",
 		Source:        lint.CABFBaselineRequirements,
 		EffectiveDate: util.RFC3280Date,
+		Applicability: lint.Applicability{Kind: lint.CACertificate},
 		Lint:          &caKeyUsageMissing{},
 	})
 }
@@ -50,6 +50,7 @@ func init() {
 		Citation:      "BRs: 7.1.6.1",
 		Source:        lint.CABFBaselineRequirements,
 		EffectiveDate: util.CABEffectiveDate,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
 		Lint:          &CertPolicyOVRequiresProvinceOrLocal{},
 	})
 }
@@ -0,0 +1,119 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package cabf_br
+
+import (
+	"encoding/asn1"
+	"fmt"
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+// crlDistributionPoint is the ASN.1 structure of a DistributionPoint. See RFC
+// 5280 Section 4.2.1.13. The distributionPoint field holds the
+// DistributionPointName CHOICE: [0] fullName or [1] nameRelativeToCRLIssuer.
+type crlDistributionPoint struct {
+	DistributionPoint asn1.RawValue  `asn1:"optional,explicit,tag:0"`
+	Reasons           asn1.BitString `asn1:"optional,tag:1"`
+	CRLIssuer         asn1.RawValue  `asn1:"optional,tag:2"`
+}
+
+type crlDistributionPointNotHTTPFullName struct{}
+
+func (l *crlDistributionPointNotHTTPFullName) Initialize() error {
+	return nil
+}
+
+func (l *crlDistributionPointNotHTTPFullName) CheckApplies(c *x509.Certificate) bool {
+	return util.IsExtInCert(c, util.CrlDistOID)
+}
+
+// checkDistributionPoint returns the reasons dp doesn't consist solely of
+// a fullName of http URIs.
+func checkDistributionPoint(dp crlDistributionPoint) ([]string, error) {
+	var problems []string
+	if dp.Reasons.BitLength > 0 {
+		problems = append(problems, "reasons is present")
+	}
+	if dp.CRLIssuer.FullBytes != nil {
+		problems = append(problems, "cRLIssuer is present")
+	}
+	if dp.DistributionPoint.FullBytes == nil {
+		return append(problems, "distributionPoint is absent"), nil
+	}
+	var name asn1.RawValue
+	if _, err := asn1.Unmarshal(dp.DistributionPoint.Bytes, &name); err != nil {
+		return nil, err
+	}
+	if name.Class != asn1.ClassContextSpecific || name.Tag != 0 {
+		return append(problems, "distributionPoint is not a fullName"), nil
+	}
+	for rest := name.Bytes; len(rest) > 0; {
+		var gn asn1.RawValue
+		var err error
+		if rest, err = asn1.Unmarshal(rest, &gn); err != nil {
+			return nil, err
+		}
+		if gn.Class != asn1.ClassContextSpecific || gn.Tag != 6 {
+			problems = append(problems, fmt.Sprintf("fullName contains a GeneralName with tag %d", gn.Tag))
+			continue
+		}
+		if uri := string(gn.Bytes); !strings.HasPrefix(strings.ToLower(uri), "http://") {
+			problems = append(problems, fmt.Sprintf("URI %q does not use the http scheme", uri))
+		}
+	}
+	return problems, nil
+}
+
+func (l *crlDistributionPointNotHTTPFullName) Execute(c *x509.Certificate) *lint.LintResult {
+	var dps []crlDistributionPoint
+	ext := util.GetExtFromCert(c, util.CrlDistOID)
+	if _, err := asn1.Unmarshal(ext.Value, &dps); err != nil {
+		return &lint.LintResult{
+			Status:  lint.Fatal,
+			Details: fmt.Sprintf("error unmarshalling CRL distribution points extension: %v", err),
+		}
+	}
+	var problems []string
+	for _, dp := range dps {
+		dpProblems, err := checkDistributionPoint(dp)
+		if err != nil {
+			return &lint.LintResult{
+				Status:  lint.Fatal,
+				Details: fmt.Sprintf("error unmarshalling distribution point name: %v", err),
+			}
+		}
+		problems = append(problems, dpProblems...)
+	}
+	if len(problems) > 0 {
+		return &lint.LintResult{Status: lint.Error, Details: strings.Join(problems, "; ")}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_crl_distribution_point_not_http_full_name",
+		Description:   "Each CRL distribution point MUST contain only a fullName of uniformResourceIdentifiers using the http scheme, without reasons or cRLIssuer",
+		Citation:      "BRs: 7.1.2.11.2",
+		Source:        lint.CABFBaselineRequirements,
+		EffectiveDate: util.SC62EffectiveDate,
+		Applicability: lint.Applicability{Extension: util.CrlDistOID},
+		Lint:          &crlDistributionPointNotHTTPFullName{},
+	})
+}
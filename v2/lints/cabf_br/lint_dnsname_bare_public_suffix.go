@@ -0,0 +1,65 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package cabf_br
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type DNSNameBarePublicSuffix struct{}
+
+func (l *DNSNameBarePublicSuffix) Initialize() error {
+	return nil
+}
+
+func (l *DNSNameBarePublicSuffix) CheckApplies(c *x509.Certificate) bool {
+	return util.IsSubscriberCert(c) && util.DNSNamesExist(c)
+}
+
+// Execute returns a Warning for DNSNames that are exactly a public suffix, or
+// a wildcard directly beneath one, e.g. "co.uk" or "*.co.uk". Labels below
+// a public suffix are controlled by the registry, not by the applicant.
+func (l *DNSNameBarePublicSuffix) Execute(c *x509.Certificate) *lint.LintResult {
+	var suffixes []string
+	for _, dns := range c.DNSNames {
+		if util.IsICANNPublicSuffix(dns) {
+			suffixes = append(suffixes, dns)
+		}
+	}
+	if len(suffixes) > 0 {
+		return &lint.LintResult{
+			Status:  lint.Warn,
+			Details: fmt.Sprintf("DNSNames that are public suffixes: %s", strings.Join(suffixes, ", ")),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "w_dnsname_bare_public_suffix",
+		Description:   "DNSNames should not be a public suffix, or a wildcard directly beneath one, since the registry rather than the applicant controls such names",
+		Citation:      "BRs: 3.2.2.6",
+		Source:        lint.CABFBaselineRequirements,
+		EffectiveDate: util.CABEffectiveDate,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
+		Lint:          &DNSNameBarePublicSuffix{},
+	})
+}
@@ -63,6 +63,7 @@ func init() {
 		Citation:      "BRs: 7.1.4.2",
 		Source:        lint.CABFBaselineRequirements,
 		EffectiveDate: util.CABEffectiveDate,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
 		Lint:          &DNSNameEmptyLabel{},
 	})
 }
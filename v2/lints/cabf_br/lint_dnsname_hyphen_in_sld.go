@@ -62,6 +62,7 @@ func init() {
 		Citation:      "BRs 7.1.4.2",
 		Source:        lint.CABFBaselineRequirements,
 		EffectiveDate: util.RFC5280Date,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
 		Lint:          &DNSNameHyphenInSLD{},
 	})
 }
@@ -0,0 +1,76 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package cabf_br
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type DNSNameHyphenInTRD struct{}
+
+func (l *DNSNameHyphenInTRD) Initialize() error {
+	return nil
+}
+
+func (l *DNSNameHyphenInTRD) CheckApplies(c *x509.Certificate) bool {
+	return util.IsSubscriberCert(c) && len(c.DNSNames) > 0
+}
+
+// Execute returns an Error for dNSNames with a label left of the registered
+// domain that starts or ends with a hyphen, breaking the LDH rule (RFC 1034
+// Section 3.5 as relaxed by RFC 1123 Section 2.1). The other LDH violations
+// are reported by the existing dNSName lints: hyphens at either end of the
+// second level domain by e_dnsname_hyphen_in_sld, underscores by
+// e_dnsname_underscore_in_sld and w_dnsname_underscore_in_trd, other
+// characters by e_dnsname_bad_character_in_label, and misplaced wildcards by
+// e_dnsname_wildcard_only_in_left_label.
+func (l *DNSNameHyphenInTRD) Execute(c *x509.Certificate) *lint.LintResult {
+	var names []string
+	for _, parsed := range c.GetParsedDNSNames(false) {
+		if parsed.ParseError != nil {
+			continue
+		}
+		for _, label := range strings.Split(parsed.ParsedDomain.TRD, ".") {
+			if strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+				names = append(names, parsed.DomainString)
+				break
+			}
+		}
+	}
+	if len(names) > 0 {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: fmt.Sprintf("dNSNames with a label starting or ending with a hyphen: %s", strings.Join(names, ", ")),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_dnsname_hyphen_in_trd",
+		Description:   "Labels of dNSNames below the registered domain MUST NOT begin or end with a hyphen",
+		Citation:      "BRs: 7.1.4.2.1; RFC 5280: 4.2.1.6",
+		Source:        lint.CABFBaselineRequirements,
+		EffectiveDate: util.CABEffectiveDate,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
+		Lint:          &DNSNameHyphenInTRD{},
+	})
+}
@@ -0,0 +1,61 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package cabf_br
+
+import (
+	"testing"
+
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/test"
+)
+
+func TestDNSNameHyphenInTRD(t *testing.T) {
+	inputPath := "sanDNSNameLeadingHyphen.pem"
+	expected := lint.Error
+	out := test.TestLint("e_dnsname_hyphen_in_trd", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+func TestDNSNameNoHyphenInTRD(t *testing.T) {
+	inputPath := "sanDNSNameLDH.pem"
+	expected := lint.Pass
+	out := test.TestLint("e_dnsname_hyphen_in_trd", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+// Underscores are reported by e_dnsname_underscore_in_sld and
+// w_dnsname_underscore_in_trd instead.
+func TestDNSNameHyphenInTRDUnderscore(t *testing.T) {
+	inputPath := "sanDNSNameUnderscore.pem"
+	expected := lint.Pass
+	out := test.TestLint("e_dnsname_hyphen_in_trd", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+// Other characters are reported by e_dnsname_bad_character_in_label instead.
+func TestDNSNameHyphenInTRDBadCharacter(t *testing.T) {
+	inputPath := "sanDNSNameBadCharacter.pem"
+	expected := lint.Pass
+	out := test.TestLint("e_dnsname_hyphen_in_trd", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
@@ -65,6 +65,7 @@ func init() {
 		Citation:      "RFC 1035",
 		Source:        lint.CABFBaselineRequirements,
 		EffectiveDate: util.CABEffectiveDate,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
 		Lint:          &DNSNameLabelLengthTooLong{},
 	})
 }
@@ -0,0 +1,79 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package cabf_br
+
+/************************************************
+BRs: 7.1.4.2.1
+Also as of the Effective Date, the CA SHALL NOT
+issue a certificate with an Expiry Date later than
+1 November 2015 with a subjectAlternativeName extension
+or Subject commonName field containing a Reserved IP
+Address or Internal Name.
+************************************************/
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type DNSNameSpecialUseDomain struct{}
+
+func (l *DNSNameSpecialUseDomain) Initialize() error {
+	return nil
+}
+
+func (l *DNSNameSpecialUseDomain) CheckApplies(c *x509.Certificate) bool {
+	return util.IsSubscriberCert(c) && util.DNSNamesExist(c) && c.NotAfter.After(util.NoReservedIP)
+}
+
+// Execute returns an Error for names within a special-use domain such as
+// home.arpa or local. Unlike names with an unknown TLD, which are reported by
+// e_dnsname_not_valid_tld, some of these end in a delegated TLD but still
+// can't be validated as belonging to the applicant.
+func (l *DNSNameSpecialUseDomain) Execute(c *x509.Certificate) *lint.LintResult {
+	names := c.DNSNames
+	if c.Subject.CommonName != "" && !util.CommonNameIsIP(c) {
+		names = append([]string{c.Subject.CommonName}, names...)
+	}
+	var found []string
+	for _, name := range names {
+		if suffix, reference, ok := util.SpecialUseDomain(name); ok {
+			found = append(found, fmt.Sprintf("%s (%s, %s)", name, suffix, reference))
+		}
+	}
+	if len(found) > 0 {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: fmt.Sprintf("names within special-use domains: %s", strings.Join(found, ", ")),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_dnsname_special_use_domain",
+		Description:   "DNSNames MUST NOT be Internal Names, including names within special-use domains that are not globally unique in the public DNS",
+		Citation:      "BRs: 7.1.4.2.1; RFC 6761",
+		Source:        lint.CABFBaselineRequirements,
+		EffectiveDate: util.CABEffectiveDate,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
+		Lint:          &DNSNameSpecialUseDomain{},
+	})
+}
@@ -32,14 +32,26 @@ func (l *DNSNameUnderscoreInSLD) CheckApplies(c *x509.Certificate) bool {
 	return util.IsSubscriberCert(c) && util.DNSNamesExist(c)
 }
 
+// Execute returns an Error for an underscore in the second level domain of the
+// common name or a dNSName.
 func (l *DNSNameUnderscoreInSLD) Execute(c *x509.Certificate) *lint.LintResult {
+	return l.ExecuteWithConfig(c, &lint.Config{})
+}
+
+// ExecuteWithConfig is like Execute, but returns a Warning instead of an Error
+// if the run's config sets UnderscoreDNSNamesWarnOnly.
+func (l *DNSNameUnderscoreInSLD) ExecuteWithConfig(c *x509.Certificate, config *lint.Config) *lint.LintResult {
+	status := lint.Error
+	if config.UnderscoreDNSNamesWarnOnly {
+		status = lint.Warn
+	}
 	if c.Subject.CommonName != "" && !util.CommonNameIsIP(c) {
 		domainInfo := c.GetParsedSubjectCommonName(false)
 		if domainInfo.ParseError != nil {
 			return &lint.LintResult{Status: lint.NA}
 		}
 		if strings.Contains(domainInfo.ParsedDomain.SLD, "_") {
-			return &lint.LintResult{Status: lint.Error}
+			return &lint.LintResult{Status: status}
 		}
 	}
 
@@ -49,7 +61,7 @@ func (l *DNSNameUnderscoreInSLD) Execute(c *x509.Certificate) *lint.LintResult {
 			return &lint.LintResult{Status: lint.NA}
 		}
 		if strings.Contains(parsedSANDNSNames[i].ParsedDomain.SLD, "_") {
-			return &lint.LintResult{Status: lint.Error}
+			return &lint.LintResult{Status: status}
 		}
 	}
 	return &lint.LintResult{Status: lint.Pass}
@@ -62,6 +74,7 @@ func init() {
 		Citation:      "BRs: 7.1.4.2",
 		Source:        lint.CABFBaselineRequirements,
 		EffectiveDate: util.RFC5280Date,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
 		Lint:          &DNSNameUnderscoreInSLD{},
 	})
 }
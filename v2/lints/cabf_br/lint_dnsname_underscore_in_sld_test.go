@@ -38,3 +38,12 @@ func TestDNSNameNoUnderscoreInSLD(t *testing.T) {
 		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
 	}
 }
+
+func TestDNSNameUnderscoreInSLDWarnOnly(t *testing.T) {
+	inputPath := "dnsNameUnderscoreInSLD.pem"
+	expected := lint.Warn
+	out := test.TestLintWithConfig("e_dnsname_underscore_in_sld", inputPath, &lint.Config{UnderscoreDNSNamesWarnOnly: true})
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
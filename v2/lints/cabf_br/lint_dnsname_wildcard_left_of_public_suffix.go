@@ -62,6 +62,7 @@ func init() {
 		Citation:      "BRs: 3.2.2.6",
 		Source:        lint.CABFBaselineRequirements,
 		EffectiveDate: util.CABEffectiveDate,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
 		Lint:          &DNSNameWildcardLeftofPublicSuffix{},
 	})
 }
@@ -54,6 +54,7 @@ func init() {
 		// Refer to BRs: 6.1.5, taking the statement "Before 31 Dec 2010" literally
 		Source:        lint.CABFBaselineRequirements,
 		EffectiveDate: util.ZeroDate,
+		Tags:          []string{lint.TagWeakCrypto},
 		Lint:          &dsaTooShort{},
 	})
 }
@@ -27,7 +27,7 @@ func (l *evNoBiz) Initialize() error {
 }
 
 func (l *evNoBiz) CheckApplies(c *x509.Certificate) bool {
-	return util.IsEV(c.PolicyIdentifiers) && util.IsSubscriberCert(c)
+	return util.IsEVCert(c) && util.IsSubscriberCert(c)
 }
 
 func (l *evNoBiz) Execute(c *x509.Certificate) *lint.LintResult {
@@ -45,6 +45,7 @@ func init() {
 		Citation:      "BRs: 7.1.6.1",
 		Source:        lint.CABFBaselineRequirements,
 		EffectiveDate: util.ZeroDate,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
 		Lint:          &evNoBiz{},
 	})
 }
@@ -27,7 +27,7 @@ func (l *evSNMissing) Initialize() error {
 }
 
 func (l *evSNMissing) CheckApplies(c *x509.Certificate) bool {
-	return util.IsEV(c.PolicyIdentifiers) && util.IsSubscriberCert(c)
+	return util.IsEVCert(c) && util.IsSubscriberCert(c)
 }
 
 func (l *evSNMissing) Execute(c *x509.Certificate) *lint.LintResult {
@@ -44,6 +44,7 @@ func init() {
 		Citation:      "EV gudelines: 9.2.6",
 		Source:        lint.CABFBaselineRequirements,
 		EffectiveDate: util.ZeroDate,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
 		Lint:          &evSNMissing{},
 	})
 }
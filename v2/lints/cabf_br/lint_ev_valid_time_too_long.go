@@ -27,11 +27,11 @@ func (l *evValidTooLong) Initialize() error {
 }
 
 func (l *evValidTooLong) CheckApplies(c *x509.Certificate) bool {
-	return util.IsEV(c.PolicyIdentifiers) && util.IsSubscriberCert(c)
+	return util.IsEVCert(c) && util.IsSubscriberCert(c)
 }
 
 func (l *evValidTooLong) Execute(c *x509.Certificate) *lint.LintResult {
-	if c.NotBefore.AddDate(0, 0, 825).Before(c.NotAfter) {
+	if (util.ValidityPeriod{Days: 825}).ExceededBy(c.NotBefore, c.NotAfter) {
 		return &lint.LintResult{Status: lint.Error}
 	}
 	return &lint.LintResult{Status: lint.Pass}
@@ -44,6 +44,7 @@ func init() {
 		Citation:      "BRs: 6.3.2",
 		Source:        lint.CABFBaselineRequirements,
 		EffectiveDate: util.ZeroDate,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
 		Lint:          &evValidTooLong{},
 	})
 }
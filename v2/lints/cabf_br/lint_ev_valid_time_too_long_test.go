@@ -38,3 +38,14 @@ func TestEvValidNotTooLong(t *testing.T) {
 		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
 	}
 }
+
+func TestEvValidOneSecondTooLong(t *testing.T) {
+	// The validity period includes both notBefore and notAfter, so a notAfter
+	// exactly 825 days after notBefore is one second too long.
+	inputPath := "evValidOneSecondTooLong.pem"
+	expected := lint.Error
+	out := test.TestLint("e_ev_valid_time_too_long", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
@@ -0,0 +1,70 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package cabf_br
+
+import (
+	"encoding/asn1"
+	"fmt"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type authorityKeyIdentifierFields struct {
+	KeyIdentifier             asn1.RawValue `asn1:"optional,tag:0"`
+	AuthorityCertIssuer       asn1.RawValue `asn1:"optional,tag:1"`
+	AuthorityCertSerialNumber asn1.RawValue `asn1:"optional,tag:2"`
+}
+
+type authorityKeyIdentifierIssuerSerialPresent struct{}
+
+func (l *authorityKeyIdentifierIssuerSerialPresent) Initialize() error {
+	return nil
+}
+
+func (l *authorityKeyIdentifierIssuerSerialPresent) CheckApplies(c *x509.Certificate) bool {
+	return util.IsExtInCert(c, util.AuthkeyOID)
+}
+
+// Execute returns an Error if the authorityKeyIdentifier identifies the
+// issuer by its issuer name and serial number rather than solely by key
+// identifier.
+func (l *authorityKeyIdentifierIssuerSerialPresent) Execute(c *x509.Certificate) *lint.LintResult {
+	var aki authorityKeyIdentifierFields
+	ext := util.GetExtFromCert(c, util.AuthkeyOID)
+	if _, err := asn1.Unmarshal(ext.Value, &aki); err != nil {
+		return &lint.LintResult{
+			Status:  lint.Fatal,
+			Details: fmt.Sprintf("error unmarshalling authority key identifier extension: %v", err),
+		}
+	}
+	if aki.AuthorityCertIssuer.FullBytes != nil || aki.AuthorityCertSerialNumber.FullBytes != nil {
+		return &lint.LintResult{Status: lint.Error}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_ext_authority_key_identifier_issuer_serial_present",
+		Description:   "The authorityKeyIdentifier extension MUST NOT include the authorityCertIssuer or authorityCertSerialNumber fields",
+		Citation:      "BRs: 7.1.2.11.1",
+		Source:        lint.CABFBaselineRequirements,
+		EffectiveDate: util.SC62EffectiveDate,
+		Applicability: lint.Applicability{Extension: util.AuthkeyOID},
+		Lint:          &authorityKeyIdentifierIssuerSerialPresent{},
+	})
+}
@@ -0,0 +1,54 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package cabf_br
+
+import (
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type certPolicyUserNoticePresent struct{}
+
+func (l *certPolicyUserNoticePresent) Initialize() error {
+	return nil
+}
+
+func (l *certPolicyUserNoticePresent) CheckApplies(c *x509.Certificate) bool {
+	return util.IsExtInCert(c, util.CertPolicyOID)
+}
+
+func (l *certPolicyUserNoticePresent) Execute(c *x509.Certificate) *lint.LintResult {
+	for _, qualifiers := range c.QualifierId {
+		for _, qualifier := range qualifiers {
+			if qualifier.Equal(util.UserNoticeOID) {
+				return &lint.LintResult{Status: lint.Error}
+			}
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_ext_cert_policy_user_notice_present",
+		Description:   "certificatePolicies MUST NOT contain the userNotice policy qualifier; only id-qt-cps is permitted",
+		Citation:      "BRs: 7.1.2.7.9, 7.1.2.10.5",
+		Source:        lint.CABFBaselineRequirements,
+		EffectiveDate: util.SC62EffectiveDate,
+		Applicability: lint.Applicability{Extension: util.CertPolicyOID},
+		Lint:          &certPolicyUserNoticePresent{},
+	})
+}
@@ -58,6 +58,7 @@ func init() {
 		Citation:      "BRs: 7.1.5 / 7.1.4.2.1",
 		Source:        lint.CABFBaselineRequirements,
 		EffectiveDate: util.CABEffectiveDate,
+		Applicability: lint.Applicability{Extension: util.NameConstOID},
 		Lint:          &NCReservedIPNet{},
 	})
 }
@@ -24,6 +24,9 @@ Address or Internal Name.
 ************************************************/
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/zmap/zcrypto/x509"
 	"github.com/zmap/zlint/v2/lint"
 	"github.com/zmap/zlint/v2/util"
@@ -40,9 +43,16 @@ func (l *SANReservedIP) CheckApplies(c *x509.Certificate) bool {
 }
 
 func (l *SANReservedIP) Execute(c *x509.Certificate) *lint.LintResult {
+	var reserved []string
 	for _, ip := range c.IPAddresses {
 		if util.IsIANAReserved(ip) {
-			return &lint.LintResult{Status: lint.Error}
+			reserved = append(reserved, ip.String())
+		}
+	}
+	if len(reserved) > 0 {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: fmt.Sprintf("reserved IP addresses: %s", strings.Join(reserved, ", ")),
 		}
 	}
 
@@ -56,6 +56,7 @@ func init() {
 		Citation:      "RFC 5280: 4.2.1.6",
 		Source:        lint.CABFBaselineRequirements,
 		EffectiveDate: util.RFC5280Date,
+		Applicability: lint.Applicability{Extension: util.SubjectAlternateNameOID},
 		Lint:          &ExtSANCriticalWithSubjectDN{},
 	})
 }
@@ -55,6 +55,7 @@ func init() {
 		Citation:      "BRs: 7.1.4.2.1",
 		Source:        lint.CABFBaselineRequirements,
 		EffectiveDate: util.CABEffectiveDate,
+		Applicability: lint.Applicability{Extension: util.SubjectAlternateNameOID},
 		Lint:          &SANEDI{},
 	})
 }
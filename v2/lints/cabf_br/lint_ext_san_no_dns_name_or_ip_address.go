@@ -0,0 +1,61 @@
+package cabf_br
+
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+/************************************************
+BRs: 7.1.4.2.1
+Subject Alternative Name Extension
+Certificate Field: extensions:subjectAltName
+Required/Optional: Required
+Contents: This extension MUST contain at least one entry. Each entry MUST be
+either a dNSName containing the Fully‐Qualified Domain Name or an iPAddress
+containing the IP address of a server for which the CA has confirmed the
+Applicant's control.
+************************************************/
+
+import (
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type SANNoDNSNameOrIPAddress struct{}
+
+func (l *SANNoDNSNameOrIPAddress) Initialize() error {
+	return nil
+}
+
+func (l *SANNoDNSNameOrIPAddress) CheckApplies(c *x509.Certificate) bool {
+	return !util.IsCACert(c) && util.IsExtInCert(c, util.SubjectAlternateNameOID)
+}
+
+func (l *SANNoDNSNameOrIPAddress) Execute(c *x509.Certificate) *lint.LintResult {
+	if len(c.DNSNames) == 0 && len(c.IPAddresses) == 0 {
+		return &lint.LintResult{Status: lint.Error}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_ext_san_no_dns_name_or_ip_address",
+		Description:   "The Subject Alternate Name extension of subscriber certificates MUST contain at least one dNSName or iPAddress",
+		Citation:      "BRs: 7.1.4.2.1",
+		Source:        lint.CABFBaselineRequirements,
+		EffectiveDate: util.CABEffectiveDate,
+		Applicability: lint.Applicability{Extension: util.SubjectAlternateNameOID},
+		Lint:          &SANNoDNSNameOrIPAddress{},
+	})
+}
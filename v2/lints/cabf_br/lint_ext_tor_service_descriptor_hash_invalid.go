@@ -53,6 +53,14 @@ var torServiceDescExtName = fmt.Sprintf(
 	"TorServiceDescriptor extension (oid %s)",
 	util.BRTorServiceDescriptor.String())
 
+// torServiceDescriptorHashBits maps the known TorServiceDescriptorHash
+// algorithms to the size of their hashes in bits.
+var torServiceDescriptorHashBits = map[string]int{
+	"SHA256": 256,
+	"SHA384": 384,
+	"SHA512": 512,
+}
+
 // lintOnionURL verifies that an Onion URI value from a TorServiceDescriptorHash
 // is:
 //
@@ -120,7 +128,10 @@ func (l *torServiceDescHashInvalid) Execute(c *x509.Certificate) *lint.LintResul
 	// Build a map of all the eTLD+1 onion subjects in the cert to compare against
 	// the service descriptors.
 	onionETLDPlusOneMap := make(map[string]string)
-	for _, subj := range append(c.DNSNames, c.Subject.CommonName) {
+	// Copy the DNS names rather than appending to c.DNSNames, which would
+	// modify the shared certificate if its backing array has spare capacity.
+	subjects := append(append([]string{}, c.DNSNames...), c.Subject.CommonName)
+	for _, subj := range subjects {
 		if !strings.HasSuffix(subj, onionTLD) {
 			continue
 		}
@@ -134,12 +145,6 @@ func (l *torServiceDescHashInvalid) Execute(c *x509.Certificate) *lint.LintResul
 		onionETLDPlusOneMap[eTLDPlusOne] = subj
 	}
 
-	expectedHashBits := map[string]int{
-		"SHA256": 256,
-		"SHA384": 384,
-		"SHA512": 512,
-	}
-
 	// Build a map of onion hostname -> TorServiceDescriptorHash using the parsed
 	// TorServiceDescriptors from zcrypto.
 	descriptorMap := make(map[string]*x509.TorServiceDescriptorHash)
@@ -150,7 +155,7 @@ func (l *torServiceDescHashInvalid) Execute(c *x509.Certificate) *lint.LintResul
 		}
 		// each descriptor should have a known hash algorithm and the correct
 		// corresponding size of hash.
-		if expectedBits, found := expectedHashBits[descriptor.AlgorithmName]; !found {
+		if expectedBits, found := torServiceDescriptorHashBits[descriptor.AlgorithmName]; !found {
 			return failResult(
 				"%s contained a TorServiceDescriptorHash for Onion URI %q with an "+
 					"unknown hash algorithm",
@@ -208,6 +213,7 @@ func init() {
 		Citation:      "BRS: Ballot 201",
 		Source:        lint.CABFBaselineRequirements,
 		EffectiveDate: util.CABV201Date,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
 		Lint:          &torServiceDescHashInvalid{},
 	})
 }
@@ -50,6 +50,7 @@ func init() {
 		Citation:      "BRs: 6.1.5",
 		Source:        lint.CABFBaselineRequirements,
 		EffectiveDate: util.ZeroDate,
+		Tags:          []string{lint.TagWeakCrypto},
 		Lint:          &rootCaModSize{},
 	})
 }
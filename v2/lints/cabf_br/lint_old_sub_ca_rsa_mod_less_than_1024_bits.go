@@ -54,6 +54,7 @@ func init() {
 		Source:      lint.CABFBaselineRequirements,
 		// since effective date should be checked against end date in this specific case, putting time check into checkApplies instead, ZeroDate here to automatically pass NE test
 		EffectiveDate: util.ZeroDate,
+		Tags:          []string{lint.TagWeakCrypto},
 		Lint:          &subCaModSize{},
 	})
 }
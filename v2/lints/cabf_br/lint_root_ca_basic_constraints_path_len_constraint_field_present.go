@@ -66,6 +66,7 @@ func init() {
 		Citation:      "BRs: 7.1.2.1",
 		Source:        lint.CABFBaselineRequirements,
 		EffectiveDate: util.CABEffectiveDate,
+		Applicability: lint.Applicability{Kind: lint.CACertificate, Extension: util.BasicConstOID},
 		Lint:          &rootCaPathLenPresent{},
 	})
 }
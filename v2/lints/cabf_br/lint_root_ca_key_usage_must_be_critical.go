@@ -46,6 +46,7 @@ func init() {
 		Citation:      "BRs: 7.1.2.1",
 		Source:        lint.CABFBaselineRequirements,
 		EffectiveDate: util.RFC2459Date,
+		Applicability: lint.Applicability{Kind: lint.CACertificate, Extension: util.KeyUsageOID},
 		Lint:          &rootCAKeyUsageMustBeCritical{},
 	})
 }
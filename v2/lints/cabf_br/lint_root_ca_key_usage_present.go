@@ -45,6 +45,7 @@ func init() {
 		Citation:      "BRs: 7.1.2.1",
 		Source:        lint.CABFBaselineRequirements,
 		EffectiveDate: util.RFC2459Date,
+		Applicability: lint.Applicability{Kind: lint.CACertificate},
 		Lint:          &rootCAKeyUsagePresent{},
 	})
 }
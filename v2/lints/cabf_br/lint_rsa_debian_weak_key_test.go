@@ -0,0 +1,54 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package cabf_br
+
+import (
+	"testing"
+
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/test"
+	"github.com/zmap/zlint/v2/util"
+)
+
+// The lint is only registered when the blocklists have been generated, so
+// these tests run it directly rather than through the registry.
+
+func TestRSADebianWeakKeyRegistered(t *testing.T) {
+	registered := lint.GlobalRegistry().ByName("e_rsa_debian_weak_key") != nil
+	if registered != util.HasDebianWeakKeys() {
+		t.Errorf("expected e_rsa_debian_weak_key registered to be %v, got %v", util.HasDebianWeakKeys(), registered)
+	}
+}
+
+func TestRSADebianWeakKeyNotWeak(t *testing.T) {
+	inputPath := "mpModulus2048.pem"
+	expected := lint.Pass
+	c := test.ReadTestCert(inputPath)
+	l := &rsaDebianWeakKey{}
+	if !l.CheckApplies(c) {
+		t.Fatalf("%s: expected lint to apply", inputPath)
+	}
+	if out := l.Execute(c); out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+func TestRSADebianWeakKeyNotRSA(t *testing.T) {
+	inputPath := "appleServerCertServerAuthEKU.pem"
+	c := test.ReadTestCert(inputPath)
+	if (&rsaDebianWeakKey{}).CheckApplies(c) {
+		t.Errorf("%s: expected lint not to apply to a non-RSA key", inputPath)
+	}
+}
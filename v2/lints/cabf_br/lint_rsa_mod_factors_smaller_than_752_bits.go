@@ -54,6 +54,7 @@ func init() {
 		Citation:      "BRs: 6.1.6",
 		Source:        lint.CABFBaselineRequirements,
 		EffectiveDate: util.CABV113Date,
+		Tags:          []string{lint.TagExpensive},
 		Lint:          &rsaModSmallFactor{},
 	})
 }
@@ -0,0 +1,60 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package cabf_br
+
+/**************************************************************************************************
+BRs: 6.1.1.3
+The CA SHALL reject a certificate request if the requested Public Key does not meet the requirements
+set forth in Sections 6.1.5 and 6.1.6 or if it has a known weak Private Key (such as a Debian weak
+key, see https://wiki.debian.org/SSLkeys).
+***************************************************************************************************/
+
+import (
+	"crypto/rsa"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type rsaROCAWeakKey struct{}
+
+func (l *rsaROCAWeakKey) Initialize() error {
+	return nil
+}
+
+func (l *rsaROCAWeakKey) CheckApplies(c *x509.Certificate) bool {
+	_, ok := c.PublicKey.(*rsa.PublicKey)
+	return ok
+}
+
+func (l *rsaROCAWeakKey) Execute(c *x509.Certificate) *lint.LintResult {
+	if util.IsROCAVulnerable(c.PublicKey.(*rsa.PublicKey)) {
+		return &lint.LintResult{Status: lint.Error}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_rsa_roca_weak_key",
+		Description:   "Certificates MUST NOT contain a known weak public key, such as a key generated by the Infineon RSALib affected by ROCA (CVE-2017-15361)",
+		Citation:      "BRs: 6.1.1.3",
+		Source:        lint.CABFBaselineRequirements,
+		EffectiveDate: util.CABEffectiveDate,
+		Tags:          []string{lint.TagWeakCrypto, lint.TagExpensive},
+		Lint:          &rsaROCAWeakKey{},
+	})
+}
@@ -0,0 +1,68 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package cabf_br
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type onionAddressInvalid struct{}
+
+func (l *onionAddressInvalid) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true if the certificate is a subscriber certificate that
+// contains a subject name ending in `.onion`.
+func (l *onionAddressInvalid) CheckApplies(c *x509.Certificate) bool {
+	return util.IsSubscriberCert(c) && util.CertificateSubjInTLD(c, util.OnionTLD)
+}
+
+// Execute returns an lint.Error lint.LintResult if any `.onion` subject name
+// doesn't contain a syntactically valid version 2 or version 3 onion address
+// (including the checksum and version byte of version 3 addresses) immediately
+// to the left of `.onion`.
+func (l *onionAddressInvalid) Execute(c *x509.Certificate) *lint.LintResult {
+	var invalid []string
+	for _, name := range util.GetOnionNames(c) {
+		if _, err := util.OnionAddressVersion(name); err != nil {
+			invalid = append(invalid, fmt.Sprintf("%s: %v", name, err))
+		}
+	}
+	if len(invalid) > 0 {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: strings.Join(invalid, "; "),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_san_dns_name_onion_invalid",
+		Description:   "certificates with a .onion subject name must contain a valid onion service address",
+		Citation:      "BRs: Appendix C",
+		Source:        lint.CABFBaselineRequirements,
+		EffectiveDate: util.OnionOnlyEVDate,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
+		Lint:          &onionAddressInvalid{},
+	})
+}
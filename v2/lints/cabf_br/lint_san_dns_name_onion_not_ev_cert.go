@@ -45,7 +45,7 @@ func (l *onionNotEV) Execute(c *x509.Certificate) *lint.LintResult {
 	 * subjectAltName Extension or commonName field unless such Certificate was
 	 * issued in accordance with Appendix F of the EV Guidelines.
 	 */
-	if !util.IsEV(c.PolicyIdentifiers) {
+	if !util.IsEVCert(c) {
 		return &lint.LintResult{
 			Status: lint.Error,
 			Details: fmt.Sprintf(
@@ -63,6 +63,7 @@ func init() {
 		Citation:      "CABF Ballot 144",
 		Source:        lint.CABFBaselineRequirements,
 		EffectiveDate: util.OnionOnlyEVDate,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
 		Lint:          &onionNotEV{},
 	})
 }
@@ -0,0 +1,69 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package cabf_br
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type onionV2Address struct{}
+
+func (l *onionV2Address) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true if the certificate is a subscriber certificate that
+// contains a subject name ending in `.onion`.
+func (l *onionV2Address) CheckApplies(c *x509.Certificate) bool {
+	return util.IsSubscriberCert(c) && util.CertificateSubjInTLD(c, util.OnionTLD)
+}
+
+// Execute returns an lint.Error lint.LintResult if any `.onion` subject name
+// contains a version 2 onion address. Version 2 onion services have been
+// deprecated by the Tor Project and are no longer supported.
+func (l *onionV2Address) Execute(c *x509.Certificate) *lint.LintResult {
+	var v2 []string
+	for _, name := range util.GetOnionNames(c) {
+		if version, err := util.OnionAddressVersion(name); err == nil && version == 2 {
+			v2 = append(v2, name)
+		}
+	}
+	if len(v2) > 0 {
+		return &lint.LintResult{
+			Status: lint.Error,
+			Details: fmt.Sprintf(
+				"certificate contains version 2 onion addresses: %s",
+				strings.Join(v2, ", ")),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_san_dns_name_onion_v2",
+		Description:   "certificates must not contain version 2 onion addresses",
+		Citation:      "BRs: Appendix C",
+		Source:        lint.CABFBaselineRequirements,
+		EffectiveDate: util.OnionV2SunsetDate,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
+		Lint:          &onionV2Address{},
+	})
+}
@@ -0,0 +1,49 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package cabf_br
+
+import (
+	"testing"
+
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/test"
+)
+
+func TestOnionV2Address2AfterSunset(t *testing.T) {
+	inputPath := "onionV2AfterSunset.pem"
+	expected := lint.Error
+	out := test.TestLint("e_san_dns_name_onion_v2", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+func TestOnionV2Address2BeforeSunset(t *testing.T) {
+	inputPath := "onionV2BeforeSunset.pem"
+	expected := lint.NE
+	out := test.TestLint("e_san_dns_name_onion_v2", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+func TestOnionV2Address3Valid(t *testing.T) {
+	inputPath := "onionV3Valid.pem"
+	expected := lint.Pass
+	out := test.TestLint("e_san_dns_name_onion_v2", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
@@ -0,0 +1,126 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package cabf_br
+
+/**************************************************************************************************
+BRs: 7.1
+Effective September 30, 2016, CAs SHALL generate non-sequential Certificate serial numbers greater
+than zero (0) containing at least 64 bits of output from a CSPRNG.
+***************************************************************************************************/
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+// minSerialEntropyOctets is the smallest number of octets that can hold 64
+// bits of CSPRNG output.
+const minSerialEntropyOctets = 8
+
+// minSerialOctets is the length below which a serial number is reported as
+// too short on its own. A compliant 64-bit serial number is encoded in 7
+// octets whenever its leading octet is zero, 1 time in 256, but in 6 or fewer
+// only 1 time in 65536.
+const minSerialOctets = minSerialEntropyOctets - 1
+
+type serialNumberLowEntropy struct{}
+
+func (l *serialNumberLowEntropy) Initialize() error {
+	return nil
+}
+
+func (l *serialNumberLowEntropy) CheckApplies(c *x509.Certificate) bool {
+	return c.SerialNumber != nil && c.SerialNumber.Sign() > 0
+}
+
+// Execute applies heuristics that a serial number containing 64 bits of
+// CSPRNG output is very unlikely to fail. It can't prove that a serial
+// number is random, only flag serial numbers that clearly aren't:
+//
+//  1. Serial numbers shorter than 7 octets, or shorter than 8 octets that
+//     also fail one of the other heuristics.
+//  2. Serial numbers containing a run of 4 or more zero octets, typical of
+//     a counter or timestamp padded to a fixed width.
+//  3. Serial numbers in which fewer than half of the octets are distinct.
+//  4. Serial numbers whose octets form an arithmetic sequence, e.g.
+//     01 02 03 04 05 06 07 08.
+func (l *serialNumberLowEntropy) Execute(c *x509.Certificate) *lint.LintResult {
+	serial := c.SerialNumber.Bytes()
+	var problems []string
+
+	var zeroRun, maxZeroRun int
+	distinct := make(map[byte]bool)
+	for _, b := range serial {
+		distinct[b] = true
+		if b == 0 {
+			zeroRun++
+			if zeroRun > maxZeroRun {
+				maxZeroRun = zeroRun
+			}
+		} else {
+			zeroRun = 0
+		}
+	}
+	if maxZeroRun >= 4 {
+		problems = append(problems, fmt.Sprintf("serial number contains %d consecutive zero octets", maxZeroRun))
+	}
+	if len(serial) >= minSerialOctets && len(distinct) < len(serial)/2 {
+		problems = append(problems, fmt.Sprintf(
+			"serial number has only %d distinct values in %d octets", len(distinct), len(serial)))
+	}
+	if len(serial) >= 3 && isArithmeticSequence(serial) {
+		problems = append(problems, "serial number octets form a sequence")
+	}
+	if len(serial) < minSerialOctets || (len(serial) < minSerialEntropyOctets && len(problems) > 0) {
+		problems = append([]string{fmt.Sprintf(
+			"serial number is %d octets, fewer than the %d required for 64 bits of entropy",
+			len(serial), minSerialEntropyOctets)}, problems...)
+	}
+
+	if len(problems) > 0 {
+		return &lint.LintResult{
+			Status:  lint.Warn,
+			Details: fmt.Sprintf("%s: %s", c.SerialNumber.Text(16), strings.Join(problems, "; ")),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+// isArithmeticSequence returns true if the difference between every pair of
+// adjacent octets is the same.
+func isArithmeticSequence(b []byte) bool {
+	step := b[1] - b[0]
+	for i := 2; i < len(b); i++ {
+		if b[i]-b[i-1] != step {
+			return false
+		}
+	}
+	return true
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "w_serial_number_low_entropy",
+		Description:   "Certificate serial numbers should contain at least 64 bits of output from a CSPRNG and should not be sequential",
+		Citation:      "BRs: 7.1",
+		Source:        lint.CABFBaselineRequirements,
+		EffectiveDate: util.CABSerialNumberEntropyDate,
+		Lint:          &serialNumberLowEntropy{},
+	})
+}
@@ -0,0 +1,82 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package cabf_br
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/test"
+)
+
+func TestSerialNumberLowEntropy(t *testing.T) {
+	testCases := []struct {
+		name     string
+		serial   string
+		expected lint.LintStatus
+	}{
+		{
+			name:     "random 16 octets",
+			serial:   "5a3f9c21e07b44d18e96f2a0c35b7d01",
+			expected: lint.Pass,
+		},
+		{
+			name:     "random 8 octets",
+			serial:   "7f1c92ab3e5d0648",
+			expected: lint.Pass,
+		},
+		{
+			name:     "random 8 octets with a leading zero octet",
+			serial:   "1c92ab3e5d0648",
+			expected: lint.Pass,
+		},
+		{
+			name:     "too short",
+			serial:   "92ab3e5d0648",
+			expected: lint.Warn,
+		},
+		{
+			name:     "7 octets with repeated octets",
+			serial:   "1c1c1c1c1c1c48",
+			expected: lint.Warn,
+		},
+		{
+			name:     "zero padded counter",
+			serial:   "4a000000000000000000000000001234",
+			expected: lint.Warn,
+		},
+		{
+			name:     "repeated octets",
+			serial:   "abababababababababab",
+			expected: lint.Warn,
+		},
+		{
+			name:     "sequence",
+			serial:   "0102030405060708090a",
+			expected: lint.Warn,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cert := test.ReadTestCert("appleServerCertServerAuthEKU.pem")
+			cert.SerialNumber, _ = new(big.Int).SetString(tc.serial, 16)
+			if result := test.TestLintCert("w_serial_number_low_entropy", cert); result.Status != tc.expected {
+				t.Errorf("expected result %v was %v (%s)", tc.expected, result.Status, result.Details)
+			}
+		})
+	}
+}
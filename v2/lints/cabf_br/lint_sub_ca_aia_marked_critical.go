@@ -46,6 +46,7 @@ func init() {
 		Citation:      "BRs: 7.1.2.2",
 		Source:        lint.CABFBaselineRequirements,
 		EffectiveDate: util.ZeroDate,
+		Applicability: lint.Applicability{Kind: lint.CACertificate, Extension: util.AiaOID},
 		Lint:          &subCaAIAMarkedCritical{},
 	})
 }
@@ -0,0 +1,60 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package cabf_br
+
+import (
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type subCAAnyPolicyWithOtherPolicies struct{}
+
+func (l *subCAAnyPolicyWithOtherPolicies) Initialize() error {
+	return nil
+}
+
+func (l *subCAAnyPolicyWithOtherPolicies) CheckApplies(c *x509.Certificate) bool {
+	return util.IsSubCA(c) && util.IsExtInCert(c, util.CertPolicyOID)
+}
+
+// Execute returns a Warning if anyPolicy is asserted together with other
+// policy identifiers. anyPolicy already permits every policy, so the specific
+// identifiers are either redundant or indicate the CA meant to be restricted
+// to them.
+func (l *subCAAnyPolicyWithOtherPolicies) Execute(c *x509.Certificate) *lint.LintResult {
+	var anyPolicy bool
+	for _, policy := range c.PolicyIdentifiers {
+		if policy.Equal(util.AnyPolicyOID) {
+			anyPolicy = true
+		}
+	}
+	if anyPolicy && len(c.PolicyIdentifiers) > 1 {
+		return &lint.LintResult{Status: lint.Warn}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "w_sub_ca_any_policy_with_other_policies",
+		Description:   "Subordinate CA Certificate: certificatePolicies should not contain other policy identifiers alongside anyPolicy",
+		Citation:      "BRs: 7.1.2.10.5",
+		Source:        lint.CABFBaselineRequirements,
+		EffectiveDate: util.SC62EffectiveDate,
+		Applicability: lint.Applicability{Kind: lint.CACertificate, Extension: util.CertPolicyOID},
+		Lint:          &subCAAnyPolicyWithOtherPolicies{},
+	})
+}
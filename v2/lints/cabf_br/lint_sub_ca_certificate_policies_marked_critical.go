@@ -51,6 +51,7 @@ func init() {
 		Citation:      "BRs: 7.1.2.2",
 		Source:        lint.CABFBaselineRequirements,
 		EffectiveDate: util.CABEffectiveDate,
+		Applicability: lint.Applicability{Kind: lint.CACertificate, Extension: util.CertPolicyOID},
 		Lint:          &subCACertPolicyCrit{},
 	})
 }
@@ -53,6 +53,7 @@ func init() {
 		Citation:      "BRs: 7.1.2.2",
 		Source:        lint.CABFBaselineRequirements,
 		EffectiveDate: util.CABV116Date,
+		Applicability: lint.Applicability{Kind: lint.CACertificate, Extension: util.EkuSynOid},
 		Lint:          &subCAEKUCrit{},
 	})
 }
@@ -45,6 +45,7 @@ func init() {
 		Citation:      "BRs: 7.1.5",
 		Source:        lint.CABFBaselineRequirements,
 		EffectiveDate: util.CABEffectiveDate,
+		Applicability: lint.Applicability{Kind: lint.CACertificate},
 		Lint:          &subCAEKUMissing{},
 	})
 }
@@ -56,6 +56,7 @@ func init() {
 		Citation:      "BRs: 7.1.2.2",
 		Source:        lint.CABFBaselineRequirements,
 		EffectiveDate: util.CABV102Date,
+		Applicability: lint.Applicability{Kind: lint.CACertificate, Extension: util.NameConstOID},
 		Lint:          &SubCANameConstraintsNotCritical{},
 	})
 }
@@ -37,8 +37,10 @@ func (l *subCertOcspUrl) Initialize() error {
 	return nil
 }
 
+// CheckApplies returns true for subscriber certificates other than Short-lived
+// Subscriber Certificates, which are permitted to omit the OCSP responder URL.
 func (l *subCertOcspUrl) CheckApplies(c *x509.Certificate) bool {
-	return !util.IsCACert(c)
+	return !util.IsCACert(c) && !util.IsShortLivedCert(c)
 }
 
 func (l *subCertOcspUrl) Execute(c *x509.Certificate) *lint.LintResult {
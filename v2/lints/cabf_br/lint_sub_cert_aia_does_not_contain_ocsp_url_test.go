@@ -16,6 +16,7 @@ package cabf_br
 
 import (
 	"testing"
+	"time"
 
 	"github.com/zmap/zlint/v2/lint"
 	"github.com/zmap/zlint/v2/test"
@@ -38,3 +39,14 @@ func TestSubCertHasIssuerOcsp(t *testing.T) {
 		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
 	}
 }
+
+func TestSubCertShortLivedNoIssuerOcsp(t *testing.T) {
+	cert := test.ReadTestCert("subCertWIssuerURL.pem")
+	cert.NotBefore = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	cert.NotAfter = cert.NotBefore.AddDate(0, 0, 7)
+	expected := lint.NA
+	out := test.TestLintCert("e_sub_cert_aia_does_not_contain_ocsp_url", cert)
+	if out.Status != expected {
+		t.Errorf("short-lived subCertWIssuerURL.pem: expected %s, got %s", expected, out.Status)
+	}
+}
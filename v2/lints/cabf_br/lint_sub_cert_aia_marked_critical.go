@@ -46,6 +46,7 @@ func init() {
 		Citation:      "BRs: 7.1.2.3",
 		Source:        lint.CABFBaselineRequirements,
 		EffectiveDate: util.CABEffectiveDate,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate, Extension: util.AiaOID},
 		Lint:          &subCertAiaMarkedCritical{},
 	})
 }
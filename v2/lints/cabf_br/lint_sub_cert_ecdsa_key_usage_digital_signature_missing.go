@@ -0,0 +1,55 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package cabf_br
+
+import (
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type subCertECDSAKeyUsageDigitalSignatureMissing struct{}
+
+func (l *subCertECDSAKeyUsageDigitalSignatureMissing) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for subscriber certificates with an ECDSA public
+// key and a keyUsage extension.
+func (l *subCertECDSAKeyUsageDigitalSignatureMissing) CheckApplies(c *x509.Certificate) bool {
+	return util.IsSubscriberCert(c) && c.PublicKeyAlgorithm == x509.ECDSA && util.IsExtInCert(c, util.KeyUsageOID)
+}
+
+// Execute returns an Error if digitalSignature is not set. TLS servers sign
+// the handshake with ECDSA keys, so a certificate asserting only keyAgreement
+// can't be used with any TLS 1.3 or ECDHE_ECDSA cipher suite.
+func (l *subCertECDSAKeyUsageDigitalSignatureMissing) Execute(c *x509.Certificate) *lint.LintResult {
+	if c.KeyUsage&x509.KeyUsageDigitalSignature == 0 {
+		return &lint.LintResult{Status: lint.Error}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_sub_cert_ecdsa_key_usage_digital_signature_missing",
+		Description:   "Subscriber certificates with an ECDSA public key MUST assert the digitalSignature key usage",
+		Citation:      "BRs: 7.1.2.7.11",
+		Source:        lint.CABFBaselineRequirements,
+		EffectiveDate: util.SC62EffectiveDate,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate, Extension: util.KeyUsageOID},
+		Lint:          &subCertECDSAKeyUsageDigitalSignatureMissing{},
+	})
+}
@@ -52,6 +52,7 @@ func init() {
 		Citation:      "BRs: 7.1.2.3",
 		Source:        lint.CABFBaselineRequirements,
 		EffectiveDate: util.CABEffectiveDate,
+		Applicability: lint.Applicability{Extension: util.KeyUsageOID},
 		Lint:          &subCertNotCA{},
 	})
 }
@@ -0,0 +1,64 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package cabf_br
+
+/************************************************
+BRs: 7.1.2.7.3 Individual Validated & 7.1.2.7.4 Organization Validated
+Attribute Name      Presence
+countryName         MUST
+
+BRs: 7.1.2.7.2 Domain Validated
+Attribute Name      Presence
+countryName         MAY
+commonName          NOT RECOMMENDED
+Any other attribute MUST NOT
+
+Every profile that permits localityName also requires countryName.
+************************************************/
+
+import (
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type subCertLocalityNameWithoutCountryName struct{}
+
+func (l *subCertLocalityNameWithoutCountryName) Initialize() error {
+	return nil
+}
+
+func (l *subCertLocalityNameWithoutCountryName) CheckApplies(c *x509.Certificate) bool {
+	return util.IsSubscriberCert(c)
+}
+
+func (l *subCertLocalityNameWithoutCountryName) Execute(c *x509.Certificate) *lint.LintResult {
+	if len(c.Subject.Locality) > 0 && len(c.Subject.Country) == 0 {
+		return &lint.LintResult{Status: lint.Error}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_sub_cert_locality_name_without_country_name",
+		Description:   "Subscriber Certificate: subject:localityName MUST NOT appear if the subject:countryName field is absent.",
+		Citation:      "BRs: 7.1.2.7.2, 7.1.2.7.3 & 7.1.2.7.4",
+		Source:        lint.CABFBaselineRequirements,
+		EffectiveDate: util.SC62EffectiveDate,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
+		Lint:          &subCertLocalityNameWithoutCountryName{},
+	})
+}
@@ -0,0 +1,75 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package cabf_br
+
+/**************************************************************************************************
+BRs: 7.1.2.7.2 / 7.1.2.11.2
+The cRLDistributionPoints extension MUST be present unless the certificate is a Short-lived
+Subscriber Certificate or contains an OCSP responder URL. The OCSP responder URL MAY be omitted
+from Short-lived Subscriber Certificates.
+
+BRs: 6.3.2
+Short-lived Subscriber Certificate: a Subscriber Certificate with a Validity Period less than or
+equal to 10 days (864,000 seconds), or 7 days (604,800 seconds) for certificates issued on or after
+15 March 2026.
+***************************************************************************************************/
+
+import (
+	"fmt"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type subCertNoRevocationInfoNotShortLived struct{}
+
+func (l *subCertNoRevocationInfoNotShortLived) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for subscriber certificates that contain neither
+// a CRL distribution point nor an OCSP responder URL, i.e. certificates that
+// can only be compliant as Short-lived Subscriber Certificates.
+func (l *subCertNoRevocationInfoNotShortLived) CheckApplies(c *x509.Certificate) bool {
+	return util.IsSubscriberCert(c) &&
+		len(c.CRLDistributionPoints) == 0 &&
+		len(c.OCSPServer) == 0
+}
+
+func (l *subCertNoRevocationInfoNotShortLived) Execute(c *x509.Certificate) *lint.LintResult {
+	if util.IsShortLivedCert(c) {
+		return &lint.LintResult{Status: lint.Pass}
+	}
+	limit, _ := util.ShortLivedValiditySchedule.LimitFor(c.NotBefore)
+	return &lint.LintResult{
+		Status: lint.Error,
+		Details: fmt.Sprintf(
+			"certificate has no CRL distribution point or OCSP responder URL but its validity period exceeds the %s allowed for Short-lived Subscriber Certificates",
+			limit.Max),
+	}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_sub_cert_no_revocation_info_not_short_lived",
+		Description:   "Subscriber Certificates without a CRL distribution point or OCSP responder URL MUST be Short-lived Subscriber Certificates",
+		Citation:      "BRs: 6.3.2, 7.1.2.7.2",
+		Source:        lint.CABFBaselineRequirements,
+		EffectiveDate: util.ShortLivedCertDate,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
+		Lint:          &subCertNoRevocationInfoNotShortLived{},
+	})
+}
@@ -0,0 +1,86 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package cabf_br
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/test"
+)
+
+func TestSubCertNoRevocationInfoNotShortLived(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	testCases := []struct {
+		name      string
+		notBefore time.Time
+		validity  int
+		ocsp      []string
+		expected  lint.LintStatus
+	}{
+		{
+			name:      "10 days in 2025",
+			notBefore: date(2025, time.January, 1),
+			validity:  10,
+			expected:  lint.Pass,
+		},
+		{
+			name:      "11 days in 2025",
+			notBefore: date(2025, time.January, 1),
+			validity:  11,
+			expected:  lint.Error,
+		},
+		{
+			name:      "10 days in 2026",
+			notBefore: date(2026, time.June, 1),
+			validity:  10,
+			expected:  lint.Error,
+		},
+		{
+			name:      "7 days in 2026",
+			notBefore: date(2026, time.June, 1),
+			validity:  7,
+			expected:  lint.Pass,
+		},
+		{
+			name:      "90 days with OCSP",
+			notBefore: date(2025, time.January, 1),
+			validity:  90,
+			ocsp:      []string{"http://ocsp.example.com"},
+			expected:  lint.NA,
+		},
+		{
+			name:      "before short-lived certificates",
+			notBefore: date(2023, time.January, 1),
+			validity:  90,
+			expected:  lint.NE,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cert := test.ReadTestCert("appleServerCertServerAuthEKU.pem")
+			cert.NotBefore = tc.notBefore
+			cert.NotAfter = tc.notBefore.AddDate(0, 0, tc.validity).Add(-time.Second)
+			cert.OCSPServer = tc.ocsp
+			if result := test.TestLintCert("e_sub_cert_no_revocation_info_not_short_lived", cert); result.Status != tc.expected {
+				t.Errorf("expected result %v was %v (%s)", tc.expected, result.Status, result.Details)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package cabf_br
+
+/************************************************
+RFC 5280: 4.1.2.5
+To indicate that a certificate has no well-defined expiration date, the
+notAfter SHOULD be assigned the GeneralizedTime value of 99991231235959Z.
+
+BRs: 6.3.2
+Subscriber Certificates issued after the Effective Date MUST have a Validity
+Period no greater than 60 months. (Later versions reduce the maximum further.)
+************************************************/
+
+import (
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type subCertNoWellDefinedExpiration struct{}
+
+func (l *subCertNoWellDefinedExpiration) Initialize() error {
+	return nil
+}
+
+func (l *subCertNoWellDefinedExpiration) CheckApplies(c *x509.Certificate) bool {
+	return util.IsSubscriberCert(c)
+}
+
+// Execute returns an Error for subscriber certificates that use the RFC 5280
+// sentinel for no well-defined expiration date. The sentinel is appropriate
+// for some private PKIs (e.g. device identity certificates) but a publicly
+// trusted certificate always has a maximum validity period.
+func (l *subCertNoWellDefinedExpiration) Execute(c *x509.Certificate) *lint.LintResult {
+	if util.HasNoWellDefinedExpiration(c) {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: "notAfter is 99991231235959Z, indicating no well-defined expiration date",
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_sub_cert_no_well_defined_expiration",
+		Description:   "Subscriber Certificates MUST have a well-defined expiration date and MUST NOT use the notAfter value 99991231235959Z",
+		Citation:      "BRs: 6.3.2",
+		Source:        lint.CABFBaselineRequirements,
+		EffectiveDate: util.CABEffectiveDate,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
+		Lint:          &subCertNoWellDefinedExpiration{},
+	})
+}
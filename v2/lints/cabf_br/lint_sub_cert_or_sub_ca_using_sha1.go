@@ -49,6 +49,7 @@ func init() {
 		Citation:      "BRs: 7.1.3",
 		Source:        lint.CABFBaselineRequirements,
 		EffectiveDate: util.NO_SHA1,
+		Tags:          []string{lint.TagWeakCrypto},
 		Lint:          &sigAlgTestsSHA1{},
 	})
 }
@@ -56,6 +56,7 @@ func init() {
 		Citation:      "BRs: 7.1.3",
 		Source:        lint.CABFBaselineRequirements,
 		EffectiveDate: time.Date(2015, time.January, 16, 0, 0, 0, 0, time.UTC),
+		Tags:          []string{lint.TagWeakCrypto},
 		Lint:          &sha1ExpireLong{},
 	})
 }
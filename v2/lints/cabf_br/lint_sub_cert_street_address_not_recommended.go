@@ -0,0 +1,66 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package cabf_br
+
+/************************************************
+BRs: 7.1.2.7.3 Individual Validated & 7.1.2.7.4 Organization Validated
+Attribute Name      Presence
+...
+streetAddress       NOT RECOMMENDED    If present, MUST contain the Subject's
+                                       streetAddress information as verified under
+                                       Section 3.2.2.1 or 3.2.3.
+************************************************/
+
+import (
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type subCertStreetAddressNotRecommended struct{}
+
+func (l *subCertStreetAddressNotRecommended) Initialize() error {
+	return nil
+}
+
+func (l *subCertStreetAddressNotRecommended) CheckApplies(c *x509.Certificate) bool {
+	return util.IsSubscriberCert(c) && (util.SliceContainsOID(c.PolicyIdentifiers, util.BROrganizationValidatedOID) ||
+		util.SliceContainsOID(c.PolicyIdentifiers, util.BRIndividualValidatedOID))
+}
+
+// Execute only considers certificates with an organizationName, givenName or
+// surname. Without one of these the streetAddress is prohibited outright, which is
+// reported separately.
+func (l *subCertStreetAddressNotRecommended) Execute(c *x509.Certificate) *lint.LintResult {
+	if len(c.Subject.Organization) == 0 && len(c.Subject.GivenName) == 0 && len(c.Subject.Surname) == 0 {
+		return &lint.LintResult{Status: lint.Pass}
+	}
+	if len(c.Subject.StreetAddress) > 0 {
+		return &lint.LintResult{Status: lint.Warn}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "w_sub_cert_street_address_not_recommended",
+		Description:   "Subscriber Certificate: subject:streetAddress is NOT RECOMMENDED in Organization and Individual Validated certificates",
+		Citation:      "BRs: 7.1.2.7.3 & 7.1.2.7.4",
+		Source:        lint.CABFBaselineRequirements,
+		EffectiveDate: util.SC62EffectiveDate,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
+		Lint:          &subCertStreetAddressNotRecommended{},
+	})
+}
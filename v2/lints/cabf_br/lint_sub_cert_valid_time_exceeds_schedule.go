@@ -0,0 +1,74 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package cabf_br
+
+import (
+	"fmt"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type subCertValidTimeExceedsSchedule struct{}
+
+func (l *subCertValidTimeExceedsSchedule) Initialize() error {
+	return nil
+}
+
+func (l *subCertValidTimeExceedsSchedule) CheckApplies(c *x509.Certificate) bool {
+	return util.IsSubscriberCert(c)
+}
+
+// Execute looks up the maximum validity period in effect when the certificate
+// was issued in util.SubscriberValiditySchedule and returns an Error if the
+// certificate exceeds it. Unlike the lints for an individual limit this one
+// always applies the limit that was in force on the certificate's notBefore
+// date, including future reductions once they take effect.
+func (l *subCertValidTimeExceedsSchedule) Execute(c *x509.Certificate) *lint.LintResult {
+	limit, ok := util.SubscriberValiditySchedule.LimitFor(c.NotBefore)
+	if !ok {
+		return &lint.LintResult{Status: lint.NE}
+	}
+	if util.HasNoWellDefinedExpiration(c) {
+		return &lint.LintResult{
+			Status: lint.Error,
+			Details: fmt.Sprintf(
+				"certificate has no well-defined expiration date but certificates issued on or after %s must have a validity period no greater than %s",
+				limit.IssuedOnOrAfter.Format("2006-01-02"), limit.Max),
+		}
+	}
+	if limit.Max.ExceededBy(c.NotBefore, c.NotAfter) {
+		return &lint.LintResult{
+			Status: lint.Error,
+			Details: fmt.Sprintf(
+				"certificates issued on or after %s must have a validity period no greater than %s",
+				limit.IssuedOnOrAfter.Format("2006-01-02"), limit.Max),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_sub_cert_valid_time_exceeds_schedule",
+		Description:   "Subscriber Certificates MUST NOT have a Validity Period greater than the maximum in effect on the date they were issued",
+		Citation:      "BRs: 6.3.2",
+		Source:        lint.CABFBaselineRequirements,
+		EffectiveDate: util.CABEffectiveDate,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
+		Lint:          &subCertValidTimeExceedsSchedule{},
+	})
+}
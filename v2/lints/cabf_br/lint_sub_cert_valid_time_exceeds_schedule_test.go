@@ -0,0 +1,119 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package cabf_br
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/test"
+)
+
+func TestSubCertValidTimeExceedsSchedule(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	testCases := []struct {
+		name      string
+		notBefore time.Time
+		notAfter  time.Time
+		expected  lint.LintStatus
+	}{
+		{
+			name:      "60 months in 2013",
+			notBefore: date(2013, time.January, 1),
+			notAfter:  date(2018, time.January, 1).Add(-time.Second),
+			expected:  lint.Pass,
+		},
+		{
+			name:      "61 months in 2013",
+			notBefore: date(2013, time.January, 1),
+			notAfter:  date(2018, time.February, 1),
+			expected:  lint.Error,
+		},
+		{
+			name:      "39 months in 2017",
+			notBefore: date(2017, time.January, 1),
+			notAfter:  date(2020, time.April, 1).Add(-time.Second),
+			expected:  lint.Pass,
+		},
+		{
+			name:      "39 months and one second in 2017",
+			notBefore: date(2017, time.January, 1),
+			notAfter:  date(2020, time.April, 1),
+			expected:  lint.Error,
+		},
+		{
+			name:      "39 months from the end of a month in 2017",
+			notBefore: date(2017, time.January, 31),
+			notAfter:  date(2020, time.April, 30).Add(-time.Second),
+			expected:  lint.Pass,
+		},
+		{
+			name:      "39 months and a day from the end of a month in 2017",
+			notBefore: date(2017, time.January, 31),
+			notAfter:  date(2020, time.May, 1).Add(-time.Second),
+			expected:  lint.Error,
+		},
+		{
+			name:      "825 days in 2019",
+			notBefore: date(2019, time.January, 1),
+			notAfter:  date(2019, time.January, 1).AddDate(0, 0, 825).Add(-time.Second),
+			expected:  lint.Pass,
+		},
+		{
+			name:      "825 days and one second in 2019",
+			notBefore: date(2019, time.January, 1),
+			notAfter:  date(2019, time.January, 1).AddDate(0, 0, 825),
+			expected:  lint.Error,
+		},
+		{
+			name:      "825 days in 2021",
+			notBefore: date(2021, time.January, 1),
+			notAfter:  date(2021, time.January, 1).AddDate(0, 0, 825),
+			expected:  lint.Error,
+		},
+		{
+			name:      "200 days in 2026",
+			notBefore: date(2026, time.June, 1),
+			notAfter:  date(2026, time.June, 1).AddDate(0, 0, 200).Add(-time.Second),
+			expected:  lint.Pass,
+		},
+		{
+			name:      "398 days in 2026",
+			notBefore: date(2026, time.June, 1),
+			notAfter:  date(2026, time.June, 1).AddDate(0, 0, 398),
+			expected:  lint.Error,
+		},
+		{
+			name:      "before the BRs",
+			notBefore: date(2011, time.January, 1),
+			notAfter:  date(2021, time.January, 1),
+			expected:  lint.NE,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cert := test.ReadTestCert("appleServerCertServerAuthEKU.pem")
+			cert.NotBefore = tc.notBefore
+			cert.NotAfter = tc.notAfter
+			if result := test.TestLintCert("e_sub_cert_valid_time_exceeds_schedule", cert); result.Status != tc.expected {
+				t.Errorf("expected result %v was %v (%s)", tc.expected, result.Status, result.Details)
+			}
+		})
+	}
+}
@@ -31,7 +31,7 @@ func (l *subCertValidTimeLongerThan39Months) CheckApplies(c *x509.Certificate) b
 }
 
 func (l *subCertValidTimeLongerThan39Months) Execute(c *x509.Certificate) *lint.LintResult {
-	if c.NotBefore.AddDate(0, 39, 0).Before(c.NotAfter) {
+	if (util.ValidityPeriod{Months: 39}).ExceededBy(c.NotBefore, c.NotAfter) {
 		return &lint.LintResult{Status: lint.Error}
 	}
 	return &lint.LintResult{Status: lint.Pass}
@@ -44,6 +44,7 @@ func init() {
 		Citation:      "BRs: 6.3.2",
 		Source:        lint.CABFBaselineRequirements,
 		EffectiveDate: util.SubCert39Month,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
 		Lint:          &subCertValidTimeLongerThan39Months{},
 	})
 }
@@ -31,7 +31,7 @@ func (l *subCertValidTimeLongerThan825Days) CheckApplies(c *x509.Certificate) bo
 }
 
 func (l *subCertValidTimeLongerThan825Days) Execute(c *x509.Certificate) *lint.LintResult {
-	if c.NotBefore.AddDate(0, 0, 825).Before(c.NotAfter) {
+	if (util.ValidityPeriod{Days: 825}).ExceededBy(c.NotBefore, c.NotAfter) {
 		return &lint.LintResult{Status: lint.Error}
 	}
 	return &lint.LintResult{Status: lint.Pass}
@@ -44,6 +44,7 @@ func init() {
 		Citation:      "BRs: 6.3.2",
 		Source:        lint.CABFBaselineRequirements,
 		EffectiveDate: util.SubCert825Days,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
 		Lint:          &subCertValidTimeLongerThan825Days{},
 	})
 }
@@ -47,3 +47,14 @@ func TestSubCertValidTime825Days(t *testing.T) {
 		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
 	}
 }
+
+func TestSubCertValidTime825DaysOneSecondTooLong(t *testing.T) {
+	// The validity period includes both notBefore and notAfter, so a notAfter
+	// exactly 825 days after notBefore is one second too long.
+	inputPath := "subCert825DaysOneSecondTooLong.pem"
+	expected := lint.Error
+	out := test.TestLint("e_sub_cert_valid_time_longer_than_825_days", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
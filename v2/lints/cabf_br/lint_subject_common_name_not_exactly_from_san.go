@@ -0,0 +1,88 @@
+package cabf_br
+
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+/************************************************
+BRs: 7.1.4.3 Subscriber Certificate Common Name Attribute (Ballot SC-062)
+If present, the commonName attribute MUST contain exactly one entry that is
+one of the values contained in the Certificate's subjectAltName extension
+... and MUST be encoded as follows:
+  - For a dNSName, a character-for-character copy of the dNSName entry value
+    from the subjectAltName extension.
+  - For an IPv4 address, the IPv4address from Section 3.2 of RFC 3986.
+  - For an IPv6 address, the encoding as specified in Section 4 of RFC 5952.
+************************************************/
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type subjectCommonNameNotExactlyFromSAN struct{}
+
+func (l *subjectCommonNameNotExactlyFromSAN) Initialize() error {
+	return nil
+}
+
+func (l *subjectCommonNameNotExactlyFromSAN) CheckApplies(c *x509.Certificate) bool {
+	return len(c.Subject.CommonNames) > 0 && !util.IsCACert(c)
+}
+
+func (l *subjectCommonNameNotExactlyFromSAN) Execute(c *x509.Certificate) *lint.LintResult {
+	for _, cn := range c.Subject.CommonNames {
+		if !commonNameInSAN(c, cn) {
+			return &lint.LintResult{
+				Status:  lint.Error,
+				Details: fmt.Sprintf("common name %q is not an exact copy of a SAN entry of the same type", cn),
+			}
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+// commonNameInSAN returns true if cn is the canonical text form of an
+// iPAddress SAN entry or, if it isn't an IP address, is identical to
+// a dNSName SAN entry.
+func commonNameInSAN(c *x509.Certificate, cn string) bool {
+	if net.ParseIP(cn) != nil {
+		for _, ip := range c.IPAddresses {
+			if cn == ip.String() {
+				return true
+			}
+		}
+		return false
+	}
+	for _, dn := range c.DNSNames {
+		if cn == dn {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_subject_common_name_not_exactly_from_san",
+		Description:   "The common name of subscriber certificates MUST be a character-for-character copy of a dNSName or the canonical form of an iPAddress in the SAN extension",
+		Citation:      "BRs: 7.1.4.3",
+		Source:        lint.CABFBaselineRequirements,
+		EffectiveDate: util.SC62EffectiveDate,
+		Lint:          &subjectCommonNameNotExactlyFromSAN{},
+	})
+}
@@ -0,0 +1,76 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package cabf_br
+
+import (
+	"testing"
+
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/test"
+)
+
+func TestSubjectCommonNameNotExactlyFromSANExactlyFromSAN(t *testing.T) {
+	inputPath := "commonNameExactlyFromSAN.pem"
+	expected := lint.Pass
+	out := test.TestLint("e_subject_common_name_not_exactly_from_san", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+func TestSubjectCommonNameNotExactlyFromSANIPExactlyFromSAN(t *testing.T) {
+	inputPath := "commonNameIPExactlyFromSAN.pem"
+	expected := lint.Pass
+	out := test.TestLint("e_subject_common_name_not_exactly_from_san", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+func TestSubjectCommonNameNotExactlyFromSANCaseDiffersFromSAN(t *testing.T) {
+	inputPath := "commonNameCaseDiffersFromSAN.pem"
+	expected := lint.Error
+	out := test.TestLint("e_subject_common_name_not_exactly_from_san", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+func TestSubjectCommonNameNotExactlyFromSANIPInDNSName(t *testing.T) {
+	inputPath := "commonNameIPInDNSName.pem"
+	expected := lint.Error
+	out := test.TestLint("e_subject_common_name_not_exactly_from_san", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+func TestSubjectCommonNameNotExactlyFromSANIPNotRFC5952(t *testing.T) {
+	inputPath := "commonNameIPNotRFC5952.pem"
+	expected := lint.Error
+	out := test.TestLint("e_subject_common_name_not_exactly_from_san", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+func TestSubjectCommonNameNotExactlyFromSANInSAN(t *testing.T) {
+	inputPath := "commonNameInSAN.pem"
+	expected := lint.NE
+	out := test.TestLint("e_subject_common_name_not_exactly_from_san", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
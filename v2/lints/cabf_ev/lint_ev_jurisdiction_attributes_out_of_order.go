@@ -0,0 +1,84 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package cabf_ev
+
+/************************************************
+CABF EV Guidelines: 9.2.4 Subject Jurisdiction of Incorporation or Registration Field
+The jurisdiction fields identify the Incorporating or Registration Agency at
+the country, state or province, and locality level. Like the countryName,
+stateOrProvinceName and localityName attributes of a distinguished name they
+are expected to run from the broadest jurisdiction to the narrowest.
+************************************************/
+
+import (
+	"encoding/asn1"
+	"fmt"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type evJurisdictionAttributesOutOfOrder struct{}
+
+// jurisdictionLevels lists the jurisdiction attributes from the broadest to
+// the narrowest.
+var jurisdictionLevels = []struct {
+	name string
+	oid  asn1.ObjectIdentifier
+}{
+	{"jurisdictionCountryName", util.JurisdictionCountryOID},
+	{"jurisdictionStateOrProvinceName", util.JurisdictionProvinceOID},
+	{"jurisdictionLocalityName", util.JurisdictionLocalityOID},
+}
+
+func (l *evJurisdictionAttributesOutOfOrder) Initialize() error {
+	return nil
+}
+
+func (l *evJurisdictionAttributesOutOfOrder) CheckApplies(c *x509.Certificate) bool {
+	return len(c.Subject.JurisdictionProvince) > 0 || len(c.Subject.JurisdictionLocality) > 0
+}
+
+func (l *evJurisdictionAttributesOutOfOrder) Execute(c *x509.Certificate) *lint.LintResult {
+	last := -1
+	lastName := ""
+	for _, attr := range c.Subject.Names {
+		for level, j := range jurisdictionLevels {
+			if !attr.Type.Equal(j.oid) {
+				continue
+			}
+			if level < last {
+				return &lint.LintResult{
+					Status:  lint.Warn,
+					Details: fmt.Sprintf("%s appears after the narrower %s", j.name, lastName),
+				}
+			}
+			last, lastName = level, j.name
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "w_ev_jurisdiction_attributes_out_of_order",
+		Description:   "Subject jurisdiction attributes should be ordered country, state or province, then locality",
+		Citation:      "CABF EV Guidelines: 9.2.4",
+		Source:        lint.CABFEVGuidelines,
+		EffectiveDate: util.ZeroDate,
+		Lint:          &evJurisdictionAttributesOutOfOrder{},
+	})
+}
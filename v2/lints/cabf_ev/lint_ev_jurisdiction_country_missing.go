@@ -0,0 +1,62 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package cabf_ev
+
+/************************************************
+CABF EV Guidelines: 9.2.4 Subject Jurisdiction of Incorporation or Registration Field
+Certificate Fields:
+  Locality (if required):
+    subject:jurisdictionLocalityName (OID: 1.3.6.1.4.1.311.60.2.1.1)
+  State or province (if required):
+    subject:jurisdictionStateOrProvinceName (OID: 1.3.6.1.4.1.311.60.2.1.2)
+  Country:
+    subject:jurisdictionCountryName (OID: 1.3.6.1.4.1.311.60.2.1.3)
+Required/Optional: Required
+************************************************/
+
+import (
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type evJurisdictionCountryMissing struct{}
+
+func (l *evJurisdictionCountryMissing) Initialize() error {
+	return nil
+}
+
+func (l *evJurisdictionCountryMissing) CheckApplies(c *x509.Certificate) bool {
+	return util.IsSubscriberCert(c) && util.IsEVCert(c)
+}
+
+func (l *evJurisdictionCountryMissing) Execute(c *x509.Certificate) *lint.LintResult {
+	if len(c.Subject.JurisdictionCountry) == 0 {
+		return &lint.LintResult{Status: lint.Error}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_ev_jurisdiction_country_missing",
+		Description:   "EV certificates MUST include jurisdictionCountryName in the subject",
+		Citation:      "CABF EV Guidelines: 9.2.4",
+		Source:        lint.CABFEVGuidelines,
+		EffectiveDate: util.ZeroDate,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
+		Lint:          &evJurisdictionCountryMissing{},
+	})
+}
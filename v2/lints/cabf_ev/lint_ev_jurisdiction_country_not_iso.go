@@ -0,0 +1,68 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package cabf_ev
+
+/************************************************
+CABF EV Guidelines: 9.2.4 Subject Jurisdiction of Incorporation or Registration Field
+Contents: These fields MUST NOT contain information that is not relevant to the
+level of the Incorporating Agency or Registration Agency. [...] Country
+information MUST be specified using the applicable ISO country code.
+************************************************/
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type evJurisdictionCountryNotISO struct{}
+
+func (l *evJurisdictionCountryNotISO) Initialize() error {
+	return nil
+}
+
+func (l *evJurisdictionCountryNotISO) CheckApplies(c *x509.Certificate) bool {
+	return len(c.Subject.JurisdictionCountry) > 0
+}
+
+// Execute returns an Error for any jurisdictionCountryName that is not an
+// ISO 3166-1 alpha-2 code. Unlike subject:countryName the user-assigned "XX"
+// code is not permitted: the jurisdiction is always a known country.
+func (l *evJurisdictionCountryNotISO) Execute(c *x509.Certificate) *lint.LintResult {
+	for _, country := range c.Subject.JurisdictionCountry {
+		upper := strings.ToUpper(country)
+		if upper == "XX" || !util.IsISOCountryCode(upper) {
+			return &lint.LintResult{
+				Status:  lint.Error,
+				Details: fmt.Sprintf("jurisdictionCountryName %q is not an ISO 3166-1 country code", country),
+			}
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_ev_jurisdiction_country_not_iso",
+		Description:   "The jurisdictionCountryName field MUST contain the two-letter ISO 3166-1 code for the country of incorporation or registration",
+		Citation:      "CABF EV Guidelines: 9.2.4",
+		Source:        lint.CABFEVGuidelines,
+		EffectiveDate: util.ZeroDate,
+		Lint:          &evJurisdictionCountryNotISO{},
+	})
+}
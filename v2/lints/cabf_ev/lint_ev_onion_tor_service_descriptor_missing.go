@@ -0,0 +1,56 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package cabf_ev
+
+import (
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type evOnionTorServiceDescriptorMissing struct{}
+
+func (l *evOnionTorServiceDescriptorMissing) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true if the certificate is an EV subscriber certificate
+// that contains a subject name ending in `.onion`.
+func (l *evOnionTorServiceDescriptorMissing) CheckApplies(c *x509.Certificate) bool {
+	return util.IsSubscriberCert(c) && util.IsEVCert(c) &&
+		util.CertificateSubjInTLD(c, util.OnionTLD)
+}
+
+// Execute returns an lint.Error lint.LintResult if the certificate doesn't
+// contain the CAB Forum TorServiceDescriptor extension. The content of the
+// extension is checked by e_ext_tor_service_descriptor_hash_invalid.
+func (l *evOnionTorServiceDescriptorMissing) Execute(c *x509.Certificate) *lint.LintResult {
+	if !util.IsExtInCert(c, util.BRTorServiceDescriptor) {
+		return &lint.LintResult{Status: lint.Error}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_ev_onion_tor_service_descriptor_missing",
+		Description:   "EV certificates with .onion names must contain the CAB Forum TorServiceDescriptor extension",
+		Citation:      "CABF EV Guidelines: Appendix F",
+		Source:        lint.CABFEVGuidelines,
+		EffectiveDate: util.CABV201Date,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
+		Lint:          &evOnionTorServiceDescriptorMissing{},
+	})
+}
@@ -0,0 +1,121 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package cabf_ev
+
+/************************************************
+CABF EV Guidelines: 9.2.8 Subject Organization Identifier Field
+The organizationIdentifier field MUST contain a Registration Reference for a
+Legal Entity assigned in accordance to the identified Registration Scheme.
+The Registration Reference MUST use the following structure in the presented
+order:
+  * 3 character Registration Scheme identifier;
+  * 2 character ISO 3166 country code for the nation in which the Registration
+    Scheme is operated, or if the scheme is operated globally ISO 3166 code
+    "XG" shall be used;
+  * For the NTR Registration Scheme identifier, if required under Section
+    9.2.4, a 2 character ISO 3166-2 identifier for the subdivision (state or
+    province) of the nation in which the Registration Scheme is operated,
+    preceded by plus "+" (0x2B (ASCII), U+002B (UTF-8));
+  * a hyphen-minus "-" (0x2D (ASCII), U+002D (UTF-8));
+  * Registration Reference allocated in accordance with the identified
+    Registration Scheme
+As in section 9.2.4, the specified location information MUST match the scope
+of the registration being referenced.
+
+Appendix H: Registration Schemes
+  NTR: The information carried in this field shall be the same as held in
+       Subject Registration Number Field as specified in 9.2.5 and the country
+       code used in the Registration Scheme identifier shall match that of the
+       subject's jurisdiction as specified in Section 9.2.4.
+  VAT: Reference allocated by the national tax authorities to a Legal Entity.
+  PSD: Authorization number as specified in ETSI TS 119 495 clause 4.4.
+************************************************/
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type evOrganizationIdentifierInvalidSyntax struct{}
+
+// evOrganizationIdentifierRegex captures the Registration Scheme, the country
+// code and the optional NTR state or province code of a Registration Reference.
+var evOrganizationIdentifierRegex = regexp.MustCompile(`^(NTR|VAT|PSD)([A-Z]{2})(\+[A-Z0-9]{1,3})?-.+$`)
+
+func (l *evOrganizationIdentifierInvalidSyntax) Initialize() error {
+	return nil
+}
+
+func (l *evOrganizationIdentifierInvalidSyntax) CheckApplies(c *x509.Certificate) bool {
+	return util.IsSubscriberCert(c) && util.IsEVCert(c) &&
+		len(util.GetNameAttributeValues(&c.Subject, util.OrganizationIdentifierOID)) > 0
+}
+
+func (l *evOrganizationIdentifierInvalidSyntax) Execute(c *x509.Certificate) *lint.LintResult {
+	for _, value := range util.GetNameAttributeValues(&c.Subject, util.OrganizationIdentifierOID) {
+		if details := checkEVOrganizationIdentifier(c, value); details != "" {
+			return &lint.LintResult{
+				Status:  lint.Error,
+				Details: fmt.Sprintf("organizationIdentifier %q %s", value, details),
+			}
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+// checkEVOrganizationIdentifier returns a description of the first problem
+// found with the Registration Reference, or an empty string if it is valid.
+func checkEVOrganizationIdentifier(c *x509.Certificate, value string) string {
+	matches := evOrganizationIdentifierRegex.FindStringSubmatch(value)
+	if matches == nil {
+		return "does not follow the Registration Reference syntax"
+	}
+	scheme, country, subdivision := matches[1], matches[2], matches[3]
+	switch {
+	case country == "XG":
+	case scheme == "VAT" && country == "EL":
+		// The EU VAT scheme identifies Greece as EL rather than GR.
+	case country == "XX" || !util.IsISOCountryCode(country):
+		return fmt.Sprintf("contains country code %q which is not an ISO 3166 code", country)
+	}
+	if subdivision != "" && scheme != "NTR" {
+		return fmt.Sprintf("contains a state or province for the %s scheme, which is only permitted for NTR", scheme)
+	}
+	if scheme == "NTR" {
+		for _, jurisdiction := range c.Subject.JurisdictionCountry {
+			if !strings.EqualFold(jurisdiction, country) {
+				return fmt.Sprintf("contains country code %q which does not match jurisdictionCountryName %q", country, jurisdiction)
+			}
+		}
+	}
+	return ""
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_ev_organization_identifier_invalid_syntax",
+		Description:   "The organizationIdentifier of an EV certificate MUST consist of a Registration Scheme identifier, a country code, an optional NTR state or province, a hyphen and a Registration Reference",
+		Citation:      "CABF EV Guidelines: 9.2.8 & Appendix H",
+		Source:        lint.CABFEVGuidelines,
+		EffectiveDate: util.EVOrganizationIDDate,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
+		Lint:          &evOrganizationIdentifierInvalidSyntax{},
+	})
+}
@@ -0,0 +1,76 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package cabf_ev
+
+import (
+	"testing"
+
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/test"
+)
+
+func TestEVOrganizationIdentifierInvalidSyntaxOrgIDNTRSubdivision(t *testing.T) {
+	inputPath := "evOrgIDNTRSubdivision.pem"
+	expected := lint.Pass
+	out := test.TestLint("e_ev_organization_identifier_invalid_syntax", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+func TestEVOrganizationIdentifierInvalidSyntaxOrgIDVATGreece(t *testing.T) {
+	inputPath := "evOrgIDVATGreece.pem"
+	expected := lint.Pass
+	out := test.TestLint("e_ev_organization_identifier_invalid_syntax", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+func TestEVOrganizationIdentifierInvalidSyntaxOrgIDBadSyntax(t *testing.T) {
+	inputPath := "evOrgIDBadSyntax.pem"
+	expected := lint.Error
+	out := test.TestLint("e_ev_organization_identifier_invalid_syntax", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+func TestEVOrganizationIdentifierInvalidSyntaxOrgIDCountryMismatch(t *testing.T) {
+	inputPath := "evOrgIDCountryMismatch.pem"
+	expected := lint.Error
+	out := test.TestLint("e_ev_organization_identifier_invalid_syntax", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+func TestEVOrganizationIdentifierInvalidSyntaxOrgIDVATSubdivision(t *testing.T) {
+	inputPath := "evOrgIDVATSubdivision.pem"
+	expected := lint.Error
+	out := test.TestLint("e_ev_organization_identifier_invalid_syntax", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+func TestEVOrganizationIdentifierInvalidSyntaxJurisdictionValid(t *testing.T) {
+	inputPath := "evJurisdictionValid.pem"
+	expected := lint.NA
+	out := test.TestLint("e_ev_organization_identifier_invalid_syntax", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
@@ -0,0 +1,66 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package cabf_ev
+
+/************************************************
+CABF EV Guidelines: 9.8.1 Subject Alternative Name Extension
+Wildcard certificates are not allowed for EV Certificates except as permitted
+under Appendix F.
+
+Appendix F permits wildcards only in names ending in `.onion`.
+************************************************/
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type evSANWildcard struct{}
+
+func (l *evSANWildcard) Initialize() error {
+	return nil
+}
+
+func (l *evSANWildcard) CheckApplies(c *x509.Certificate) bool {
+	return util.IsSubscriberCert(c) && util.IsEVCert(c)
+}
+
+func (l *evSANWildcard) Execute(c *x509.Certificate) *lint.LintResult {
+	for _, name := range append([]string{c.Subject.CommonName}, c.DNSNames...) {
+		if strings.HasPrefix(name, "*") && !strings.HasSuffix(strings.ToLower(name), util.OnionTLD) {
+			return &lint.LintResult{
+				Status:  lint.Error,
+				Details: fmt.Sprintf("EV certificate contains wildcard name %q", name),
+			}
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_ev_san_wildcard",
+		Description:   "EV certificates MUST NOT contain wildcard names other than .onion names",
+		Citation:      "CABF EV Guidelines: 9.8.1",
+		Source:        lint.CABFEVGuidelines,
+		EffectiveDate: util.ZeroDate,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
+		Lint:          &evSANWildcard{},
+	})
+}
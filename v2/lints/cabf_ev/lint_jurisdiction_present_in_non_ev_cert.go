@@ -0,0 +1,60 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package cabf_ev
+
+/************************************************
+CABF EV Guidelines: 9.2.4 Subject Jurisdiction of Incorporation or Registration Field
+The jurisdictionLocalityName, jurisdictionStateOrProvinceName and
+jurisdictionCountryName attributes are defined for EV Certificates, whose
+jurisdiction of incorporation or registration is verified under Section 11.5.
+Certificates issued under other policies have no such verification to convey.
+************************************************/
+
+import (
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type jurisdictionPresentInNonEVCert struct{}
+
+func (l *jurisdictionPresentInNonEVCert) Initialize() error {
+	return nil
+}
+
+func (l *jurisdictionPresentInNonEVCert) CheckApplies(c *x509.Certificate) bool {
+	return util.IsSubscriberCert(c) && !util.IsEVCert(c)
+}
+
+func (l *jurisdictionPresentInNonEVCert) Execute(c *x509.Certificate) *lint.LintResult {
+	if len(c.Subject.JurisdictionCountry) > 0 ||
+		len(c.Subject.JurisdictionProvince) > 0 ||
+		len(c.Subject.JurisdictionLocality) > 0 {
+		return &lint.LintResult{Status: lint.Warn}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "w_jurisdiction_present_in_non_ev_cert",
+		Description:   "Subject jurisdiction attributes should only be present in EV certificates",
+		Citation:      "CABF EV Guidelines: 9.2.4",
+		Source:        lint.CABFEVGuidelines,
+		EffectiveDate: util.ZeroDate,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
+		Lint:          &jurisdictionPresentInNonEVCert{},
+	})
+}
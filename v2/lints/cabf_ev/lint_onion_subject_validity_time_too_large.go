@@ -47,7 +47,7 @@ func (l *torValidityTooLarge) CheckApplies(c *x509.Certificate) bool {
 // a validity period longer than the maximum allowed validity for a certificate
 // with a .onion subject.
 func (l *torValidityTooLarge) Execute(c *x509.Certificate) *lint.LintResult {
-	if c.NotBefore.AddDate(0, maxOnionValidityMonths, 0).Before(c.NotAfter) {
+	if (util.ValidityPeriod{Months: maxOnionValidityMonths}).ExceededBy(c.NotBefore, c.NotAfter) {
 		return &lint.LintResult{
 			Status: lint.Error,
 		}
@@ -64,6 +64,7 @@ func init() {
 		Citation:      "CABF EV Guidelines: Appendix F",
 		Source:        lint.CABFEVGuidelines,
 		EffectiveDate: util.OnionOnlyEVDate,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
 		Lint:          &torValidityTooLarge{},
 	})
 }
@@ -0,0 +1,82 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package chrome
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zcrypto/x509/ct"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type embeddedSCTCount struct{}
+
+func (l *embeddedSCTCount) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for subscriber TLS server certificates that are not
+// precertificates.
+func (l *embeddedSCTCount) CheckApplies(c *x509.Certificate) bool {
+	return util.IsSubscriberCert(c) && util.IsServerAuthCert(c) && !util.IsPrecert(c)
+}
+
+// Execute checks that the certificate has embedded SCTs from enough distinct
+// logs to meet Chrome's CT policy:
+//
+// | Certificate lifetime   | # of SCTs from distinct logs |
+// --------------------------------------------------------
+// | 180 days or less       | 2                            |
+// | More than 180 days     | 3                            |
+// --------------------------------------------------------
+//
+// As with w_ct_sct_policy_count_unsatisfied, SCTs may also be delivered in
+// a TLS extension or a stapled OCSP response, so a shortfall is only a Notice.
+func (l *embeddedSCTCount) Execute(c *x509.Certificate) *lint.LintResult {
+	expected := 3
+	if c.NotAfter.Sub(c.NotBefore) <= 180*24*time.Hour {
+		expected = 2
+	}
+
+	logs := make(map[ct.SHA256Hash]bool)
+	for _, sct := range c.SignedCertificateTimestampList {
+		logs[sct.LogID] = true
+	}
+	if len(logs) < expected {
+		return &lint.LintResult{
+			Status: lint.Notice,
+			Details: fmt.Sprintf(
+				"Certificate had %d embedded SCTs from distinct log IDs. "+
+					"Chrome's CT policy requires %d for this certificate unless SCTs are delivered by TLS or OCSP.",
+				len(logs), expected),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "n_chrome_ct_embedded_sct_count_unsatisfied",
+		Description:   "Certificates should have enough embedded SCTs from distinct logs to meet Chrome's CT policy",
+		Citation:      "https://googlechrome.github.io/CertificateTransparency/ct_policy.html",
+		Source:        lint.ChromePolicy,
+		EffectiveDate: util.ChromeCTPolicyDate,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
+		Lint:          &embeddedSCTCount{},
+	})
+}
@@ -0,0 +1,86 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package chrome
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type sctLogOperatorDiversity struct{}
+
+func (l *sctLogOperatorDiversity) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for subscriber TLS server certificates with
+// embedded SCTs.
+func (l *sctLogOperatorDiversity) CheckApplies(c *x509.Certificate) bool {
+	return util.IsSubscriberCert(c) && util.IsServerAuthCert(c) && !util.IsPrecert(c) &&
+		len(c.SignedCertificateTimestampList) > 0
+}
+
+// Execute returns NA, since without a CT log list the operator of each log is
+// unknown.
+func (l *sctLogOperatorDiversity) Execute(c *x509.Certificate) *lint.LintResult {
+	return l.ExecuteWithConfig(c, &lint.Config{})
+}
+
+// ExecuteWithConfig returns a Notice if the embedded SCTs don't come from logs
+// run by at least two distinct operators in the run's CT log list, or NA if
+// there is no list. SCTs from logs missing from the log list can't count
+// towards the requirement and are listed in the details.
+func (l *sctLogOperatorDiversity) ExecuteWithConfig(c *x509.Certificate, config *lint.Config) *lint.LintResult {
+	if len(config.CTLogOperators) == 0 {
+		return &lint.LintResult{Status: lint.NA}
+	}
+	operators := make(map[string]bool)
+	var unknown []string
+	for _, sct := range c.SignedCertificateTimestampList {
+		operator, ok := config.CTLogOperators[sct.LogID]
+		if !ok {
+			unknown = append(unknown, sct.LogID.Base64String())
+			continue
+		}
+		operators[operator] = true
+	}
+	if len(operators) >= 2 {
+		return &lint.LintResult{Status: lint.Pass}
+	}
+	details := fmt.Sprintf("Certificate had embedded SCTs from %d known log operators. Chrome's CT policy requires at least 2.", len(operators))
+	if len(unknown) > 0 {
+		details += fmt.Sprintf(" SCTs from unknown logs: %s.", strings.Join(unknown, ", "))
+	}
+	return &lint.LintResult{
+		Status:  lint.Notice,
+		Details: details,
+	}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "n_chrome_ct_sct_log_operator_diversity",
+		Description:   "Embedded SCTs should come from logs run by at least two distinct log operators",
+		Citation:      "https://googlechrome.github.io/CertificateTransparency/ct_policy.html",
+		Source:        lint.ChromePolicy,
+		EffectiveDate: util.ChromeCTPolicyDate,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
+		Lint:          &sctLogOperatorDiversity{},
+	})
+}
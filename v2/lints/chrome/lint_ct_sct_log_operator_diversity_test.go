@@ -0,0 +1,73 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package chrome
+
+import (
+	"testing"
+
+	"github.com/zmap/zcrypto/x509/ct"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/test"
+)
+
+// testLogID returns the log ID used for the n-th embedded SCT of the test
+// certificates: 32 bytes, the first of which is n.
+func testLogID(n byte) ct.SHA256Hash {
+	var id ct.SHA256Hash
+	id[0] = n
+	return id
+}
+
+func TestSCTLogOperatorDiversity(t *testing.T) {
+	testCases := []struct {
+		name      string
+		testCert  string
+		operators map[ct.SHA256Hash]string
+		expected  lint.LintStatus
+	}{
+		{
+			name:     "no log list",
+			testCert: "chromeSCTTwoShortLived.pem",
+			expected: lint.NA,
+		},
+		{
+			name:      "distinct operators",
+			testCert:  "chromeSCTTwoShortLived.pem",
+			operators: map[ct.SHA256Hash]string{testLogID(1): "Google", testLogID(2): "Cloudflare"},
+			expected:  lint.Pass,
+		},
+		{
+			name:      "single operator",
+			testCert:  "chromeSCTThreeLongLived.pem",
+			operators: map[ct.SHA256Hash]string{testLogID(1): "Google", testLogID(2): "Google", testLogID(3): "Google"},
+			expected:  lint.Notice,
+		},
+		{
+			name:      "unknown log",
+			testCert:  "chromeSCTTwoShortLived.pem",
+			operators: map[ct.SHA256Hash]string{testLogID(1): "Google"},
+			expected:  lint.Notice,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := &lint.Config{CTLogOperators: tc.operators}
+			if result := test.TestLintWithConfig("n_chrome_ct_sct_log_operator_diversity", tc.testCert, config); result.Status != tc.expected {
+				t.Errorf("expected result %v was %v (%s)", tc.expected, result.Status, result.Details)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package community
+
+import (
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type ctPoisonNotCritical struct{}
+
+func (l *ctPoisonNotCritical) Initialize() error {
+	return nil
+}
+
+func (l *ctPoisonNotCritical) CheckApplies(c *x509.Certificate) bool {
+	return util.IsPrecert(c)
+}
+
+// Execute returns an Error if the CT poison extension is not marked critical.
+// The poison only prevents a precertificate from being accepted as a TLS
+// certificate because it is critical; without that it is an ordinary
+// certificate that happens to have been logged.
+func (l *ctPoisonNotCritical) Execute(c *x509.Certificate) *lint.LintResult {
+	if ext := util.GetExtFromCert(c, util.CtPoisonOID); !ext.Critical {
+		return &lint.LintResult{Status: lint.Error}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_ct_poison_not_critical",
+		Description:   "The CT poison extension must be marked critical",
+		Citation:      "RFC 6962: 3.1",
+		Source:        lint.ZLint,
+		EffectiveDate: util.ZeroDate,
+		Lint:          &ctPoisonNotCritical{},
+	})
+}
@@ -0,0 +1,56 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package community
+
+import (
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type ctPoisonWithEmbeddedSCTs struct{}
+
+func (l *ctPoisonWithEmbeddedSCTs) Initialize() error {
+	return nil
+}
+
+func (l *ctPoisonWithEmbeddedSCTs) CheckApplies(c *x509.Certificate) bool {
+	return util.IsPrecert(c)
+}
+
+// Execute returns an Error if a precertificate also carries an embedded SCT
+// list. SCTs are issued for a precertificate and embedded in the final
+// certificate, so a certificate containing both has been assembled from the
+// wrong pieces and can't be validated by any CT-enforcing client.
+func (l *ctPoisonWithEmbeddedSCTs) Execute(c *x509.Certificate) *lint.LintResult {
+	if util.IsExtInCert(c, util.TimestampOID) {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: "certificate contains both the CT poison extension and an embedded SCT list",
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_ct_poison_with_embedded_scts",
+		Description:   "Precertificates containing the CT poison extension must not contain an embedded SCT list",
+		Citation:      "RFC 6962: 3.1, 3.3",
+		Source:        lint.ZLint,
+		EffectiveDate: util.ZeroDate,
+		Lint:          &ctPoisonWithEmbeddedSCTs{},
+	})
+}
@@ -0,0 +1,65 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package community
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type sctTimestampOutsideValidity struct{}
+
+func (l *sctTimestampOutsideValidity) Initialize() error {
+	return nil
+}
+
+func (l *sctTimestampOutsideValidity) CheckApplies(c *x509.Certificate) bool {
+	return len(c.SignedCertificateTimestampList) > 0
+}
+
+// Execute returns a Warning for any embedded SCT issued outside the
+// certificate's validity period. An SCT issued after notAfter can't relate to
+// the issuance of this certificate, and one issued before notBefore means the
+// certificate was logged before it became valid, which usually indicates
+// forward-dating or an SCT copied from another certificate.
+func (l *sctTimestampOutsideValidity) Execute(c *x509.Certificate) *lint.LintResult {
+	for _, sct := range c.SignedCertificateTimestampList {
+		ts := util.SCTTime(sct)
+		if ts.Before(c.NotBefore) || ts.After(c.NotAfter) {
+			return &lint.LintResult{
+				Status: lint.Warn,
+				Details: fmt.Sprintf("SCT from log %s has timestamp %s outside the validity period %s to %s",
+					sct.LogID.Base64String(), ts.Format(time.RFC3339),
+					c.NotBefore.UTC().Format(time.RFC3339), c.NotAfter.UTC().Format(time.RFC3339)),
+			}
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "w_ct_sct_timestamp_outside_validity",
+		Description:   "Embedded SCTs should have timestamps within the certificate's validity period",
+		Citation:      "RFC 6962: 3.2",
+		Source:        lint.ZLint,
+		EffectiveDate: util.ZeroDate,
+		Lint:          &sctTimestampOutsideValidity{},
+	})
+}
@@ -0,0 +1,59 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package community
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type SANDNSNameInEmailProtectionCert struct{}
+
+func (l *SANDNSNameInEmailProtectionCert) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for subscriber certificates whose only EKU is
+// emailProtection.
+func (l *SANDNSNameInEmailProtectionCert) CheckApplies(c *x509.Certificate) bool {
+	return util.IsSubscriberCert(c) && util.HasOnlyEKU(c, x509.ExtKeyUsageEmailProtection) &&
+		util.IsExtInCert(c, util.SubjectAlternateNameOID)
+}
+
+func (l *SANDNSNameInEmailProtectionCert) Execute(c *x509.Certificate) *lint.LintResult {
+	if len(c.DNSNames) > 0 {
+		return &lint.LintResult{
+			Status:  lint.Warn,
+			Details: fmt.Sprintf("dNSNames in an S/MIME certificate: %s", strings.Join(c.DNSNames, ", ")),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "w_ext_san_dns_name_in_email_protection_cert",
+		Description:   "Subscriber certificates whose only EKU is emailProtection should identify mailboxes, not hosts, and so should not contain dNSName entries",
+		Citation:      "S/MIME BRs: 7.1.2.3",
+		Source:        lint.ZLint,
+		EffectiveDate: util.RFC5280Date,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate, Extension: util.SubjectAlternateNameOID},
+		Lint:          &SANDNSNameInEmailProtectionCert{},
+	})
+}
@@ -0,0 +1,65 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package community
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type SANOtherNameNotSmtpUTF8Mailbox struct{}
+
+func (l *SANOtherNameNotSmtpUTF8Mailbox) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for subscriber certificates whose only EKU is
+// emailProtection and that have an otherName SAN entry. The BRs already
+// prohibit otherNames in serverAuth certificates (e_ext_san_other_name_present).
+func (l *SANOtherNameNotSmtpUTF8Mailbox) CheckApplies(c *x509.Certificate) bool {
+	return util.IsSubscriberCert(c) && util.HasOnlyEKU(c, x509.ExtKeyUsageEmailProtection) && len(c.OtherNames) > 0
+}
+
+func (l *SANOtherNameNotSmtpUTF8Mailbox) Execute(c *x509.Certificate) *lint.LintResult {
+	var types []string
+	for _, name := range c.OtherNames {
+		if !name.TypeID.Equal(util.SmtpUTF8MailboxOID) {
+			types = append(types, name.TypeID.String())
+		}
+	}
+	if len(types) > 0 {
+		return &lint.LintResult{
+			Status:  lint.Warn,
+			Details: fmt.Sprintf("otherName types other than id-on-SmtpUTF8Mailbox: %s", strings.Join(types, ", ")),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "w_ext_san_other_name_not_smtp_utf8_mailbox",
+		Description:   "otherName entries in subscriber certificates whose only EKU is emailProtection should be of type id-on-SmtpUTF8Mailbox",
+		Citation:      "S/MIME BRs: 7.1.2.3; RFC 8398",
+		Source:        lint.ZLint,
+		EffectiveDate: util.RFC5280Date,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
+		Lint:          &SANOtherNameNotSmtpUTF8Mailbox{},
+	})
+}
@@ -0,0 +1,94 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package community
+
+/*******************************************************************************
+RFC 5280: 4.2
+   A certificate-using system MUST reject the certificate if it encounters
+   a critical extension it does not recognize or a critical extension
+   that contains information that it cannot process.
+
+Common TLS clients only process a small set of extensions when validating a
+certificate, so marking any other extension critical makes the certificate
+unusable with them even though it is permitted by RFC 5280.
+*******************************************************************************/
+
+import (
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+// understoodCriticalExtensions are the extensions that common clients process
+// when they are marked critical.
+var understoodCriticalExtensions = map[string]bool{
+	util.BasicConstOID.String():           true,
+	util.KeyUsageOID.String():             true,
+	util.EkuSynOid.String():               true,
+	util.SubjectAlternateNameOID.String(): true,
+	util.NameConstOID.String():            true,
+	util.CertPolicyOID.String():           true,
+	util.PolicyConstOID.String():          true,
+	util.PolicyMapOID.String():            true,
+	util.InhibitAnyPolicyOID.String():     true,
+	util.CtPoisonOID.String():             true,
+}
+
+type extUnknownCritical struct{}
+
+func (l *extUnknownCritical) Initialize() error {
+	return nil
+}
+
+func (l *extUnknownCritical) CheckApplies(c *x509.Certificate) bool {
+	for _, ext := range c.Extensions {
+		if ext.Critical {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *extUnknownCritical) Execute(c *x509.Certificate) *lint.LintResult {
+	var unknown []string
+	seen := make(map[string]bool)
+	for _, ext := range c.Extensions {
+		oid := ext.Id.String()
+		if ext.Critical && !understoodCriticalExtensions[oid] && !seen[oid] {
+			seen[oid] = true
+			unknown = append(unknown, oid)
+		}
+	}
+	if len(unknown) > 0 {
+		return &lint.LintResult{
+			Status:  lint.Warn,
+			Details: "critical extensions not understood by common clients: " + strings.Join(unknown, ", "),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "w_ext_unknown_critical",
+		Description:   "Extensions that common clients don't process SHOULD NOT be marked critical",
+		Citation:      "RFC 5280: 4.2",
+		Source:        lint.ZLint,
+		EffectiveDate: util.ZeroDate,
+		Lint:          &extUnknownCritical{},
+	})
+}
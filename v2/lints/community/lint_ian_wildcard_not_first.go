@@ -48,6 +48,7 @@ func init() {
 		Citation:      "awslabs certlint",
 		Source:        lint.AWSLabs,
 		EffectiveDate: util.ZeroDate,
+		Applicability: lint.Applicability{Extension: util.IssuerAlternateNameOID},
 		Lint:          &brIANWildcardFirst{},
 	})
 }
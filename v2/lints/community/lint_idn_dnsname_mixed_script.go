@@ -0,0 +1,75 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package community
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type IDNMixedScript struct{}
+
+func (l *IDNMixedScript) Initialize() error {
+	return nil
+}
+
+func (l *IDNMixedScript) CheckApplies(c *x509.Certificate) bool {
+	return util.IsExtInCert(c, util.SubjectAlternateNameOID)
+}
+
+// Execute returns a Warning for U-labels that mix scripts beyond the
+// combinations UTS #39 considers highly restrictive, e.g. Cyrillic letters in
+// an otherwise Latin label. A-labels that do not decode are reported by
+// e_international_dns_name_not_idna2008 and are ignored here.
+func (l *IDNMixedScript) Execute(c *x509.Certificate) *lint.LintResult {
+	var mixed []string
+	for _, dns := range c.DNSNames {
+		for _, label := range strings.Split(dns, ".") {
+			if !util.IsALabelCandidate(label) {
+				continue
+			}
+			ulabel, err := util.ToIDNA2008ULabel(label)
+			if err != nil {
+				continue
+			}
+			if util.IsMixedScriptLabel(ulabel) {
+				mixed = append(mixed, fmt.Sprintf("%s (%s: %s)", dns, ulabel, strings.Join(util.LabelScripts(ulabel), ", ")))
+			}
+		}
+	}
+	if len(mixed) > 0 {
+		return &lint.LintResult{
+			Status:  lint.Warn,
+			Details: fmt.Sprintf("DNSNames with mixed-script labels: %s", strings.Join(mixed, "; ")),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "w_international_dns_name_mixed_script",
+		Description:   "Internationalized labels of DNSNames should not mix scripts, as mixed-script labels are easily confused with other names",
+		Citation:      "UTS #39: 5.2",
+		Source:        lint.ZLint,
+		EffectiveDate: util.RFC5891Date,
+		Applicability: lint.Applicability{Extension: util.SubjectAlternateNameOID},
+		Lint:          &IDNMixedScript{},
+	})
+}
@@ -58,6 +58,7 @@ func init() {
 		Source:        lint.ZLint,
 		Citation:      "IETF Draft: https://tools.ietf.org/id/draft-strad-trans-redaction-00.html",
 		EffectiveDate: util.ZeroDate,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
 		Lint:          &DNSNameRedacted{},
 	})
 }
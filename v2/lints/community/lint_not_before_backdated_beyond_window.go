@@ -0,0 +1,79 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package community
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type notBeforeBackdatedBeyondWindow struct{}
+
+func (l *notBeforeBackdatedBeyondWindow) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for certificates with embedded SCTs, whose
+// timestamps bound the time at which the certificate was actually issued.
+func (l *notBeforeBackdatedBeyondWindow) CheckApplies(c *x509.Certificate) bool {
+	return len(c.SignedCertificateTimestampList) > 0
+}
+
+// Execute returns a Warning if notBefore precedes the earliest embedded SCT
+// timestamp by more than util.DefaultMaxNotBeforeBackdate. A precertificate
+// must be logged before the certificate is issued, so the earliest SCT is an
+// upper bound on the time of issuance.
+func (l *notBeforeBackdatedBeyondWindow) Execute(c *x509.Certificate) *lint.LintResult {
+	return l.ExecuteWithConfig(c, &lint.Config{})
+}
+
+// ExecuteWithConfig is like Execute, but allows the run's backdating window.
+func (l *notBeforeBackdatedBeyondWindow) ExecuteWithConfig(c *x509.Certificate, config *lint.Config) *lint.LintResult {
+	window := config.MaxNotBeforeBackdate
+	if window == 0 {
+		window = util.DefaultMaxNotBeforeBackdate
+	}
+	var earliest time.Time
+	for _, sct := range c.SignedCertificateTimestampList {
+		ts := util.SCTTime(sct)
+		if earliest.IsZero() || ts.Before(earliest) {
+			earliest = ts
+		}
+	}
+	if backdate := earliest.Sub(c.NotBefore); backdate > window {
+		return &lint.LintResult{
+			Status: lint.Warn,
+			Details: fmt.Sprintf("notBefore %s is %s before the earliest SCT timestamp %s",
+				c.NotBefore.UTC().Format(time.RFC3339), backdate.Round(time.Minute),
+				earliest.UTC().Format(time.RFC3339)),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "w_not_before_backdated_beyond_window",
+		Description:   "The notBefore date should not precede the earliest embedded SCT timestamp by more than the configured backdating window",
+		Citation:      "ZLint",
+		Source:        lint.ZLint,
+		EffectiveDate: util.ZeroDate,
+		Lint:          &notBeforeBackdatedBeyondWindow{},
+	})
+}
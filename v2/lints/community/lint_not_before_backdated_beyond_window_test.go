@@ -0,0 +1,59 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package community
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/test"
+)
+
+func TestNotBeforeBackdatedBeyondWindowSCTWithinWindow(t *testing.T) {
+	inputPath := "notBeforeSCTWithinWindow.pem"
+	expected := lint.Pass
+	out := test.TestLint("w_not_before_backdated_beyond_window", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+func TestNotBeforeBackdatedBeyondWindowSCTBackdated(t *testing.T) {
+	inputPath := "notBeforeSCTBackdated.pem"
+	expected := lint.Warn
+	out := test.TestLint("w_not_before_backdated_beyond_window", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+func TestNotBeforeBackdatedBeyondWindowFarFuture(t *testing.T) {
+	inputPath := "notBeforeFarFuture.pem"
+	expected := lint.NA
+	out := test.TestLint("w_not_before_backdated_beyond_window", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+func TestNotBeforeBackdatedBeyondWindowConfigured(t *testing.T) {
+	inputPath := "notBeforeSCTBackdated.pem"
+	expected := lint.Pass
+	out := test.TestLintWithConfig("w_not_before_backdated_beyond_window", inputPath, &lint.Config{MaxNotBeforeBackdate: 14 * 24 * time.Hour})
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
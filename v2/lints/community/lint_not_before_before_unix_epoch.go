@@ -0,0 +1,58 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package community
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type notBeforeBeforeUnixEpoch struct{}
+
+func (l *notBeforeBeforeUnixEpoch) Initialize() error {
+	return nil
+}
+
+func (l *notBeforeBeforeUnixEpoch) CheckApplies(c *x509.Certificate) bool {
+	return true
+}
+
+// Execute returns a Warning for a notBefore prior to 1970-01-01T00:00:00Z.
+// Such dates predate X.509 and can't be represented by implementations that
+// store times as unsigned seconds since the UNIX epoch.
+func (l *notBeforeBeforeUnixEpoch) Execute(c *x509.Certificate) *lint.LintResult {
+	if c.NotBefore.Before(time.Unix(0, 0)) {
+		return &lint.LintResult{
+			Status:  lint.Warn,
+			Details: fmt.Sprintf("notBefore %s is before the UNIX epoch", c.NotBefore.UTC().Format(time.RFC3339)),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "w_not_before_before_unix_epoch",
+		Description:   "The notBefore date should not be before the UNIX epoch",
+		Citation:      "ZLint",
+		Source:        lint.ZLint,
+		EffectiveDate: util.ZeroDate,
+		Lint:          &notBeforeBeforeUnixEpoch{},
+	})
+}
@@ -0,0 +1,69 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package community
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+// notBeforeFutureTolerance is how far in the future notBefore may be before
+// it's reported. It allows for clock skew between the issuer and the linter.
+const notBeforeFutureTolerance = 24 * time.Hour
+
+type notBeforeInFuture struct{}
+
+func (l *notBeforeInFuture) Initialize() error {
+	return nil
+}
+
+func (l *notBeforeInFuture) CheckApplies(c *x509.Certificate) bool {
+	return true
+}
+
+// Execute returns a Warning if notBefore is more than a day after the time the
+// lint is run. Certificates are normally linted around the time they are
+// issued so a notBefore far in the future usually indicates a clock or
+// encoding error on the part of the issuer.
+func (l *notBeforeInFuture) Execute(c *x509.Certificate) *lint.LintResult {
+	return l.ExecuteWithConfig(c, &lint.Config{})
+}
+
+// ExecuteWithConfig is like Execute, but compares notBefore with the run's
+// reference time, e.g. the time a certificate being re-linted was issued.
+func (l *notBeforeInFuture) ExecuteWithConfig(c *x509.Certificate, config *lint.Config) *lint.LintResult {
+	if ahead := c.NotBefore.Sub(config.Now()); ahead > notBeforeFutureTolerance {
+		return &lint.LintResult{
+			Status:  lint.Warn,
+			Details: fmt.Sprintf("notBefore %s is %s in the future", c.NotBefore.UTC().Format(time.RFC3339), ahead.Round(time.Hour)),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "w_not_before_in_future",
+		Description:   "The notBefore date should not be significantly later than the time the certificate is linted",
+		Citation:      "ZLint",
+		Source:        lint.ZLint,
+		EffectiveDate: util.ZeroDate,
+		Lint:          &notBeforeInFuture{},
+	})
+}
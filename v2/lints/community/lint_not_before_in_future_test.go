@@ -0,0 +1,51 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package community
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/test"
+)
+
+func TestNotBeforeInFutureFarFuture(t *testing.T) {
+	inputPath := "notBeforeFarFuture.pem"
+	expected := lint.Warn
+	out := test.TestLint("w_not_before_in_future", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+func TestNotBeforeInFutureSCTBackdated(t *testing.T) {
+	inputPath := "notBeforeSCTBackdated.pem"
+	expected := lint.Pass
+	out := test.TestLint("w_not_before_in_future", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+func TestNotBeforeInFutureAtReferenceTime(t *testing.T) {
+	inputPath := "notBeforeFarFuture.pem"
+	expected := lint.Pass
+	config := &lint.Config{ReferenceTime: time.Date(2099, time.January, 1, 0, 0, 0, 0, time.UTC)}
+	out := test.TestLintWithConfig("w_not_before_in_future", inputPath, config)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
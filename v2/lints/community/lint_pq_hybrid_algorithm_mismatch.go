@@ -0,0 +1,67 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package community
+
+import (
+	"fmt"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type pqHybridAlgorithmMismatch struct{}
+
+func (l *pqHybridAlgorithmMismatch) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true if either the subject public key or the signature
+// uses a post-quantum or composite algorithm.
+func (l *pqHybridAlgorithmMismatch) CheckApplies(c *x509.Certificate) bool {
+	return util.IsPQOID(c.PublicKeyAlgorithmOID) || util.IsPQOID(c.SignatureAlgorithmOID)
+}
+
+// Execute returns a Notice when only one of the subject public key and the
+// issuer's signature is post-quantum. Such a certificate is valid but only
+// part of the chain is protected against a quantum adversary.
+func (l *pqHybridAlgorithmMismatch) Execute(c *x509.Certificate) *lint.LintResult {
+	pqKey := util.IsPQOID(c.PublicKeyAlgorithmOID)
+	pqSig := util.IsPQOID(c.SignatureAlgorithmOID)
+	if pqKey && !pqSig {
+		return &lint.LintResult{
+			Status:  lint.Notice,
+			Details: fmt.Sprintf("post-quantum public key %s is signed with classical algorithm %s", c.PublicKeyAlgorithmOID, c.SignatureAlgorithmOID),
+		}
+	}
+	if pqSig && !pqKey {
+		return &lint.LintResult{
+			Status:  lint.Notice,
+			Details: fmt.Sprintf("classical public key %s is signed with post-quantum algorithm %s", c.PublicKeyAlgorithmOID, c.SignatureAlgorithmOID),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "n_pq_hybrid_algorithm_mismatch",
+		Description:   "Certificates that use post-quantum algorithms should use them for both the subject public key and the signature",
+		Citation:      "IETF Draft: https://datatracker.ietf.org/doc/draft-ietf-lamps-pq-composite-sigs/",
+		Source:        lint.ZLint,
+		EffectiveDate: util.ZeroDate,
+		Lint:          &pqHybridAlgorithmMismatch{},
+	})
+}
@@ -0,0 +1,67 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package community
+
+/*******************************************************************************
+The subjectPublicKey of an ML-DSA or ML-KEM key is the raw encoded public key,
+whose length is fixed by the parameter set: 1312, 1952 or 2592 bytes for
+ML-DSA-44, -65 and -87 and 800, 1184 or 1568 bytes for ML-KEM-512, -768 and
+-1024.
+*******************************************************************************/
+
+import (
+	"fmt"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type pqPublicKeyLengthInvalid struct{}
+
+func (l *pqPublicKeyLengthInvalid) Initialize() error {
+	return nil
+}
+
+func (l *pqPublicKeyLengthInvalid) CheckApplies(c *x509.Certificate) bool {
+	_, ok := util.PQPublicKeySizes[c.PublicKeyAlgorithmOID.String()]
+	return ok
+}
+
+func (l *pqPublicKeyLengthInvalid) Execute(c *x509.Certificate) *lint.LintResult {
+	key, err := util.GetPublicKeyBytes(c)
+	if err != nil {
+		return &lint.LintResult{Status: lint.Fatal, Details: err.Error()}
+	}
+	expected := util.PQPublicKeySizes[c.PublicKeyAlgorithmOID.String()]
+	if len(key) != expected {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: fmt.Sprintf("public key for %s is %d bytes, expected %d", c.PublicKeyAlgorithmOID, len(key), expected),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_pq_public_key_length_invalid",
+		Description:   "ML-DSA and ML-KEM public keys MUST have the length defined for their parameter set",
+		Citation:      "IETF Draft: https://datatracker.ietf.org/doc/draft-ietf-lamps-dilithium-certificates/, https://datatracker.ietf.org/doc/draft-ietf-lamps-kyber-certificates/",
+		Source:        lint.ZLint,
+		EffectiveDate: util.ZeroDate,
+		Lint:          &pqPublicKeyLengthInvalid{},
+	})
+}
@@ -0,0 +1,62 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package community
+
+/*******************************************************************************
+The parameters field of the AlgorithmIdentifier for ML-DSA, ML-KEM and the
+composite ML-DSA algorithms MUST be absent.
+*******************************************************************************/
+
+import (
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type pqPublicKeyParamsPresent struct{}
+
+func (l *pqPublicKeyParamsPresent) Initialize() error {
+	return nil
+}
+
+func (l *pqPublicKeyParamsPresent) CheckApplies(c *x509.Certificate) bool {
+	return util.IsPQOID(c.PublicKeyAlgorithmOID)
+}
+
+func (l *pqPublicKeyParamsPresent) Execute(c *x509.Certificate) *lint.LintResult {
+	aid, err := util.GetPublicKeyAidEncoded(c)
+	if err != nil {
+		return &lint.LintResult{Status: lint.Fatal, Details: err.Error()}
+	}
+	hasParams, err := util.AlgorithmIDHasParams(aid)
+	if err != nil {
+		return &lint.LintResult{Status: lint.Fatal, Details: err.Error()}
+	}
+	if hasParams {
+		return &lint.LintResult{Status: lint.Error}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_pq_public_key_params_present",
+		Description:   "The subjectPublicKeyInfo algorithm parameters of ML-DSA, ML-KEM and composite keys MUST be absent",
+		Citation:      "IETF Draft: https://datatracker.ietf.org/doc/draft-ietf-lamps-dilithium-certificates/, https://datatracker.ietf.org/doc/draft-ietf-lamps-kyber-certificates/",
+		Source:        lint.ZLint,
+		EffectiveDate: util.ZeroDate,
+		Lint:          &pqPublicKeyParamsPresent{},
+	})
+}
@@ -0,0 +1,57 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package community
+
+import (
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type pqSignatureAlgorithmParamsPresent struct{}
+
+func (l *pqSignatureAlgorithmParamsPresent) Initialize() error {
+	return nil
+}
+
+func (l *pqSignatureAlgorithmParamsPresent) CheckApplies(c *x509.Certificate) bool {
+	return util.IsMLDSAOID(c.SignatureAlgorithmOID) || util.IsCompositeOID(c.SignatureAlgorithmOID)
+}
+
+func (l *pqSignatureAlgorithmParamsPresent) Execute(c *x509.Certificate) *lint.LintResult {
+	aid, err := util.GetSignatureAlgorithmInTBSEncoded(c)
+	if err != nil {
+		return &lint.LintResult{Status: lint.Fatal, Details: err.Error()}
+	}
+	hasParams, err := util.AlgorithmIDHasParams(aid)
+	if err != nil {
+		return &lint.LintResult{Status: lint.Fatal, Details: err.Error()}
+	}
+	if hasParams {
+		return &lint.LintResult{Status: lint.Error}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_pq_signature_algorithm_params_present",
+		Description:   "The signature algorithm parameters of ML-DSA and composite signatures MUST be absent",
+		Citation:      "IETF Draft: https://datatracker.ietf.org/doc/draft-ietf-lamps-dilithium-certificates/",
+		Source:        lint.ZLint,
+		EffectiveDate: util.ZeroDate,
+		Lint:          &pqSignatureAlgorithmParamsPresent{},
+	})
+}
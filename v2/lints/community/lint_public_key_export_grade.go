@@ -0,0 +1,76 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package community
+
+/*******************************************************************************
+Keys of 512 bits or fewer were the maximum permitted for export from the U.S.
+in the 1990s. RSA moduli and DSA primes of this size can be factored or solved
+with modest resources and provide no meaningful security.
+*******************************************************************************/
+
+import (
+	"crypto/dsa"
+	"crypto/rsa"
+	"fmt"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+const exportGradeKeyBits = 512
+
+type publicKeyExportGrade struct{}
+
+func (l *publicKeyExportGrade) Initialize() error {
+	return nil
+}
+
+func (l *publicKeyExportGrade) CheckApplies(c *x509.Certificate) bool {
+	switch c.PublicKey.(type) {
+	case *rsa.PublicKey, *dsa.PublicKey:
+		return true
+	}
+	return false
+}
+
+func (l *publicKeyExportGrade) Execute(c *x509.Certificate) *lint.LintResult {
+	var bits int
+	switch key := c.PublicKey.(type) {
+	case *rsa.PublicKey:
+		bits = key.N.BitLen()
+	case *dsa.PublicKey:
+		bits = key.P.BitLen()
+	}
+	if bits <= exportGradeKeyBits {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: fmt.Sprintf("%d bit key is export grade", bits),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_public_key_export_grade",
+		Description:   "RSA and DSA keys MUST be longer than 512 bits",
+		Citation:      "NIST SP 800-131A",
+		Source:        lint.ZLint,
+		EffectiveDate: util.ZeroDate,
+		Tags:          []string{lint.TagWeakCrypto},
+		Lint:          &publicKeyExportGrade{},
+	})
+}
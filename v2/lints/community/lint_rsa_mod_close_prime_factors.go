@@ -0,0 +1,64 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package community
+
+/*******************************************************************************
+An RSA modulus whose two prime factors are close to each other can be factored
+in a handful of steps with Fermat's factorization method. Several key
+generators have produced such keys (see CVE-2022-26320), so the modulus is
+checked with a bounded number of Fermat iterations.
+*******************************************************************************/
+
+import (
+	"crypto/rsa"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type rsaModClosePrimeFactors struct{}
+
+func (l *rsaModClosePrimeFactors) Initialize() error {
+	return nil
+}
+
+func (l *rsaModClosePrimeFactors) CheckApplies(c *x509.Certificate) bool {
+	_, ok := c.PublicKey.(*rsa.PublicKey)
+	return ok
+}
+
+func (l *rsaModClosePrimeFactors) Execute(c *x509.Certificate) *lint.LintResult {
+	key := c.PublicKey.(*rsa.PublicKey)
+	if p, _ := util.FermatFactor(key.N, util.FermatFactorRounds); p != nil {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: "modulus was factored with Fermat's method",
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_rsa_mod_close_prime_factors",
+		Description:   "RSA moduli MUST NOT have prime factors close enough to be found with Fermat's factorization method",
+		Citation:      "CVE-2022-26320",
+		Source:        lint.ZLint,
+		EffectiveDate: util.ZeroDate,
+		Tags:          []string{lint.TagWeakCrypto, lint.TagExpensive},
+		Lint:          &rsaModClosePrimeFactors{},
+	})
+}
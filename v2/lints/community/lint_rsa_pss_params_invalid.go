@@ -0,0 +1,99 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package community
+
+/*******************************************************************************
+RFC 4055: 3.1 RSASSA-PSS Public Keys
+   When RSASSA-PSS is used in an AlgorithmIdentifier, the parameters MUST
+   employ the RSASSA-PSS-params syntax.  The parameters may be either
+   absent or present when used as subject public key information.  The
+   parameters MUST be present when used in the algorithm identifier
+   associated with a signature value.
+   ...
+   The mask generation function is ... MGF1 ...
+   The trailerField field is an integer.  It provides compatibility with
+   IEEE Std 1363a-2004.  The value MUST be 1, which represents the
+   trailer field with hexadecimal value 0xBC.
+
+The defaults of RSASSA-PSS-params use SHA-1, which is no longer approved for
+signatures (NIST SP 800-131A), so the hash and the MGF1 hash must be explicitly
+set to the same SHA-2 algorithm.
+*******************************************************************************/
+
+import (
+	"fmt"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type rsaPSSParamsInvalid struct{}
+
+func (l *rsaPSSParamsInvalid) Initialize() error {
+	return nil
+}
+
+func (l *rsaPSSParamsInvalid) CheckApplies(c *x509.Certificate) bool {
+	return c.SignatureAlgorithmOID.Equal(util.OidRSASSAPSS)
+}
+
+func (l *rsaPSSParamsInvalid) Execute(c *x509.Certificate) *lint.LintResult {
+	aid, err := util.GetSignatureAlgorithmInTBSEncoded(c)
+	if err != nil {
+		return &lint.LintResult{Status: lint.Fatal, Details: err.Error()}
+	}
+	params, err := util.ParseRSASSAPSSParams(aid)
+	if err != nil {
+		return &lint.LintResult{Status: lint.Error, Details: err.Error()}
+	}
+	if !params.Hash.Equal(util.SHA256OID) && !params.Hash.Equal(util.SHA384OID) && !params.Hash.Equal(util.SHA512OID) {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: fmt.Sprintf("hash algorithm %s is not SHA-256, SHA-384 or SHA-512", params.Hash),
+		}
+	}
+	if !params.MGF.Equal(util.OidMGF1) {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: fmt.Sprintf("mask generation function %s is not MGF1", params.MGF),
+		}
+	}
+	if !params.MGFHash.Equal(params.Hash) {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: fmt.Sprintf("MGF1 hash algorithm %s does not match hash algorithm %s", params.MGFHash, params.Hash),
+		}
+	}
+	if params.TrailerField != 1 {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: fmt.Sprintf("trailer field is %d", params.TrailerField),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_rsa_pss_params_invalid",
+		Description:   "RSASSA-PSS signature parameters MUST be present, use MGF1 with the same SHA-2 hash as the signature and a trailer field of 1",
+		Citation:      "RFC 4055: 3.1, NIST SP 800-131A",
+		Source:        lint.ZLint,
+		EffectiveDate: util.ZeroDate,
+		Tags:          []string{lint.TagWeakCrypto},
+		Lint:          &rsaPSSParamsInvalid{},
+	})
+}
@@ -0,0 +1,84 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package community
+
+/*******************************************************************************
+NIST SP 800-57 Part 1 Rev. 5, Section 5.6
+The security strength provided by a certificate is limited by both the key it
+certifies and the hash function used to sign it. A security strength of less
+than 112 bits is not acceptable for applying cryptographic protection.
+*******************************************************************************/
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type securityStrengthBelowTarget struct{}
+
+func (l *securityStrengthBelowTarget) Initialize() error {
+	return nil
+}
+
+func (l *securityStrengthBelowTarget) CheckApplies(c *x509.Certificate) bool {
+	_, ok := util.PublicKeySecurityStrength(c)
+	return ok
+}
+
+// Execute compares the strength of the public key and of the signature hash
+// with util.DefaultTargetSecurityStrength.
+func (l *securityStrengthBelowTarget) Execute(c *x509.Certificate) *lint.LintResult {
+	return l.ExecuteWithConfig(c, &lint.Config{})
+}
+
+// ExecuteWithConfig is like Execute, but compares with the run's target
+// security strength.
+func (l *securityStrengthBelowTarget) ExecuteWithConfig(c *x509.Certificate, config *lint.Config) *lint.LintResult {
+	target := config.TargetSecurityStrength
+	if target == 0 {
+		target = util.DefaultTargetSecurityStrength
+	}
+	var weak []string
+	keyStrength, _ := util.PublicKeySecurityStrength(c)
+	if keyStrength < target {
+		weak = append(weak, fmt.Sprintf("public key provides %d bits", keyStrength))
+	}
+	if hashStrength, ok := util.SignatureHashSecurityStrength(c.SignatureAlgorithm); ok && hashStrength < target {
+		weak = append(weak, fmt.Sprintf("signature hash provides %d bits", hashStrength))
+	}
+	if len(weak) > 0 {
+		return &lint.LintResult{
+			Status:  lint.Warn,
+			Details: fmt.Sprintf("security strength below %d bits: %s", target, strings.Join(weak, ", ")),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "w_security_strength_below_target",
+		Description:   "The public key and signature hash of a certificate should provide at least the target security strength (112 bits by default)",
+		Citation:      "NIST SP 800-57 Part 1 Rev. 5: 5.6",
+		Source:        lint.ZLint,
+		EffectiveDate: util.ZeroDate,
+		Tags:          []string{lint.TagWeakCrypto},
+		Lint:          &securityStrengthBelowTarget{},
+	})
+}
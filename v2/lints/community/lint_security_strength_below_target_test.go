@@ -0,0 +1,65 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package community
+
+import (
+	"testing"
+
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/test"
+)
+
+func TestSecurityStrengthBelowTargetMpModulus1024(t *testing.T) {
+	inputPath := "mpModulus1024.pem"
+	expected := lint.Warn
+	out := test.TestLint("w_security_strength_below_target", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+func TestSecurityStrengthBelowTargetMpModulus2048(t *testing.T) {
+	inputPath := "mpModulus2048.pem"
+	expected := lint.Pass
+	out := test.TestLint("w_security_strength_below_target", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+func TestSecurityStrengthBelowTargetRSASHA1Good(t *testing.T) {
+	inputPath := "RSASHA1Good.pem"
+	expected := lint.Warn
+	out := test.TestLint("w_security_strength_below_target", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+func TestSecurityStrengthBelowTargetStrengthP384SignedSHA256(t *testing.T) {
+	inputPath := "strengthP384SignedSHA256.pem"
+	expected := lint.Pass
+	out := test.TestLint("w_security_strength_below_target", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+func TestSecurityStrengthBelowRaisedTarget(t *testing.T) {
+	config := &lint.Config{TargetSecurityStrength: 128}
+	if result := test.TestLintWithConfig("w_security_strength_below_target", "mpModulus2048.pem", config); result.Status != lint.Warn {
+		t.Errorf("expected result %v was %v", lint.Warn, result.Status)
+	}
+}
@@ -0,0 +1,84 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package community
+
+/*******************************************************************************
+A certificate's signature is produced with its issuer's private key, so the
+signature algorithm must be one that the issuer's key type can produce. The
+issuer's key is only known when the certificate's signature verifies with its
+own public key.
+*******************************************************************************/
+
+import (
+	"crypto/ed25519"
+	"fmt"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type signatureAlgorithmKeyTypeMismatch struct{}
+
+func (l *signatureAlgorithmKeyTypeMismatch) Initialize() error {
+	return nil
+}
+
+func (l *signatureAlgorithmKeyTypeMismatch) CheckApplies(c *x509.Certificate) bool {
+	return util.SignatureKeyAlgorithms(c.SignatureAlgorithmOID) != nil && signedByOwnKey(c)
+}
+
+// signedByOwnKey returns true if the certificate's signature verifies with its
+// own public key. zcrypto sets SelfSigned when the signature verifies using the
+// hash of the declared algorithm and the type of the certificate's key, so it
+// holds even when the declared algorithm is for a different key type. zcrypto
+// can't verify Ed25519 signatures, so those are checked here.
+func signedByOwnKey(c *x509.Certificate) bool {
+	if c.SelfSigned {
+		return true
+	}
+	if !c.PublicKeyAlgorithmOID.Equal(util.Ed25519OID) {
+		return false
+	}
+	key, err := util.GetPublicKeyBytes(c)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return false
+	}
+	return ed25519.Verify(ed25519.PublicKey(key), c.RawTBSCertificate, c.Signature)
+}
+
+func (l *signatureAlgorithmKeyTypeMismatch) Execute(c *x509.Certificate) *lint.LintResult {
+	for _, keyAlgorithm := range util.SignatureKeyAlgorithms(c.SignatureAlgorithmOID) {
+		if c.PublicKeyAlgorithmOID.Equal(keyAlgorithm) {
+			return &lint.LintResult{Status: lint.Pass}
+		}
+	}
+	return &lint.LintResult{
+		Status: lint.Error,
+		Details: fmt.Sprintf("signature algorithm %s can't be produced by a %s key",
+			c.SignatureAlgorithmOID, c.PublicKeyAlgorithmOID),
+	}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_signature_algorithm_key_type_mismatch",
+		Description:   "Certificates signed by their own key MUST use a signature algorithm that matches the type of that key",
+		Citation:      "RFC 5280: 4.1.1.2",
+		Source:        lint.ZLint,
+		EffectiveDate: util.ZeroDate,
+		Lint:          &signatureAlgorithmKeyTypeMismatch{},
+	})
+}
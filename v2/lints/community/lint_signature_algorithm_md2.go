@@ -0,0 +1,56 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package community
+
+/*******************************************************************************
+RFC 6149: MD2 to Historic Status
+MD2 has been showing signs of weakness since 1995 and is no longer
+acceptable for use in digital signatures.
+*******************************************************************************/
+
+import (
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type signatureAlgorithmMD2 struct{}
+
+func (l *signatureAlgorithmMD2) Initialize() error {
+	return nil
+}
+
+func (l *signatureAlgorithmMD2) CheckApplies(c *x509.Certificate) bool {
+	return true
+}
+
+func (l *signatureAlgorithmMD2) Execute(c *x509.Certificate) *lint.LintResult {
+	if c.SignatureAlgorithm == x509.MD2WithRSA {
+		return &lint.LintResult{Status: lint.Error}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_signature_algorithm_md2",
+		Description:   "Certificates MUST NOT be signed using MD2",
+		Citation:      "RFC 6149",
+		Source:        lint.ZLint,
+		EffectiveDate: util.RFC6149Date,
+		Tags:          []string{lint.TagWeakCrypto},
+		Lint:          &signatureAlgorithmMD2{},
+	})
+}
@@ -0,0 +1,62 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package community
+
+import (
+	"fmt"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type signatureHashWeakerThanKey struct{}
+
+func (l *signatureHashWeakerThanKey) Initialize() error {
+	return nil
+}
+
+func (l *signatureHashWeakerThanKey) CheckApplies(c *x509.Certificate) bool {
+	_, keyOK := util.PublicKeySecurityStrength(c)
+	_, hashOK := util.SignatureHashSecurityStrength(c.SignatureAlgorithm)
+	return keyOK && hashOK
+}
+
+// Execute returns a Notice if the hash used to sign the certificate provides
+// less security strength than the key being certified, e.g. a P-384 key in
+// a certificate signed with SHA-256. The binding between the key and its
+// subject is then weaker than the key itself.
+func (l *signatureHashWeakerThanKey) Execute(c *x509.Certificate) *lint.LintResult {
+	keyStrength, _ := util.PublicKeySecurityStrength(c)
+	hashStrength, _ := util.SignatureHashSecurityStrength(c.SignatureAlgorithm)
+	if hashStrength < keyStrength {
+		return &lint.LintResult{
+			Status:  lint.Notice,
+			Details: fmt.Sprintf("signature hash provides %d bits of security but public key provides %d bits", hashStrength, keyStrength),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "n_signature_hash_weaker_than_key",
+		Description:   "The hash function used to sign a certificate should provide at least the security strength of the certified public key",
+		Citation:      "NIST SP 800-57 Part 1 Rev. 5: 5.6.1",
+		Source:        lint.ZLint,
+		EffectiveDate: util.ZeroDate,
+		Lint:          &signatureHashWeakerThanKey{},
+	})
+}
@@ -0,0 +1,53 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package community
+
+import (
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type mustStapleWithoutOCSPURL struct{}
+
+func (l *mustStapleWithoutOCSPURL) Initialize() error {
+	return nil
+}
+
+func (l *mustStapleWithoutOCSPURL) CheckApplies(c *x509.Certificate) bool {
+	return util.IsMustStaple(c)
+}
+
+// Execute returns an Error if a certificate asserting the status_request TLS
+// feature has no OCSP responder in its AIA extension. Clients enforcing the
+// feature hard-fail without a stapled OCSP response, and a server can't
+// obtain one for a certificate that doesn't say where to ask.
+func (l *mustStapleWithoutOCSPURL) Execute(c *x509.Certificate) *lint.LintResult {
+	if len(c.OCSPServer) == 0 {
+		return &lint.LintResult{Status: lint.Error}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_tls_feature_must_staple_without_ocsp_url",
+		Description:   "Certificates asserting the status_request TLS feature must include an OCSP URL in the AIA extension",
+		Citation:      "RFC 7633: 4",
+		Source:        lint.ZLint,
+		EffectiveDate: util.ZeroDate,
+		Lint:          &mustStapleWithoutOCSPURL{},
+	})
+}
@@ -0,0 +1,65 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package community
+
+import (
+	"fmt"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type tlsFeatureUnrecognizedValue struct{}
+
+func (l *tlsFeatureUnrecognizedValue) Initialize() error {
+	return nil
+}
+
+func (l *tlsFeatureUnrecognizedValue) CheckApplies(c *x509.Certificate) bool {
+	return util.IsExtInCert(c, util.TLSFeatureOID)
+}
+
+// Execute returns a Warning if the TLS Feature extension lists anything other
+// than the OCSP status request extensions. Other TLS extensions have no
+// defined meaning as certificate features and clients will not know how to
+// enforce them. A Fatal result is returned if the extension can't be parsed.
+func (l *tlsFeatureUnrecognizedValue) Execute(c *x509.Certificate) *lint.LintResult {
+	features, err := util.GetTLSFeatures(c)
+	if err != nil {
+		return &lint.LintResult{Status: lint.Fatal, Details: err.Error()}
+	}
+	for _, f := range features {
+		if f != util.TLSFeatureStatusRequest && f != util.TLSFeatureStatusRequestV2 {
+			return &lint.LintResult{
+				Status:  lint.Warn,
+				Details: fmt.Sprintf("TLS Feature extension contains unrecognized feature %d", f),
+			}
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "w_tls_feature_unrecognized_value",
+		Description:   "The TLS Feature extension should only contain the status_request or status_request_v2 features",
+		Citation:      "RFC 7633: 4",
+		Source:        lint.ZLint,
+		EffectiveDate: util.ZeroDate,
+		Applicability: lint.Applicability{Extension: util.TLSFeatureOID},
+		Lint:          &tlsFeatureUnrecognizedValue{},
+	})
+}
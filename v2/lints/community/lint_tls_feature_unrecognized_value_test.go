@@ -0,0 +1,67 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package community
+
+import (
+	"testing"
+
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/test"
+)
+
+func TestTLSFeatureUnrecognizedValueMustStapleWithOCSP(t *testing.T) {
+	inputPath := "mustStapleWithOCSP.pem"
+	expected := lint.Pass
+	out := test.TestLint("w_tls_feature_unrecognized_value", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+func TestTLSFeatureUnrecognizedValueTlsFeatureStatusRequestV2(t *testing.T) {
+	inputPath := "tlsFeatureStatusRequestV2.pem"
+	expected := lint.Pass
+	out := test.TestLint("w_tls_feature_unrecognized_value", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+func TestTLSFeatureUnrecognizedValueTlsFeatureUnrecognized(t *testing.T) {
+	inputPath := "tlsFeatureUnrecognized.pem"
+	expected := lint.Warn
+	out := test.TestLint("w_tls_feature_unrecognized_value", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+func TestTLSFeatureUnrecognizedValueTlsFeatureMalformed(t *testing.T) {
+	inputPath := "tlsFeatureMalformed.pem"
+	expected := lint.Fatal
+	out := test.TestLint("w_tls_feature_unrecognized_value", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+func TestTLSFeatureUnrecognizedValuePrecertPoisonCritical(t *testing.T) {
+	inputPath := "precertPoisonCritical.pem"
+	expected := lint.NA
+	out := test.TestLint("w_tls_feature_unrecognized_value", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
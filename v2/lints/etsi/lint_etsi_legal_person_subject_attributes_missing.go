@@ -0,0 +1,73 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package etsi
+
+import (
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type legalPersonSubjectAttributesMissing struct{}
+
+func (l *legalPersonSubjectAttributesMissing) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for subscriber certificates issued to legal
+// persons under a qualified certificate policy or with the legal person
+// semantics identifier.
+func (l *legalPersonSubjectAttributesMissing) CheckApplies(c *x509.Certificate) bool {
+	return util.IsSubscriberCert(c) && util.IsEtsiLegalPersonCert(c)
+}
+
+// Execute returns an Error if the subject is missing any of countryName,
+// organizationName, organizationIdentifier or commonName.
+func (l *legalPersonSubjectAttributesMissing) Execute(c *x509.Certificate) *lint.LintResult {
+	var missing []string
+	if len(c.Subject.Country) == 0 {
+		missing = append(missing, "countryName")
+	}
+	if len(c.Subject.Organization) == 0 {
+		missing = append(missing, "organizationName")
+	}
+	if len(util.GetNameAttributeValues(&c.Subject, util.OrganizationIdentifierOID)) == 0 {
+		missing = append(missing, "organizationIdentifier")
+	}
+	if len(c.Subject.CommonName) == 0 {
+		missing = append(missing, "commonName")
+	}
+	if len(missing) > 0 {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: "subject is missing " + strings.Join(missing, ", "),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_etsi_legal_person_subject_attributes_missing",
+		Description:   "The subject of a certificate issued to a legal person shall include countryName, organizationName, organizationIdentifier and commonName",
+		Citation:      "ETSI EN 319 412-3 V1.1.1 (2016-02) / Section 4.2.1",
+		Source:        lint.EtsiEsi,
+		EffectiveDate: util.EtsiEn319_412_3_V1_1_1_Date,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
+		Lint:          &legalPersonSubjectAttributesMissing{},
+	})
+}
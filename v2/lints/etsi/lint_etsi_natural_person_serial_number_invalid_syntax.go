@@ -0,0 +1,66 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package etsi
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type naturalPersonSerialNumberInvalidSyntax struct{}
+
+// naturalPersonSerialNumberRegex matches a three character identity type
+// reference (PAS, IDC, PNO, TAX or TIN) or a two character national identity
+// type reference followed by a colon, then a two character country code,
+// a hyphen and the identifier itself.
+var naturalPersonSerialNumberRegex = regexp.MustCompile(`^((PAS|IDC|PNO|TAX|TIN)[A-Z]{2}|[A-Z]{2}:[A-Z]{2})-.+$`)
+
+func (l *naturalPersonSerialNumberInvalidSyntax) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for certificates with the natural person
+// semantics identifier and a subject serialNumber.
+func (l *naturalPersonSerialNumberInvalidSyntax) CheckApplies(c *x509.Certificate) bool {
+	return util.GetQcSemanticsIdentifier(c).Equal(util.IdEtsiQcsSemanticsIdNatural) &&
+		len(util.GetNameAttributeValues(&c.Subject, util.SerialOID)) > 0
+}
+
+func (l *naturalPersonSerialNumberInvalidSyntax) Execute(c *x509.Certificate) *lint.LintResult {
+	for _, value := range util.GetNameAttributeValues(&c.Subject, util.SerialOID) {
+		if !naturalPersonSerialNumberRegex.MatchString(value) {
+			return &lint.LintResult{
+				Status:  lint.Error,
+				Details: fmt.Sprintf("serialNumber %q does not follow the semantics identifier syntax", value),
+			}
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_etsi_natural_person_serial_number_invalid_syntax",
+		Description:   "When the natural person semantics identifier is included, the subject serialNumber shall consist of an identity type reference, a country code, a hyphen and an identifier",
+		Citation:      "ETSI EN 319 412-1 V1.1.1 (2016-02) / Section 5.1.3",
+		Source:        lint.EtsiEsi,
+		EffectiveDate: util.EtsiEn319_412_1_V1_1_1_Date,
+		Lint:          &naturalPersonSerialNumberInvalidSyntax{},
+	})
+}
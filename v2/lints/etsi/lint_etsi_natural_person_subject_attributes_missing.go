@@ -0,0 +1,71 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package etsi
+
+import (
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type naturalPersonSubjectAttributesMissing struct{}
+
+func (l *naturalPersonSubjectAttributesMissing) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for subscriber certificates issued to natural
+// persons under a qualified certificate policy or with the natural person
+// semantics identifier.
+func (l *naturalPersonSubjectAttributesMissing) CheckApplies(c *x509.Certificate) bool {
+	return util.IsSubscriberCert(c) && util.IsEtsiNaturalPersonCert(c)
+}
+
+// Execute returns an Error if the subject is missing countryName, commonName,
+// or both of (givenName and/or surname) and pseudonym.
+func (l *naturalPersonSubjectAttributesMissing) Execute(c *x509.Certificate) *lint.LintResult {
+	var missing []string
+	if len(c.Subject.Country) == 0 {
+		missing = append(missing, "countryName")
+	}
+	if len(c.Subject.GivenName) == 0 && len(c.Subject.Surname) == 0 &&
+		len(util.GetNameAttributeValues(&c.Subject, util.PseudonymOID)) == 0 {
+		missing = append(missing, "givenName, surname or pseudonym")
+	}
+	if len(c.Subject.CommonName) == 0 {
+		missing = append(missing, "commonName")
+	}
+	if len(missing) > 0 {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: "subject is missing " + strings.Join(missing, "; "),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_etsi_natural_person_subject_attributes_missing",
+		Description:   "The subject of a certificate issued to a natural person shall include countryName, a choice of (givenName and/or surname) or pseudonym, and commonName",
+		Citation:      "ETSI EN 319 412-2 V2.1.1 (2016-02) / Section 4.2.4",
+		Source:        lint.EtsiEsi,
+		EffectiveDate: util.EtsiEn319_412_2_V2_1_1_Date,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
+		Lint:          &naturalPersonSubjectAttributesMissing{},
+	})
+}
@@ -0,0 +1,67 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package etsi
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type organizationIdentifierInvalidSyntax struct{}
+
+// organizationIdentifierRegex matches a three character identity type
+// reference (VAT, NTR, PSD or LEI) or a two character national identity type
+// reference followed by a colon, then a two character country code, a hyphen
+// and the identifier itself.
+var organizationIdentifierRegex = regexp.MustCompile(`^((VAT|NTR|PSD|LEI)[A-Z]{2}|[A-Z]{2}:[A-Z]{2})-.+$`)
+
+func (l *organizationIdentifierInvalidSyntax) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for certificates with the legal person semantics
+// identifier, which indicates the organizationIdentifier follows the syntax
+// defined by ETSI.
+func (l *organizationIdentifierInvalidSyntax) CheckApplies(c *x509.Certificate) bool {
+	return util.GetQcSemanticsIdentifier(c).Equal(util.IdEtsiQcsSemanticsIdLegal) &&
+		len(util.GetNameAttributeValues(&c.Subject, util.OrganizationIdentifierOID)) > 0
+}
+
+func (l *organizationIdentifierInvalidSyntax) Execute(c *x509.Certificate) *lint.LintResult {
+	for _, value := range util.GetNameAttributeValues(&c.Subject, util.OrganizationIdentifierOID) {
+		if !organizationIdentifierRegex.MatchString(value) {
+			return &lint.LintResult{
+				Status:  lint.Error,
+				Details: fmt.Sprintf("organizationIdentifier %q does not follow the semantics identifier syntax", value),
+			}
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_etsi_organization_identifier_invalid_syntax",
+		Description:   "When the legal person semantics identifier is included, the organizationIdentifier shall consist of an identity type reference, a country code, a hyphen and an identifier",
+		Citation:      "ETSI EN 319 412-1 V1.1.1 (2016-02) / Section 5.1.4",
+		Source:        lint.EtsiEsi,
+		EffectiveDate: util.EtsiEn319_412_1_V1_1_1_Date,
+		Lint:          &organizationIdentifierInvalidSyntax{},
+	})
+}
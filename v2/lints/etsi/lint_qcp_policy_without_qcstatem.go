@@ -0,0 +1,66 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package etsi
+
+import (
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type qcpPolicyWithoutQcStatem struct{}
+
+func (l *qcpPolicyWithoutQcStatem) Initialize() error {
+	return nil
+}
+
+func (l *qcpPolicyWithoutQcStatem) CheckApplies(c *x509.Certificate) bool {
+	return len(util.GetEtsiQcPolicies(c)) > 0
+}
+
+// Execute returns an Error if a certificate asserting a qualified certificate
+// policy lacks the QcCompliance statement, or asserts QCP-n-qscd or
+// QCP-l-qscd without the QcSSCD statement.
+func (l *qcpPolicyWithoutQcStatem) Execute(c *x509.Certificate) *lint.LintResult {
+	var missing []string
+	if !util.IsQcStatementPresent(c, util.IdEtsiQcsQcCompliance) {
+		missing = append(missing, "QcCompliance")
+	}
+	qscd := util.SliceContainsOID(c.PolicyIdentifiers, util.EtsiQcpNQscd) ||
+		util.SliceContainsOID(c.PolicyIdentifiers, util.EtsiQcpLQscd)
+	if qscd && !util.IsQcStatementPresent(c, util.IdEtsiQcsQcSSCD) {
+		missing = append(missing, "QcSSCD")
+	}
+	if len(missing) > 0 {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: "qualified certificate policy asserted without the " + strings.Join(missing, ", ") + " QC statement",
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_qcp_policy_without_qcstatem",
+		Description:   "Certificates asserting a qualified certificate policy shall include the QcCompliance statement, and the QcSSCD statement for the QSCD policies",
+		Citation:      "ETSI EN 319 411-2 V2.1.1 (2016-02) / Section 6.6.1",
+		Source:        lint.EtsiEsi,
+		EffectiveDate: util.EtsiEn319_411_2_V2_1_1_Date,
+		Lint:          &qcpPolicyWithoutQcStatem{},
+	})
+}
@@ -64,6 +64,7 @@ func init() {
 		Citation:      "ETSI EN 319 412 - 5 V2.2.1 (2017 - 11) / Section 4.2.3",
 		Source:        lint.EtsiEsi,
 		EffectiveDate: util.EtsiEn319_412_5_V2_2_1_Date,
+		Applicability: lint.Applicability{Extension: util.QcStateOid},
 		Lint:          &qcStatemEtsiTypeAsStatem{},
 	})
 }
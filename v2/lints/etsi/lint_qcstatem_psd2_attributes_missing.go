@@ -0,0 +1,80 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package etsi
+
+import (
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type psd2AttributesMissing struct{}
+
+func (l *psd2AttributesMissing) Initialize() error {
+	return nil
+}
+
+func (l *psd2AttributesMissing) CheckApplies(c *x509.Certificate) bool {
+	return util.IsQcStatementPresent(c, util.IdEtsiPsd2Statement)
+}
+
+// Execute returns an Error if the PSD2 QC statement doesn't name at least one
+// PSP role and the national competent authority, or if the subject lacks an
+// organizationIdentifier using the PSD scheme.
+func (l *psd2AttributesMissing) Execute(c *x509.Certificate) *lint.LintResult {
+	psd2, err := util.GetPsd2QcType(c)
+	if err != nil {
+		return &lint.LintResult{Status: lint.Error, Details: "error parsing PSD2 QC statement: " + err.Error()}
+	}
+	var missing []string
+	if len(psd2.RolesOfPsp) == 0 {
+		missing = append(missing, "rolesOfPSP")
+	}
+	if psd2.NcaName == "" {
+		missing = append(missing, "nCAName")
+	}
+	if psd2.NcaId == "" {
+		missing = append(missing, "nCAId")
+	}
+	var psdOrgID bool
+	for _, orgID := range util.GetNameAttributeValues(&c.Subject, util.OrganizationIdentifierOID) {
+		if strings.HasPrefix(orgID, "PSD") {
+			psdOrgID = true
+		}
+	}
+	if !psdOrgID {
+		missing = append(missing, "subject organizationIdentifier with the PSD scheme")
+	}
+	if len(missing) > 0 {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: "PSD2 certificate is missing " + strings.Join(missing, ", "),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_qcstatem_psd2_attributes_missing",
+		Description:   "PSD2 certificates shall include the PSP roles, the competent authority name and identifier, and a PSD organizationIdentifier",
+		Citation:      "ETSI TS 119 495 V1.1.2 (2018-07) / Sections 5.1 and 5.2.1",
+		Source:        lint.EtsiEsi,
+		EffectiveDate: util.EtsiTs119_495_V1_1_2_Date,
+		Lint:          &psd2AttributesMissing{},
+	})
+}
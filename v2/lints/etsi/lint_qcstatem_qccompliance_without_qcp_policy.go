@@ -0,0 +1,55 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package etsi
+
+import (
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type qcComplianceWithoutQcpPolicy struct{}
+
+func (l *qcComplianceWithoutQcpPolicy) Initialize() error {
+	return nil
+}
+
+func (l *qcComplianceWithoutQcpPolicy) CheckApplies(c *x509.Certificate) bool {
+	return util.IsQcStatementPresent(c, util.IdEtsiQcsQcCompliance)
+}
+
+// Execute returns an Error if a certificate claiming to be an EU qualified
+// certificate through the QcCompliance statement does not assert any of the
+// qualified certificate policies.
+func (l *qcComplianceWithoutQcpPolicy) Execute(c *x509.Certificate) *lint.LintResult {
+	if len(util.GetEtsiQcPolicies(c)) == 0 {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: "QcCompliance statement present but none of QCP-n, QCP-l, QCP-n-qscd, QCP-l-qscd or QCP-w is asserted",
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_qcstatem_qccompliance_without_qcp_policy",
+		Description:   "Certificates with the QcCompliance statement shall assert one of the qualified certificate policies",
+		Citation:      "ETSI EN 319 411-2 V2.1.1 (2016-02) / Section 6.3.3",
+		Source:        lint.EtsiEsi,
+		EffectiveDate: util.EtsiEn319_411_2_V2_1_1_Date,
+		Lint:          &qcComplianceWithoutQcpPolicy{},
+	})
+}
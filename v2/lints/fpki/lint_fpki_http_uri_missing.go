@@ -0,0 +1,79 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package fpki
+
+import (
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type httpURIMissing struct{}
+
+func (l *httpURIMissing) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for subscriber certificates that assert a Common
+// Policy certificate policy and have a CRL distribution points or caIssuers
+// access method to check. Missing extensions are reported by
+// e_fpki_required_extension_missing.
+func (l *httpURIMissing) CheckApplies(c *x509.Certificate) bool {
+	return util.IsSubscriberCert(c) && util.IsFPKICommonPolicyCert(c) &&
+		(len(c.CRLDistributionPoints) > 0 || len(c.IssuingCertificateURL) > 0)
+}
+
+// Execute returns an Error if the CRL distribution points or the caIssuers
+// access method don't include at least one http URI. LDAP URIs are allowed in
+// addition, but relying parties outside of agency networks are generally only
+// able to reach HTTP repositories.
+func (l *httpURIMissing) Execute(c *x509.Certificate) *lint.LintResult {
+	var missing []string
+	if len(c.CRLDistributionPoints) > 0 && !hasHTTPURI(c.CRLDistributionPoints) {
+		missing = append(missing, "cRLDistributionPoints")
+	}
+	if len(c.IssuingCertificateURL) > 0 && !hasHTTPURI(c.IssuingCertificateURL) {
+		missing = append(missing, "authorityInfoAccess caIssuers")
+	}
+	if len(missing) > 0 {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: "no http URI in " + strings.Join(missing, ", "),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func hasHTTPURI(uris []string) bool {
+	for _, uri := range uris {
+		if strings.HasPrefix(strings.ToLower(uri), "http://") {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_fpki_http_uri_missing",
+		Description:   "The CRL distribution points and caIssuers access method of certificates issued under the Common Policy must include an http URI",
+		Citation:      "X.509 Certificate and CRL Extensions Profile for the Common Policy: End Entity Certificate Worksheets",
+		Source:        lint.FederalPKI,
+		EffectiveDate: util.ZeroDate,
+		Lint:          &httpURIMissing{},
+	})
+}
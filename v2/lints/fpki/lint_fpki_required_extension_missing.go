@@ -0,0 +1,77 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package fpki
+
+import (
+	"encoding/asn1"
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type requiredExtensionMissing struct{}
+
+// requiredExtensions are the extensions every end entity certificate profile
+// in the Common Policy profile worksheets marks as required.
+var requiredExtensions = []struct {
+	oid  asn1.ObjectIdentifier
+	name string
+}{
+	{util.AuthkeyOID, "authorityKeyIdentifier"},
+	{util.KeyUsageOID, "keyUsage"},
+	{util.CertPolicyOID, "certificatePolicies"},
+	{util.CrlDistOID, "cRLDistributionPoints"},
+	{util.AiaOID, "authorityInfoAccess"},
+}
+
+func (l *requiredExtensionMissing) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for subscriber certificates that assert a Common
+// Policy certificate policy.
+func (l *requiredExtensionMissing) CheckApplies(c *x509.Certificate) bool {
+	return util.IsSubscriberCert(c) && util.IsFPKICommonPolicyCert(c)
+}
+
+func (l *requiredExtensionMissing) Execute(c *x509.Certificate) *lint.LintResult {
+	var missing []string
+	for _, ext := range requiredExtensions {
+		if !util.IsExtInCert(c, ext.oid) {
+			missing = append(missing, ext.name)
+		}
+	}
+	if len(missing) > 0 {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: "certificate is missing " + strings.Join(missing, ", "),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_fpki_required_extension_missing",
+		Description:   "End entity certificates issued under the Common Policy must include the authority key identifier, key usage, certificate policies, CRL distribution points and authority information access extensions",
+		Citation:      "X.509 Certificate and CRL Extensions Profile for the Common Policy: End Entity Certificate Worksheets",
+		Source:        lint.FederalPKI,
+		EffectiveDate: util.ZeroDate,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
+		Lint:          &requiredExtensionMissing{},
+	})
+}
@@ -0,0 +1,60 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package fpki
+
+import (
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type subjectNotUSGovernment struct{}
+
+func (l *subjectNotUSGovernment) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for subscriber certificates that assert a Common
+// Policy certificate policy.
+func (l *subjectNotUSGovernment) CheckApplies(c *x509.Certificate) bool {
+	return util.IsSubscriberCert(c) && util.IsFPKICommonPolicyCert(c)
+}
+
+// Execute returns a Warning unless the subject has a single countryName of
+// "US" and a single organizationName of "U.S. Government", the name space used
+// for Federal subscribers. Certificates for affiliated organizations may
+// legitimately use a different name space, so this is not an Error.
+func (l *subjectNotUSGovernment) Execute(c *x509.Certificate) *lint.LintResult {
+	if len(c.Subject.Country) != 1 || c.Subject.Country[0] != "US" ||
+		len(c.Subject.Organization) != 1 || c.Subject.Organization[0] != "U.S. Government" {
+		return &lint.LintResult{
+			Status:  lint.Warn,
+			Details: "subject is not in the C=US, O=U.S. Government name space",
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "w_fpki_subject_not_us_government",
+		Description:   "Subject names of certificates issued under the Common Policy should be in the C=US, O=U.S. Government name space",
+		Citation:      "X.509 Certificate Policy for the U.S. Federal PKI Common Policy Framework: 3.1.1",
+		Source:        lint.FederalPKI,
+		EffectiveDate: util.ZeroDate,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
+		Lint:          &subjectNotUSGovernment{},
+	})
+}
@@ -0,0 +1,59 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package icao
+
+import (
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type cscaKeyUsageInvalid struct{}
+
+func (l *cscaKeyUsageInvalid) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for CSCA certificates that can be identified as
+// such, i.e. link certificates carrying the name change extension.
+func (l *cscaKeyUsageInvalid) CheckApplies(c *x509.Certificate) bool {
+	return util.IsICAOCSCACert(c)
+}
+
+// Execute returns an Error unless the key usage extension is present, critical
+// and asserts exactly keyCertSign and cRLSign.
+func (l *cscaKeyUsageInvalid) Execute(c *x509.Certificate) *lint.LintResult {
+	ext := util.GetExtFromCert(c, util.KeyUsageOID)
+	switch {
+	case ext == nil:
+		return &lint.LintResult{Status: lint.Error, Details: "key usage extension is missing"}
+	case !ext.Critical:
+		return &lint.LintResult{Status: lint.Error, Details: "key usage extension is not critical"}
+	case c.KeyUsage != x509.KeyUsageCertSign|x509.KeyUsageCRLSign:
+		return &lint.LintResult{Status: lint.Error, Details: "key usage is not exactly keyCertSign and cRLSign"}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_icao_csca_key_usage_invalid",
+		Description:   "CSCA certificates must have a critical key usage extension asserting only keyCertSign and cRLSign",
+		Citation:      "ICAO Doc 9303 Part 12: 7.1.1",
+		Source:        lint.ICAO,
+		EffectiveDate: util.ZeroDate,
+		Lint:          &cscaKeyUsageInvalid{},
+	})
+}
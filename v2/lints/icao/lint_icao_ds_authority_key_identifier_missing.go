@@ -0,0 +1,53 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package icao
+
+import (
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type dsAuthorityKeyIdentifierMissing struct{}
+
+func (l *dsAuthorityKeyIdentifierMissing) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for Document Signer certificates.
+func (l *dsAuthorityKeyIdentifierMissing) CheckApplies(c *x509.Certificate) bool {
+	return util.IsICAODocumentSignerCert(c)
+}
+
+// Execute returns an Error if the authority key identifier extension is
+// missing. Inspection systems use it to select the CSCA certificate to verify
+// the Document Signer with, which matters once a CSCA has rolled over its key.
+func (l *dsAuthorityKeyIdentifierMissing) Execute(c *x509.Certificate) *lint.LintResult {
+	if !util.IsExtInCert(c, util.AuthkeyOID) {
+		return &lint.LintResult{Status: lint.Error}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_icao_ds_authority_key_identifier_missing",
+		Description:   "Document Signer certificates must include the authority key identifier extension",
+		Citation:      "ICAO Doc 9303 Part 12: 7.1.1",
+		Source:        lint.ICAO,
+		EffectiveDate: util.ZeroDate,
+		Lint:          &dsAuthorityKeyIdentifierMissing{},
+	})
+}
@@ -0,0 +1,58 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package icao
+
+import (
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type dsKeyUsageInvalid struct{}
+
+func (l *dsKeyUsageInvalid) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for Document Signer certificates.
+func (l *dsKeyUsageInvalid) CheckApplies(c *x509.Certificate) bool {
+	return util.IsICAODocumentSignerCert(c)
+}
+
+// Execute returns an Error unless the key usage extension is present, critical
+// and asserts only digitalSignature.
+func (l *dsKeyUsageInvalid) Execute(c *x509.Certificate) *lint.LintResult {
+	ext := util.GetExtFromCert(c, util.KeyUsageOID)
+	switch {
+	case ext == nil:
+		return &lint.LintResult{Status: lint.Error, Details: "key usage extension is missing"}
+	case !ext.Critical:
+		return &lint.LintResult{Status: lint.Error, Details: "key usage extension is not critical"}
+	case c.KeyUsage != x509.KeyUsageDigitalSignature:
+		return &lint.LintResult{Status: lint.Error, Details: "key usage is not exactly digitalSignature"}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_icao_ds_key_usage_invalid",
+		Description:   "Document Signer certificates must have a critical key usage extension asserting only digitalSignature",
+		Citation:      "ICAO Doc 9303 Part 12: 7.1.1",
+		Source:        lint.ICAO,
+		EffectiveDate: util.ZeroDate,
+		Lint:          &dsKeyUsageInvalid{},
+	})
+}
@@ -0,0 +1,58 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package icao
+
+import (
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type dsValidityTooLong struct{}
+
+func (l *dsValidityTooLong) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for Document Signer certificates.
+func (l *dsValidityTooLong) CheckApplies(c *x509.Certificate) bool {
+	return util.IsICAODocumentSignerCert(c)
+}
+
+// Execute returns a Warning if the certificate is valid for longer than ten
+// years and three months. A Document Signer certificate must remain valid for
+// as long as the documents it signed, so its validity is the private key
+// usage period (at most three months) plus the validity of the longest lived
+// document issued (ten years for passports).
+func (l *dsValidityTooLong) Execute(c *x509.Certificate) *lint.LintResult {
+	if c.NotAfter.After(c.NotBefore.AddDate(10, 3, 0)) {
+		return &lint.LintResult{
+			Status:  lint.Warn,
+			Details: "certificate is valid for longer than the key usage period plus the validity of the documents it signs",
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "w_icao_ds_validity_too_long",
+		Description:   "Document Signer certificates should not be valid for longer than the private key usage period plus the validity period of the documents signed",
+		Citation:      "ICAO Doc 9303 Part 12: 5.3",
+		Source:        lint.ICAO,
+		EffectiveDate: util.ZeroDate,
+		Lint:          &dsValidityTooLong{},
+	})
+}
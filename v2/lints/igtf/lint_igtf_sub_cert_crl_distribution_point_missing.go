@@ -0,0 +1,54 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package igtf
+
+import (
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type subCertCRLDistributionPointMissing struct{}
+
+func (l *subCertCRLDistributionPointMissing) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for subscriber certificates asserting an IGTF
+// policy.
+func (l *subCertCRLDistributionPointMissing) CheckApplies(c *x509.Certificate) bool {
+	return util.IsSubscriberCert(c) && util.IsIGTFCert(c)
+}
+
+// Execute returns an Error if the certificate has no CRL distribution points.
+// Grid middleware relies on CRLs rather than OCSP to check revocation.
+func (l *subCertCRLDistributionPointMissing) Execute(c *x509.Certificate) *lint.LintResult {
+	if len(c.CRLDistributionPoints) == 0 {
+		return &lint.LintResult{Status: lint.Error}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_igtf_sub_cert_crl_distribution_point_missing",
+		Description:   "End entity certificates issued under an IGTF authentication profile must include a CRL distribution point",
+		Citation:      "IGTF Grid Certificate Profile (GFD.225)",
+		Source:        lint.IGTF,
+		EffectiveDate: util.ZeroDate,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
+		Lint:          &subCertCRLDistributionPointMissing{},
+	})
+}
@@ -0,0 +1,52 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package igtf
+
+import (
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type subCertValidTimeLongerThan13Months struct{}
+
+func (l *subCertValidTimeLongerThan13Months) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for subscriber certificates asserting an IGTF
+// policy.
+func (l *subCertValidTimeLongerThan13Months) CheckApplies(c *x509.Certificate) bool {
+	return util.IsSubscriberCert(c) && util.IsIGTFCert(c)
+}
+
+func (l *subCertValidTimeLongerThan13Months) Execute(c *x509.Certificate) *lint.LintResult {
+	if c.NotAfter.After(c.NotBefore.AddDate(0, 13, 0)) {
+		return &lint.LintResult{Status: lint.Error}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_igtf_sub_cert_valid_time_longer_than_13_months",
+		Description:   "End entity certificates issued under an IGTF authentication profile must not be valid for longer than 13 months",
+		Citation:      "IGTF Classic Authentication Profile",
+		Source:        lint.IGTF,
+		EffectiveDate: util.ZeroDate,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
+		Lint:          &subCertValidTimeLongerThan13Months{},
+	})
+}
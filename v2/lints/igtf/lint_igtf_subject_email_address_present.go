@@ -0,0 +1,54 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package igtf
+
+import (
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type subjectEmailAddressPresent struct{}
+
+func (l *subjectEmailAddressPresent) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for certificates asserting an IGTF policy.
+func (l *subjectEmailAddressPresent) CheckApplies(c *x509.Certificate) bool {
+	return util.IsIGTFCert(c)
+}
+
+// Execute returns a Warning if the subject contains the emailAddress
+// attribute. Grid software commonly compares subject names as strings and
+// the attribute is rendered inconsistently (e.g. "Email", "E" or
+// "emailAddress") by different libraries, breaking authorization.
+func (l *subjectEmailAddressPresent) Execute(c *x509.Certificate) *lint.LintResult {
+	if len(util.GetNameAttributeValues(&c.Subject, util.EmailAddressOID)) > 0 {
+		return &lint.LintResult{Status: lint.Warn}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "w_igtf_subject_email_address_present",
+		Description:   "The emailAddress attribute should not be used in the subject of certificates issued under an IGTF authentication profile",
+		Citation:      "IGTF Grid Certificate Profile (GFD.225)",
+		Source:        lint.IGTF,
+		EffectiveDate: util.ZeroDate,
+		Lint:          &subjectEmailAddressPresent{},
+	})
+}
@@ -0,0 +1,88 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package microsoft
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type caEKUMixesPurposes struct{}
+
+// purposes maps the EKUs that the Microsoft Trusted Root Program requires to
+// be issued from separate hierarchies to a short name for each use. Other EKUs
+// (e.g. id-kp-clientAuth) may be combined with any of these.
+var purposes = map[x509.ExtKeyUsage]string{
+	x509.ExtKeyUsageServerAuth:      "server authentication",
+	x509.ExtKeyUsageEmailProtection: "S/MIME",
+	x509.ExtKeyUsageCodeSigning:     "code signing",
+	x509.ExtKeyUsageTimeStamping:    "time stamping",
+}
+
+func (l *caEKUMixesPurposes) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for CA certificates with an EKU extension.
+func (l *caEKUMixesPurposes) CheckApplies(c *x509.Certificate) bool {
+	return util.IsCACert(c) && util.IsExtInCert(c, util.EkuSynOid)
+}
+
+// Execute returns a Warning if the EKU extension of the CA certificate allows
+// more than one of the uses that Microsoft requires to be separated. The
+// anyExtendedKeyUsage EKU allows all of them.
+func (l *caEKUMixesPurposes) Execute(c *x509.Certificate) *lint.LintResult {
+	found := make(map[string]bool)
+	for _, eku := range c.ExtKeyUsage {
+		if eku == x509.ExtKeyUsageAny {
+			return &lint.LintResult{
+				Status:  lint.Warn,
+				Details: "CA certificate EKU extension includes anyExtendedKeyUsage",
+			}
+		}
+		if purpose, ok := purposes[eku]; ok {
+			found[purpose] = true
+		}
+	}
+	if len(found) <= 1 {
+		return &lint.LintResult{Status: lint.Pass}
+	}
+	var names []string
+	for name := range found {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return &lint.LintResult{
+		Status:  lint.Warn,
+		Details: fmt.Sprintf("CA certificate EKU extension allows %s", strings.Join(names, ", ")),
+	}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "w_ms_ca_eku_mixes_purposes",
+		Description:   "CAs must separate server authentication, S/MIME, code signing and time stamping uses into separate hierarchies",
+		Citation:      "Microsoft Trusted Root Program Requirements: 3.A",
+		Source:        lint.MicrosoftRootProgram,
+		EffectiveDate: util.ZeroDate,
+		Applicability: lint.Applicability{Kind: lint.CACertificate, Extension: util.EkuSynOid},
+		Lint:          &caEKUMixesPurposes{},
+	})
+}
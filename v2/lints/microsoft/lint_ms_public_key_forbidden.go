@@ -0,0 +1,93 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package microsoft
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"fmt"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type publicKeyForbidden struct{}
+
+func (l *publicKeyForbidden) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for certificates with an RSA, ECDSA or DSA public
+// key. Microsoft doesn't address other key types.
+func (l *publicKeyForbidden) CheckApplies(c *x509.Certificate) bool {
+	switch c.PublicKeyAlgorithm {
+	case x509.RSA, x509.ECDSA, x509.DSA:
+		return true
+	}
+	return false
+}
+
+// Execute returns an Error for DSA keys, RSA keys with a modulus shorter than
+// 2048 bits and ECDSA keys on curves other than NIST P-256, P-384 and P-521.
+func (l *publicKeyForbidden) Execute(c *x509.Certificate) *lint.LintResult {
+	if c.PublicKeyAlgorithm == x509.DSA {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: "certificate has a DSA public key",
+		}
+	}
+	var key *ecdsa.PublicKey
+	switch k := c.PublicKey.(type) {
+	case *rsa.PublicKey:
+		if bits := k.N.BitLen(); bits < 2048 {
+			return &lint.LintResult{
+				Status:  lint.Error,
+				Details: fmt.Sprintf("certificate has a %d bit RSA modulus", bits),
+			}
+		}
+		return &lint.LintResult{Status: lint.Pass}
+	case *x509.AugmentedECDSA:
+		key = k.Pub
+	case *ecdsa.PublicKey:
+		key = k
+	}
+	if key == nil {
+		return &lint.LintResult{
+			Status:  lint.Fatal,
+			Details: "unable to parse the certificate's public key",
+		}
+	}
+	switch name := key.Curve.Params().Name; name {
+	case "P-256", "P-384", "P-521":
+		return &lint.LintResult{Status: lint.Pass}
+	default:
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: fmt.Sprintf("certificate has an ECDSA public key on curve %s", name),
+		}
+	}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_ms_public_key_forbidden",
+		Description:   "Certificates must not have DSA public keys, RSA public keys shorter than 2048 bits or ECDSA public keys on curves other than NIST P-256, P-384 and P-521",
+		Citation:      "Microsoft Trusted Root Program Requirements: 3.B",
+		Source:        lint.MicrosoftRootProgram,
+		EffectiveDate: util.ZeroDate,
+		Lint:          &publicKeyForbidden{},
+	})
+}
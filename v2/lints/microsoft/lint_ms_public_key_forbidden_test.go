@@ -0,0 +1,76 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package microsoft
+
+import (
+	"testing"
+
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/test"
+)
+
+func TestPublicKeyRSA1024(t *testing.T) {
+	inputPath := "mpModulus1024.pem"
+	expected := lint.Error
+	out := test.TestLint("e_ms_public_key_forbidden", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+func TestPublicKeyRSA4096(t *testing.T) {
+	inputPath := "msRootCARSA4096.pem"
+	expected := lint.Pass
+	out := test.TestLint("e_ms_public_key_forbidden", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+func TestPublicKeyECDSAP224(t *testing.T) {
+	inputPath := "ecdsaP224.pem"
+	expected := lint.Error
+	out := test.TestLint("e_ms_public_key_forbidden", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+func TestPublicKeyECDSAP256(t *testing.T) {
+	inputPath := "ecdsaP256.pem"
+	expected := lint.Pass
+	out := test.TestLint("e_ms_public_key_forbidden", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+func TestPublicKeyDSA(t *testing.T) {
+	inputPath := "dsaNotShorterThan2048Bits.pem"
+	expected := lint.Error
+	out := test.TestLint("e_ms_public_key_forbidden", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+func TestPublicKeyEd25519(t *testing.T) {
+	inputPath := "eddsaEd25519.pem"
+	expected := lint.NA
+	out := test.TestLint("e_ms_public_key_forbidden", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
@@ -0,0 +1,61 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package microsoft
+
+import (
+	"crypto/rsa"
+	"fmt"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type rootCARSAModLessThan4096Bits struct{}
+
+func (l *rootCARSAModLessThan4096Bits) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for root CA certificates with an RSA public key.
+func (l *rootCARSAModLessThan4096Bits) CheckApplies(c *x509.Certificate) bool {
+	_, ok := c.PublicKey.(*rsa.PublicKey)
+	return ok && c.PublicKeyAlgorithm == x509.RSA && util.IsRootCA(c)
+}
+
+// Execute returns a Warning if the root's RSA modulus is shorter than 4096
+// bits. Microsoft accepts 2048 bit roots that are already trusted but expects
+// new RSA roots submitted to the program to use 4096 bit keys.
+func (l *rootCARSAModLessThan4096Bits) Execute(c *x509.Certificate) *lint.LintResult {
+	key := c.PublicKey.(*rsa.PublicKey)
+	if bits := key.N.BitLen(); bits < 4096 {
+		return &lint.LintResult{
+			Status:  lint.Warn,
+			Details: fmt.Sprintf("root CA has a %d bit RSA modulus", bits),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "w_ms_root_ca_rsa_mod_less_than_4096_bits",
+		Description:   "New root CA certificates using the RSA public key algorithm should use a 4096 bit modulus",
+		Citation:      "Microsoft Trusted Root Program Requirements: 3.A",
+		Source:        lint.MicrosoftRootProgram,
+		EffectiveDate: util.ZeroDate,
+		Lint:          &rootCARSAModLessThan4096Bits{},
+	})
+}
@@ -0,0 +1,49 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package microsoft
+
+import (
+	"testing"
+
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/test"
+)
+
+func TestRootCARSAModLessThan4096BitsRootCARSA2048(t *testing.T) {
+	inputPath := "msRootCARSA2048.pem"
+	expected := lint.Warn
+	out := test.TestLint("w_ms_root_ca_rsa_mod_less_than_4096_bits", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+func TestRootCARSAModLessThan4096BitsRootCARSA4096(t *testing.T) {
+	inputPath := "msRootCARSA4096.pem"
+	expected := lint.Pass
+	out := test.TestLint("w_ms_root_ca_rsa_mod_less_than_4096_bits", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+func TestRootCARSAModLessThan4096BitsSubCAEKUServerAuthClientAuth(t *testing.T) {
+	inputPath := "msSubCAEKUServerAuthClientAuth.pem"
+	expected := lint.NA
+	out := test.TestLint("w_ms_root_ca_rsa_mod_less_than_4096_bits", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
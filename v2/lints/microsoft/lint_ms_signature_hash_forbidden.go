@@ -0,0 +1,58 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package microsoft
+
+import (
+	"fmt"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type signatureHashForbidden struct{}
+
+func (l *signatureHashForbidden) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for all certificates.
+func (l *signatureHashForbidden) CheckApplies(c *x509.Certificate) bool {
+	return true
+}
+
+// Execute returns an Error if the certificate is signed using MD2, MD5 or
+// SHA-1. Microsoft only accepts signatures using SHA-256, SHA-384 or SHA-512.
+func (l *signatureHashForbidden) Execute(c *x509.Certificate) *lint.LintResult {
+	switch c.SignatureAlgorithm {
+	case x509.MD2WithRSA, x509.MD5WithRSA, x509.SHA1WithRSA, x509.DSAWithSHA1, x509.ECDSAWithSHA1:
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: fmt.Sprintf("certificate is signed with %s", c.SignatureAlgorithm),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_ms_signature_hash_forbidden",
+		Description:   "Certificates must not be signed using MD2, MD5 or SHA-1",
+		Citation:      "Microsoft Trusted Root Program Requirements: 3.B",
+		Source:        lint.MicrosoftRootProgram,
+		EffectiveDate: util.ZeroDate,
+		Lint:          &signatureHashForbidden{},
+	})
+}
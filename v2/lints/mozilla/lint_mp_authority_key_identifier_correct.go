@@ -73,6 +73,7 @@ func init() {
 		Citation:      "Mozilla Root Store Policy / Section 5.2",
 		Source:        lint.MozillaRootStorePolicy,
 		EffectiveDate: util.MozillaPolicy22Date,
+		Applicability: lint.Applicability{Extension: util.AuthkeyOID},
 		Lint:          &authorityKeyIdentifierCorrect{},
 	})
 }
@@ -0,0 +1,57 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+/********************************************************************
+Section 5.1 - Algorithms
+Root certificates in our root program, and any certificate which chains up to
+them, MUST use only algorithms and key sizes from the following set:
+RSA keys ... ECDSA keys ...
+********************************************************************/
+
+package mozilla
+
+import (
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type dsaKeyNotAllowed struct{}
+
+func (l *dsaKeyNotAllowed) Initialize() error {
+	return nil
+}
+
+func (l *dsaKeyNotAllowed) CheckApplies(c *x509.Certificate) bool {
+	return true
+}
+
+func (l *dsaKeyNotAllowed) Execute(c *x509.Certificate) *lint.LintResult {
+	if c.PublicKeyAlgorithm == x509.DSA {
+		return &lint.LintResult{Status: lint.Error}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_mp_dsa_key_not_allowed",
+		Description:   "Certificates must not contain DSA public keys",
+		Citation:      "Mozilla Root Store Policy / Section 5.1",
+		Source:        lint.MozillaRootStorePolicy,
+		EffectiveDate: util.MozillaPolicy24Date,
+		Tags:          []string{lint.TagWeakCrypto},
+		Lint:          &dsaKeyNotAllowed{},
+	})
+}
@@ -0,0 +1,52 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package mozilla
+
+import (
+	"testing"
+
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/test"
+)
+
+// Certificate with DSA key issued after policy 2.4
+func TestDSAKeyNotAllowedWeakDSA2048Key2018(t *testing.T) {
+	inputPath := "weakDSA2048Key2018.pem"
+	expected := lint.Error
+	out := test.TestLint("e_mp_dsa_key_not_allowed", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+// Certificate with DSA key issued before policy 2.4
+func TestDSAKeyNotAllowedDsaNotShorterThan2048Bits(t *testing.T) {
+	inputPath := "dsaNotShorterThan2048Bits.pem"
+	expected := lint.NE
+	out := test.TestLint("e_mp_dsa_key_not_allowed", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+// Certificate with RSA key
+func TestDSAKeyNotAllowedMpModulus2048(t *testing.T) {
+	inputPath := "mpModulus2048.pem"
+	expected := lint.Pass
+	out := test.TestLint("e_mp_dsa_key_not_allowed", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
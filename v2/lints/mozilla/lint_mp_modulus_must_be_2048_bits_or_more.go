@@ -60,6 +60,7 @@ func init() {
 		Citation:      "Mozilla Root Store Policy / Section 5.1",
 		Source:        lint.MozillaRootStorePolicy,
 		EffectiveDate: util.MozillaPolicy24Date,
+		Tags:          []string{lint.TagWeakCrypto},
 		Lint:          &modulus2048OrMore{},
 	})
 }
@@ -0,0 +1,71 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package online
+
+import (
+	"encoding/asn1"
+	"fmt"
+
+	"github.com/zmap/zcrypto/x509/pkix"
+	"github.com/zmap/zlint/v2/util"
+)
+
+// issuingDistributionPoint is the ASN.1 structure of the CRL extension of the
+// same name. See RFC 5280 Section 5.2.5.
+type issuingDistributionPoint struct {
+	DistributionPoint          distributionPointName `asn1:"optional,tag:0"`
+	OnlyContainsUserCerts      bool                  `asn1:"optional,tag:1"`
+	OnlyContainsCACerts        bool                  `asn1:"optional,tag:2"`
+	OnlySomeReasons            asn1.BitString        `asn1:"optional,tag:3"`
+	IndirectCRL                bool                  `asn1:"optional,tag:4"`
+	OnlyContainsAttributeCerts bool                  `asn1:"optional,tag:5"`
+}
+
+type distributionPointName struct {
+	FullName     []asn1.RawValue  `asn1:"optional,tag:0"`
+	RelativeName pkix.RDNSequence `asn1:"optional,tag:1"`
+}
+
+// uris returns the uniformResourceIdentifier members of the distribution
+// point's fullName.
+func (n *distributionPointName) uris() []string {
+	var uris []string
+	for _, name := range n.FullName {
+		if name.Class == asn1.ClassContextSpecific && name.Tag == 6 {
+			uris = append(uris, string(name.Bytes))
+		}
+	}
+	return uris
+}
+
+// parseIDP returns the issuing distribution point extension of crl, or nil if
+// the CRL doesn't have one (i.e. it is a complete CRL for its issuer).
+func parseIDP(crl *pkix.CertificateList) (*issuingDistributionPoint, error) {
+	for _, ext := range crl.TBSCertList.Extensions {
+		if !ext.Id.Equal(util.IssuingDistPointOID) {
+			continue
+		}
+		idp := &issuingDistributionPoint{}
+		rest, err := asn1.Unmarshal(ext.Value, idp)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse issuing distribution point: %v", err)
+		}
+		if len(rest) > 0 {
+			return nil, fmt.Errorf("trailing data after issuing distribution point")
+		}
+		return idp, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,261 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+// Package online contains lints that make network requests to check the
+// revocation infrastructure advertised by a certificate. It is not imported by
+// the zlint package: callers must import it explicitly to register the lints,
+// and can exclude them again with lint.FilterOptions.ExcludeOnline.
+package online
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zcrypto/x509/pkix"
+	"github.com/zmap/zcrypto/x509/revocation/ocsp"
+)
+
+const (
+	// crlContentType is the media type CRLs fetched over HTTP are expected to be
+	// served with. See RFC 2585 Section 4.2.
+	crlContentType = "application/pkix-crl"
+	// ocspRequestContentType and ocspResponseContentType are the media types
+	// used for OCSP over HTTP. See RFC 6960 Appendix A.
+	ocspRequestContentType  = "application/ocsp-request"
+	ocspResponseContentType = "application/ocsp-response"
+	// maxResponseSize bounds how much of any response body is read.
+	maxResponseSize = 32 << 20
+	// cacheTTL is how long a fetched response is reused before it is requested
+	// again. Several lints inspect the same response so without a cache each
+	// URL would be fetched once per lint.
+	cacheTTL = time.Minute
+)
+
+// httpClient is the client used for all requests made by online lints.
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+// fetchResult holds the outcome of a single HTTP request.
+type fetchResult struct {
+	url         string
+	statusCode  int
+	contentType string
+	body        []byte
+	err         error
+	fetched     time.Time
+}
+
+// mediaType returns the media type of the response's Content-Type header
+// without any parameters.
+func (r *fetchResult) mediaType() string {
+	mt, _, err := mime.ParseMediaType(r.contentType)
+	if err != nil {
+		return r.contentType
+	}
+	return mt
+}
+
+// failure returns a description of why the request didn't produce a usable
+// response, or the empty string if it did.
+func (r *fetchResult) failure() string {
+	if r.err != nil {
+		return fmt.Sprintf("request to %s failed: %v", r.url, r.err)
+	}
+	if r.statusCode != http.StatusOK {
+		return fmt.Sprintf("request to %s returned HTTP status %d", r.url, r.statusCode)
+	}
+	return ""
+}
+
+var (
+	cacheMu       sync.Mutex
+	responseCache = make(map[string]*fetchResult)
+)
+
+// fetch performs a GET request (or a POST request if body is not nil) to the
+// given URL, returning a cached result if the same request was made within
+// cacheTTL.
+func fetch(rawURL string, body []byte) *fetchResult {
+	key := rawURL
+	if body != nil {
+		sum := sha256.Sum256(body)
+		key = "POST " + rawURL + " " + hex.EncodeToString(sum[:])
+	}
+
+	now := time.Now()
+	cacheMu.Lock()
+	if cached, ok := responseCache[key]; ok && now.Sub(cached.fetched) < cacheTTL {
+		cacheMu.Unlock()
+		return cached
+	}
+	cacheMu.Unlock()
+
+	result := doFetch(rawURL, body)
+	result.fetched = now
+
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	for k, v := range responseCache {
+		if now.Sub(v.fetched) >= cacheTTL {
+			delete(responseCache, k)
+		}
+	}
+	responseCache[key] = result
+	return result
+}
+
+func doFetch(rawURL string, body []byte) *fetchResult {
+	result := &fetchResult{url: rawURL}
+
+	var resp *http.Response
+	if body != nil {
+		resp, result.err = httpClient.Post(rawURL, ocspRequestContentType, bytes.NewReader(body))
+	} else {
+		resp, result.err = httpClient.Get(rawURL)
+	}
+	if result.err != nil {
+		return result
+	}
+	defer resp.Body.Close()
+
+	result.statusCode = resp.StatusCode
+	result.contentType = resp.Header.Get("Content-Type")
+	result.body, result.err = ioutil.ReadAll(&limitedReader{r: resp.Body, n: maxResponseSize})
+	return result
+}
+
+// httpURLs returns the members of urls that use the http or https scheme.
+// Other schemes (e.g. ldap) are not fetched by online lints.
+func httpURLs(urls []string) []string {
+	var results []string
+	for _, u := range urls {
+		parsed, err := url.Parse(u)
+		if err != nil {
+			continue
+		}
+		if scheme := strings.ToLower(parsed.Scheme); scheme == "http" || scheme == "https" {
+			results = append(results, u)
+		}
+	}
+	return results
+}
+
+// fetchCRL fetches and parses the CRL at the given URL. The fetchResult is
+// returned alongside the parsed CRL so callers can inspect the HTTP response.
+func fetchCRL(rawURL string) (*fetchResult, *pkix.CertificateList, error) {
+	result := fetch(rawURL, nil)
+	if failure := result.failure(); failure != "" {
+		return result, nil, errors.New(failure)
+	}
+	crl, err := x509.ParseCRL(result.body)
+	if err != nil {
+		return result, nil, fmt.Errorf("unable to parse CRL from %s: %v", rawURL, err)
+	}
+	return result, crl, nil
+}
+
+// fetchIssuer fetches the issuer of c from the first of its AIA caIssuers URLs
+// that returns a parseable certificate.
+func fetchIssuer(c *x509.Certificate) (*x509.Certificate, error) {
+	urls := httpURLs(c.IssuingCertificateURL)
+	if len(urls) == 0 {
+		return nil, errors.New("certificate has no http caIssuers URL")
+	}
+	var failures []string
+	for _, u := range urls {
+		result := fetch(u, nil)
+		if failure := result.failure(); failure != "" {
+			failures = append(failures, failure)
+			continue
+		}
+		der := result.body
+		if block, _ := pem.Decode(der); block != nil {
+			der = block.Bytes
+		}
+		issuer, err := x509.ParseCertificate(der)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("unable to parse issuer from %s: %v", u, err))
+			continue
+		}
+		return issuer, nil
+	}
+	return nil, errors.New(strings.Join(failures, "; "))
+}
+
+// fetchOCSP fetches the issuer of c and then POSTs an OCSP request for c to the
+// given responder URL. The fetchResult is returned alongside the parsed
+// response so callers can inspect the HTTP response.
+func fetchOCSP(c *x509.Certificate, responder string) (*fetchResult, *ocsp.Response, error) {
+	issuer, err := fetchIssuer(c)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to fetch issuer to build OCSP request: %v", err)
+	}
+	keyHash, err := ocsp.GetKeyHashSHA1(issuer)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to hash issuer key: %v", err)
+	}
+	req, err := ocsp.CreateRequest(c, keyHash, ocsp.GetNameHashSHA1(issuer))
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create OCSP request: %v", err)
+	}
+
+	result := fetch(responder, req)
+	if failure := result.failure(); failure != "" {
+		return result, nil, errors.New(failure)
+	}
+	resp, err := ocsp.ParseResponseForCert(result.body, c, issuer)
+	if err != nil {
+		return result, nil, fmt.Errorf("unable to parse OCSP response from %s: %v", responder, err)
+	}
+	return result, resp, nil
+}
+
+// limitedReader is like io.LimitedReader except that it returns an error
+// instead of io.EOF once the limit is exceeded. A body of exactly n bytes is
+// read in full.
+type limitedReader struct {
+	r io.Reader
+	n int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.n <= 0 {
+		// The limit has been reached; the body is only too large if there
+		// is at least one more byte to read.
+		var b [1]byte
+		n, err := l.r.Read(b[:])
+		if n > 0 {
+			return 0, fmt.Errorf("response larger than %d bytes", maxResponseSize)
+		}
+		return 0, err
+	}
+	if int64(len(p)) > l.n {
+		p = p[:l.n]
+	}
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	return n, err
+}
@@ -0,0 +1,39 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package online
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+)
+
+func TestLimitedReaderExactSize(t *testing.T) {
+	body := bytes.Repeat([]byte{'a'}, maxResponseSize)
+	got, err := ioutil.ReadAll(&limitedReader{r: bytes.NewReader(body), n: maxResponseSize})
+	if err != nil {
+		t.Fatalf("unexpected error reading a %d byte body: %v", len(body), err)
+	}
+	if len(got) != len(body) {
+		t.Errorf("expected %d bytes, got %d", len(body), len(got))
+	}
+}
+
+func TestLimitedReaderTooLarge(t *testing.T) {
+	body := bytes.Repeat([]byte{'a'}, maxResponseSize+1)
+	if _, err := ioutil.ReadAll(&limitedReader{r: bytes.NewReader(body), n: maxResponseSize}); err == nil {
+		t.Errorf("expected an error reading a %d byte body", len(body))
+	}
+}
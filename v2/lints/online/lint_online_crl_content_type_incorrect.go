@@ -0,0 +1,76 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package online
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type crlContentTypeIncorrect struct{}
+
+func (l *crlContentTypeIncorrect) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for certificates with at least one http or https
+// CRL distribution point.
+func (l *crlContentTypeIncorrect) CheckApplies(c *x509.Certificate) bool {
+	return len(httpURLs(c.CRLDistributionPoints)) > 0
+}
+
+// Execute returns a Warn result if any CRL that could be fetched was served
+// with a Content-Type other than application/pkix-crl. CRLs that can't be
+// fetched are reported by e_online_crl_unavailable and are ignored here.
+func (l *crlContentTypeIncorrect) Execute(c *x509.Certificate) *lint.LintResult {
+	var fetched int
+	var failures []string
+	for _, u := range httpURLs(c.CRLDistributionPoints) {
+		result, _, err := fetchCRL(u)
+		if err != nil {
+			continue
+		}
+		fetched++
+		if mt := result.mediaType(); mt != crlContentType {
+			failures = append(failures, fmt.Sprintf("%s served with Content-Type %q", u, mt))
+		}
+	}
+	if fetched == 0 {
+		return &lint.LintResult{Status: lint.NA}
+	}
+	if len(failures) > 0 {
+		return &lint.LintResult{
+			Status:  lint.Warn,
+			Details: strings.Join(failures, "; "),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "w_online_crl_content_type_incorrect",
+		Description:   "CRLs fetched over HTTP should be served with the application/pkix-crl media type",
+		Citation:      "RFC 5280: 4.2.1.13, RFC 2585: 4.2",
+		Source:        lint.RFC5280,
+		EffectiveDate: util.RFC5280Date,
+		Online:        true,
+		Lint:          &crlContentTypeIncorrect{},
+	})
+}
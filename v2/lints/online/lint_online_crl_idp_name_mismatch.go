@@ -0,0 +1,98 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package online
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type crlIDPNameMismatch struct{}
+
+func (l *crlIDPNameMismatch) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for certificates with at least one http or https
+// CRL distribution point.
+func (l *crlIDPNameMismatch) CheckApplies(c *x509.Certificate) bool {
+	return len(httpURLs(c.CRLDistributionPoints)) > 0
+}
+
+// Execute returns an Error result if any CRL that could be fetched has an
+// issuing distribution point with a fullName that does not include the URL it
+// was fetched from. Relying parties following RFC 5280 Section 6.3.3 reject
+// such a CRL as out of scope, which is a common mistake when a CA partitions
+// its CRLs and serves a shard from the wrong URL. CRLs without an issuing
+// distribution point, or whose distribution point has no URI names, are not
+// checked. Unparseable extensions are reported by
+// e_online_crl_idp_scope_excludes_certificate.
+func (l *crlIDPNameMismatch) Execute(c *x509.Certificate) *lint.LintResult {
+	var fetched int
+	var failures []string
+	for _, u := range httpURLs(c.CRLDistributionPoints) {
+		_, crl, err := fetchCRL(u)
+		if err != nil {
+			continue
+		}
+		fetched++
+		idp, err := parseIDP(crl)
+		if err != nil || idp == nil {
+			continue
+		}
+		names := idp.DistributionPoint.uris()
+		if len(names) == 0 {
+			continue
+		}
+		var found bool
+		for _, name := range names {
+			if name == u {
+				found = true
+				break
+			}
+		}
+		if !found {
+			failures = append(failures, fmt.Sprintf(
+				"CRL from %s has issuing distribution point names [%s]",
+				u, strings.Join(names, ", ")))
+		}
+	}
+	if fetched == 0 {
+		return &lint.LintResult{Status: lint.NA}
+	}
+	if len(failures) > 0 {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: strings.Join(failures, "; "),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_online_crl_idp_name_mismatch",
+		Description:   "CRLs referenced by the CRL distribution points extension MUST include the distribution point they were fetched from in their issuing distribution point",
+		Citation:      "RFC 5280: 5.2.5, 6.3.3",
+		Source:        lint.RFC5280,
+		EffectiveDate: util.RFC5280Date,
+		Online:        true,
+		Lint:          &crlIDPNameMismatch{},
+	})
+}
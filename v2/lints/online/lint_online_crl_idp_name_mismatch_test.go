@@ -0,0 +1,75 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package online
+
+import (
+	"encoding/asn1"
+	"testing"
+
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/test"
+)
+
+func TestCRLIDPNameMismatch(t *testing.T) {
+	uri := func(u string) asn1.RawValue {
+		return asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 6, Bytes: []byte(u)}
+	}
+	testCases := []struct {
+		name     string
+		idp      func(crlURL string) *issuingDistributionPoint
+		expected lint.LintStatus
+	}{
+		{
+			name:     "complete CRL",
+			expected: lint.Pass,
+		},
+		{
+			name: "matching distribution point",
+			idp: func(crlURL string) *issuingDistributionPoint {
+				return &issuingDistributionPoint{
+					DistributionPoint: distributionPointName{FullName: []asn1.RawValue{uri(crlURL)}},
+				}
+			},
+			expected: lint.Pass,
+		},
+		{
+			name: "scope only",
+			idp: func(crlURL string) *issuingDistributionPoint {
+				return &issuingDistributionPoint{OnlyContainsUserCerts: true}
+			},
+			expected: lint.Pass,
+		},
+		{
+			name: "other shard",
+			idp: func(crlURL string) *issuingDistributionPoint {
+				return &issuingDistributionPoint{
+					DistributionPoint: distributionPointName{FullName: []asn1.RawValue{uri(crlURL + "/2")}},
+				}
+			},
+			expected: lint.Error,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			responder := defaultResponder()
+			responder.crlIDP = tc.idp
+			cert := newTestPKI(t, responder)
+			if result := test.TestLintCert("e_online_crl_idp_name_mismatch", cert); result.Status != tc.expected {
+				t.Errorf("expected result %v was %v (%s)", tc.expected, result.Status, result.Details)
+			}
+		})
+	}
+}
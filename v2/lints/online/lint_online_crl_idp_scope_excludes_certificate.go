@@ -0,0 +1,98 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package online
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type crlIDPScopeExcludesCertificate struct{}
+
+func (l *crlIDPScopeExcludesCertificate) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for certificates with at least one http or https
+// CRL distribution point.
+func (l *crlIDPScopeExcludesCertificate) CheckApplies(c *x509.Certificate) bool {
+	return len(httpURLs(c.CRLDistributionPoints)) > 0
+}
+
+// Execute returns an Error result if any CRL that could be fetched has an
+// issuing distribution point whose scope flags exclude the certificate, e.g.
+// a CRL that only contains CA certificates referenced by a subscriber
+// certificate. A certificate pointing at such a CRL can never be found on it,
+// so revoking it has no effect for relying parties that check the CRL.
+func (l *crlIDPScopeExcludesCertificate) Execute(c *x509.Certificate) *lint.LintResult {
+	isCA := util.IsCACert(c)
+	var fetched int
+	var failures []string
+	for _, u := range httpURLs(c.CRLDistributionPoints) {
+		_, crl, err := fetchCRL(u)
+		if err != nil {
+			continue
+		}
+		fetched++
+		idp, err := parseIDP(crl)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("CRL from %s: %v", u, err))
+			continue
+		}
+		if idp == nil {
+			continue
+		}
+		switch {
+		case idp.OnlyContainsUserCerts && idp.OnlyContainsCACerts:
+			failures = append(failures, fmt.Sprintf(
+				"CRL from %s asserts both onlyContainsUserCerts and onlyContainsCACerts", u))
+		case idp.OnlyContainsAttributeCerts:
+			failures = append(failures, fmt.Sprintf(
+				"CRL from %s only contains attribute certificates", u))
+		case idp.OnlyContainsCACerts && !isCA:
+			failures = append(failures, fmt.Sprintf(
+				"CRL from %s only contains CA certificates but the certificate is not a CA", u))
+		case idp.OnlyContainsUserCerts && isCA:
+			failures = append(failures, fmt.Sprintf(
+				"CRL from %s only contains end entity certificates but the certificate is a CA", u))
+		}
+	}
+	if fetched == 0 {
+		return &lint.LintResult{Status: lint.NA}
+	}
+	if len(failures) > 0 {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: strings.Join(failures, "; "),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_online_crl_idp_scope_excludes_certificate",
+		Description:   "CRLs referenced by the CRL distribution points extension MUST NOT have an issuing distribution point whose scope excludes the certificate",
+		Citation:      "RFC 5280: 5.2.5, 6.3.3",
+		Source:        lint.RFC5280,
+		EffectiveDate: util.RFC5280Date,
+		Online:        true,
+		Lint:          &crlIDPScopeExcludesCertificate{},
+	})
+}
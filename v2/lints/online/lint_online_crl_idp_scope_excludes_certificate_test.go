@@ -0,0 +1,68 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package online
+
+import (
+	"testing"
+
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/test"
+)
+
+func TestCRLIDPScopeExcludesCertificate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		idp      *issuingDistributionPoint
+		expected lint.LintStatus
+	}{
+		{
+			name:     "complete CRL",
+			expected: lint.Pass,
+		},
+		{
+			name:     "user certificate CRL",
+			idp:      &issuingDistributionPoint{OnlyContainsUserCerts: true},
+			expected: lint.Pass,
+		},
+		{
+			name:     "CA certificate CRL",
+			idp:      &issuingDistributionPoint{OnlyContainsCACerts: true},
+			expected: lint.Error,
+		},
+		{
+			name:     "attribute certificate CRL",
+			idp:      &issuingDistributionPoint{OnlyContainsAttributeCerts: true},
+			expected: lint.Error,
+		},
+		{
+			name:     "user and CA certificate CRL",
+			idp:      &issuingDistributionPoint{OnlyContainsUserCerts: true, OnlyContainsCACerts: true},
+			expected: lint.Error,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			responder := defaultResponder()
+			if tc.idp != nil {
+				responder.crlIDP = func(string) *issuingDistributionPoint { return tc.idp }
+			}
+			cert := newTestPKI(t, responder)
+			if result := test.TestLintCert("e_online_crl_idp_scope_excludes_certificate", cert); result.Status != tc.expected {
+				t.Errorf("expected result %v was %v (%s)", tc.expected, result.Status, result.Details)
+			}
+		})
+	}
+}
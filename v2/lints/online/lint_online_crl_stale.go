@@ -0,0 +1,89 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package online
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type crlStale struct{}
+
+func (l *crlStale) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for certificates with at least one http or https
+// CRL distribution point.
+func (l *crlStale) CheckApplies(c *x509.Certificate) bool {
+	return len(httpURLs(c.CRLDistributionPoints)) > 0
+}
+
+// Execute returns an Error result if any CRL that could be fetched has
+// a nextUpdate in the past or is missing nextUpdate entirely. CRLs that can't
+// be fetched are reported by e_online_crl_unavailable and are ignored here.
+func (l *crlStale) Execute(c *x509.Certificate) *lint.LintResult {
+	return l.ExecuteWithConfig(c, &lint.Config{})
+}
+
+// ExecuteWithConfig is like Execute, but compares nextUpdate with the run's
+// reference time.
+func (l *crlStale) ExecuteWithConfig(c *x509.Certificate, config *lint.Config) *lint.LintResult {
+	now := config.Now()
+	var fetched int
+	var failures []string
+	for _, u := range httpURLs(c.CRLDistributionPoints) {
+		_, crl, err := fetchCRL(u)
+		if err != nil {
+			continue
+		}
+		fetched++
+		nextUpdate := crl.TBSCertList.NextUpdate
+		if nextUpdate.IsZero() {
+			failures = append(failures, fmt.Sprintf("CRL from %s has no nextUpdate", u))
+		} else if nextUpdate.Before(now) {
+			failures = append(failures, fmt.Sprintf(
+				"CRL from %s has nextUpdate %s in the past",
+				u, nextUpdate.UTC().Format(time.RFC3339)))
+		}
+	}
+	if fetched == 0 {
+		return &lint.LintResult{Status: lint.NA}
+	}
+	if len(failures) > 0 {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: strings.Join(failures, "; "),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_online_crl_stale",
+		Description:   "CRLs referenced by the CRL distribution points extension MUST have a nextUpdate that has not passed",
+		Citation:      "BRs: 4.9.7",
+		Source:        lint.CABFBaselineRequirements,
+		EffectiveDate: util.CABEffectiveDate,
+		Online:        true,
+		Lint:          &crlStale{},
+	})
+}
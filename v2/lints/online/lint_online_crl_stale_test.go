@@ -0,0 +1,67 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package online
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/test"
+)
+
+func TestCRLStale(t *testing.T) {
+	testCases := []struct {
+		name      string
+		responder func(r *testResponder)
+		expected  lint.LintStatus
+	}{
+		{
+			name:      "fresh CRL",
+			responder: func(r *testResponder) {},
+			expected:  lint.Pass,
+		},
+		{
+			name:      "stale CRL",
+			responder: func(r *testResponder) { r.crlNextUpdate = time.Now().Add(-time.Minute) },
+			expected:  lint.Error,
+		},
+		{
+			name:      "CRL not found",
+			responder: func(r *testResponder) { r.crlStatus = http.StatusNotFound },
+			expected:  lint.NA,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			responder := defaultResponder()
+			tc.responder(&responder)
+			cert := newTestPKI(t, responder)
+			if result := test.TestLintCert("e_online_crl_stale", cert); result.Status != tc.expected {
+				t.Errorf("expected result %v was %v (%s)", tc.expected, result.Status, result.Details)
+			}
+		})
+	}
+}
+
+func TestCRLStaleReferenceTime(t *testing.T) {
+	cert := newTestPKI(t, defaultResponder())
+	config := &lint.Config{ReferenceTime: time.Now().Add(48 * time.Hour)}
+	if result := test.TestLintCertWithConfig("e_online_crl_stale", cert, config); result.Status != lint.Error {
+		t.Errorf("expected a CRL that is stale at the reference time to be an error, got %v (%s)", result.Status, result.Details)
+	}
+}
@@ -0,0 +1,65 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package online
+
+import (
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type crlUnavailable struct{}
+
+func (l *crlUnavailable) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for certificates with at least one http or https
+// CRL distribution point.
+func (l *crlUnavailable) CheckApplies(c *x509.Certificate) bool {
+	return len(httpURLs(c.CRLDistributionPoints)) > 0
+}
+
+// Execute fetches every http or https CRL distribution point and returns an
+// Error if any of them can not be fetched or does not contain a parseable CRL.
+func (l *crlUnavailable) Execute(c *x509.Certificate) *lint.LintResult {
+	var failures []string
+	for _, u := range httpURLs(c.CRLDistributionPoints) {
+		if _, _, err := fetchCRL(u); err != nil {
+			failures = append(failures, err.Error())
+		}
+	}
+	if len(failures) > 0 {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: strings.Join(failures, "; "),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_online_crl_unavailable",
+		Description:   "CRLs referenced by the CRL distribution points extension MUST be available and return a DER encoded CRL",
+		Citation:      "BRs: 4.10.2",
+		Source:        lint.CABFBaselineRequirements,
+		EffectiveDate: util.CABEffectiveDate,
+		Online:        true,
+		Lint:          &crlUnavailable{},
+	})
+}
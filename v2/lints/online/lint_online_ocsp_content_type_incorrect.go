@@ -0,0 +1,77 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package online
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type ocspContentTypeIncorrect struct{}
+
+func (l *ocspContentTypeIncorrect) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for certificates with at least one http or https
+// OCSP responder URL and an http or https caIssuers URL.
+func (l *ocspContentTypeIncorrect) CheckApplies(c *x509.Certificate) bool {
+	return len(httpURLs(c.OCSPServer)) > 0 && len(httpURLs(c.IssuingCertificateURL)) > 0
+}
+
+// Execute returns a Warn result if any OCSP response that could be fetched was
+// served with a Content-Type other than application/ocsp-response. Responders
+// that can't be queried are reported by e_online_ocsp_unavailable and are
+// ignored here.
+func (l *ocspContentTypeIncorrect) Execute(c *x509.Certificate) *lint.LintResult {
+	var fetched int
+	var failures []string
+	for _, u := range httpURLs(c.OCSPServer) {
+		result, _, err := fetchOCSP(c, u)
+		if err != nil {
+			continue
+		}
+		fetched++
+		if mt := result.mediaType(); mt != ocspResponseContentType {
+			failures = append(failures, fmt.Sprintf("%s served with Content-Type %q", u, mt))
+		}
+	}
+	if fetched == 0 {
+		return &lint.LintResult{Status: lint.NA}
+	}
+	if len(failures) > 0 {
+		return &lint.LintResult{
+			Status:  lint.Warn,
+			Details: strings.Join(failures, "; "),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "w_online_ocsp_content_type_incorrect",
+		Description:   "OCSP responses fetched over HTTP should be served with the application/ocsp-response media type",
+		Citation:      "BRs: 4.9.9, RFC 6960: Appendix A.1",
+		Source:        lint.CABFBaselineRequirements,
+		EffectiveDate: util.CABEffectiveDate,
+		Online:        true,
+		Lint:          &ocspContentTypeIncorrect{},
+	})
+}
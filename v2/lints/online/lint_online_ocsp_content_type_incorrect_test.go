@@ -0,0 +1,58 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package online
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/test"
+)
+
+func TestOCSPContentTypeIncorrect(t *testing.T) {
+	testCases := []struct {
+		name      string
+		responder func(r *testResponder)
+		expected  lint.LintStatus
+	}{
+		{
+			name:      "correct Content-Type",
+			responder: func(r *testResponder) {},
+			expected:  lint.Pass,
+		},
+		{
+			name:      "incorrect Content-Type",
+			responder: func(r *testResponder) { r.ocspContentType = "text/plain" },
+			expected:  lint.Warn,
+		},
+		{
+			name:      "OCSP responder error",
+			responder: func(r *testResponder) { r.ocspStatus = http.StatusInternalServerError },
+			expected:  lint.NA,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			responder := defaultResponder()
+			tc.responder(&responder)
+			cert := newTestPKI(t, responder)
+			if result := test.TestLintCert("w_online_ocsp_content_type_incorrect", cert); result.Status != tc.expected {
+				t.Errorf("expected result %v was %v (%s)", tc.expected, result.Status, result.Details)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package online
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type ocspResponseStale struct{}
+
+func (l *ocspResponseStale) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for certificates with at least one http or https
+// OCSP responder URL and an http or https caIssuers URL.
+func (l *ocspResponseStale) CheckApplies(c *x509.Certificate) bool {
+	return len(httpURLs(c.OCSPServer)) > 0 && len(httpURLs(c.IssuingCertificateURL)) > 0
+}
+
+// Execute returns an Error result if any OCSP response that could be fetched
+// has a nextUpdate in the past or a thisUpdate in the future. Responders that
+// can't be queried are reported by e_online_ocsp_unavailable and are ignored
+// here.
+func (l *ocspResponseStale) Execute(c *x509.Certificate) *lint.LintResult {
+	return l.ExecuteWithConfig(c, &lint.Config{})
+}
+
+// ExecuteWithConfig is like Execute, but compares nextUpdate and thisUpdate
+// with the run's reference time.
+func (l *ocspResponseStale) ExecuteWithConfig(c *x509.Certificate, config *lint.Config) *lint.LintResult {
+	now := config.Now()
+	var fetched int
+	var failures []string
+	for _, u := range httpURLs(c.OCSPServer) {
+		_, resp, err := fetchOCSP(c, u)
+		if err != nil {
+			continue
+		}
+		fetched++
+		if !resp.NextUpdate.IsZero() && resp.NextUpdate.Before(now) {
+			failures = append(failures, fmt.Sprintf(
+				"OCSP response from %s has nextUpdate %s in the past",
+				u, resp.NextUpdate.UTC().Format(time.RFC3339)))
+		}
+		if resp.ThisUpdate.After(now) {
+			failures = append(failures, fmt.Sprintf(
+				"OCSP response from %s has thisUpdate %s in the future",
+				u, resp.ThisUpdate.UTC().Format(time.RFC3339)))
+		}
+	}
+	if fetched == 0 {
+		return &lint.LintResult{Status: lint.NA}
+	}
+	if len(failures) > 0 {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: strings.Join(failures, "; "),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_online_ocsp_response_stale",
+		Description:   "OCSP responses MUST be current: nextUpdate must not have passed and thisUpdate must not be in the future",
+		Citation:      "BRs: 4.9.10",
+		Source:        lint.CABFBaselineRequirements,
+		EffectiveDate: util.CABEffectiveDate,
+		Online:        true,
+		Lint:          &ocspResponseStale{},
+	})
+}
@@ -0,0 +1,72 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package online
+
+import (
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type ocspUnavailable struct{}
+
+func (l *ocspUnavailable) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for certificates with at least one http or https
+// OCSP responder URL and an http or https caIssuers URL. The issuer certificate
+// is required to construct an OCSP request.
+func (l *ocspUnavailable) CheckApplies(c *x509.Certificate) bool {
+	return len(httpURLs(c.OCSPServer)) > 0 && len(httpURLs(c.IssuingCertificateURL)) > 0
+}
+
+// Execute sends an OCSP request for the certificate to every http or https
+// OCSP responder and returns an Error result if any of them does not return
+// a successful response for the certificate. A Fatal result is returned if the
+// issuer certificate needed to build the request can't be fetched.
+func (l *ocspUnavailable) Execute(c *x509.Certificate) *lint.LintResult {
+	var failures []string
+	for _, u := range httpURLs(c.OCSPServer) {
+		result, _, err := fetchOCSP(c, u)
+		if result == nil {
+			return &lint.LintResult{Status: lint.Fatal, Details: err.Error()}
+		}
+		if err != nil {
+			failures = append(failures, err.Error())
+		}
+	}
+	if len(failures) > 0 {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: strings.Join(failures, "; "),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_online_ocsp_unavailable",
+		Description:   "OCSP responders referenced by the authority information access extension MUST be available and return a successful response for the certificate",
+		Citation:      "BRs: 4.10.2",
+		Source:        lint.CABFBaselineRequirements,
+		EffectiveDate: util.CABEffectiveDate,
+		Online:        true,
+		Lint:          &ocspUnavailable{},
+	})
+}
@@ -0,0 +1,203 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package online
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	stdx509 "crypto/x509"
+	"encoding/asn1"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zcrypto/x509/pkix"
+	"github.com/zmap/zlint/v2/util"
+	"golang.org/x/crypto/ocsp"
+)
+
+// testResponder describes how the test server should respond to CRL and OCSP
+// requests.
+type testResponder struct {
+	crlStatus       int
+	crlContentType  string
+	crlNextUpdate   time.Time
+	ocspStatus      int
+	ocspContentType string
+	ocspNextUpdate  time.Time
+	// crlIDP, if set, returns the issuing distribution point extension to
+	// include in the CRL served at crlURL.
+	crlIDP func(crlURL string) *issuingDistributionPoint
+}
+
+// defaultResponder returns a testResponder that serves fresh responses with
+// the correct status codes and media types.
+func defaultResponder() testResponder {
+	return testResponder{
+		crlStatus:       http.StatusOK,
+		crlContentType:  crlContentType,
+		crlNextUpdate:   time.Now().Add(24 * time.Hour),
+		ocspStatus:      http.StatusOK,
+		ocspContentType: ocspResponseContentType,
+		ocspNextUpdate:  time.Now().Add(24 * time.Hour),
+	}
+}
+
+// newTestPKI starts an HTTP server acting as the CRL distribution point, OCSP
+// responder and caIssuers repository of a freshly generated CA and returns
+// a subscriber certificate issued by that CA that points at the server.
+func newTestPKI(t *testing.T, r testResponder) *x509.Certificate {
+	t.Helper()
+
+	// Each test uses a new server so cached responses never apply, but clear
+	// the cache regardless to keep tests independent.
+	cacheMu.Lock()
+	responseCache = make(map[string]*fetchResult)
+	cacheMu.Unlock()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate CA key: %v", err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "online lint test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, caKey.Public(), caKey)
+	if err != nil {
+		t.Fatalf("unable to create CA certificate: %v", err)
+	}
+	ca, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		t.Fatalf("unable to parse CA certificate: %v", err)
+	}
+	stdCA, err := stdx509.ParseCertificate(caDER)
+	if err != nil {
+		t.Fatalf("unable to parse CA certificate: %v", err)
+	}
+
+	var server *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("/issuer.der", func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/pkix-cert")
+		_, _ = w.Write(caDER)
+	})
+	mux.HandleFunc("/crl", func(w http.ResponseWriter, req *http.Request) {
+		var crl []byte
+		var err error
+		if r.crlIDP != nil {
+			crl, err = createCRLWithIDP(ca, caKey, r.crlNextUpdate, r.crlIDP(server.URL+"/crl"))
+		} else {
+			crl, err = ca.CreateCRL(rand.Reader, caKey, nil, time.Now().Add(-time.Hour), r.crlNextUpdate)
+		}
+		if err != nil {
+			t.Errorf("unable to create CRL: %v", err)
+		}
+		w.Header().Set("Content-Type", r.crlContentType)
+		w.WriteHeader(r.crlStatus)
+		_, _ = w.Write(crl)
+	})
+	mux.HandleFunc("/ocsp", func(w http.ResponseWriter, req *http.Request) {
+		resp, err := ocsp.CreateResponse(stdCA, stdCA, ocsp.Response{
+			Status:       ocsp.Good,
+			SerialNumber: big.NewInt(2),
+			ThisUpdate:   time.Now().Add(-time.Hour),
+			NextUpdate:   r.ocspNextUpdate,
+		}, caKey)
+		if err != nil {
+			t.Errorf("unable to create OCSP response: %v", err)
+		}
+		w.Header().Set("Content-Type", r.ocspContentType)
+		w.WriteHeader(r.ocspStatus)
+		_, _ = w.Write(resp)
+	})
+	server = httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	leafKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate leaf key: %v", err)
+	}
+	leafTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(2),
+		Subject:               pkix.Name{CommonName: "example.com"},
+		DNSNames:              []string{"example.com"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		CRLDistributionPoints: []string{server.URL + "/crl"},
+		OCSPServer:            []string{server.URL + "/ocsp"},
+		IssuingCertificateURL: []string{server.URL + "/issuer.der"},
+	}
+	leafDER, err := x509.CreateCertificate(rand.Reader, leafTemplate, ca, leafKey.Public(), caKey)
+	if err != nil {
+		t.Fatalf("unable to create leaf certificate: %v", err)
+	}
+	leaf, err := x509.ParseCertificate(leafDER)
+	if err != nil {
+		t.Fatalf("unable to parse leaf certificate: %v", err)
+	}
+	return leaf
+}
+
+// createCRLWithIDP returns an empty CRL issued by ca that has the given issuing
+// distribution point extension. zcrypto's CreateCRL can't add CRL extensions
+// so the CRL is built and signed by hand.
+func createCRLWithIDP(ca *x509.Certificate, caKey *ecdsa.PrivateKey, nextUpdate time.Time, idp *issuingDistributionPoint) ([]byte, error) {
+	idpDER, err := asn1.Marshal(*idp)
+	if err != nil {
+		return nil, err
+	}
+	var issuer pkix.RDNSequence
+	if _, err := asn1.Unmarshal(ca.RawSubject, &issuer); err != nil {
+		return nil, err
+	}
+	sigAlg := pkix.AlgorithmIdentifier{Algorithm: asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 2}}
+	tbs := pkix.TBSCertificateList{
+		Version:    1,
+		Signature:  sigAlg,
+		Issuer:     issuer,
+		ThisUpdate: time.Now().Add(-time.Hour).UTC(),
+		NextUpdate: nextUpdate.UTC(),
+		Extensions: []pkix.Extension{{Id: util.IssuingDistPointOID, Critical: true, Value: idpDER}},
+	}
+	tbsDER, err := asn1.Marshal(tbs)
+	if err != nil {
+		return nil, err
+	}
+	digest := sha256.Sum256(tbsDER)
+	sig, err := caKey.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		return nil, err
+	}
+	tbs.Raw = tbsDER
+	return asn1.Marshal(pkix.CertificateList{
+		TBSCertList:        tbs,
+		SignatureAlgorithm: sigAlg,
+		SignatureValue:     asn1.BitString{Bytes: sig, BitLength: 8 * len(sig)},
+	})
+}
@@ -61,6 +61,7 @@ func init() {
 		Citation:      "RFC 5280: 4.2.1.9",
 		Source:        lint.RFC5280,
 		EffectiveDate: util.RFC2459Date,
+		Applicability: lint.Applicability{Kind: lint.CACertificate, Extension: util.BasicConstOID},
 		Lint:          &basicConstCrit{},
 	})
 }
@@ -0,0 +1,62 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package rfc
+
+/************************************************
+RFC 5280: 4.2.1.9
+Conforming CAs MUST include this extension in all CA certificates that contain
+public keys used to validate digital signatures on certificates and MUST mark
+the extension as critical in such certificates.
+************************************************/
+
+import (
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type caBasicConstraintsMissing struct{}
+
+func (l *caBasicConstraintsMissing) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for certificates whose keyUsage asserts
+// keyCertSign, i.e. whose key is used to validate signatures on certificates.
+func (l *caBasicConstraintsMissing) CheckApplies(c *x509.Certificate) bool {
+	return util.IsExtInCert(c, util.KeyUsageOID) && c.KeyUsage&x509.KeyUsageCertSign != 0
+}
+
+// Execute returns an Error if the basicConstraints extension is absent.
+// e_basic_constraints_not_critical covers the extension being present but not
+// critical.
+func (l *caBasicConstraintsMissing) Execute(c *x509.Certificate) *lint.LintResult {
+	if !util.IsExtInCert(c, util.BasicConstOID) {
+		return &lint.LintResult{Status: lint.Error}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_ca_basic_constraints_missing",
+		Description:   "CA certificates whose keys are used to verify certificate signatures MUST include the basicConstraints extension",
+		Citation:      "RFC 5280: 4.2.1.9",
+		Source:        lint.RFC5280,
+		EffectiveDate: util.RFC2459Date,
+		Applicability: lint.Applicability{Extension: util.KeyUsageOID},
+		Lint:          &caBasicConstraintsMissing{},
+	})
+}
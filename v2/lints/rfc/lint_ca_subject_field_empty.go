@@ -57,6 +57,7 @@ func init() {
 		Citation:      "RFC 5280: 4.1.2.6",
 		Source:        lint.RFC5280,
 		EffectiveDate: util.RFC2459Date,
+		Applicability: lint.Applicability{Kind: lint.CACertificate},
 		Lint:          &caSubjectEmpty{},
 	})
 }
@@ -0,0 +1,74 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package rfc
+
+/************************************************
+RFC 5280: 4.1.1.2
+   The signatureAlgorithm field contains the identifier for the
+   cryptographic algorithm used by the CA to sign this certificate.
+   ...
+   This field MUST contain the same algorithm identifier as the
+   signature field in the sequence tbsCertificate (Section 4.1.2.3).
+************************************************/
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type certSigAlgNotMatchTBSSigAlg struct{}
+
+func (l *certSigAlgNotMatchTBSSigAlg) Initialize() error {
+	return nil
+}
+
+func (l *certSigAlgNotMatchTBSSigAlg) CheckApplies(c *x509.Certificate) bool {
+	return true
+}
+
+func (l *certSigAlgNotMatchTBSSigAlg) Execute(c *x509.Certificate) *lint.LintResult {
+	outer, err := util.GetSignatureAlgorithmEncoded(c)
+	if err != nil {
+		return &lint.LintResult{Status: lint.Fatal, Details: err.Error()}
+	}
+	tbs, err := util.GetSignatureAlgorithmInTBSEncoded(c)
+	if err != nil {
+		return &lint.LintResult{Status: lint.Fatal, Details: err.Error()}
+	}
+	if !bytes.Equal(outer, tbs) {
+		return &lint.LintResult{
+			Status: lint.Error,
+			Details: fmt.Sprintf("signatureAlgorithm %s does not match tbsCertificate signature %s",
+				hex.EncodeToString(outer), hex.EncodeToString(tbs)),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_cert_sig_alg_not_match_tbs_sig_alg",
+		Description:   "The signatureAlgorithm field of a certificate MUST contain the same algorithm identifier as the signature field of its tbsCertificate",
+		Citation:      "RFC 5280: 4.1.1.2",
+		Source:        lint.RFC5280,
+		EffectiveDate: util.RFC2459Date,
+		Lint:          &certSigAlgNotMatchTBSSigAlg{},
+	})
+}
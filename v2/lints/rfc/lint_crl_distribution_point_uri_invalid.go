@@ -0,0 +1,75 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package rfc
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type crlDistributionPointURIInvalid struct{}
+
+func (l *crlDistributionPointURIInvalid) Initialize() error {
+	return nil
+}
+
+func (l *crlDistributionPointURIInvalid) CheckApplies(c *x509.Certificate) bool {
+	return util.IsExtInCert(c, util.CrlDistOID) && len(c.CRLDistributionPoints) > 0
+}
+
+// Execute returns an Error for distribution point URIs that don't parse as
+// absolute URIs, or that use the http or https schemes without a host. LDAP
+// URIs may omit the host (RFC 4516 Section 2).
+func (l *crlDistributionPointURIInvalid) Execute(c *x509.Certificate) *lint.LintResult {
+	var invalid []string
+	for _, uri := range c.CRLDistributionPoints {
+		u, err := url.Parse(uri)
+		if err != nil {
+			invalid = append(invalid, fmt.Sprintf("%q: %v", uri, err))
+			continue
+		}
+		if !u.IsAbs() {
+			invalid = append(invalid, fmt.Sprintf("%q: not an absolute URI", uri))
+			continue
+		}
+		if scheme := strings.ToLower(u.Scheme); (scheme == "http" || scheme == "https") && u.Host == "" {
+			invalid = append(invalid, fmt.Sprintf("%q: no host", uri))
+		}
+	}
+	if len(invalid) > 0 {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: fmt.Sprintf("invalid CRL distribution point URIs: %s", strings.Join(invalid, "; ")),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_crl_distribution_point_uri_invalid",
+		Description:   "uniformResourceIdentifiers in the CRL distribution points extension MUST be valid absolute URIs",
+		Citation:      "RFC 5280: 4.2.1.13 & 4.2.1.6",
+		Source:        lint.RFC5280,
+		EffectiveDate: util.RFC5280Date,
+		Applicability: lint.Applicability{Extension: util.CrlDistOID},
+		Lint:          &crlDistributionPointURIInvalid{},
+	})
+}
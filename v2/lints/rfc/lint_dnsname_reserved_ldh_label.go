@@ -0,0 +1,68 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package rfc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type DNSNameReservedLDHLabel struct{}
+
+func (l *DNSNameReservedLDHLabel) Initialize() error {
+	return nil
+}
+
+func (l *DNSNameReservedLDHLabel) CheckApplies(c *x509.Certificate) bool {
+	return util.IsExtInCert(c, util.SubjectAlternateNameOID)
+}
+
+// Execute returns an Error for labels with hyphens in the third and fourth
+// positions that do not carry the ACE prefix, e.g. "ab--cd". Such labels are
+// reserved for future ACE prefixes and look like A-labels to many parsers.
+func (l *DNSNameReservedLDHLabel) Execute(c *x509.Certificate) *lint.LintResult {
+	var reserved []string
+	for _, dns := range c.DNSNames {
+		for _, label := range strings.Split(dns, ".") {
+			if util.IsReservedLDHLabel(label) && !util.IsALabelCandidate(label) {
+				reserved = append(reserved, dns)
+				break
+			}
+		}
+	}
+	if len(reserved) > 0 {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: fmt.Sprintf("DNSNames with reserved LDH labels: %s", strings.Join(reserved, ", ")),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_dnsname_reserved_ldh_label",
+		Description:   "Labels of DNSNames MUST NOT have hyphens in both the third and fourth positions unless they are A-labels",
+		Citation:      "RFC 5890: 2.3.1; RFC 5891: 4.2.3.1",
+		Source:        lint.RFC5891,
+		EffectiveDate: util.RFC5891Date,
+		Applicability: lint.Applicability{Extension: util.SubjectAlternateNameOID},
+		Lint:          &DNSNameReservedLDHLabel{},
+	})
+}
@@ -0,0 +1,69 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package rfc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type ecKeyUsageEncipherment struct{}
+
+func (l *ecKeyUsageEncipherment) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for certificates with an EC public key and
+// a keyUsage extension.
+func (l *ecKeyUsageEncipherment) CheckApplies(c *x509.Certificate) bool {
+	return c.PublicKeyAlgorithm == x509.ECDSA && util.IsExtInCert(c, util.KeyUsageOID)
+}
+
+// Execute returns an Error if keyEncipherment or dataEncipherment is set. EC
+// keys can't encrypt directly and RFC 5480 doesn't list either bit among
+// those that may be present for id-ecPublicKey, in CA or end entity
+// certificates. n_ecdsa_ee_invalid_ku also reports other unexpected bits in
+// end entity certificates.
+func (l *ecKeyUsageEncipherment) Execute(c *x509.Certificate) *lint.LintResult {
+	var bits []string
+	for _, ku := range []x509.KeyUsage{x509.KeyUsageKeyEncipherment, x509.KeyUsageDataEncipherment} {
+		if c.KeyUsage&ku != 0 {
+			bits = append(bits, util.KeyUsageToString[ku])
+		}
+	}
+	if len(bits) > 0 {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: fmt.Sprintf("EC key certificate has key usage(s): %s", strings.Join(bits, ", ")),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_ec_key_usage_encipherment",
+		Description:   "Certificates with an EC public key MUST NOT assert the keyEncipherment or dataEncipherment key usages",
+		Citation:      "RFC 5480: 3",
+		Source:        lint.RFC5480,
+		EffectiveDate: util.RFC5480Date,
+		Applicability: lint.Applicability{Extension: util.KeyUsageOID},
+		Lint:          &ecKeyUsageEncipherment{},
+	})
+}
@@ -0,0 +1,76 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package rfc
+
+/************************************************
+RFC 5480: 2.1.1 Unrestricted Algorithm Identifier and Parameters
+   The parameter for id-ecPublicKey is as follows and MUST always be
+   present:
+
+     ECParameters ::= CHOICE {
+       namedCurve         OBJECT IDENTIFIER
+       -- implicitCurve   NULL
+       -- specifiedCurve  SpecifiedECDomain
+     }
+
+   ... implicitCurve and specifiedCurve MUST NOT be used in PKIX.
+************************************************/
+
+import (
+	"fmt"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type ecPublicKeyParamsNotNamedCurve struct{}
+
+func (l *ecPublicKeyParamsNotNamedCurve) Initialize() error {
+	return nil
+}
+
+func (l *ecPublicKeyParamsNotNamedCurve) CheckApplies(c *x509.Certificate) bool {
+	return c.PublicKeyAlgorithmOID.Equal(util.OidPublicKeyECDSA)
+}
+
+func (l *ecPublicKeyParamsNotNamedCurve) Execute(c *x509.Certificate) *lint.LintResult {
+	aid, err := util.GetPublicKeyAidEncoded(c)
+	if err != nil {
+		return &lint.LintResult{Status: lint.Fatal, Details: err.Error()}
+	}
+	form, _, err := util.GetECParameters(aid)
+	if err != nil {
+		return &lint.LintResult{Status: lint.Error, Details: err.Error()}
+	}
+	if form != util.ECNamedCurve {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: fmt.Sprintf("EC parameters are %s", form),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_ec_public_key_params_not_named_curve",
+		Description:   "The parameters of an id-ecPublicKey algorithm identifier MUST be present and MUST be a named curve",
+		Citation:      "RFC 5480: 2.1.1",
+		Source:        lint.RFC5480,
+		EffectiveDate: util.RFC5480Date,
+		Lint:          &ecPublicKeyParamsNotNamedCurve{},
+	})
+}
@@ -0,0 +1,95 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package rfc
+
+import (
+	"encoding/asn1"
+	"testing"
+
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/test"
+	"github.com/zmap/zlint/v2/util"
+)
+
+// marshalSPKI returns a SubjectPublicKeyInfo with the given DER encoded
+// algorithm identifier and subjectPublicKey. zcrypto refuses to parse
+// certificates with malformed EC keys, so tests substitute the raw
+// SubjectPublicKeyInfo of a parsed certificate instead of using testdata.
+func marshalSPKI(t *testing.T, aid []byte, key []byte) []byte {
+	spki, err := asn1.Marshal(struct {
+		Algorithm asn1.RawValue
+		PublicKey asn1.BitString
+	}{
+		Algorithm: asn1.RawValue{FullBytes: aid},
+		PublicKey: asn1.BitString{Bytes: key, BitLength: 8 * len(key)},
+	})
+	if err != nil {
+		t.Fatalf("marshalling SubjectPublicKeyInfo: %v", err)
+	}
+	return spki
+}
+
+func TestECPublicKeyParamsNotNamedCurve(t *testing.T) {
+	ecOID := []byte{0x06, 0x07, 0x2a, 0x86, 0x48, 0xce, 0x3d, 0x02, 0x01}
+	testCases := []struct {
+		name     string
+		params   []byte
+		expected lint.LintStatus
+	}{
+		{
+			name:     "absent",
+			expected: lint.Error,
+		},
+		{
+			name:     "implicitCurve",
+			params:   []byte{0x05, 0x00},
+			expected: lint.Error,
+		},
+		{
+			name:     "specifiedCurve",
+			params:   []byte{0x30, 0x03, 0x02, 0x01, 0x01},
+			expected: lint.Error,
+		},
+	}
+
+	cert := test.ReadTestCert("bsiECP256.pem")
+	key, err := util.GetPublicKeyBytes(cert)
+	if err != nil {
+		t.Fatalf("reading public key: %v", err)
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			aid := append([]byte{0x30, byte(len(ecOID) + len(tc.params))}, ecOID...)
+			aid = append(aid, tc.params...)
+			cert := test.ReadTestCert("bsiECP256.pem")
+			cert.RawSubjectPublicKeyInfo = marshalSPKI(t, aid, key)
+			if out := test.TestLintCert("e_ec_public_key_params_not_named_curve", cert); out.Status != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, out.Status)
+			}
+		})
+	}
+
+	for _, tc := range []struct {
+		inPath   string
+		expected lint.LintStatus
+	}{
+		{inPath: "bsiECP256.pem", expected: lint.Pass},
+		{inPath: "mpModulus2048.pem", expected: lint.NA},
+	} {
+		if out := test.TestLint("e_ec_public_key_params_not_named_curve", tc.inPath); out.Status != tc.expected {
+			t.Errorf("%s: expected %s, got %s", tc.inPath, tc.expected, out.Status)
+		}
+	}
+}
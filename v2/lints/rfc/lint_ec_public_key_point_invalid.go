@@ -0,0 +1,83 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package rfc
+
+/************************************************
+RFC 5480: 2.2 Subject Public Key
+   The elliptic curve public key (a value of type ECPoint that is an
+   OCTET STRING) is mapped to a subjectPublicKey (a value of type BIT
+   STRING) as follows: the most significant bit of the OCTET STRING
+   value becomes the most significant bit of the BIT STRING value, and
+   so on; the least significant bit of the OCTET STRING becomes the
+   least significant bit of the BIT STRING.
+
+NIST SP 800-56A: 5.6.2.3.4 ECC Partial Public-Key Validation Routine
+   Verify that Q is not the identity element, that xQ and yQ are integers
+   in the interval [0, p-1] and that Q is on the curve.
+************************************************/
+
+import (
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type ecPublicKeyPointInvalid struct{}
+
+func (l *ecPublicKeyPointInvalid) Initialize() error {
+	return nil
+}
+
+func (l *ecPublicKeyPointInvalid) CheckApplies(c *x509.Certificate) bool {
+	if !c.PublicKeyAlgorithmOID.Equal(util.OidPublicKeyECDSA) {
+		return false
+	}
+	aid, err := util.GetPublicKeyAidEncoded(c)
+	if err != nil {
+		return false
+	}
+	form, curve, err := util.GetECParameters(aid)
+	return err == nil && form == util.ECNamedCurve && util.CurveFromOID(curve) != nil
+}
+
+func (l *ecPublicKeyPointInvalid) Execute(c *x509.Certificate) *lint.LintResult {
+	aid, err := util.GetPublicKeyAidEncoded(c)
+	if err != nil {
+		return &lint.LintResult{Status: lint.Fatal, Details: err.Error()}
+	}
+	_, curve, err := util.GetECParameters(aid)
+	if err != nil {
+		return &lint.LintResult{Status: lint.Fatal, Details: err.Error()}
+	}
+	point, err := util.GetPublicKeyBytes(c)
+	if err != nil {
+		return &lint.LintResult{Status: lint.Fatal, Details: err.Error()}
+	}
+	if err := util.ValidateECPoint(util.CurveFromOID(curve), point); err != nil {
+		return &lint.LintResult{Status: lint.Error, Details: err.Error()}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_ec_public_key_point_invalid",
+		Description:   "EC public keys MUST be a valid point on the named curve other than the point at infinity",
+		Citation:      "RFC 5480: 2.2, NIST SP 800-56A: 5.6.2.3.4",
+		Source:        lint.RFC5480,
+		EffectiveDate: util.RFC5480Date,
+		Lint:          &ecPublicKeyPointInvalid{},
+	})
+}
@@ -0,0 +1,78 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package rfc
+
+import (
+	"testing"
+
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/test"
+	"github.com/zmap/zlint/v2/util"
+)
+
+func TestECPublicKeyPointInvalid(t *testing.T) {
+	cert := test.ReadTestCert("bsiECP256.pem")
+	aid, err := util.GetPublicKeyAidEncoded(cert)
+	if err != nil {
+		t.Fatalf("reading algorithm identifier: %v", err)
+	}
+	key, err := util.GetPublicKeyBytes(cert)
+	if err != nil {
+		t.Fatalf("reading public key: %v", err)
+	}
+	offCurve := append([]byte{}, key...)
+	offCurve[len(offCurve)-1] ^= 0x01
+
+	testCases := []struct {
+		name     string
+		key      []byte
+		expected lint.LintStatus
+	}{
+		{
+			name:     "valid",
+			key:      key,
+			expected: lint.Pass,
+		},
+		{
+			name:     "point at infinity",
+			key:      []byte{0x00},
+			expected: lint.Error,
+		},
+		{
+			name:     "not on curve",
+			key:      offCurve,
+			expected: lint.Error,
+		},
+		{
+			name:     "truncated",
+			key:      key[:len(key)-1],
+			expected: lint.Error,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cert := test.ReadTestCert("bsiECP256.pem")
+			cert.RawSubjectPublicKeyInfo = marshalSPKI(t, aid, tc.key)
+			if out := test.TestLintCert("e_ec_public_key_point_invalid", cert); out.Status != tc.expected {
+				t.Errorf("expected %s, got %s (%s)", tc.expected, out.Status, out.Details)
+			}
+		})
+	}
+
+	if out := test.TestLint("e_ec_public_key_point_invalid", "mpModulus2048.pem"); out.Status != lint.NA {
+		t.Errorf("expected %s, got %s", lint.NA, out.Status)
+	}
+}
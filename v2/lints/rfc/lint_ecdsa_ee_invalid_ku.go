@@ -37,34 +37,34 @@ func (l *ecdsaInvalidKU) CheckApplies(c *x509.Certificate) bool {
 	return util.IsSubscriberCert(c) && c.PublicKeyAlgorithm == x509.ECDSA
 }
 
+// ecdsaEEAllowedKUs are the key usages allowed for ECDSA end entity
+// certificates. RFC 5480, Section 3 "Key Usage Bits" says:
+//
+//	If the keyUsage extension is present in an End Entity (EE)
+//	certificate that indicates id-ecPublicKey in SubjectPublicKeyInfo,
+//	then any combination of the following values MAY be present:
+//
+//	  digitalSignature;
+//	  nonRepudiation; and
+//	  keyAgreement.
+//
+// Note that per RFC 5280: recent editions of X.509 renamed "nonRepudiation" to
+// "contentCommitment", which is the name of the Go x509 constant we use here
+// alongside the digitalSignature and keyAgreement constants.
+var ecdsaEEAllowedKUs = map[x509.KeyUsage]bool{
+	x509.KeyUsageDigitalSignature:  true,
+	x509.KeyUsageContentCommitment: true,
+	x509.KeyUsageKeyAgreement:      true,
+}
+
 // Execute returns a Notice level lint.LintResult if the ECDSA end entity certificate
 // being linted has Key Usage bits set other than digitalSignature,
 // nonRepudiation/contentCommentment, and keyAgreement.
 func (l *ecdsaInvalidKU) Execute(c *x509.Certificate) *lint.LintResult {
-	// RFC 5480, Section 3 "Key Usage Bits" says:
-	//
-	//   If the keyUsage extension is present in an End Entity (EE)
-	//   certificate that indicates id-ecPublicKey in SubjectPublicKeyInfo,
-	//   then any combination of the following values MAY be present:
-	//
-	//     digitalSignature;
-	//     nonRepudiation; and
-	//     keyAgreement.
-	//
-	// So we set up `allowedKUs` to match. Note that per RFC 5280: recent editions
-	// of X.509 renamed "nonRepudiation" to "contentCommitment", which is the name
-	// of the Go x509 constant we use here alongside the digitalSignature and
-	// keyAgreement constants.
-	allowedKUs := map[x509.KeyUsage]bool{
-		x509.KeyUsageDigitalSignature:  true,
-		x509.KeyUsageContentCommitment: true,
-		x509.KeyUsageKeyAgreement:      true,
-	}
-
 	var invalidKUs []string
 	for ku, kuName := range util.KeyUsageToString {
 		if c.KeyUsage&ku != 0 {
-			if !allowedKUs[ku] {
+			if !ecdsaEEAllowedKUs[ku] {
 				invalidKUs = append(invalidKUs, kuName)
 			}
 		}
@@ -94,6 +94,7 @@ func init() {
 		Citation:      "RFC 5480 Section 3",
 		Source:        lint.RFC5480,
 		EffectiveDate: util.CABEffectiveDate,
+		Applicability: lint.Applicability{Kind: lint.SubscriberCertificate},
 		Lint:          &ecdsaInvalidKU{},
 	})
 }
@@ -0,0 +1,83 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package rfc
+
+/************************************************
+RFC 8410: 3. Curve25519 and Curve448 Algorithm Identifiers
+   The same algorithm identifiers are used for signatures as are used
+   for public keys.  When used to identify signature algorithms, the
+   parameters MUST be absent.
+   ...
+   In this document, we define four new OIDs for identifying the
+   different curve/algorithm pairs ... For all of the OIDs, the
+   parameters MUST be absent.
+************************************************/
+
+import (
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type eddsaAlgorithmParamsPresent struct{}
+
+func (l *eddsaAlgorithmParamsPresent) Initialize() error {
+	return nil
+}
+
+func (l *eddsaAlgorithmParamsPresent) CheckApplies(c *x509.Certificate) bool {
+	return util.IsEdDSAOID(c.PublicKeyAlgorithmOID) || util.IsEdDSAOID(c.SignatureAlgorithmOID)
+}
+
+func (l *eddsaAlgorithmParamsPresent) Execute(c *x509.Certificate) *lint.LintResult {
+	if util.IsEdDSAOID(c.PublicKeyAlgorithmOID) {
+		aid, err := util.GetPublicKeyAidEncoded(c)
+		if err != nil {
+			return &lint.LintResult{Status: lint.Fatal, Details: err.Error()}
+		}
+		hasParams, err := util.AlgorithmIDHasParams(aid)
+		if err != nil {
+			return &lint.LintResult{Status: lint.Fatal, Details: err.Error()}
+		}
+		if hasParams {
+			return &lint.LintResult{Status: lint.Error, Details: "public key algorithm parameters are present"}
+		}
+	}
+	if util.IsEdDSAOID(c.SignatureAlgorithmOID) {
+		aid, err := util.GetSignatureAlgorithmInTBSEncoded(c)
+		if err != nil {
+			return &lint.LintResult{Status: lint.Fatal, Details: err.Error()}
+		}
+		hasParams, err := util.AlgorithmIDHasParams(aid)
+		if err != nil {
+			return &lint.LintResult{Status: lint.Fatal, Details: err.Error()}
+		}
+		if hasParams {
+			return &lint.LintResult{Status: lint.Error, Details: "signature algorithm parameters are present"}
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_eddsa_algorithm_params_present",
+		Description:   "The parameters of Ed25519 and Ed448 algorithm identifiers MUST be absent",
+		Citation:      "RFC 8410: 3",
+		Source:        lint.RFC5280, // RFC 8410 profiles algorithms for use in RFC 5280 certificates
+		EffectiveDate: util.RFC8410Date,
+		Lint:          &eddsaAlgorithmParamsPresent{},
+	})
+}
@@ -0,0 +1,69 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package rfc
+
+/************************************************
+RFC 8410: 4. Subject Public Key Fields
+   subjectPublicKey contains the byte stream of the public key.  The
+   algorithms defined in this document always encode the public key as
+   an exact multiple of 8 bits.
+
+RFC 8032 defines Ed25519 public keys as 32 octets and Ed448 public keys
+as 57 octets.
+************************************************/
+
+import (
+	"fmt"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type eddsaPublicKeyLengthInvalid struct{}
+
+func (l *eddsaPublicKeyLengthInvalid) Initialize() error {
+	return nil
+}
+
+func (l *eddsaPublicKeyLengthInvalid) CheckApplies(c *x509.Certificate) bool {
+	return util.IsEdDSAOID(c.PublicKeyAlgorithmOID)
+}
+
+func (l *eddsaPublicKeyLengthInvalid) Execute(c *x509.Certificate) *lint.LintResult {
+	key, err := util.GetPublicKeyBytes(c)
+	if err != nil {
+		return &lint.LintResult{Status: lint.Fatal, Details: err.Error()}
+	}
+	expected := util.EdDSAPublicKeySizes[c.PublicKeyAlgorithmOID.String()]
+	if len(key) != expected {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: fmt.Sprintf("public key is %d bytes, expected %d", len(key), expected),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_eddsa_public_key_length_invalid",
+		Description:   "Ed25519 public keys MUST be 32 bytes and Ed448 public keys MUST be 57 bytes",
+		Citation:      "RFC 8410: 4, RFC 8032: 5.1.5, 5.2.5",
+		Source:        lint.RFC5280, // RFC 8410 profiles algorithms for use in RFC 5280 certificates
+		EffectiveDate: util.RFC8410Date,
+		Lint:          &eddsaPublicKeyLengthInvalid{},
+	})
+}
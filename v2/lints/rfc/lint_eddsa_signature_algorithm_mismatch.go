@@ -0,0 +1,66 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package rfc
+
+/************************************************
+RFC 8410: 3. Curve25519 and Curve448 Algorithm Identifiers
+   The same algorithm identifiers are used for signatures as are used
+   for public keys.
+
+A self-issued certificate is signed with its own key, so when either its key
+or its signature uses EdDSA the two algorithm identifiers must be the same.
+************************************************/
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type eddsaSignatureAlgorithmMismatch struct{}
+
+func (l *eddsaSignatureAlgorithmMismatch) Initialize() error {
+	return nil
+}
+
+func (l *eddsaSignatureAlgorithmMismatch) CheckApplies(c *x509.Certificate) bool {
+	return bytes.Equal(c.RawIssuer, c.RawSubject) &&
+		(util.IsEdDSAOID(c.PublicKeyAlgorithmOID) || util.IsEdDSAOID(c.SignatureAlgorithmOID))
+}
+
+func (l *eddsaSignatureAlgorithmMismatch) Execute(c *x509.Certificate) *lint.LintResult {
+	if !c.SignatureAlgorithmOID.Equal(c.PublicKeyAlgorithmOID) {
+		return &lint.LintResult{
+			Status: lint.Error,
+			Details: fmt.Sprintf("signature algorithm %s does not match public key algorithm %s",
+				c.SignatureAlgorithmOID, c.PublicKeyAlgorithmOID),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_eddsa_signature_algorithm_mismatch",
+		Description:   "Self-issued certificates with an Ed25519 or Ed448 key or signature MUST use the same algorithm for both",
+		Citation:      "RFC 8410: 3",
+		Source:        lint.RFC5280, // RFC 8410 profiles algorithms for use in RFC 5280 certificates
+		EffectiveDate: util.RFC8410Date,
+		Lint:          &eddsaSignatureAlgorithmMismatch{},
+	})
+}
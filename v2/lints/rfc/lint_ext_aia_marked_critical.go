@@ -51,6 +51,7 @@ func init() {
 		Citation:      "RFC 5280: 4.2.2.1",
 		Source:        lint.RFC5280,
 		EffectiveDate: util.RFC2459Date,
+		Applicability: lint.Applicability{Extension: util.AiaOID},
 		Lint:          &ExtAiaMarkedCritical{},
 	})
 }
@@ -0,0 +1,76 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package rfc
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type cpsURIInvalid struct{}
+
+func (l *cpsURIInvalid) Initialize() error {
+	return nil
+}
+
+func (l *cpsURIInvalid) CheckApplies(c *x509.Certificate) bool {
+	for _, uris := range c.CPSuri {
+		if len(uris) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Execute returns an Error for CPS pointer qualifiers that don't parse as
+// absolute URIs, or that use the http or https schemes without a host.
+func (l *cpsURIInvalid) Execute(c *x509.Certificate) *lint.LintResult {
+	var invalid []string
+	for _, uris := range c.CPSuri {
+		for _, uri := range uris {
+			u, err := url.Parse(uri)
+			if err != nil {
+				invalid = append(invalid, fmt.Sprintf("%q: %v", uri, err))
+			} else if !u.IsAbs() {
+				invalid = append(invalid, fmt.Sprintf("%q: not an absolute URI", uri))
+			} else if scheme := strings.ToLower(u.Scheme); (scheme == "http" || scheme == "https") && u.Host == "" {
+				invalid = append(invalid, fmt.Sprintf("%q: no host", uri))
+			}
+		}
+	}
+	if len(invalid) > 0 {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: fmt.Sprintf("invalid CPS URIs: %s", strings.Join(invalid, "; ")),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_ext_cert_policy_cps_uri_invalid",
+		Description:   "The CPS pointer qualifier MUST contain a valid absolute URI",
+		Citation:      "RFC 5280: 4.2.1.4",
+		Source:        lint.RFC5280,
+		EffectiveDate: util.RFC5280Date,
+		Lint:          &cpsURIInvalid{},
+	})
+}
@@ -52,6 +52,7 @@ func init() {
 		Citation:      "RFC 5280: 4.2.1.13",
 		Source:        lint.RFC5280,
 		EffectiveDate: util.RFC2459Date,
+		Applicability: lint.Applicability{Extension: util.CrlDistOID},
 		Lint:          &ExtCrlDistributionMarkedCritical{},
 	})
 }
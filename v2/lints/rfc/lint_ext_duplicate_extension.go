@@ -19,6 +19,9 @@ package rfc
 ************************************************/
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/zmap/zcrypto/x509"
 	"github.com/zmap/zlint/v2/lint"
 	"github.com/zmap/zlint/v2/util"
@@ -35,15 +38,27 @@ func (l *ExtDuplicateExtension) CheckApplies(cert *x509.Certificate) bool {
 }
 
 func (l *ExtDuplicateExtension) Execute(cert *x509.Certificate) *lint.LintResult {
-	// O(n^2) is not terrible here because n is capped around 10
-	for i := 0; i < len(cert.Extensions); i++ {
-		for j := i + 1; j < len(cert.Extensions); j++ {
-			if i != j && cert.Extensions[i].Id.Equal(cert.Extensions[j].Id) {
-				return &lint.LintResult{Status: lint.Error}
-			}
+	// Count the instances of each extension, remembering the order in which
+	// the duplicated OIDs first appear so the details are deterministic.
+	counts := make(map[string]int, len(cert.Extensions))
+	var duplicated []string
+	for _, ext := range cert.Extensions {
+		oid := ext.Id.String()
+		counts[oid]++
+		if counts[oid] == 2 {
+			duplicated = append(duplicated, oid)
+		}
+	}
+	if len(duplicated) > 0 {
+		details := make([]string, len(duplicated))
+		for i, oid := range duplicated {
+			details[i] = fmt.Sprintf("%s (%d instances)", oid, counts[oid])
+		}
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: "duplicated extensions: " + strings.Join(details, ", "),
 		}
 	}
-	// Nested loop will return if it finds a duplicate, so safe to assume pass
 	return &lint.LintResult{Status: lint.Pass}
 }
 
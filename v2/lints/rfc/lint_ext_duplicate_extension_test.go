@@ -38,3 +38,25 @@ func TestNoDuplicateExtension(t *testing.T) {
 		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
 	}
 }
+
+func TestDuplicateExtensionDetails(t *testing.T) {
+	testCases := []struct {
+		inputPath string
+		details   string
+	}{
+		{
+			inputPath: "extSANDuplicated.pem",
+			details:   "duplicated extensions: 2.5.29.17 (2 instances)",
+		},
+		{
+			inputPath: "extUnknownDuplicated.pem",
+			details:   "duplicated extensions: 2.5.29.48.48.48.56 (2 instances)",
+		},
+	}
+	for _, tc := range testCases {
+		out := test.TestLint("e_ext_duplicate_extension", tc.inputPath)
+		if out.Details != tc.details {
+			t.Errorf("%s: expected details %q, got %q", tc.inputPath, tc.details, out.Details)
+		}
+	}
+}
@@ -55,6 +55,7 @@ func init() {
 		Citation:      "RFC5280: 4.2.1.7",
 		Source:        lint.RFC5280,
 		EffectiveDate: util.RFC5280Date,
+		Applicability: lint.Applicability{Extension: util.IssuerAlternateNameOID},
 		Lint:          &IANURIIA5String{},
 	})
 }
@@ -54,6 +54,7 @@ func init() {
 		Citation:      "RFC 5280: 4.2.1.3",
 		Source:        lint.RFC5280,
 		EffectiveDate: util.RFC5280Date,
+		Applicability: lint.Applicability{Extension: util.KeyUsageOID},
 		Lint:          &keyUsageBitsSet{},
 	})
 }
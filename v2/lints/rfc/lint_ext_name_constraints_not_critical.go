@@ -58,6 +58,7 @@ func init() {
 		Citation:      "RFC 5280: 4.2.1.10",
 		Source:        lint.RFC5280,
 		EffectiveDate: util.RFC2459Date,
+		Applicability: lint.Applicability{Extension: util.NameConstOID},
 		Lint:          &nameConstraintCrit{},
 	})
 }
@@ -51,6 +51,7 @@ func init() {
 		Citation:      "RFC 5280: 4.2.1.11",
 		Source:        lint.RFC5280,
 		EffectiveDate: util.RFC5280Date,
+		Applicability: lint.Applicability{Extension: util.PolicyConstOID},
 		Lint:          &policyConstraintsCritical{},
 	})
 }
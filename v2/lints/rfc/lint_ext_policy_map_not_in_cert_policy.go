@@ -59,6 +59,7 @@ func init() {
 		Citation:      "RFC 5280: 4.2.1.5",
 		Source:        lint.RFC5280,
 		EffectiveDate: util.RFC3280Date,
+		Applicability: lint.Applicability{Extension: util.PolicyMapOID},
 		Lint:          &policyMapMatchesCertPolicy{},
 	})
 }
@@ -0,0 +1,79 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package rfc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type SANDNSNameEmptyLabel struct{}
+
+func (l *SANDNSNameEmptyLabel) Initialize() error {
+	return nil
+}
+
+func (l *SANDNSNameEmptyLabel) CheckApplies(c *x509.Certificate) bool {
+	return util.IsExtInCert(c, util.SubjectAlternateNameOID) && len(c.DNSNames) > 0 &&
+		!brDNSNameLintsApply(c)
+}
+
+// brDNSNameLintsApply returns true if the Baseline Requirements dNSName lints
+// (e_dnsname_empty_label, e_dnsname_label_too_long, etc.) lint c. Those
+// already report the defects found by the RFC 5280 dNSName structure lints
+// for server authentication subscriber certificates, so the RFC lints are
+// limited to the certificates they don't cover to avoid reporting the same
+// defect twice.
+func brDNSNameLintsApply(c *x509.Certificate) bool {
+	return util.IsSubscriberCert(c) && util.IsServerAuthCert(c) && !c.NotBefore.Before(util.CABEffectiveDate)
+}
+
+// Execute returns an Error for DNSNames that are empty or contain an empty
+// label, e.g. "www..example.com" or ".example.com". A single trailing dot is
+// reported by e_ext_san_dns_name_trailing_dot instead.
+func (l *SANDNSNameEmptyLabel) Execute(c *x509.Certificate) *lint.LintResult {
+	var names []string
+	for _, dns := range c.DNSNames {
+		for _, label := range strings.Split(strings.TrimSuffix(dns, "."), ".") {
+			if label == "" {
+				names = append(names, fmt.Sprintf("%q", dns))
+				break
+			}
+		}
+	}
+	if len(names) > 0 {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: fmt.Sprintf("DNSNames with an empty label: %s", strings.Join(names, ", ")),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_ext_san_dns_name_empty_label",
+		Description:   "DNSNames MUST NOT be empty or contain empty labels",
+		Citation:      "RFC 5280: 4.2.1.6; RFC 1034: 3.5",
+		Source:        lint.RFC5280,
+		EffectiveDate: util.RFC5280Date,
+		Applicability: lint.Applicability{Extension: util.SubjectAlternateNameOID},
+		Lint:          &SANDNSNameEmptyLabel{},
+	})
+}
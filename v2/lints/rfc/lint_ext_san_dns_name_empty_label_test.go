@@ -0,0 +1,60 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package rfc
+
+import (
+	"testing"
+
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/test"
+)
+
+func TestSANDNSNameEmptyLabel(t *testing.T) {
+	inputPath := "sanDNSNameEmptyLabelClientAuth.pem"
+	expected := lint.Error
+	out := test.TestLint("e_ext_san_dns_name_empty_label", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+func TestSANDNSNameNoEmptyLabel(t *testing.T) {
+	inputPath := "sanDNSNameLabelTooLongClientAuth.pem"
+	expected := lint.Pass
+	out := test.TestLint("e_ext_san_dns_name_empty_label", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+// A single trailing dot is reported by e_ext_san_dns_name_trailing_dot instead.
+func TestSANDNSNameEmptyLabelTrailingDot(t *testing.T) {
+	inputPath := "sanDNSNameTrailingDotClientAuth.pem"
+	expected := lint.Pass
+	out := test.TestLint("e_ext_san_dns_name_empty_label", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
+
+// Server authentication subscriber certificates are linted by e_dnsname_empty_label instead.
+func TestSANDNSNameEmptyLabelServerAuth(t *testing.T) {
+	inputPath := "sanDNSNameEmptyLabel.pem"
+	expected := lint.NA
+	out := test.TestLint("e_ext_san_dns_name_empty_label", inputPath)
+	if out.Status != expected {
+		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
+	}
+}
@@ -0,0 +1,65 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package rfc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type SANDNSNameLabelTooLong struct{}
+
+func (l *SANDNSNameLabelTooLong) Initialize() error {
+	return nil
+}
+
+func (l *SANDNSNameLabelTooLong) CheckApplies(c *x509.Certificate) bool {
+	return util.IsExtInCert(c, util.SubjectAlternateNameOID) && len(c.DNSNames) > 0 &&
+		!brDNSNameLintsApply(c)
+}
+
+func (l *SANDNSNameLabelTooLong) Execute(c *x509.Certificate) *lint.LintResult {
+	var labels []string
+	for _, dns := range c.DNSNames {
+		for _, label := range strings.Split(dns, ".") {
+			if len(label) > 63 {
+				labels = append(labels, fmt.Sprintf("%s (%d octets)", label, len(label)))
+			}
+		}
+	}
+	if len(labels) > 0 {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: fmt.Sprintf("DNSName labels longer than 63 octets: %s", strings.Join(labels, ", ")),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_ext_san_dns_name_label_too_long",
+		Description:   "DNSName labels MUST be 63 octets or less",
+		Citation:      "RFC 5280: 4.2.1.6; RFC 1035: 2.3.4",
+		Source:        lint.RFC5280,
+		EffectiveDate: util.RFC5280Date,
+		Applicability: lint.Applicability{Extension: util.SubjectAlternateNameOID},
+		Lint:          &SANDNSNameLabelTooLong{},
+	})
+}
@@ -15,6 +15,9 @@ package rfc
  */
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/zmap/zcrypto/x509"
 	"github.com/zmap/zlint/v2/lint"
 	"github.com/zmap/zlint/v2/util"
@@ -31,9 +34,16 @@ func (l *SANDNSTooLong) CheckApplies(c *x509.Certificate) bool {
 }
 
 func (l *SANDNSTooLong) Execute(c *x509.Certificate) *lint.LintResult {
+	var names []string
 	for _, dns := range c.DNSNames {
 		if len(dns) > 253 {
-			return &lint.LintResult{Status: lint.Error}
+			names = append(names, fmt.Sprintf("%s (%d octets)", dns, len(dns)))
+		}
+	}
+	if len(names) > 0 {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: fmt.Sprintf("DNSNames longer than 253 octets: %s", strings.Join(names, ", ")),
 		}
 	}
 	return &lint.LintResult{Status: lint.Pass}
@@ -46,6 +56,7 @@ func init() {
 		Citation:      "RFC 5280",
 		Source:        lint.RFC5280,
 		EffectiveDate: util.RFC5280Date,
+		Applicability: lint.Applicability{Extension: util.SubjectAlternateNameOID},
 		Lint:          &SANDNSTooLong{},
 	})
 }
@@ -0,0 +1,62 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package rfc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type SANDNSNameTrailingDot struct{}
+
+func (l *SANDNSNameTrailingDot) Initialize() error {
+	return nil
+}
+
+func (l *SANDNSNameTrailingDot) CheckApplies(c *x509.Certificate) bool {
+	return util.IsExtInCert(c, util.SubjectAlternateNameOID) && len(c.DNSNames) > 0
+}
+
+func (l *SANDNSNameTrailingDot) Execute(c *x509.Certificate) *lint.LintResult {
+	var names []string
+	for _, dns := range c.DNSNames {
+		if strings.HasSuffix(dns, ".") {
+			names = append(names, dns)
+		}
+	}
+	if len(names) > 0 {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: fmt.Sprintf("DNSNames with a trailing dot: %s", strings.Join(names, ", ")),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_ext_san_dns_name_trailing_dot",
+		Description:   "DNSNames MUST be in the preferred name syntax, which does not include a trailing dot for the root label",
+		Citation:      "RFC 5280: 4.2.1.6; RFC 1034: 3.5",
+		Source:        lint.RFC5280,
+		EffectiveDate: util.RFC5280Date,
+		Applicability: lint.Applicability{Extension: util.SubjectAlternateNameOID},
+		Lint:          &SANDNSNameTrailingDot{},
+	})
+}
@@ -65,6 +65,7 @@ func init() {
 		Citation:      "RFC 5280: 4.2 & 4.2.1.2",
 		Source:        lint.RFC5280,
 		EffectiveDate: util.RFC2459Date,
+		Applicability: lint.Applicability{Kind: lint.CACertificate},
 		Lint:          &subjectKeyIdMissingCA{},
 	})
 }
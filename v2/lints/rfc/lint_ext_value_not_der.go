@@ -0,0 +1,73 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package rfc
+
+/************************************************
+RFC 5280: 4.1
+   extnValue   OCTET STRING
+               -- contains the DER encoding of an ASN.1 value
+               -- corresponding to the extension type identified
+               -- by extnID
+
+Extension values that fail to parse as DER are rejected by the certificate
+parser for the extensions it knows about, so this mostly catches BER
+encodings, such as indefinite lengths, in extensions it doesn't.
+************************************************/
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type extValueNotDER struct{}
+
+func (l *extValueNotDER) Initialize() error {
+	return nil
+}
+
+func (l *extValueNotDER) CheckApplies(c *x509.Certificate) bool {
+	return len(c.Extensions) > 0
+}
+
+func (l *extValueNotDER) Execute(c *x509.Certificate) *lint.LintResult {
+	var failures []string
+	for _, ext := range c.Extensions {
+		if err := util.CheckDER(ext.Value); err != nil {
+			failures = append(failures, fmt.Sprintf("extension %s: %v", ext.Id, err))
+		}
+	}
+	if len(failures) > 0 {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: strings.Join(failures, "; "),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_ext_value_not_der",
+		Description:   "Extension values MUST contain the DER encoding of an ASN.1 value",
+		Citation:      "RFC 5280: 4.1",
+		Source:        lint.RFC5280,
+		EffectiveDate: util.RFC2459Date,
+		Lint:          &extValueNotDER{},
+	})
+}
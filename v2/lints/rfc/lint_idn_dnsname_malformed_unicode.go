@@ -54,6 +54,7 @@ func init() {
 		Description:   "Internationalized DNSNames punycode not valid unicode",
 		Citation:      "RFC 3490",
 		EffectiveDate: util.RFC3490Date,
+		Applicability: lint.Applicability{Extension: util.SubjectAlternateNameOID},
 		Source:        lint.RFC5280,
 		Lint:          &IDNMalformedUnicode{},
 	})
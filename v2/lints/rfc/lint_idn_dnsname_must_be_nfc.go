@@ -59,6 +59,7 @@ func init() {
 		Citation:      "RFC 8399",
 		Source:        lint.RFC5891,
 		EffectiveDate: util.RFC8399Date,
+		Applicability: lint.Applicability{Extension: util.SubjectAlternateNameOID},
 		Lint:          &IDNNotNFC{},
 	})
 }
@@ -0,0 +1,67 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package rfc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type IDNNotIDNA2008 struct{}
+
+func (l *IDNNotIDNA2008) Initialize() error {
+	return nil
+}
+
+func (l *IDNNotIDNA2008) CheckApplies(c *x509.Certificate) bool {
+	return util.IsExtInCert(c, util.SubjectAlternateNameOID)
+}
+
+func (l *IDNNotIDNA2008) Execute(c *x509.Certificate) *lint.LintResult {
+	var invalid []string
+	for _, dns := range c.DNSNames {
+		for _, label := range strings.Split(dns, ".") {
+			if !util.IsALabelCandidate(label) {
+				continue
+			}
+			if _, err := util.ToIDNA2008ULabel(label); err != nil {
+				invalid = append(invalid, err.Error())
+			}
+		}
+	}
+	if len(invalid) > 0 {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: fmt.Sprintf("invalid A-labels: %s", strings.Join(invalid, "; ")),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_international_dns_name_not_idna2008",
+		Description:   "Labels of DNSNames starting with the ACE prefix MUST be A-labels that decode to valid IDNA2008 U-labels",
+		Citation:      "RFC 5890: 2.3.2.1; RFC 5891: 5.4; RFC 5892",
+		Source:        lint.RFC5891,
+		EffectiveDate: util.RFC5891Date,
+		Applicability: lint.Applicability{Extension: util.SubjectAlternateNameOID},
+		Lint:          &IDNNotIDNA2008{},
+	})
+}
@@ -0,0 +1,64 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package rfc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type issuerDNStringTypeDeprecated struct{}
+
+func (l *issuerDNStringTypeDeprecated) Initialize() error {
+	return nil
+}
+
+func (l *issuerDNStringTypeDeprecated) CheckApplies(c *x509.Certificate) bool {
+	return len(c.RawIssuer) > 0
+}
+
+// Execute returns a Warning rather than an Error since the issuer MUST be
+// encoded as in the issuing CA's own subject, which may predate RFC 3280.
+func (l *issuerDNStringTypeDeprecated) Execute(c *x509.Certificate) *lint.LintResult {
+	found, err := deprecatedStringAttributes(c.RawIssuer)
+	if err != nil {
+		return &lint.LintResult{
+			Status:  lint.Fatal,
+			Details: fmt.Sprintf("failed to parse issuer: %v", err),
+		}
+	}
+	if len(found) > 0 {
+		return &lint.LintResult{
+			Status:  lint.Warn,
+			Details: fmt.Sprintf("issuer attributes with deprecated string types: %s", strings.Join(found, ", ")),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "w_issuer_dn_string_type_deprecated",
+		Description:   "Issuer attributes should be encoded as PrintableString or UTF8String, not TeletexString, BMPString or UniversalString",
+		Citation:      "RFC 5280: 4.1.2.4 & 4.1.2.6",
+		Source:        lint.RFC5280,
+		EffectiveDate: util.DirectoryStringUTF8Date,
+		Lint:          &issuerDNStringTypeDeprecated{},
+	})
+}
@@ -0,0 +1,83 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package rfc
+
+import (
+	"encoding/asn1"
+	"fmt"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type issuerPrintableStringBadAlpha struct{}
+
+func (l *issuerPrintableStringBadAlpha) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for any certificate with a non-empty RawIssuer.
+func (l *issuerPrintableStringBadAlpha) CheckApplies(c *x509.Certificate) bool {
+	return len(c.RawIssuer) > 0
+}
+
+// Execute checks the certificate's RawIssuer to ensure that any
+// PrintableString attribute/value pairs match the character set defined for
+// this type in RFC 5280, as e_subject_printable_string_badalpha does for the
+// subject.
+func (l *issuerPrintableStringBadAlpha) Execute(c *x509.Certificate) *lint.LintResult {
+	rdnSequence := util.RawRDNSequence{}
+	rest, err := asn1.Unmarshal(c.RawIssuer, &rdnSequence)
+	if err != nil {
+		return &lint.LintResult{
+			Status:  lint.Fatal,
+			Details: "Failed to Unmarshal RawIssuer into RawRDNSequence",
+		}
+	}
+	if len(rest) > 0 {
+		return &lint.LintResult{
+			Status:  lint.Fatal,
+			Details: "Trailing data after RawIssuer RawRDNSequence",
+		}
+	}
+
+	for _, attrTypeAndValueSet := range rdnSequence {
+		for _, attrTypeAndValue := range attrTypeAndValueSet {
+			if attrTypeAndValue.Value.Tag == asn1.TagPrintableString {
+				if err := validatePrintableString(attrTypeAndValue.Value.Bytes); err != nil {
+					return &lint.LintResult{
+						Status: lint.Error,
+						Details: fmt.Sprintf("RawIssuer attr oid %s %s",
+							attrTypeAndValue.Type, err.Error()),
+					}
+				}
+			}
+		}
+	}
+
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_issuer_printable_string_badalpha",
+		Description:   "PrintableString type's alphabet only includes a-z, A-Z, 0-9, and 11 special characters",
+		Citation:      "RFC 5280: Appendix B. ASN.1 Notes",
+		Source:        lint.RFC5280,
+		EffectiveDate: util.RFC2459Date,
+		Lint:          &issuerPrintableStringBadAlpha{},
+	})
+}
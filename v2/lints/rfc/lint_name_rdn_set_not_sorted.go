@@ -0,0 +1,66 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package rfc
+
+/************************************************
+RFC 5280: 4.1
+   ... the certificate ... is encoded using the ASN.1 Distinguished Encoding
+   Rules (DER) [X.690].
+
+X.690: 11.6 Set-of components
+   The encodings of the component values of a set-of value shall appear in
+   ascending order, the encodings being compared as octet strings with the
+   shorter components being padded at their trailing end with 0-octets.
+
+A RelativeDistinguishedName is a SET OF AttributeTypeAndValue, so the values
+of a multi-valued RDN must be sorted by their encodings.
+************************************************/
+
+import (
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type nameRDNSetNotSorted struct{}
+
+func (l *nameRDNSetNotSorted) Initialize() error {
+	return nil
+}
+
+func (l *nameRDNSetNotSorted) CheckApplies(c *x509.Certificate) bool {
+	return true
+}
+
+func (l *nameRDNSetNotSorted) Execute(c *x509.Certificate) *lint.LintResult {
+	if err := util.CheckRDNSetOrder(c.RawSubject); err != nil {
+		return &lint.LintResult{Status: lint.Error, Details: "subject: " + err.Error()}
+	}
+	if err := util.CheckRDNSetOrder(c.RawIssuer); err != nil {
+		return &lint.LintResult{Status: lint.Error, Details: "issuer: " + err.Error()}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_name_rdn_set_not_sorted",
+		Description:   "The attribute type and values of multi-valued RDNs in the subject and issuer MUST be in DER SET OF order",
+		Citation:      "RFC 5280: 4.1, X.690: 11.6",
+		Source:        lint.RFC5280,
+		EffectiveDate: util.RFC2459Date,
+		Lint:          &nameRDNSetNotSorted{},
+	})
+}
@@ -0,0 +1,69 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package rfc
+
+/************************************************
+RFC 5280: 4.1.2.5
+CAs conforming to this profile MUST always encode certificate validity dates
+through the year 2049 as UTCTime; certificate validity dates in 2050 or later
+MUST be encoded as GeneralizedTime.
+
+4.1.2.5.1 UTCTime
+Where YY is greater than or equal to 50, the year SHALL be interpreted as 19YY;
+and where YY is less than 50, the year SHALL be interpreted as 20YY.
+************************************************/
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type notBeforeBefore1950 struct{}
+
+func (l *notBeforeBefore1950) Initialize() error {
+	return nil
+}
+
+func (l *notBeforeBefore1950) CheckApplies(c *x509.Certificate) bool {
+	return true
+}
+
+// Execute returns an Error for a notBefore prior to 1950. Dates before 2050
+// MUST be UTCTime, which can't represent years before 1950, so there's no
+// conforming encoding of such a date.
+func (l *notBeforeBefore1950) Execute(c *x509.Certificate) *lint.LintResult {
+	if c.NotBefore.Year() < 1950 {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: fmt.Sprintf("notBefore %s can't be encoded as UTCTime", c.NotBefore.UTC().Format(time.RFC3339)),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_not_before_before_1950",
+		Description:   "The notBefore date MUST NOT be before 1950, the earliest year UTCTime can represent",
+		Citation:      "RFC 5280: 4.1.2.5",
+		Source:        lint.RFC5280,
+		EffectiveDate: util.ZeroDate,
+		Lint:          &notBeforeBefore1950{},
+	})
+}
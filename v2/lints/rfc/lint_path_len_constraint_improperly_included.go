@@ -54,9 +54,14 @@ func (l *pathLenIncluded) Execute(cert *x509.Certificate) *lint.LintResult {
 	if err != nil {
 		return &lint.LintResult{Status: lint.Fatal}
 	}
-	keyUsageValue := util.IsExtInCert(cert, util.KeyUsageOID)
-	if len(rest) > 0 && (!cert.IsCA || !keyUsageValue || (keyUsageValue && cert.KeyUsage&x509.KeyUsageCertSign == 0)) {
-		return &lint.LintResult{Status: lint.Error}
+	if len(rest) == 0 {
+		return &lint.LintResult{Status: lint.Pass}
+	}
+	if !cert.IsCA {
+		return &lint.LintResult{Status: lint.Error, Details: "pathLenConstraint is present but cA is not asserted"}
+	}
+	if !util.IsExtInCert(cert, util.KeyUsageOID) || cert.KeyUsage&x509.KeyUsageCertSign == 0 {
+		return &lint.LintResult{Status: lint.Error, Details: "pathLenConstraint is present but keyCertSign is not asserted"}
 	}
 	return &lint.LintResult{Status: lint.Pass}
 }
@@ -68,6 +73,7 @@ func init() {
 		Citation:      "RFC 5280: 4.2.1.9",
 		Source:        lint.RFC5280,
 		EffectiveDate: util.RFC3280Date,
+		Applicability: lint.Applicability{Extension: util.BasicConstOID},
 		Lint:          &pathLenIncluded{},
 	})
 }
@@ -65,3 +65,16 @@ func TestSubCertMaxLenNone(t *testing.T) {
 		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
 	}
 }
+
+func TestPathLenIncludedDetails(t *testing.T) {
+	testCases := map[string]string{
+		"caMaxPathLenPresentNoCertSign.pem": "pathLenConstraint is present but keyCertSign is not asserted",
+		"subCertPathLenPositive.pem":        "pathLenConstraint is present but cA is not asserted",
+	}
+	for inputPath, expected := range testCases {
+		out := test.TestLint("e_path_len_constraint_improperly_included", inputPath)
+		if out.Details != expected {
+			t.Errorf("%s: expected details %q, got %q", inputPath, expected, out.Details)
+		}
+	}
+}
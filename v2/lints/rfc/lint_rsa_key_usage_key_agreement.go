@@ -0,0 +1,58 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package rfc
+
+import (
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type rsaKeyUsageKeyAgreement struct{}
+
+func (l *rsaKeyUsageKeyAgreement) Initialize() error {
+	return nil
+}
+
+// CheckApplies returns true for certificates with an RSA public key and
+// a keyUsage extension.
+func (l *rsaKeyUsageKeyAgreement) CheckApplies(c *x509.Certificate) bool {
+	return c.PublicKeyAlgorithm == x509.RSA && util.IsExtInCert(c, util.KeyUsageOID)
+}
+
+// Execute returns an Error if keyAgreement (or encipherOnly or decipherOnly,
+// which qualify it) is set. RSA keys can't be used for key agreement.
+func (l *rsaKeyUsageKeyAgreement) Execute(c *x509.Certificate) *lint.LintResult {
+	if c.KeyUsage&(x509.KeyUsageKeyAgreement|x509.KeyUsageEncipherOnly|x509.KeyUsageDecipherOnly) != 0 {
+		return &lint.LintResult{
+			Status:  lint.Error,
+			Details: "RSA key certificate asserts key agreement",
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		// RFC 3279 defines the algorithm profile that RFC 5280 relies upon for RSA keys.
+		Name:          "e_rsa_key_usage_key_agreement",
+		Description:   "Certificates with an RSA public key MUST NOT assert the keyAgreement, encipherOnly or decipherOnly key usages",
+		Citation:      "RFC 3279: 2.3.1",
+		Source:        lint.RFC5280,
+		EffectiveDate: util.RFC3280Date,
+		Applicability: lint.Applicability{Extension: util.KeyUsageOID},
+		Lint:          &rsaKeyUsageKeyAgreement{},
+	})
+}
@@ -47,12 +47,19 @@ func (l *serialNumberTooLong) CheckApplies(c *x509.Certificate) bool {
 	return true
 }
 
+// Execute counts the octets of the DER encoding of the serial number, which
+// includes the leading 0x00 octet needed to keep a positive serial number whose
+// most significant bit is set from being interpreted as negative. A 160 bit
+// serial number with its high bit set is therefore 21 octets long.
 func (l *serialNumberTooLong) Execute(c *x509.Certificate) *lint.LintResult {
-	if c.SerialNumber.BitLen() > 160 { // 20 octets
+	serial, err := util.GetSerialNumberEncoded(c)
+	if err != nil {
+		return &lint.LintResult{Status: lint.Fatal, Details: err.Error()}
+	}
+	if len(serial) > 20 {
 		return &lint.LintResult{Status: lint.Error}
-	} else {
-		return &lint.LintResult{Status: lint.Pass}
 	}
+	return &lint.LintResult{Status: lint.Pass}
 }
 
 func init() {
@@ -0,0 +1,67 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package rfc
+
+/************************************************
+X.690 8.3.2
+If the contents octets of an integer value encoding consist of more than one
+octet, then the bits of the first octet and bit 8 of the second octet:
+  a) shall not all be ones; and
+  b) shall not all be zero.
+************************************************/
+
+import (
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type serialNumberNotMinimallyEncoded struct{}
+
+func (l *serialNumberNotMinimallyEncoded) Initialize() error {
+	return nil
+}
+
+func (l *serialNumberNotMinimallyEncoded) CheckApplies(c *x509.Certificate) bool {
+	return true
+}
+
+// Execute checks the serialNumber as it is encoded in the tbsCertificate for
+// superfluous leading 0x00 (or 0xFF) octets. The encoding/asn1 package used by
+// zcrypto refuses to parse such certificates, so this lint only fires for
+// certificates that were parsed by a more lenient parser.
+func (l *serialNumberNotMinimallyEncoded) Execute(c *x509.Certificate) *lint.LintResult {
+	serial, err := util.GetSerialNumberEncoded(c)
+	if err != nil {
+		return &lint.LintResult{Status: lint.Fatal, Details: err.Error()}
+	}
+	if len(serial) > 1 &&
+		((serial[0] == 0x00 && serial[1]&0x80 == 0) ||
+			(serial[0] == 0xff && serial[1]&0x80 == 0x80)) {
+		return &lint.LintResult{Status: lint.Error}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_serial_number_not_minimally_encoded",
+		Description:   "The serial number must be DER encoded using the minimum number of octets",
+		Citation:      "RFC 5280: 4.1, X.690: 8.3.2",
+		Source:        lint.RFC5280,
+		EffectiveDate: util.RFC3280Date,
+		Lint:          &serialNumberNotMinimallyEncoded{},
+	})
+}
@@ -0,0 +1,76 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package rfc
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/test"
+)
+
+func TestSerialNumberNotMinimallyEncoded(t *testing.T) {
+	testCases := []struct {
+		name     string
+		serial   []byte
+		expected lint.LintStatus
+	}{
+		{
+			name:     "minimal",
+			serial:   []byte{0x02, 0x08, 0x5a, 0x3f, 0x9c, 0x21, 0xe0, 0x7b, 0x44, 0xd1},
+			expected: lint.Pass,
+		},
+		{
+			name:     "sign octet",
+			serial:   []byte{0x02, 0x09, 0x00, 0x8a, 0x3f, 0x9c, 0x21, 0xe0, 0x7b, 0x44, 0xd1},
+			expected: lint.Pass,
+		},
+		{
+			name:     "superfluous zero octet",
+			serial:   []byte{0x02, 0x09, 0x00, 0x5a, 0x3f, 0x9c, 0x21, 0xe0, 0x7b, 0x44, 0xd1},
+			expected: lint.Error,
+		},
+		{
+			name:     "superfluous 0xff octet",
+			serial:   []byte{0x02, 0x09, 0xff, 0x8a, 0x3f, 0x9c, 0x21, 0xe0, 0x7b, 0x44, 0xd1},
+			expected: lint.Error,
+		},
+	}
+
+	// encoding/asn1 refuses to parse certificates with non-minimal integers, so
+	// splice each serial number into the tbsCertificate of a parsed certificate
+	// in place of its original serial number.
+	cert := test.ReadTestCert("serialNumberValid.pem")
+	original := append([]byte{0x02, byte(len(cert.SerialNumber.Bytes()))}, cert.SerialNumber.Bytes()...)
+	if cert.SerialNumber.Bytes()[0]&0x80 != 0 {
+		t.Fatalf("serialNumberValid.pem serial number needs a sign octet")
+	}
+	tbs := cert.RawTBSCertificate
+	i := bytes.Index(tbs, original)
+	if i < 0 {
+		t.Fatalf("serial number not found in tbsCertificate")
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body := append(append(append([]byte{}, tbs[4:i]...), tc.serial...), tbs[i+len(original):]...)
+			cert.RawTBSCertificate = append([]byte{0x30, 0x82, byte(len(body) >> 8), byte(len(body))}, body...)
+			if out := test.TestLintCert("e_serial_number_not_minimally_encoded", cert); out.Status != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, out.Status)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package rfc
+
+/************************************************
+4.1.2.2.  Serial Number
+   The serial number MUST be a positive integer assigned by the CA to each
+   certificate.
+************************************************/
+
+import (
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type serialNumberZero struct{}
+
+func (l *serialNumberZero) Initialize() error {
+	return nil
+}
+
+func (l *serialNumberZero) CheckApplies(c *x509.Certificate) bool {
+	return true
+}
+
+func (l *serialNumberZero) Execute(c *x509.Certificate) *lint.LintResult {
+	if c.SerialNumber.Sign() == 0 {
+		return &lint.LintResult{Status: lint.Error}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_serial_number_zero",
+		Description:   "Certificates must not have a serial number of zero",
+		Citation:      "RFC 5280: 4.1.2.2",
+		Source:        lint.RFC5280,
+		EffectiveDate: util.RFC3280Date,
+		Lint:          &serialNumberZero{},
+	})
+}
@@ -0,0 +1,35 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package rfc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/test"
+)
+
+func TestSerialNumberZero(t *testing.T) {
+	cert := test.ReadTestCert("serialNumberValid.pem")
+	if out := test.TestLintCert("e_serial_number_zero", cert); out.Status != lint.Pass {
+		t.Errorf("serialNumberValid.pem: expected %s, got %s", lint.Pass, out.Status)
+	}
+
+	cert.SerialNumber = big.NewInt(0)
+	if out := test.TestLintCert("e_serial_number_zero", cert); out.Status != lint.Error {
+		t.Errorf("zero serial: expected %s, got %s", lint.Error, out.Status)
+	}
+}
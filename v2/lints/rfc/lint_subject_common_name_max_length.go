@@ -22,6 +22,7 @@ RFC 5280: A.1
 ************************************************/
 
 import (
+	"fmt"
 	"unicode/utf8"
 
 	"github.com/zmap/zcrypto/x509"
@@ -40,11 +41,15 @@ func (l *subjectCommonNameMaxLength) CheckApplies(c *x509.Certificate) bool {
 }
 
 func (l *subjectCommonNameMaxLength) Execute(c *x509.Certificate) *lint.LintResult {
-	if utf8.RuneCountInString(c.Subject.CommonName) > 64 {
-		return &lint.LintResult{Status: lint.Error}
-	} else {
-		return &lint.LintResult{Status: lint.Pass}
+	for _, j := range c.Subject.CommonNames {
+		if length := utf8.RuneCountInString(j); length > 64 {
+			return &lint.LintResult{
+				Status:  lint.Error,
+				Details: fmt.Sprintf("subject commonName %q is %d characters long, exceeding the upper bound of 64", j, length),
+			}
+		}
 	}
+	return &lint.LintResult{Status: lint.Pass}
 }
 
 func init() {
@@ -0,0 +1,63 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package rfc
+
+/************************************************
+RFC 5280: A.1
+	X520countryName ::=     PrintableString (SIZE (2))
+	ub-country-name-alpha-length INTEGER ::= 2
+************************************************/
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type subjectCountryNameLength struct{}
+
+func (l *subjectCountryNameLength) Initialize() error {
+	return nil
+}
+
+func (l *subjectCountryNameLength) CheckApplies(c *x509.Certificate) bool {
+	return len(c.Subject.Country) > 0
+}
+
+func (l *subjectCountryNameLength) Execute(c *x509.Certificate) *lint.LintResult {
+	for _, j := range c.Subject.Country {
+		if length := utf8.RuneCountInString(j); length != 2 {
+			return &lint.LintResult{
+				Status:  lint.Error,
+				Details: fmt.Sprintf("subject countryName %q is %d characters long, but MUST be exactly 2", j, length),
+			}
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_subject_country_name_length",
+		Description:   "The 'Country Name' field of the subject MUST be exactly 2 characters",
+		Citation:      "RFC 5280: A.1",
+		Source:        lint.RFC5280,
+		EffectiveDate: util.RFC2459Date,
+		Lint:          &subjectCountryNameLength{},
+	})
+}
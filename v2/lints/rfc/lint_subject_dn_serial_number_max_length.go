@@ -15,6 +15,7 @@ package rfc
  */
 
 import (
+	"fmt"
 	"unicode/utf8"
 
 	"github.com/zmap/zcrypto/x509"
@@ -33,8 +34,13 @@ func (l *SubjectDNSerialNumberMaxLength) CheckApplies(c *x509.Certificate) bool
 }
 
 func (l *SubjectDNSerialNumberMaxLength) Execute(c *x509.Certificate) *lint.LintResult {
-	if utf8.RuneCountInString(c.Subject.SerialNumber) > 64 {
-		return &lint.LintResult{Status: lint.Error}
+	for _, j := range c.Subject.SerialNumbers {
+		if length := utf8.RuneCountInString(j); length > 64 {
+			return &lint.LintResult{
+				Status:  lint.Error,
+				Details: fmt.Sprintf("subject serialNumber %q is %d characters long, exceeding the upper bound of 64", j, length),
+			}
+		}
 	}
 	return &lint.LintResult{Status: lint.Pass}
 }
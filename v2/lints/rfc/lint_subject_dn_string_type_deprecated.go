@@ -0,0 +1,104 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package rfc
+
+/************************************************
+RFC 5280: 4.1.2.6
+The DirectoryString type is defined as a choice of PrintableString,
+TeletexString, BMPString, UTF8String, and UniversalString.  CAs
+conforming to this profile MUST use either the PrintableString or
+UTF8String encoding of DirectoryString, with two exceptions.
+************************************************/
+
+import (
+	"encoding/asn1"
+	"fmt"
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+// deprecatedStringTags maps the DirectoryString choices RFC 5280 only permits
+// for backwards compatibility to their names.
+var deprecatedStringTags = map[int]string{
+	asn1.TagT61String: "TeletexString",
+	asn1.TagBMPString: "BMPString",
+	28:                "UniversalString",
+}
+
+// deprecatedStringAttributes returns a description of each attribute in the
+// raw Name that uses TeletexString, BMPString or UniversalString.
+func deprecatedStringAttributes(rawName []byte) ([]string, error) {
+	var rdnSequence util.RawRDNSequence
+	rest, err := asn1.Unmarshal(rawName, &rdnSequence)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("trailing data after Name")
+	}
+	var found []string
+	for _, rdn := range rdnSequence {
+		for _, atv := range rdn {
+			if name, ok := deprecatedStringTags[atv.Value.Tag]; ok && atv.Value.Class == asn1.ClassUniversal {
+				found = append(found, fmt.Sprintf("%s (%s)", atv.Type, name))
+			}
+		}
+	}
+	return found, nil
+}
+
+type subjectDNStringTypeDeprecated struct{}
+
+func (l *subjectDNStringTypeDeprecated) Initialize() error {
+	return nil
+}
+
+func (l *subjectDNStringTypeDeprecated) CheckApplies(c *x509.Certificate) bool {
+	return len(c.RawSubject) > 0
+}
+
+// Execute returns a Warning rather than an Error since RFC 5280 permits the
+// deprecated types when reusing a name encoded that way in existing
+// certificates, which can't be determined from the certificate alone.
+func (l *subjectDNStringTypeDeprecated) Execute(c *x509.Certificate) *lint.LintResult {
+	found, err := deprecatedStringAttributes(c.RawSubject)
+	if err != nil {
+		return &lint.LintResult{
+			Status:  lint.Fatal,
+			Details: fmt.Sprintf("failed to parse subject: %v", err),
+		}
+	}
+	if len(found) > 0 {
+		return &lint.LintResult{
+			Status:  lint.Warn,
+			Details: fmt.Sprintf("subject attributes with deprecated string types: %s", strings.Join(found, ", ")),
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "w_subject_dn_string_type_deprecated",
+		Description:   "Subject attributes should be encoded as PrintableString or UTF8String, not TeletexString, BMPString or UniversalString",
+		Citation:      "RFC 5280: 4.1.2.6",
+		Source:        lint.RFC5280,
+		EffectiveDate: util.DirectoryStringUTF8Date,
+		Lint:          &subjectDNStringTypeDeprecated{},
+	})
+}
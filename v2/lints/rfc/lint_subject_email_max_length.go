@@ -29,6 +29,7 @@ ub-emailaddress-length INTEGER ::= 255
 ************************************************/
 
 import (
+	"fmt"
 	"unicode/utf8"
 
 	"github.com/zmap/zcrypto/x509"
@@ -48,8 +49,11 @@ func (l *subjectEmailMaxLength) CheckApplies(c *x509.Certificate) bool {
 
 func (l *subjectEmailMaxLength) Execute(c *x509.Certificate) *lint.LintResult {
 	for _, j := range c.Subject.EmailAddress {
-		if utf8.RuneCountInString(j) > 255 {
-			return &lint.LintResult{Status: lint.Error}
+		if length := utf8.RuneCountInString(j); length > 255 {
+			return &lint.LintResult{
+				Status:  lint.Error,
+				Details: fmt.Sprintf("subject emailAddress %q is %d characters long, exceeding the upper bound of 255", j, length),
+			}
 		}
 	}
 
@@ -49,6 +49,7 @@ func init() {
 		Citation:      "RFC 5280: 4.2.2.2",
 		Source:        lint.RFC5280,
 		EffectiveDate: util.RFC3280Date,
+		Applicability: lint.Applicability{Extension: util.SubjectInfoAccessOID},
 		Lint:          &siaCrit{},
 	})
 }
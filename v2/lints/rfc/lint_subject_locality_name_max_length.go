@@ -22,6 +22,7 @@ RFC 5280: A.1
 ************************************************/
 
 import (
+	"fmt"
 	"unicode/utf8"
 
 	"github.com/zmap/zcrypto/x509"
@@ -41,8 +42,11 @@ func (l *subjectLocalityNameMaxLength) CheckApplies(c *x509.Certificate) bool {
 
 func (l *subjectLocalityNameMaxLength) Execute(c *x509.Certificate) *lint.LintResult {
 	for _, j := range c.Subject.Locality {
-		if utf8.RuneCountInString(j) > 128 {
-			return &lint.LintResult{Status: lint.Error}
+		if length := utf8.RuneCountInString(j); length > 128 {
+			return &lint.LintResult{
+				Status:  lint.Error,
+				Details: fmt.Sprintf("subject localityName %q is %d characters long, exceeding the upper bound of 128", j, length),
+			}
 		}
 	}
 
@@ -22,6 +22,7 @@ RFC 5280: A.1
 ************************************************/
 
 import (
+	"fmt"
 	"unicode/utf8"
 
 	"github.com/zmap/zcrypto/x509"
@@ -41,8 +42,11 @@ func (l *subjectOrganizationNameMaxLength) CheckApplies(c *x509.Certificate) boo
 
 func (l *subjectOrganizationNameMaxLength) Execute(c *x509.Certificate) *lint.LintResult {
 	for _, j := range c.Subject.Organization {
-		if utf8.RuneCountInString(j) > 64 {
-			return &lint.LintResult{Status: lint.Error}
+		if length := utf8.RuneCountInString(j); length > 64 {
+			return &lint.LintResult{
+				Status:  lint.Error,
+				Details: fmt.Sprintf("subject organizationName %q is %d characters long, exceeding the upper bound of 64", j, length),
+			}
 		}
 	}
 
@@ -15,6 +15,7 @@ package rfc
  */
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/zmap/zlint/v2/lint"
@@ -38,3 +39,11 @@ func TestSubjectOrganzationNameLong(t *testing.T) {
 		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
 	}
 }
+
+func TestSubjectOrganizationNameLongDetails(t *testing.T) {
+	inputPath := "subjectOrganizationNameLong.pem"
+	out := test.TestLint("e_subject_organization_name_max_length", inputPath)
+	if !strings.Contains(out.Details, "exceeding the upper bound of 64") {
+		t.Errorf("%s: expected details to report the upper bound, got %q", inputPath, out.Details)
+	}
+}
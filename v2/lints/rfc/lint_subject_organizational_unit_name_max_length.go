@@ -22,6 +22,7 @@ RFC 5280: A.1
 ************************************************/
 
 import (
+	"fmt"
 	"unicode/utf8"
 
 	"github.com/zmap/zcrypto/x509"
@@ -41,8 +42,11 @@ func (l *subjectOrganizationalUnitNameMaxLength) CheckApplies(c *x509.Certificat
 
 func (l *subjectOrganizationalUnitNameMaxLength) Execute(c *x509.Certificate) *lint.LintResult {
 	for _, j := range c.Subject.OrganizationalUnit {
-		if utf8.RuneCountInString(j) > 64 {
-			return &lint.LintResult{Status: lint.Error}
+		if length := utf8.RuneCountInString(j); length > 64 {
+			return &lint.LintResult{
+				Status:  lint.Error,
+				Details: fmt.Sprintf("subject organizationalUnitName %q is %d characters long, exceeding the upper bound of 64", j, length),
+			}
 		}
 	}
 
@@ -23,6 +23,7 @@ RFC 5280: A.1
 ************************************************/
 
 import (
+	"fmt"
 	"unicode/utf8"
 
 	"github.com/zmap/zcrypto/x509"
@@ -42,8 +43,11 @@ func (l *subjectPostalCodeMaxLength) CheckApplies(c *x509.Certificate) bool {
 
 func (l *subjectPostalCodeMaxLength) Execute(c *x509.Certificate) *lint.LintResult {
 	for _, j := range c.Subject.PostalCode {
-		if utf8.RuneCountInString(j) > 16 {
-			return &lint.LintResult{Status: lint.Error}
+		if length := utf8.RuneCountInString(j); length > 16 {
+			return &lint.LintResult{
+				Status:  lint.Error,
+				Details: fmt.Sprintf("subject postalCode %q is %d characters long, exceeding the upper bound of 16", j, length),
+			}
 		}
 	}
 
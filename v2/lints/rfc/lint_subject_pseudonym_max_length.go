@@ -0,0 +1,64 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package rfc
+
+/************************************************
+RFC 5280: A.1
+	* In this Appendix, there is a list of upperbounds
+	for fields in a x509 Certificate. *
+	ub-pseudonym INTEGER ::= 128
+************************************************/
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
+)
+
+type subjectPseudonymMaxLength struct{}
+
+func (l *subjectPseudonymMaxLength) Initialize() error {
+	return nil
+}
+
+func (l *subjectPseudonymMaxLength) CheckApplies(c *x509.Certificate) bool {
+	return util.TypeInName(&c.Subject, util.PseudonymOID)
+}
+
+func (l *subjectPseudonymMaxLength) Execute(c *x509.Certificate) *lint.LintResult {
+	for _, j := range util.GetNameAttributeValues(&c.Subject, util.PseudonymOID) {
+		if length := utf8.RuneCountInString(j); length > 128 {
+			return &lint.LintResult{
+				Status:  lint.Error,
+				Details: fmt.Sprintf("subject pseudonym %q is %d characters long, exceeding the upper bound of 128", j, length),
+			}
+		}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_subject_pseudonym_max_length",
+		Description:   "The 'Pseudonym' field of the subject MUST be less than 129 characters",
+		Citation:      "RFC 5280: A.1",
+		Source:        lint.RFC5280,
+		EffectiveDate: util.RFC5280Date,
+		Lint:          &subjectPseudonymMaxLength{},
+	})
+}
@@ -21,6 +21,7 @@ ub-street-address INTEGER ::= 128
 ************************************************/
 
 import (
+	"fmt"
 	"unicode/utf8"
 
 	"github.com/zmap/zcrypto/x509"
@@ -40,8 +41,11 @@ func (l *subjectStreetAddressMaxLength) CheckApplies(c *x509.Certificate) bool {
 
 func (l *subjectStreetAddressMaxLength) Execute(c *x509.Certificate) *lint.LintResult {
 	for _, j := range c.Subject.StreetAddress {
-		if utf8.RuneCountInString(j) > 128 {
-			return &lint.LintResult{Status: lint.Error}
+		if length := utf8.RuneCountInString(j); length > 128 {
+			return &lint.LintResult{
+				Status:  lint.Error,
+				Details: fmt.Sprintf("subject streetAddress %q is %d characters long, exceeding the upper bound of 128", j, length),
+			}
 		}
 	}
 
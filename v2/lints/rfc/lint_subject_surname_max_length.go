@@ -23,6 +23,7 @@ RFC 5280: A.1
 ************************************************/
 
 import (
+	"fmt"
 	"unicode/utf8"
 
 	"github.com/zmap/zcrypto/x509"
@@ -42,8 +43,11 @@ func (l *subjectSurnameMaxLength) CheckApplies(c *x509.Certificate) bool {
 
 func (l *subjectSurnameMaxLength) Execute(c *x509.Certificate) *lint.LintResult {
 	for _, j := range c.Subject.Surname {
-		if utf8.RuneCountInString(j) > 40 {
-			return &lint.LintResult{Status: lint.Error}
+		if length := utf8.RuneCountInString(j); length > 40 {
+			return &lint.LintResult{
+				Status:  lint.Error,
+				Details: fmt.Sprintf("subject surname %q is %d characters long, exceeding the upper bound of 40", j, length),
+			}
 		}
 	}
 
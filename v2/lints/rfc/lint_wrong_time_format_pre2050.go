@@ -24,6 +24,7 @@ are encoded in either UTCTime or GeneralizedTime.
 
 import (
 	"encoding/asn1"
+	"fmt"
 	"time"
 
 	"github.com/zmap/zcrypto/x509"
@@ -43,23 +44,18 @@ func (l *generalizedPre2050) CheckApplies(c *x509.Certificate) bool {
 
 func (l *generalizedPre2050) Execute(c *x509.Certificate) *lint.LintResult {
 	date1, date2 := util.GetTimes(c)
-	var t time.Time
-	type1, type2 := util.FindTimeType(date1, date2)
-	if type1 == 24 {
-		temp, err := asn1.Marshal(date1)
-		if err != nil {
-			return &lint.LintResult{Status: lint.Fatal}
+	for _, field := range []struct {
+		name string
+		date asn1.RawValue
+	}{
+		{"notBefore", date1},
+		{"notAfter", date2},
+	} {
+		if field.date.Tag != 24 {
+			continue
 		}
-		_, err = asn1.Unmarshal(temp, &t)
-		if err != nil {
-			return &lint.LintResult{Status: lint.Fatal}
-		}
-		if t.Before(util.GeneralizedDate) {
-			return &lint.LintResult{Status: lint.Error}
-		}
-	}
-	if type2 == 24 {
-		temp, err := asn1.Marshal(date2)
+		var t time.Time
+		temp, err := asn1.Marshal(field.date)
 		if err != nil {
 			return &lint.LintResult{Status: lint.Fatal}
 		}
@@ -68,7 +64,10 @@ func (l *generalizedPre2050) Execute(c *x509.Certificate) *lint.LintResult {
 			return &lint.LintResult{Status: lint.Fatal}
 		}
 		if t.Before(util.GeneralizedDate) {
-			return &lint.LintResult{Status: lint.Error}
+			return &lint.LintResult{
+				Status:  lint.Error,
+				Details: fmt.Sprintf("%s %s is encoded as GeneralizedTime but is before 2050", field.name, t.UTC().Format(time.RFC3339)),
+			}
 		}
 	}
 	return &lint.LintResult{Status: lint.Pass}
@@ -15,6 +15,7 @@ package rfc
  */
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/zmap/zlint/v2/lint"
@@ -47,3 +48,11 @@ func TestGeneralizedPrior2050(t *testing.T) {
 		t.Errorf("%s: expected %s, got %s", inputPath, expected, out.Status)
 	}
 }
+
+func TestGeneralizedPrior2050Details(t *testing.T) {
+	inputPath := "generalizedPrior2050.pem"
+	out := test.TestLint("e_wrong_time_format_pre2050", inputPath)
+	if !strings.HasPrefix(out.Details, "notBefore ") && !strings.HasPrefix(out.Details, "notAfter ") {
+		t.Errorf("%s: expected details to name the validity field, got %q", inputPath, out.Details)
+	}
+}
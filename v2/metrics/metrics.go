@@ -0,0 +1,143 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+// Package metrics counts linted certificates and their results, and exposes
+// the counts over HTTP in the Prometheus text exposition format for servers
+// and long running zlint processes.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zmap/zlint/v2"
+	"github.com/zmap/zlint/v2/lint"
+)
+
+// DurationBuckets are the upper bounds in seconds of the histogram of the
+// time taken to lint a certificate.
+var DurationBuckets = []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Metrics holds the counters for the certificates linted by a process. It is
+// safe for concurrent use and implements http.Handler, serving the counters
+// in the Prometheus text format.
+type Metrics struct {
+	mu           sync.Mutex
+	certificates uint64
+	failures     uint64
+	statuses     map[lint.LintStatus]uint64
+	lintErrors   map[string]uint64
+	buckets      []uint64
+	durationSum  float64
+}
+
+// New returns a Metrics with all counters at zero.
+func New() *Metrics {
+	return &Metrics{
+		statuses:   make(map[lint.LintStatus]uint64),
+		lintErrors: make(map[string]uint64),
+		buckets:    make([]uint64, len(DurationBuckets)),
+	}
+}
+
+// Observe records a certificate linted in d with the results in rs.
+func (m *Metrics) Observe(rs *zlint.ResultSet, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.certificates++
+	for name, result := range rs.Results {
+		m.statuses[result.Status]++
+		if result.Status == lint.Error || result.Status == lint.Fatal {
+			m.lintErrors[name]++
+		}
+	}
+	seconds := d.Seconds()
+	m.durationSum += seconds
+	for i, le := range DurationBuckets {
+		if seconds <= le {
+			m.buckets[i]++
+		}
+	}
+}
+
+// ObserveFailure records a certificate that couldn't be linted, e.g. because
+// it couldn't be parsed.
+func (m *Metrics) ObserveFailure() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.failures++
+}
+
+// ServeHTTP writes the counters in the Prometheus text format.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = m.Write(w)
+}
+
+// Write writes the counters to w in the Prometheus text format.
+func (m *Metrics) Write(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var b strings.Builder
+	writeHeader(&b, "zlint_certificates_total", "counter", "Certificates linted.")
+	fmt.Fprintf(&b, "zlint_certificates_total %d\n", m.certificates)
+	writeHeader(&b, "zlint_certificate_failures_total", "counter", "Certificates that couldn't be linted, e.g. because they couldn't be parsed.")
+	fmt.Fprintf(&b, "zlint_certificate_failures_total %d\n", m.failures)
+
+	writeHeader(&b, "zlint_results_total", "counter", "Lint results by status.")
+	statuses := make([]lint.LintStatus, 0, len(m.statuses))
+	for status := range m.statuses {
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i] < statuses[j] })
+	for _, status := range statuses {
+		fmt.Fprintf(&b, "zlint_results_total{status=\"%s\"} %d\n", escapeLabel(status.String()), m.statuses[status])
+	}
+
+	writeHeader(&b, "zlint_lint_errors_total", "counter", "Error and fatal results by lint.")
+	names := make([]string, 0, len(m.lintErrors))
+	for name := range m.lintErrors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(&b, "zlint_lint_errors_total{lint=\"%s\"} %d\n", escapeLabel(name), m.lintErrors[name])
+	}
+
+	writeHeader(&b, "zlint_certificate_duration_seconds", "histogram", "Time taken to lint a certificate.")
+	for i, le := range DurationBuckets {
+		fmt.Fprintf(&b, "zlint_certificate_duration_seconds_bucket{le=\"%s\"} %d\n", strconv.FormatFloat(le, 'g', -1, 64), m.buckets[i])
+	}
+	fmt.Fprintf(&b, "zlint_certificate_duration_seconds_bucket{le=\"+Inf\"} %d\n", m.certificates)
+	fmt.Fprintf(&b, "zlint_certificate_duration_seconds_sum %s\n", strconv.FormatFloat(m.durationSum, 'g', -1, 64))
+	fmt.Fprintf(&b, "zlint_certificate_duration_seconds_count %d\n", m.certificates)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeHeader(b *strings.Builder, name, typ, help string) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+// escapeLabel escapes a label value as required by the text format.
+func escapeLabel(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
@@ -0,0 +1,75 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package metrics
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zmap/zlint/v2"
+	"github.com/zmap/zlint/v2/lint"
+)
+
+func TestMetrics(t *testing.T) {
+	m := New()
+	m.Observe(&zlint.ResultSet{Results: map[string]*lint.LintResult{
+		"e_a": {Status: lint.Error},
+		"e_b": {Status: lint.Pass},
+		"w_c": {Status: lint.Warn},
+	}}, 3*time.Millisecond)
+	m.Observe(&zlint.ResultSet{Results: map[string]*lint.LintResult{
+		"e_a": {Status: lint.Fatal},
+		"e_b": {Status: lint.Pass},
+		"w_c": {Status: lint.NA},
+	}}, 2*time.Second)
+	m.ObserveFailure()
+
+	var b bytes.Buffer
+	if err := m.Write(&b); err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+	for _, expected := range []string{
+		"# TYPE zlint_certificates_total counter\nzlint_certificates_total 2\n",
+		"zlint_certificate_failures_total 1\n",
+		`zlint_results_total{status="NA"} 1` + "\n" +
+			`zlint_results_total{status="pass"} 2` + "\n" +
+			`zlint_results_total{status="warn"} 1` + "\n" +
+			`zlint_results_total{status="error"} 1` + "\n" +
+			`zlint_results_total{status="fatal"} 1` + "\n",
+		`zlint_lint_errors_total{lint="e_a"} 2` + "\n",
+		`zlint_certificate_duration_seconds_bucket{le="0.0025"} 0` + "\n",
+		`zlint_certificate_duration_seconds_bucket{le="0.005"} 1` + "\n",
+		`zlint_certificate_duration_seconds_bucket{le="2.5"} 2` + "\n",
+		`zlint_certificate_duration_seconds_bucket{le="+Inf"} 2` + "\n",
+		"zlint_certificate_duration_seconds_sum 2.003\n",
+		"zlint_certificate_duration_seconds_count 2\n",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected output to contain %q, got:\n%s", expected, out)
+		}
+	}
+	if strings.Contains(out, `lint="e_b"`) || strings.Contains(out, `lint="w_c"`) {
+		t.Errorf("expected only lints with errors to be counted, got:\n%s", out)
+	}
+}
+
+func TestEscapeLabel(t *testing.T) {
+	if got := escapeLabel("a\\b\"c\nd"); got != `a\\b\"c\nd` {
+		t.Errorf("unexpected escaping %q", got)
+	}
+}
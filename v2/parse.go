@@ -0,0 +1,113 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package zlint
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+)
+
+// The certificate encodings accepted by ParseCertificate and LintBytes.
+const (
+	FormatPEM    = "pem"
+	FormatDER    = "der"
+	FormatBase64 = "base64"
+)
+
+// The classes of error returned for certificates that can't be parsed. The
+// errors returned by ParseCertificate, LintBytes and LintStdCertificate wrap
+// one of these with details of the failure, so callers can check the class
+// with errors.Is.
+var (
+	// ErrUnsupportedFormat is returned for a format other than FormatPEM,
+	// FormatDER or FormatBase64.
+	ErrUnsupportedFormat = errors.New("unknown input format")
+	// ErrPEMDecode is returned if the input doesn't start with a PEM
+	// CERTIFICATE block.
+	ErrPEMDecode = errors.New("unable to parse PEM")
+	// ErrBase64Decode is returned if base64 input can't be decoded.
+	ErrBase64Decode = errors.New("unable to parse base64")
+	// ErrNotACertificate is returned if the decoded input isn't a DER
+	// certificate that can be parsed.
+	ErrNotACertificate = errors.New("unable to parse certificate")
+)
+
+// DetectFormat guesses the encoding of the certificate in data, returning one
+// of FormatPEM, FormatDER or FormatBase64.
+func DetectFormat(data []byte) string {
+	switch trimmed := bytes.TrimSpace(data); {
+	case bytes.HasPrefix(trimmed, []byte("-----BEGIN")) || bytes.Contains(trimmed, []byte("\n-----BEGIN")):
+		return FormatPEM
+	case len(data) > 0 && data[0] == 0x30:
+		return FormatDER
+	}
+	return FormatBase64
+}
+
+// ParseCertificate parses the certificate encoded in data in the given format.
+// For FormatPEM the first PEM block must be a CERTIFICATE; any text before it
+// is ignored. If format is empty it is guessed with DetectFormat.
+func ParseCertificate(data []byte, format string) (*x509.Certificate, error) {
+	if format == "" {
+		format = DetectFormat(data)
+	}
+	var asn1Data []byte
+	var err error
+	switch format {
+	case FormatPEM:
+		p, _ := pem.Decode(data)
+		if p == nil || p.Type != "CERTIFICATE" {
+			return nil, ErrPEMDecode
+		}
+		asn1Data = p.Bytes
+	case FormatDER:
+		asn1Data = data
+	case FormatBase64:
+		asn1Data, err = base64.StdEncoding.DecodeString(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrBase64Decode, err)
+		}
+	default:
+		return nil, fmt.Errorf("%w %s", ErrUnsupportedFormat, format)
+	}
+
+	c, err := x509.ParseCertificate(asn1Data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrNotACertificate, err)
+	}
+	return c, nil
+}
+
+// LintBytes parses the certificate encoded in data in the given format, as
+// ParseCertificate does, and runs lints from the provided registry on it. If
+// registry is nil then the global registry of all lints is used. The remaining
+// defaults can be changed with opts, as for LintCertificateEx.
+//
+// Unlike the zlint command, LintBytes never exits the program; it returns an
+// error if the certificate can't be parsed, so it is safe to call with
+// untrusted input from a server.
+func LintBytes(data []byte, format string, registry lint.Registry, opts ...Option) (*ResultSet, error) {
+	c, err := ParseCertificate(data, format)
+	if err != nil {
+		return nil, err
+	}
+	return LintCertificateEx(c, registry, opts...), nil
+}
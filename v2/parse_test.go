@@ -0,0 +1,84 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package zlint
+
+import (
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/zmap/zlint/v2/lint"
+)
+
+func TestLintBytes(t *testing.T) {
+	pemBytes, err := ioutil.ReadFile("testdata/badRsaExp.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(pemBytes)
+	der := block.Bytes
+	b64 := []byte(base64.StdEncoding.EncodeToString(der))
+
+	registry, err := lint.GlobalRegistry().Filter(lint.FilterOptions{IncludeNames: []string{"e_rsa_public_exponent_not_odd"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		name    string
+		data    []byte
+		format  string
+		wantErr error
+	}{
+		{"pem", pemBytes, FormatPEM, nil},
+		{"der", der, FormatDER, nil},
+		{"base64", b64, FormatBase64, nil},
+		{"detected pem", pemBytes, "", nil},
+		{"detected der", der, "", nil},
+		{"detected base64", b64, "", nil},
+		{"der as pem", der, FormatPEM, ErrPEMDecode},
+		{"bad base64", []byte("!!!"), FormatBase64, ErrBase64Decode},
+		{"truncated der", der[:len(der)/2], FormatDER, ErrNotACertificate},
+		{"unknown format", der, "p12", ErrUnsupportedFormat},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rs, err := LintBytes(tc.data, tc.format, registry)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("expected %v, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result := rs.Results["e_rsa_public_exponent_not_odd"]; result == nil || result.Status != lint.Error {
+				t.Errorf("expected an error result, got %v", result)
+			}
+		})
+	}
+
+	reference := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	rs, err := LintBytes(pemBytes, FormatPEM, registry, WithReferenceTime(reference))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rs.Timestamp != reference.Unix() {
+		t.Errorf("expected timestamp %d, got %d", reference.Unix(), rs.Timestamp)
+	}
+}
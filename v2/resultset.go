@@ -15,8 +15,13 @@
 package zlint
 
 import (
+	"context"
+	"fmt"
+	"sync"
+
 	"github.com/zmap/zcrypto/x509"
 	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/util"
 )
 
 // ResultSet contains the output of running all lints in a registry against
@@ -31,17 +36,134 @@ type ResultSet struct {
 	FatalsPresent   bool                        `json:"fatals_present"`
 }
 
+// resultSlicePool holds the slices used to collect results from concurrently
+// executed lints, so that linting many certificates doesn't allocate a new
+// one for each.
+var resultSlicePool = sync.Pool{
+	New: func() interface{} { return new([]*lint.LintResult) },
+}
+
+// lintPlan is the lints of a registry resolved ahead of time, so that the
+// registry only needs to be consulted once when linting many certificates.
+type lintPlan struct {
+	groups []plannedGroup
+	size   int
+}
+
+// plannedGroup is an applicability group together with its lints.
+type plannedGroup struct {
+	*lint.ApplicabilityGroup
+	lints []*lint.Lint
+	// skipped are the names of the group's lints that won't be run.
+	skipped []string
+}
+
+// newLintPlan resolves the lints in registry. If fast is true lints tagged
+// lint.TagExpensive are planned to be skipped.
+func newLintPlan(registry lint.Registry, fast bool) *lintPlan {
+	plan := &lintPlan{size: len(registry.Names())}
+	for _, group := range registry.ApplicabilityGroups() {
+		planned := plannedGroup{ApplicabilityGroup: group}
+		for _, name := range group.Names {
+			l := registry.ByName(name)
+			if fast && l.HasTag(lint.TagExpensive) {
+				planned.skipped = append(planned.skipped, name)
+				continue
+			}
+			planned.lints = append(planned.lints, l)
+		}
+		plan.groups = append(plan.groups, planned)
+	}
+	return plan
+}
+
 // Execute lints the given certificate with all of the lints in the provided
-// registry. The ResultSet is mutated to trace the lint results obtained from
-// linting the certificate.
-func (z *ResultSet) execute(cert *x509.Certificate, registry lint.Registry) {
-	z.Results = make(map[string]*lint.LintResult, len(registry.Names()))
-	// Run each lints from the registry.
-	for _, name := range registry.Names() {
-		res := registry.ByName(name).Execute(cert)
-		z.Results[name] = res
-		z.updateErrorStatePresent(res)
+// plan, running up to concurrency lints at a time. The ResultSet is mutated
+// to trace the lint results obtained from linting the certificate.
+//
+// Lints in an applicability group that doesn't match the certificate are NA
+// without being run, so most of the registry can usually be skipped with a
+// few cheap checks. Lints the plan skips are reported as Skipped, and lints
+// not started before ctx (if not nil) is done are reported as Canceled,
+// unless their applicability group doesn't match the certificate. Lints
+// implementing lint.ConfigurableLint are run with config.
+func (z *ResultSet) execute(ctx context.Context, cert *x509.Certificate, plan *lintPlan, concurrency int, config *lint.Config) {
+	z.Results = make(map[string]*lint.LintResult, plan.size)
+	defer util.CacheCertificate(cert)()
+	var run []*lint.Lint
+	for _, group := range plan.groups {
+		if !group.Matches(cert) {
+			for _, name := range group.Names {
+				z.Results[name] = &lint.LintResult{Status: lint.NA}
+			}
+			continue
+		}
+		for _, name := range group.skipped {
+			z.Results[name] = &lint.LintResult{Status: lint.Skipped}
+		}
+		if concurrency < 2 {
+			for _, l := range group.lints {
+				res := executeLint(ctx, l, cert, config)
+				z.Results[l.Name] = res
+				z.updateErrorStatePresent(res)
+			}
+			continue
+		}
+		run = append(run, group.lints...)
+	}
+	if len(run) == 0 {
+		return
+	}
+
+	// Each result is written to its own index so the workers don't need to
+	// synchronize beyond handing out the lints.
+	pooled := resultSlicePool.Get().(*[]*lint.LintResult)
+	if cap(*pooled) < len(run) {
+		*pooled = make([]*lint.LintResult, len(run))
+	}
+	results := (*pooled)[:len(run)]
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency && w < len(run); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = executeLint(ctx, run[i], cert, config)
+			}
+		}()
+	}
+	for i := range run {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	for i, l := range run {
+		z.Results[l.Name] = results[i]
+		z.updateErrorStatePresent(results[i])
+		// Don't keep the result alive through the pool.
+		results[i] = nil
 	}
+	resultSlicePool.Put(pooled)
+}
+
+// executeLint runs l on cert with config, unless ctx is done. A lint that
+// panics, for example on a certificate malformed in a way it didn't
+// anticipate, gives a Fatal result rather than taking down the caller.
+func executeLint(ctx context.Context, l *lint.Lint, cert *x509.Certificate, config *lint.Config) (result *lint.LintResult) {
+	if ctx != nil && ctx.Err() != nil {
+		return &lint.LintResult{Status: lint.Canceled}
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			result = &lint.LintResult{
+				Status:  lint.Fatal,
+				Details: fmt.Sprintf("lint panicked: %v", r),
+			}
+		}
+	}()
+	return l.ExecuteWithConfig(cert, config)
 }
 
 func (z *ResultSet) updateErrorStatePresent(result *lint.LintResult) {
@@ -56,3 +178,53 @@ func (z *ResultSet) updateErrorStatePresent(result *lint.LintResult) {
 		z.FatalsPresent = true
 	}
 }
+
+// MaxStatus returns the most severe status in z, ignoring lints that were
+// skipped. It returns lint.Reserved if z has no results.
+func (z *ResultSet) MaxStatus() lint.LintStatus {
+	max := lint.Reserved
+	for _, result := range z.Results {
+		if result.Status > max && result.Status <= lint.Fatal {
+			max = result.Status
+		}
+	}
+	return max
+}
+
+// HasStatus returns true if any lint in z has the given status. For the
+// Notice, Warn, Error and Fatal statuses it is equivalent to the
+// NoticesPresent, WarningsPresent, ErrorsPresent and FatalsPresent fields.
+func (z *ResultSet) HasStatus(status lint.LintStatus) bool {
+	for _, result := range z.Results {
+		if result.Status == status {
+			return true
+		}
+	}
+	return false
+}
+
+// ResultsByStatus returns the results in z with the given status, keyed by
+// lint name. It returns nil if there are none.
+func (z *ResultSet) ResultsByStatus(status lint.LintStatus) map[string]*lint.LintResult {
+	return z.filter(func(s lint.LintStatus) bool { return s == status })
+}
+
+// Failing returns the results in z at least as severe as threshold, e.g.
+// lint.Warn for warnings, errors and fatals, keyed by lint name. Skipped and
+// canceled lints are never included. It returns nil if there are none.
+func (z *ResultSet) Failing(threshold lint.LintStatus) map[string]*lint.LintResult {
+	return z.filter(func(s lint.LintStatus) bool { return s >= threshold && s <= lint.Fatal })
+}
+
+func (z *ResultSet) filter(match func(lint.LintStatus) bool) map[string]*lint.LintResult {
+	var results map[string]*lint.LintResult
+	for name, result := range z.Results {
+		if match(result.Status) {
+			if results == nil {
+				results = make(map[string]*lint.LintResult)
+			}
+			results[name] = result
+		}
+	}
+	return results
+}
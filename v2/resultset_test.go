@@ -0,0 +1,82 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package zlint
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2/lint"
+)
+
+func TestResultSetQueries(t *testing.T) {
+	pass := &lint.LintResult{Status: lint.Pass}
+	notice := &lint.LintResult{Status: lint.Notice}
+	warn := &lint.LintResult{Status: lint.Warn}
+	otherWarn := &lint.LintResult{Status: lint.Warn}
+	skipped := &lint.LintResult{Status: lint.Skipped}
+	rs := &ResultSet{Results: map[string]*lint.LintResult{
+		"a": pass,
+		"b": notice,
+		"c": warn,
+		"d": otherWarn,
+		"e": skipped,
+	}}
+
+	if max := rs.MaxStatus(); max != lint.Warn {
+		t.Errorf("expected max status warn, got %s", max)
+	}
+	if !rs.HasStatus(lint.Warn) || rs.HasStatus(lint.Error) {
+		t.Error("unexpected HasStatus results")
+	}
+	if byStatus := rs.ResultsByStatus(lint.Warn); !reflect.DeepEqual(byStatus, map[string]*lint.LintResult{"c": warn, "d": otherWarn}) {
+		t.Errorf("unexpected warn results %v", byStatus)
+	}
+	if byStatus := rs.ResultsByStatus(lint.Fatal); byStatus != nil {
+		t.Errorf("expected no fatal results, got %v", byStatus)
+	}
+	if failing := rs.Failing(lint.Notice); !reflect.DeepEqual(failing, map[string]*lint.LintResult{"b": notice, "c": warn, "d": otherWarn}) {
+		t.Errorf("unexpected failing results %v", failing)
+	}
+	if failing := rs.Failing(lint.Error); failing != nil {
+		t.Errorf("expected no failing results, got %v", failing)
+	}
+
+	if max := new(ResultSet).MaxStatus(); max != lint.Reserved {
+		t.Errorf("expected an empty result set's max status to be reserved, got %s", max)
+	}
+}
+
+type panickingLint struct{}
+
+func (l *panickingLint) Initialize() error                     { return nil }
+func (l *panickingLint) CheckApplies(c *x509.Certificate) bool { return true }
+func (l *panickingLint) Execute(c *x509.Certificate) *lint.LintResult {
+	panic("unexpected certificate")
+}
+
+func TestExecuteLintRecoversPanic(t *testing.T) {
+	l := &lint.Lint{Name: "e_panics", Source: lint.ZLint, Lint: &panickingLint{}}
+	result := executeLint(context.Background(), l, &x509.Certificate{}, nil)
+	if result.Status != lint.Fatal {
+		t.Errorf("expected a panicking lint to give %s, got %s", lint.Fatal, result.Status)
+	}
+	if !strings.Contains(result.Details, "panicked") {
+		t.Errorf("expected details describing the panic, got %q", result.Details)
+	}
+}
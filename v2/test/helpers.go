@@ -44,6 +44,25 @@ func TestLint(lintName string, testCertFilename string) *lint.LintResult {
 // Important: TestLintCert is only appropriate for unit tests. It will panic if
 // the lintName is not known or if the lint result is nil.
 func TestLintCert(lintName string, cert *x509.Certificate) *lint.LintResult {
+	return TestLintCertWithConfig(lintName, cert, nil)
+}
+
+// TestLintWithConfig is like TestLint but runs the lint with the given
+// lint.Config, for lints whose result depends on the settings of the lint run.
+//
+// Important: TestLintWithConfig is only appropriate for unit tests. It will
+// panic if the lintName is not known or if the testCertFilename can not be
+// loaded, or if the lint result is nil.
+func TestLintWithConfig(lintName string, testCertFilename string, config *lint.Config) *lint.LintResult {
+	return TestLintCertWithConfig(lintName, ReadTestCert(testCertFilename), config)
+}
+
+// TestLintCertWithConfig is like TestLintCert but runs the lint with the given
+// lint.Config.
+//
+// Important: TestLintCertWithConfig is only appropriate for unit tests. It will
+// panic if the lintName is not known or if the lint result is nil.
+func TestLintCertWithConfig(lintName string, cert *x509.Certificate, config *lint.Config) *lint.LintResult {
 	l := lint.GlobalRegistry().ByName(lintName)
 	if l == nil {
 		panic(fmt.Sprintf(
@@ -52,7 +71,7 @@ func TestLintCert(lintName string, cert *x509.Certificate) *lint.LintResult {
 			lintName))
 	}
 
-	res := l.Execute(cert)
+	res := l.ExecuteWithConfig(cert, config)
 	// We never expect a lint to return a nil LintResult
 	if res == nil {
 		panic(fmt.Sprintf(
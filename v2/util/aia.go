@@ -0,0 +1,62 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"encoding/asn1"
+	"errors"
+
+	"github.com/zmap/zcrypto/x509"
+)
+
+var (
+	OidAccessMethodOCSP      = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 48, 1}
+	OidAccessMethodCAIssuers = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 48, 2}
+)
+
+// AccessDescription is the ASN.1 structure of the same name. See RFC 5280
+// Section 4.2.2.1.
+type AccessDescription struct {
+	AccessMethod   asn1.ObjectIdentifier
+	AccessLocation asn1.RawValue
+}
+
+// URI returns the accessLocation if it is a uniformResourceIdentifier.
+func (ad *AccessDescription) URI() (string, bool) {
+	if ad.AccessLocation.Class != asn1.ClassContextSpecific || ad.AccessLocation.Tag != 6 {
+		return "", false
+	}
+	return string(ad.AccessLocation.Bytes), true
+}
+
+// GetAccessDescriptions returns the access descriptions of the certificate's
+// authorityInformationAccess extension, in order, including those with
+// access methods or location types that the x509 package ignores. It returns
+// nil if the extension isn't present.
+func GetAccessDescriptions(c *x509.Certificate) ([]AccessDescription, error) {
+	ext := GetExtFromCert(c, AiaOID)
+	if ext == nil {
+		return nil, nil
+	}
+	var ads []AccessDescription
+	rest, err := asn1.Unmarshal(ext.Value, &ads)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, errors.New("trailing data after authorityInformationAccess")
+	}
+	return ads, nil
+}
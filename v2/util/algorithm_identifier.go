@@ -111,6 +111,14 @@ func CheckAlgorithmIDParamNotNULL(algorithmIdentifier []byte, requiredAlgoID asn
 //                             -- If present, version MUST be v3
 //        }
 func GetSignatureAlgorithmInTBSEncoded(c *x509.Certificate) ([]byte, error) {
+	result := cached(c, "tbs-signature-algorithm", func() interface{} {
+		encoded, err := getSignatureAlgorithmInTBSEncoded(c)
+		return encodedResult{encoded, err}
+	}).(encodedResult)
+	return result.encoded, result.err
+}
+
+func getSignatureAlgorithmInTBSEncoded(c *x509.Certificate) ([]byte, error) {
 	input := cryptobyte.String(c.RawTBSCertificate)
 
 	var tbsCert cryptobyte.String
@@ -136,6 +144,35 @@ func GetSignatureAlgorithmInTBSEncoded(c *x509.Certificate) ([]byte, error) {
 	return signatureAlgoID, nil
 }
 
+// Returns the signatureAlgorithm field of the Certificate in a DER encoded form or an error
+// if the signatureAlgorithm field could not be extracted. The encoded form contains the tag and the length.
+//
+//    Certificate  ::=  SEQUENCE  {
+//        tbsCertificate       TBSCertificate,
+//        signatureAlgorithm   AlgorithmIdentifier,
+//        signatureValue       BIT STRING  }
+func GetSignatureAlgorithmEncoded(c *x509.Certificate) ([]byte, error) {
+	input := cryptobyte.String(c.Raw)
+
+	var cert cryptobyte.String
+	if !input.ReadASN1(&cert, cryptobyte_asn1.SEQUENCE) {
+		return nil, errors.New("error reading certificate")
+	}
+
+	if !cert.SkipASN1(cryptobyte_asn1.SEQUENCE) {
+		return nil, errors.New("error reading tbsCertificate")
+	}
+
+	var signatureAlgoID cryptobyte.String
+	var tag cryptobyte_asn1.Tag
+	// use ReadAnyElement to preserve tag and length octets
+	if !cert.ReadAnyASN1Element(&signatureAlgoID, &tag) {
+		return nil, errors.New("error reading signatureAlgorithm")
+	}
+
+	return signatureAlgoID, nil
+}
+
 // Returns the algorithm field of the SubjectPublicKeyInfo of the certificate or an error
 // if the algorithm field could not be extracted.
 //
@@ -172,6 +209,14 @@ func GetPublicKeyOID(c *x509.Certificate) (asn1.ObjectIdentifier, error) {
 //        subjectPublicKey     BIT STRING  }
 //
 func GetPublicKeyAidEncoded(c *x509.Certificate) ([]byte, error) {
+	result := cached(c, "public-key-algorithm", func() interface{} {
+		encoded, err := getPublicKeyAidEncoded(c)
+		return encodedResult{encoded, err}
+	}).(encodedResult)
+	return result.encoded, result.err
+}
+
+func getPublicKeyAidEncoded(c *x509.Certificate) ([]byte, error) {
 	input := cryptobyte.String(c.RawSubjectPublicKeyInfo)
 	var spkiContent cryptobyte.String
 
@@ -188,3 +233,10 @@ func GetPublicKeyAidEncoded(c *x509.Certificate) ([]byte, error) {
 
 	return algorithm, nil
 }
+
+// encodedResult is the cached result of extracting an encoded field from a
+// certificate.
+type encodedResult struct {
+	encoded []byte
+	err     error
+}
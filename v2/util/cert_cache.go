@@ -0,0 +1,80 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"sync"
+
+	"github.com/zmap/zcrypto/x509"
+)
+
+// certCaches holds the *certCache for each certificate that is currently
+// being linted. Entries are added by CacheCertificate and removed by the
+// release function it returns.
+var certCaches sync.Map
+
+// certCache holds values derived from a single certificate that several lints
+// need, so that they are computed once per certificate rather than once per
+// lint.
+type certCache struct {
+	// populate fills in zcrypto's lazily parsed caches for the certificate
+	// before any caller of CacheCertificate returns.
+	populate sync.Once
+	mu       sync.Mutex
+	values   map[string]interface{}
+}
+
+// CacheCertificate enables caching of derived values (e.g. the encoded
+// validity times, the encoded algorithm identifiers and the EV determination)
+// for c until the returned release function is called. Lints running
+// concurrently against c share the cache. Calling CacheCertificate for a
+// certificate that is already cached is a no-op whose release function does
+// nothing.
+//
+// CacheCertificate also populates the parsed DNS name and common name caches
+// kept by zcrypto, which are otherwise filled in lazily and would be written
+// to by concurrently running lints. They are populated once, and every call
+// waits for that to finish, including concurrent calls for the same
+// certificate.
+func CacheCertificate(c *x509.Certificate) (release func()) {
+	v, loaded := certCaches.LoadOrStore(c, &certCache{values: map[string]interface{}{}})
+	v.(*certCache).populate.Do(func() {
+		c.GetParsedDNSNames(false)
+		c.GetParsedSubjectCommonName(false)
+	})
+	if loaded {
+		return func() {}
+	}
+	return func() { certCaches.Delete(c) }
+}
+
+// cached returns the value stored under key in c's cache, calling compute to
+// produce and store it if it's not present. If c isn't cached compute is
+// called every time.
+func cached(c *x509.Certificate, key string, compute func() interface{}) interface{} {
+	v, ok := certCaches.Load(c)
+	if !ok {
+		return compute()
+	}
+	cache := v.(*certCache)
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if value, ok := cache.values[key]; ok {
+		return value
+	}
+	value := compute()
+	cache.values[key] = value
+	return value
+}
@@ -0,0 +1,80 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/zmap/zcrypto/x509"
+)
+
+func TestCertificateCache(t *testing.T) {
+	c := &x509.Certificate{}
+	var calls int
+	compute := func() interface{} {
+		calls++
+		return calls
+	}
+
+	if cached(c, "key", compute); cached(c, "key", compute) != 2 || calls != 2 {
+		t.Fatalf("expected values to be computed on each call for an uncached certificate, got %d calls", calls)
+	}
+
+	release := CacheCertificate(c)
+	if CacheCertificate(c)(); cached(c, "key", compute) != 3 || cached(c, "key", compute) != 3 {
+		t.Errorf("expected a cached value to be reused")
+	}
+	if cached(c, "other", compute) != 4 {
+		t.Errorf("expected a value for a different key to be computed")
+	}
+	release()
+
+	if cached(c, "key", compute) != 5 {
+		t.Errorf("expected values to be computed again after the cache is released")
+	}
+}
+
+// TestCertificateCacheConcurrent caches the same certificate from many
+// goroutines that then read the zcrypto caches CacheCertificate populates.
+// Run with -race to check that none of them reads before they are populated.
+func TestCertificateCacheConcurrent(t *testing.T) {
+	c := &x509.Certificate{DNSNames: []string{"www.example.com", "example.org"}}
+	c.Subject.CommonName = "www.example.com"
+
+	const n = 16
+	start := make(chan struct{})
+	// Every goroutine keeps the certificate cached until all of them have
+	// used it, so that only the first call populates the caches.
+	var used, wg sync.WaitGroup
+	used.Add(n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			release := CacheCertificate(c)
+			if names := c.GetParsedDNSNames(false); len(names) != 2 {
+				t.Errorf("expected 2 parsed DNS names, got %d", len(names))
+			}
+			c.GetParsedSubjectCommonName(false)
+			used.Done()
+			used.Wait()
+			release()
+		}()
+	}
+	close(start)
+	wg.Wait()
+}
@@ -0,0 +1,79 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zcrypto/x509/ct"
+)
+
+// ctLogList is the subset of the log list JSON format published for Chrome
+// (https://www.gstatic.com/ct/log_list/v3/log_list.json) needed to map log IDs
+// to operators.
+type ctLogList struct {
+	Operators []struct {
+		Name string `json:"name"`
+		Logs []struct {
+			LogID string `json:"log_id"`
+		} `json:"logs"`
+		TiledLogs []struct {
+			LogID string `json:"log_id"`
+		} `json:"tiled_logs"`
+	} `json:"operators"`
+}
+
+// ParseCTLogList returns a map from the log ID (the SHA-256 hash of the log's
+// public key) of each log listed in a version 3 CT log list to the name of the
+// organization that operates it, for use as lint.Config.CTLogOperators.
+func ParseCTLogList(r io.Reader) (map[ct.SHA256Hash]string, error) {
+	var list ctLogList
+	if err := json.NewDecoder(r).Decode(&list); err != nil {
+		return nil, fmt.Errorf("parsing CT log list: %v", err)
+	}
+	operators := make(map[ct.SHA256Hash]string)
+	for _, operator := range list.Operators {
+		ids := make([]string, 0, len(operator.Logs)+len(operator.TiledLogs))
+		for _, log := range operator.Logs {
+			ids = append(ids, log.LogID)
+		}
+		for _, log := range operator.TiledLogs {
+			ids = append(ids, log.LogID)
+		}
+		for _, id := range ids {
+			var logID ct.SHA256Hash
+			if err := logID.FromBase64String(id); err != nil {
+				return nil, fmt.Errorf("CT log list contains invalid log ID %q for operator %q: %v", id, operator.Name, err)
+			}
+			operators[logID] = operator.Name
+		}
+	}
+	return operators, nil
+}
+
+// SCTTime returns the time at which the SCT was issued.
+func SCTTime(sct *ct.SignedCertificateTimestamp) time.Time {
+	return time.Unix(0, int64(sct.Timestamp)*int64(time.Millisecond)).UTC()
+}
+
+// IsPrecert returns true if the certificate contains the CT poison extension
+// defined in RFC 6962, marking it as a precertificate.
+func IsPrecert(c *x509.Certificate) bool {
+	return IsExtInCert(c, CtPoisonOID)
+}
@@ -0,0 +1,47 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/zmap/zcrypto/x509/ct"
+)
+
+func TestParseCTLogList(t *testing.T) {
+	list := `{
+		"operators": [
+			{"name": "Google", "logs": [{"log_id": "AQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="}]},
+			{"name": "Cloudflare", "tiled_logs": [{"log_id": "AgAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="}]}
+		]
+	}`
+	operators, err := ParseCTLogList(strings.NewReader(list))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for id, expected := range map[byte]string{1: "Google", 2: "Cloudflare"} {
+		var logID ct.SHA256Hash
+		logID[0] = id
+		if operator := operators[logID]; operator != expected {
+			t.Errorf("expected log %s to be operated by %q, got %q", logID.Base64String(), expected, operator)
+		}
+	}
+
+	invalid := `{"operators": [{"name": "Example", "logs": [{"log_id": "AQID"}]}]}`
+	if _, err := ParseCTLogList(strings.NewReader(invalid)); err == nil {
+		t.Error("expected an error for a log ID of the wrong length")
+	}
+}
@@ -0,0 +1,93 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rsa"
+	"crypto/sha1"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// The `debianWeakKeys` map is generated by the `zlint-debian-weak-keys-update`
+// command from the blocklist files of the Debian openssl-blacklist and
+// openssl-blacklist-extra packages:
+//
+//	zlint-debian-weak-keys-update ./debian_weak_keys_map.go /usr/share/openssl-blacklist/blacklist.RSA-*
+
+// DebianWeakKeyFingerprintLen is the number of octets of each fingerprint in
+// the Debian openssl-blacklist files. They contain the last 80 bits of the
+// SHA-1 hash of the key's modulus as printed by `openssl rsa -modulus`.
+const DebianWeakKeyFingerprintLen = 10
+
+var (
+	debianWeakKeysOnce sync.Once
+	// debianWeakKeySets maps an RSA modulus size to the sorted, decompressed
+	// fingerprints of the Debian weak keys of that size.
+	debianWeakKeySets map[int][]byte
+)
+
+// DebianWeakKeyFingerprint returns the fingerprint of an RSA public key in the
+// form used by the Debian openssl-blacklist files.
+func DebianWeakKeyFingerprint(key *rsa.PublicKey) []byte {
+	h := sha1.Sum([]byte(fmt.Sprintf("Modulus=%s\n", strings.ToUpper(key.N.Text(16)))))
+	return h[len(h)-DebianWeakKeyFingerprintLen:]
+}
+
+// HasDebianWeakKeys returns true if the generated blocklists contain any keys.
+func HasDebianWeakKeys() bool {
+	return len(debianWeakKeys) > 0
+}
+
+// IsDebianWeakKey returns true if the RSA public key was generated by
+// a Debian or Ubuntu system affected by the OpenSSL random number generator
+// vulnerability (DSA-1571-1) and appears in the embedded blocklists. The
+// blocklists are decompressed the first time this function is called.
+func IsDebianWeakKey(key *rsa.PublicKey) bool {
+	debianWeakKeysOnce.Do(loadDebianWeakKeys)
+	set := debianWeakKeySets[key.N.BitLen()]
+	if len(set) == 0 {
+		return false
+	}
+	fp := DebianWeakKeyFingerprint(key)
+	n := len(set) / DebianWeakKeyFingerprintLen
+	i := sort.Search(n, func(i int) bool {
+		return bytes.Compare(set[i*DebianWeakKeyFingerprintLen:(i+1)*DebianWeakKeyFingerprintLen], fp) >= 0
+	})
+	return i < n && bytes.Equal(set[i*DebianWeakKeyFingerprintLen:(i+1)*DebianWeakKeyFingerprintLen], fp)
+}
+
+// loadDebianWeakKeys decompresses the generated debianWeakKeys map into
+// debianWeakKeySets. The generated data is checked by TestDebianWeakKeysData so
+// a corrupt entry panics here rather than silently disabling the lookup.
+func loadDebianWeakKeys() {
+	debianWeakKeySets = make(map[int][]byte, len(debianWeakKeys))
+	for bits, compressed := range debianWeakKeys {
+		r, err := gzip.NewReader(strings.NewReader(compressed))
+		if err != nil {
+			panic(fmt.Sprintf("corrupt Debian weak key data for %d bit keys: %v", bits, err))
+		}
+		set, err := ioutil.ReadAll(r)
+		if err != nil || len(set)%DebianWeakKeyFingerprintLen != 0 {
+			panic(fmt.Sprintf("corrupt Debian weak key data for %d bit keys: %v", bits, err))
+		}
+		debianWeakKeySets[bits] = set
+	}
+}
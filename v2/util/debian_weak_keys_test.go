@@ -0,0 +1,69 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"crypto/rsa"
+	"math/big"
+	"testing"
+)
+
+func TestDebianWeakKeysData(t *testing.T) {
+	debianWeakKeysOnce.Do(loadDebianWeakKeys)
+	for bits, set := range debianWeakKeySets {
+		for i := DebianWeakKeyFingerprintLen; i < len(set); i += DebianWeakKeyFingerprintLen {
+			if bytes.Compare(set[i-DebianWeakKeyFingerprintLen:i], set[i:i+DebianWeakKeyFingerprintLen]) >= 0 {
+				t.Fatalf("fingerprints for %d bit keys are not sorted at offset %d", bits, i)
+			}
+		}
+	}
+}
+
+func TestIsDebianWeakKey(t *testing.T) {
+	weak, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	notWeak := &rsa.PublicKey{N: new(big.Int).Add(weak.N, big.NewInt(2)), E: weak.E}
+	fp := DebianWeakKeyFingerprint(&weak.PublicKey)
+
+	// Build a blocklist with the weak key's fingerprint between two others.
+	before := make([]byte, DebianWeakKeyFingerprintLen)
+	after := bytes.Repeat([]byte{0xff}, DebianWeakKeyFingerprintLen)
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	for _, b := range [][]byte{before, fp, after} {
+		w.Write(b)
+	}
+	w.Close()
+
+	defer func(saved map[int]string) {
+		debianWeakKeys = saved
+		loadDebianWeakKeys()
+	}(debianWeakKeys)
+	debianWeakKeysOnce.Do(func() {})
+	debianWeakKeys = map[int]string{1024: buf.String()}
+	loadDebianWeakKeys()
+
+	if !IsDebianWeakKey(&weak.PublicKey) {
+		t.Errorf("expected key in blocklist to be weak")
+	}
+	if IsDebianWeakKey(notWeak) {
+		t.Errorf("expected key not in blocklist not to be weak")
+	}
+}
@@ -0,0 +1,137 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/cryptobyte"
+	cryptobyte_asn1 "golang.org/x/crypto/cryptobyte/asn1"
+)
+
+// CheckDER returns an error describing the first BER encoding found in data,
+// which must contain exactly one ASN.1 element. It reports indefinite
+// lengths, lengths that are not minimally encoded, BOOLEAN values other than
+// 0x00 and 0xFF, truncated elements and trailing data. Constructed elements
+// are checked recursively; the contents of primitive elements such as
+// OCTET STRINGs are not.
+func CheckDER(data []byte) error {
+	rest, err := checkDERElement(data, 0)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 0 {
+		return fmt.Errorf("%d bytes of trailing data", len(rest))
+	}
+	return nil
+}
+
+// checkDERElement checks the element at the start of data, found at the given
+// offset of the outermost input, and returns the data that follows it.
+func checkDERElement(data []byte, offset int) ([]byte, error) {
+	if len(data) < 2 {
+		return nil, fmt.Errorf("truncated element at offset %d", offset)
+	}
+	i := 1
+	if data[0]&0x1f == 0x1f {
+		// High tag number form. The first subsequent octet MUST NOT be 0x80.
+		if data[i] == 0x80 {
+			return nil, fmt.Errorf("non-minimal tag at offset %d", offset)
+		}
+		for i < len(data) && data[i]&0x80 != 0 {
+			i++
+		}
+		i++
+		if i >= len(data) {
+			return nil, fmt.Errorf("truncated tag at offset %d", offset)
+		}
+	}
+	tag := data[0]
+	constructed := tag&0x20 != 0
+
+	var length int
+	switch l := data[i]; {
+	case l == 0x80:
+		return nil, fmt.Errorf("indefinite length at offset %d", offset)
+	case l < 0x80:
+		length = int(l)
+		i++
+	default:
+		n := int(l & 0x7f)
+		if n > 4 || i+1+n > len(data) {
+			return nil, fmt.Errorf("invalid length at offset %d", offset)
+		}
+		if data[i+1] == 0 {
+			return nil, fmt.Errorf("non-minimal length at offset %d", offset)
+		}
+		for _, b := range data[i+1 : i+1+n] {
+			length = length<<8 | int(b)
+		}
+		if length < 0x80 {
+			return nil, fmt.Errorf("non-minimal length at offset %d", offset)
+		}
+		i += 1 + n
+	}
+	if length > len(data)-i {
+		return nil, fmt.Errorf("truncated element at offset %d", offset)
+	}
+	contents := data[i : i+length]
+
+	if tag == 0x01 && (length != 1 || (contents[0] != 0x00 && contents[0] != 0xff)) {
+		return nil, fmt.Errorf("BOOLEAN at offset %d is not 0x00 or 0xFF", offset)
+	}
+	if constructed {
+		inner := contents
+		for len(inner) > 0 {
+			rest, err := checkDERElement(inner, offset+i+length-len(inner))
+			if err != nil {
+				return nil, err
+			}
+			inner = rest
+		}
+	}
+	return data[i+length:], nil
+}
+
+// CheckRDNSetOrder returns an error if a multi-valued RDN of the DER encoded
+// Name in raw doesn't have its AttributeTypeAndValues in the ascending order
+// that DER requires for the elements of a SET OF (X.690, Section 11.6).
+func CheckRDNSetOrder(raw []byte) error {
+	input := cryptobyte.String(raw)
+	var rdnSequence cryptobyte.String
+	if !input.ReadASN1(&rdnSequence, cryptobyte_asn1.SEQUENCE) {
+		return errors.New("error reading name")
+	}
+	for !rdnSequence.Empty() {
+		var rdn cryptobyte.String
+		if !rdnSequence.ReadASN1(&rdn, cryptobyte_asn1.SET) {
+			return errors.New("error reading relative distinguished name")
+		}
+		var previous cryptobyte.String
+		for !rdn.Empty() {
+			var atv cryptobyte.String
+			if !rdn.ReadASN1Element(&atv, cryptobyte_asn1.SEQUENCE) {
+				return errors.New("error reading attribute type and value")
+			}
+			if previous != nil && bytes.Compare(previous, atv) > 0 {
+				return errors.New("attribute type and values of a multi-valued RDN are not sorted")
+			}
+			previous = atv
+		}
+	}
+	return nil
+}
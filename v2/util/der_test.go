@@ -0,0 +1,78 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestCheckDER(t *testing.T) {
+	testCases := []struct {
+		name  string
+		data  string
+		valid bool
+	}{
+		{name: "sequence", data: "3006020101010100", valid: true},
+		{name: "long form length", data: "048180" + strings.Repeat("00", 128), valid: true},
+		{name: "high tag number", data: "9f2001ff", valid: true},
+		{name: "indefinite length", data: "30800401000000"},
+		{name: "non-minimal long form length", data: "3081030401ff"},
+		{name: "length with leading zero", data: "308200030401ff"},
+		{name: "nested indefinite length", data: "300aa080020100000002010a"},
+		{name: "trailing data", data: "30030401ffff"},
+		{name: "truncated", data: "30050401ff"},
+		{name: "BOOLEAN true as 0x01", data: "3003010101"},
+		{name: "empty", data: ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := hex.DecodeString(tc.data)
+			if err != nil {
+				t.Fatalf("bad test data: %v", err)
+			}
+			if err := CheckDER(data); (err == nil) != tc.valid {
+				t.Errorf("expected valid %v got error %v", tc.valid, err)
+			}
+		})
+	}
+}
+
+func TestCheckRDNSetOrder(t *testing.T) {
+	// cn and o are the encoded AttributeTypeAndValues CN=a and O=a.
+	cn := "300806035504030c0161"
+	o := "3008060355040a0c0161"
+	testCases := []struct {
+		name  string
+		data  string
+		valid bool
+	}{
+		{name: "sorted", data: "3016" + "3114" + cn + o, valid: true},
+		{name: "unsorted", data: "3016" + "3114" + o + cn, valid: false},
+		{name: "separate RDNs", data: "3018" + "310a" + o + "310a" + cn, valid: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := hex.DecodeString(tc.data)
+			if err != nil {
+				t.Fatalf("bad test data: %v", err)
+			}
+			if err := CheckRDNSetOrder(data); (err == nil) != tc.valid {
+				t.Errorf("expected valid %v got error %v", tc.valid, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"crypto/elliptic"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/cryptobyte"
+	cryptobyte_asn1 "golang.org/x/crypto/cryptobyte/asn1"
+)
+
+var (
+	// 1.2.840.10045.2.1 is id-ecPublicKey
+	OidPublicKeyECDSA = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+
+	OidNamedCurveP224 = asn1.ObjectIdentifier{1, 3, 132, 0, 33}
+	OidNamedCurveP256 = asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}
+	OidNamedCurveP384 = asn1.ObjectIdentifier{1, 3, 132, 0, 34}
+	OidNamedCurveP521 = asn1.ObjectIdentifier{1, 3, 132, 0, 35}
+)
+
+// ECParametersForm is the choice made in the ECParameters of an
+// id-ecPublicKey AlgorithmIdentifier (RFC 5480, Section 2.1.1).
+type ECParametersForm int
+
+const (
+	ECParametersAbsent ECParametersForm = iota
+	ECNamedCurve
+	ECImplicitCurve
+	ECSpecifiedCurve
+)
+
+func (f ECParametersForm) String() string {
+	switch f {
+	case ECNamedCurve:
+		return "namedCurve"
+	case ECImplicitCurve:
+		return "implicitCurve"
+	case ECSpecifiedCurve:
+		return "specifiedCurve"
+	}
+	return "absent"
+}
+
+// GetECParameters parses the DER encoded AlgorithmIdentifier of an EC public
+// key and returns the form of its parameters and, for named curves, the
+// curve OID.
+func GetECParameters(algorithmIdentifier []byte) (ECParametersForm, asn1.ObjectIdentifier, error) {
+	input := cryptobyte.String(algorithmIdentifier)
+
+	var aid cryptobyte.String
+	if !input.ReadASN1(&aid, cryptobyte_asn1.SEQUENCE) {
+		return 0, nil, errors.New("error reading algorithm identifier")
+	}
+	if !aid.SkipASN1(cryptobyte_asn1.OBJECT_IDENTIFIER) {
+		return 0, nil, errors.New("error reading algorithm OID")
+	}
+	if aid.Empty() {
+		return ECParametersAbsent, nil, nil
+	}
+	var curve asn1.ObjectIdentifier
+	switch {
+	case aid.PeekASN1Tag(cryptobyte_asn1.OBJECT_IDENTIFIER):
+		if !aid.ReadASN1ObjectIdentifier(&curve) {
+			return 0, nil, errors.New("error reading named curve OID")
+		}
+		return ECNamedCurve, curve, nil
+	case aid.PeekASN1Tag(cryptobyte_asn1.NULL):
+		return ECImplicitCurve, nil, nil
+	case aid.PeekASN1Tag(cryptobyte_asn1.SEQUENCE):
+		return ECSpecifiedCurve, nil, nil
+	}
+	return 0, nil, errors.New("error reading EC parameters")
+}
+
+// CurveFromOID returns the elliptic curve identified by a named curve OID, or
+// nil if the curve isn't supported.
+func CurveFromOID(oid asn1.ObjectIdentifier) elliptic.Curve {
+	switch {
+	case oid.Equal(OidNamedCurveP224):
+		return elliptic.P224()
+	case oid.Equal(OidNamedCurveP256):
+		return elliptic.P256()
+	case oid.Equal(OidNamedCurveP384):
+		return elliptic.P384()
+	case oid.Equal(OidNamedCurveP521):
+		return elliptic.P521()
+	}
+	return nil
+}
+
+// ValidateECPoint performs the partial public key validation of NIST SP
+// 800-56A Section 5.6.2.3.4 on an encoded ECPoint: the point MUST NOT be the
+// point at infinity, its coordinates MUST be in range and it MUST lie on the
+// curve.
+func ValidateECPoint(curve elliptic.Curve, point []byte) error {
+	if len(point) == 0 {
+		return errors.New("empty EC point")
+	}
+	byteLen := (curve.Params().BitSize + 7) / 8
+	switch point[0] {
+	case 0:
+		return errors.New("EC point is the point at infinity")
+	case 4:
+		if len(point) != 1+2*byteLen {
+			return fmt.Errorf("uncompressed EC point is %d octets, expected %d", len(point), 1+2*byteLen)
+		}
+		if x, _ := elliptic.Unmarshal(curve, point); x == nil {
+			return errors.New("EC point is not on the curve")
+		}
+	case 2, 3:
+		if len(point) != 1+byteLen {
+			return fmt.Errorf("compressed EC point is %d octets, expected %d", len(point), 1+byteLen)
+		}
+		if x, _ := elliptic.UnmarshalCompressed(curve, point); x == nil {
+			return errors.New("EC point is not on the curve")
+		}
+	default:
+		return fmt.Errorf("unknown EC point encoding 0x%02x", point[0])
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/asn1"
+	"testing"
+)
+
+func TestGetECParameters(t *testing.T) {
+	testCases := []struct {
+		name  string
+		aid   []byte
+		form  ECParametersForm
+		curve asn1.ObjectIdentifier
+		err   bool
+	}{
+		{
+			name:  "named curve P-256",
+			aid:   []byte{0x30, 0x13, 0x06, 0x07, 0x2a, 0x86, 0x48, 0xce, 0x3d, 0x02, 0x01, 0x06, 0x08, 0x2a, 0x86, 0x48, 0xce, 0x3d, 0x03, 0x01, 0x07},
+			form:  ECNamedCurve,
+			curve: OidNamedCurveP256,
+		},
+		{
+			name: "absent",
+			aid:  []byte{0x30, 0x09, 0x06, 0x07, 0x2a, 0x86, 0x48, 0xce, 0x3d, 0x02, 0x01},
+			form: ECParametersAbsent,
+		},
+		{
+			name: "implicit curve",
+			aid:  []byte{0x30, 0x0b, 0x06, 0x07, 0x2a, 0x86, 0x48, 0xce, 0x3d, 0x02, 0x01, 0x05, 0x00},
+			form: ECImplicitCurve,
+		},
+		{
+			name: "specified curve",
+			aid:  []byte{0x30, 0x0b, 0x06, 0x07, 0x2a, 0x86, 0x48, 0xce, 0x3d, 0x02, 0x01, 0x30, 0x00},
+			form: ECSpecifiedCurve,
+		},
+		{
+			name: "truncated",
+			aid:  []byte{0x30, 0x0b, 0x06, 0x07, 0x2a, 0x86, 0x48},
+			err:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			form, curve, err := GetECParameters(tc.aid)
+			if (err != nil) != tc.err {
+				t.Fatalf("expected error %v got %v", tc.err, err)
+			}
+			if err != nil {
+				return
+			}
+			if form != tc.form {
+				t.Errorf("expected form %s got %s", tc.form, form)
+			}
+			if !curve.Equal(tc.curve) {
+				t.Errorf("expected curve %v got %v", tc.curve, curve)
+			}
+		})
+	}
+}
+
+func TestValidateECPoint(t *testing.T) {
+	curve := elliptic.P256()
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	uncompressed := elliptic.Marshal(curve, key.X, key.Y)
+	offCurve := append([]byte{}, uncompressed...)
+	offCurve[len(offCurve)-1] ^= 0x01
+
+	testCases := []struct {
+		name  string
+		point []byte
+		valid bool
+	}{
+		{name: "uncompressed", point: uncompressed, valid: true},
+		{name: "compressed", point: elliptic.MarshalCompressed(curve, key.X, key.Y), valid: true},
+		{name: "point at infinity", point: []byte{0x00}},
+		{name: "not on curve", point: offCurve},
+		{name: "truncated", point: uncompressed[:33]},
+		{name: "hybrid encoding", point: append([]byte{0x06}, uncompressed[1:]...)},
+		{name: "empty", point: nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := ValidateECPoint(curve, tc.point); (err == nil) != tc.valid {
+				t.Errorf("expected valid %v got error %v", tc.valid, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package util
+
+import "encoding/asn1"
+
+var (
+	// EdDSA (RFC 8410) signature and public key algorithm identifiers.
+	Ed25519OID = asn1.ObjectIdentifier{1, 3, 101, 112}
+	Ed448OID   = asn1.ObjectIdentifier{1, 3, 101, 113}
+)
+
+// EdDSAPublicKeySizes maps the OID of each EdDSA algorithm to the length in
+// bytes of its encoded public key.
+var EdDSAPublicKeySizes = map[string]int{
+	Ed25519OID.String(): 32,
+	Ed448OID.String():   57,
+}
+
+// IsEdDSAOID returns true if oid identifies Ed25519 or Ed448.
+func IsEdDSAOID(oid asn1.ObjectIdentifier) bool {
+	return oid.Equal(Ed25519OID) || oid.Equal(Ed448OID)
+}
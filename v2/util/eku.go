@@ -12,3 +12,8 @@ func HasEKU(cert *x509.Certificate, eku x509.ExtKeyUsage) bool {
 
 	return false
 }
+
+// HasOnlyEKU tests whether eku is the only EKU present in a certificate.
+func HasOnlyEKU(cert *x509.Certificate, eku x509.ExtKeyUsage) bool {
+	return len(cert.ExtKeyUsage) == 1 && cert.ExtKeyUsage[0] == eku && len(cert.UnknownExtKeyUsage) == 0
+}
@@ -64,14 +64,17 @@ func IsIA5String(raw []byte) bool {
 	return true
 }
 
+// prefSynRegex is the expression that matches the ABNF syntax from RFC 1034:
+// Sec 3.5, specifically for subdomain since the " " case for domain is covered
+// by IsInPrefSyn.
+var prefSynRegex = regexp.MustCompile(`^([[:alpha:]]{1}(([[:alnum:]]|[-])*[[:alnum:]]{1})*){1}([.][[:alpha:]]{1}(([[:alnum:]]|[-])*[[:alnum:]]{1})*)*$`)
+
 func IsInPrefSyn(name string) bool {
 	// If the DNS name is just a space, it is valid
 	if name == " " {
 		return true
 	}
-	// This is the expression that matches the ABNF syntax from RFC 1034: Sec 3.5, specifically for subdomain since the " " case for domain is covered above
-	prefsyn := regexp.MustCompile(`^([[:alpha:]]{1}(([[:alnum:]]|[-])*[[:alnum:]]{1})*){1}([.][[:alpha:]]{1}(([[:alnum:]]|[-])*[[:alnum:]]{1})*)*$`)
-	return prefsyn.MatchString(name)
+	return prefSynRegex.MatchString(name)
 }
 
 // AllAlternateNameWithTagAreIA5 returns true if all sequence members with the
@@ -0,0 +1,179 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"encoding/asn1"
+	"errors"
+
+	"github.com/zmap/zcrypto/x509"
+)
+
+var (
+	// Certificate policies for EU qualified certificates defined in ETSI EN
+	// 319 411-2 Section 5.3.
+	EtsiQcpN     = asn1.ObjectIdentifier{0, 4, 0, 194112, 1, 0}
+	EtsiQcpL     = asn1.ObjectIdentifier{0, 4, 0, 194112, 1, 1}
+	EtsiQcpNQscd = asn1.ObjectIdentifier{0, 4, 0, 194112, 1, 2}
+	EtsiQcpLQscd = asn1.ObjectIdentifier{0, 4, 0, 194112, 1, 3}
+	EtsiQcpW     = asn1.ObjectIdentifier{0, 4, 0, 194112, 1, 4}
+
+	// IdEtsiPsd2Statement is the PSD2 QC statement defined in ETSI TS 119 495
+	// Section 5.1.
+	IdEtsiPsd2Statement = asn1.ObjectIdentifier{0, 4, 0, 19495, 2}
+
+	// IdQcsPkixQCSyntaxV2 is the QC statement carrying semantics information,
+	// see RFC 3739 Section 3.2.6.1.
+	IdQcsPkixQCSyntaxV2 = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 11, 2}
+	// Semantics identifiers defined in ETSI EN 319 412-1 Section 5.1.
+	IdEtsiQcsSemanticsIdNatural = asn1.ObjectIdentifier{0, 4, 0, 194121, 1, 1}
+	IdEtsiQcsSemanticsIdLegal   = asn1.ObjectIdentifier{0, 4, 0, 194121, 1, 2}
+
+	// X.520 attribute types used by ETSI certificate profiles.
+	PseudonymOID              = asn1.ObjectIdentifier{2, 5, 4, 65}
+	OrganizationIdentifierOID = asn1.ObjectIdentifier{2, 5, 4, 97}
+)
+
+// semanticsInformation is the statementInfo of an id-qcs-pkixQCSyntax-v2 QC
+// statement (RFC 3739 Section 3.2.6.1).
+type semanticsInformation struct {
+	SemanticsIdentifier         asn1.ObjectIdentifier `asn1:"optional"`
+	NameRegistrationAuthorities []asn1.RawValue       `asn1:"optional"`
+}
+
+// Psd2RoleOfPsp is a payment service provider role asserted in a PSD2 QC
+// statement.
+type Psd2RoleOfPsp struct {
+	RoleOid  asn1.ObjectIdentifier
+	RoleName string `asn1:"utf8"`
+}
+
+// Psd2QcType is the statementInfo of a PSD2 QC statement (ETSI TS 119 495
+// Section 5.1).
+type Psd2QcType struct {
+	RolesOfPsp []Psd2RoleOfPsp
+	NcaName    string `asn1:"utf8"`
+	NcaId      string `asn1:"utf8"`
+}
+
+// getQcStatementInfo returns the statementInfo of the QC statement with the
+// given OID and whether the statement is present in c.
+func getQcStatementInfo(c *x509.Certificate, oid asn1.ObjectIdentifier) (asn1.RawValue, bool) {
+	ext := GetExtFromCert(c, QcStateOid)
+	if ext == nil {
+		return asn1.RawValue{}, false
+	}
+	var statements []anyContent
+	if _, err := asn1.Unmarshal(ext.Value, &statements); err != nil {
+		return asn1.RawValue{}, false
+	}
+	for _, raw := range statements {
+		var statement qcStatementWithInfoField
+		if _, err := asn1.Unmarshal(raw.Raw, &statement); err != nil {
+			var withoutInfo qcStatementWithoutInfoField
+			if _, err := asn1.Unmarshal(raw.Raw, &withoutInfo); err != nil {
+				continue
+			}
+			statement.Oid = withoutInfo.Oid
+		}
+		if statement.Oid.Equal(oid) {
+			return statement.Any, true
+		}
+	}
+	return asn1.RawValue{}, false
+}
+
+// IsQcStatementPresent returns true if c has a QC statement with the given
+// OID.
+func IsQcStatementPresent(c *x509.Certificate, oid asn1.ObjectIdentifier) bool {
+	_, present := getQcStatementInfo(c, oid)
+	return present
+}
+
+// GetPsd2QcType returns the parsed PSD2 QC statement in c, or nil if there
+// isn't one. An error is returned if the statement can not be parsed.
+func GetPsd2QcType(c *x509.Certificate) (*Psd2QcType, error) {
+	info, present := getQcStatementInfo(c, IdEtsiPsd2Statement)
+	if !present {
+		return nil, nil
+	}
+	var psd2 Psd2QcType
+	rest, err := asn1.Unmarshal(info.FullBytes, &psd2)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("trailing data after PSD2 QC statement")
+	}
+	return &psd2, nil
+}
+
+// GetEtsiQcPolicies returns the qualified certificate policies from ETSI EN
+// 319 411-2 asserted by c.
+func GetEtsiQcPolicies(c *x509.Certificate) []asn1.ObjectIdentifier {
+	var policies []asn1.ObjectIdentifier
+	for _, policy := range []asn1.ObjectIdentifier{EtsiQcpN, EtsiQcpL, EtsiQcpNQscd, EtsiQcpLQscd, EtsiQcpW} {
+		if SliceContainsOID(c.PolicyIdentifiers, policy) {
+			policies = append(policies, policy)
+		}
+	}
+	return policies
+}
+
+// GetQcSemanticsIdentifier returns the semantics identifier of the
+// id-qcs-pkixQCSyntax-v2 QC statement in c, or nil if there isn't one.
+func GetQcSemanticsIdentifier(c *x509.Certificate) asn1.ObjectIdentifier {
+	ext := GetExtFromCert(c, QcStateOid)
+	if ext == nil {
+		return nil
+	}
+	var statements []anyContent
+	if _, err := asn1.Unmarshal(ext.Value, &statements); err != nil {
+		return nil
+	}
+	for _, raw := range statements {
+		var statement qcStatementWithInfoField
+		if _, err := asn1.Unmarshal(raw.Raw, &statement); err != nil {
+			continue
+		}
+		if !statement.Oid.Equal(IdQcsPkixQCSyntaxV2) {
+			continue
+		}
+		var info semanticsInformation
+		if _, err := asn1.Unmarshal(statement.Any.FullBytes, &info); err != nil {
+			return nil
+		}
+		return info.SemanticsIdentifier
+	}
+	return nil
+}
+
+// IsEtsiNaturalPersonCert returns true if c asserts a certificate policy for
+// qualified certificates issued to natural persons, or has the natural person
+// semantics identifier.
+func IsEtsiNaturalPersonCert(c *x509.Certificate) bool {
+	return SliceContainsOID(c.PolicyIdentifiers, EtsiQcpN) ||
+		SliceContainsOID(c.PolicyIdentifiers, EtsiQcpNQscd) ||
+		GetQcSemanticsIdentifier(c).Equal(IdEtsiQcsSemanticsIdNatural)
+}
+
+// IsEtsiLegalPersonCert returns true if c asserts a certificate policy for
+// qualified certificates issued to legal persons, or has the legal person
+// semantics identifier.
+func IsEtsiLegalPersonCert(c *x509.Certificate) bool {
+	return SliceContainsOID(c.PolicyIdentifiers, EtsiQcpL) ||
+		SliceContainsOID(c.PolicyIdentifiers, EtsiQcpLQscd) ||
+		GetQcSemanticsIdentifier(c).Equal(IdEtsiQcsSemanticsIdLegal)
+}
@@ -16,6 +16,8 @@ package util
 
 import (
 	"encoding/asn1"
+
+	"github.com/zmap/zcrypto/x509"
 )
 
 var evoids = map[string]bool{
@@ -70,4 +72,12 @@ func IsEV(in []asn1.ObjectIdentifier) bool {
 	return false
 }
 
+// IsEVCert returns true if any of the certificate policies of c is a known
+// Extended Validation OID.
+func IsEVCert(c *x509.Certificate) bool {
+	return cached(c, "ev", func() interface{} {
+		return IsEV(c.PolicyIdentifiers)
+	}).(bool)
+}
+
 const OnionTLD = ".onion"
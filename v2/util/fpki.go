@@ -0,0 +1,47 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"encoding/asn1"
+
+	"github.com/zmap/zcrypto/x509"
+)
+
+// FPKICommonPolicyOIDs are the certificate policies defined by the X.509
+// Certificate Policy for the U.S. Federal PKI Common Policy Framework.
+var FPKICommonPolicyOIDs = []asn1.ObjectIdentifier{
+	{2, 16, 840, 1, 101, 3, 2, 1, 3, 6},  // id-fpki-common-policy
+	{2, 16, 840, 1, 101, 3, 2, 1, 3, 7},  // id-fpki-common-hardware
+	{2, 16, 840, 1, 101, 3, 2, 1, 3, 8},  // id-fpki-common-devices
+	{2, 16, 840, 1, 101, 3, 2, 1, 3, 13}, // id-fpki-common-authentication
+	{2, 16, 840, 1, 101, 3, 2, 1, 3, 16}, // id-fpki-common-High
+	{2, 16, 840, 1, 101, 3, 2, 1, 3, 17}, // id-fpki-common-cardAuth
+	{2, 16, 840, 1, 101, 3, 2, 1, 3, 36}, // id-fpki-common-devicesHardware
+	{2, 16, 840, 1, 101, 3, 2, 1, 3, 39}, // id-fpki-common-piv-contentSigning
+	{2, 16, 840, 1, 101, 3, 2, 1, 3, 40}, // id-fpki-common-derived-pivAuth
+	{2, 16, 840, 1, 101, 3, 2, 1, 3, 41}, // id-fpki-common-derived-pivAuth-hardware
+}
+
+// IsFPKICommonPolicyCert returns true if c asserts any of the Federal PKI
+// Common Policy Framework certificate policies.
+func IsFPKICommonPolicyCert(c *x509.Certificate) bool {
+	for _, oid := range FPKICommonPolicyOIDs {
+		if SliceContainsOID(c.PolicyIdentifiers, oid) {
+			return true
+		}
+	}
+	return false
+}
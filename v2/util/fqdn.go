@@ -114,6 +114,19 @@ func ICANNPublicSuffixParse(domain string) (*publicsuffix.DomainName, error) {
 	return publicsuffix.ParseFromListWithOptions(publicsuffix.DefaultList, domain, &publicsuffix.FindOptions{IgnorePrivate: true, DefaultRule: publicsuffix.DefaultRule})
 }
 
+// IsICANNPublicSuffix returns true if domain, ignoring a leading wildcard
+// label, is exactly a public suffix from the ICANN section of the Public
+// Suffix List bundled with publicsuffix-go, e.g. "com" or "co.uk". Names
+// whose TLD isn't on the list return false.
+func IsICANNPublicSuffix(domain string) bool {
+	domain = strings.ToLower(strings.TrimSuffix(RemovePrependedWildcard(domain), "."))
+	rule := publicsuffix.DefaultList.Find(domain, &publicsuffix.FindOptions{IgnorePrivate: true})
+	if rule == nil {
+		return false
+	}
+	return rule.Decompose(domain)[1] == ""
+}
+
 func CommonNameIsIP(cert *x509.Certificate) bool {
 	ip := net.ParseIP(cert.Subject.CommonName)
 	if ip == nil {
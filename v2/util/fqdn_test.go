@@ -1012,3 +1012,25 @@ func TestGetHostWithUserinfoWithPortWithAbsolutePathWithQueryWithFragment(t *tes
 		)
 	}
 }
+
+func TestIsICANNPublicSuffix(t *testing.T) {
+	testCases := map[string]bool{
+		"com":             true,
+		"co.uk":           true,
+		"*.co.uk":         true,
+		"CO.UK.":          true,
+		"example.co.uk":   false,
+		"*.example.co.uk": false,
+		"blogspot.com":    false,
+		"corp.internal":   false,
+	}
+	for domain, expected := range testCases {
+		if actual := IsICANNPublicSuffix(domain); actual != expected {
+			t.Error(
+				"For", domain,
+				"expected", expected,
+				"got", actual,
+			)
+		}
+	}
+}
@@ -111,7 +111,10 @@ func CertificateSubjInTLD(c *x509.Certificate, label string) bool {
 	if !IsInTLDMap(label) {
 		return false
 	}
-	for _, name := range append(c.DNSNames, c.Subject.CommonName) {
+	// Copy the DNS names rather than appending to c.DNSNames, which would
+	// modify the shared certificate if its backing array has spare capacity.
+	names := append(append([]string{}, c.DNSNames...), c.Subject.CommonName)
+	for _, name := range names {
 		if strings.HasSuffix(name, "."+label) {
 			return true
 		}
@@ -0,0 +1,41 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"encoding/asn1"
+
+	"github.com/zmap/zcrypto/x509"
+)
+
+var (
+	// ICAO Doc 9303 Part 12 certificate extensions.
+	IcaoNameChangeOID       = asn1.ObjectIdentifier{2, 23, 136, 1, 1, 6, 1}
+	IcaoDocumentTypeListOID = asn1.ObjectIdentifier{2, 23, 136, 1, 1, 6, 2}
+)
+
+// IsICAODocumentSignerCert returns true if c is an end entity certificate
+// with the document type list extension, which Doc 9303 requires in Document
+// Signer certificates.
+func IsICAODocumentSignerCert(c *x509.Certificate) bool {
+	return !IsCACert(c) && IsExtInCert(c, IcaoDocumentTypeListOID)
+}
+
+// IsICAOCSCACert returns true if c is a CA certificate with the name change
+// extension. Only CSCA link certificates are required to have this extension,
+// and other CSCA certificates can't be told apart from any other CA.
+func IsICAOCSCACert(c *x509.Certificate) bool {
+	return IsCACert(c) && IsExtInCert(c, IcaoNameChangeOID)
+}
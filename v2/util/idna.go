@@ -0,0 +1,157 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/idna"
+)
+
+// ACEPrefix is the prefix identifying an A-label (RFC 5890 Section 2.3.2.1).
+const ACEPrefix = "xn--"
+
+// IsReservedLDHLabel returns true if label is an R-LDH label, i.e. it has
+// hyphens in both its third and fourth positions (RFC 5890 Section 2.3.1).
+// A-labels are the only R-LDH labels with a defined meaning.
+func IsReservedLDHLabel(label string) bool {
+	return len(label) >= 4 && label[2:4] == "--"
+}
+
+// IsALabelCandidate returns true if label carries the ACE prefix, compared
+// case-insensitively, and so claims to be an A-label.
+func IsALabelCandidate(label string) bool {
+	return len(label) >= len(ACEPrefix) && strings.EqualFold(label[:len(ACEPrefix)], ACEPrefix)
+}
+
+// idna2008Exceptions holds the code points whose IDNA2008 derived property is
+// fixed by RFC 5892 Section 2.6 rather than by their General Category.
+var idna2008Exceptions = map[rune]bool{
+	// PVALID
+	0x00DF: true, 0x03C2: true, 0x06FD: true, 0x06FE: true, 0x0F0B: true, 0x3007: true,
+	// CONTEXTO
+	0x00B7: true, 0x0375: true, 0x05F3: true, 0x05F4: true, 0x30FB: true,
+	// DISALLOWED
+	0x0640: false, 0x07FA: false, 0x302E: false, 0x302F: false, 0x3031: false,
+	0x3032: false, 0x3033: false, 0x3034: false, 0x3035: false, 0x303B: false,
+}
+
+// isIDNA2008Rune returns true if r may appear in a U-label: the hyphen, the
+// LetterDigits categories of RFC 5892 Section 2.1, the joiners that RFC 5892
+// Appendix A permits in context, and the exceptions of Section 2.6. Rules
+// that depend on normalization and case folding are enforced separately by
+// the IDNA registration profile.
+func isIDNA2008Rune(r rune) bool {
+	if allowed, ok := idna2008Exceptions[r]; ok {
+		return allowed
+	}
+	if r == '-' || r == 0x200C || r == 0x200D {
+		return true
+	}
+	return unicode.In(r, unicode.Ll, unicode.Lo, unicode.Lm, unicode.Mn, unicode.Mc, unicode.Nd)
+}
+
+// ToIDNA2008ULabel decodes the A-label label and returns its U-label, or an
+// error if the label is not a valid IDNA2008 A-label: its Punycode must
+// decode, every code point must be permitted by RFC 5892, the result must
+// satisfy the registration checks of RFC 5891 Section 4 (normalization,
+// hyphen placement, joiners and the Bidi rule) and re-encoding it must give
+// back label.
+func ToIDNA2008ULabel(label string) (string, error) {
+	if !IsALabelCandidate(label) {
+		return "", fmt.Errorf("%q does not start with %q", label, ACEPrefix)
+	}
+	if label[:len(ACEPrefix)] != ACEPrefix {
+		return "", fmt.Errorf("%q has an upper case ACE prefix", label)
+	}
+	ulabel, err := idna.Registration.ToUnicode(label)
+	if err != nil {
+		return "", err
+	}
+	if ulabel == label {
+		return "", fmt.Errorf("%q does not decode to a U-label", label)
+	}
+	for _, r := range ulabel {
+		if !isIDNA2008Rune(r) {
+			return "", fmt.Errorf("%q contains code point %U which IDNA2008 does not permit", label, r)
+		}
+	}
+	alabel, err := idna.Registration.ToASCII(ulabel)
+	if err != nil {
+		return "", err
+	}
+	if alabel != label {
+		return "", fmt.Errorf("%q re-encodes as %q", label, alabel)
+	}
+	return ulabel, nil
+}
+
+// restrictiveScriptSets are the combinations of more than one script that
+// the "Highly Restrictive" level of UTS #39 Section 5.2 permits within
+// a single label, in addition to any single script.
+var restrictiveScriptSets = []map[string]bool{
+	{"Latin": true, "Han": true, "Hiragana": true, "Katakana": true},
+	{"Latin": true, "Han": true, "Bopomofo": true},
+	{"Latin": true, "Han": true, "Hangul": true},
+}
+
+// LabelScripts returns the sorted names of the Unicode scripts used by the
+// code points of label, ignoring the Common and Inherited scripts.
+func LabelScripts(label string) []string {
+	seen := map[string]bool{}
+	for _, r := range label {
+		for name, table := range unicode.Scripts {
+			if name == "Common" || name == "Inherited" {
+				continue
+			}
+			if unicode.Is(table, r) {
+				seen[name] = true
+				break
+			}
+		}
+	}
+	scripts := make([]string, 0, len(seen))
+	for name := range seen {
+		scripts = append(scripts, name)
+	}
+	sort.Strings(scripts)
+	return scripts
+}
+
+// IsMixedScriptLabel returns true if label combines scripts in a way that the
+// "Highly Restrictive" level of UTS #39 does not permit, such as Latin with
+// Cyrillic, which is a common source of confusable names.
+func IsMixedScriptLabel(label string) bool {
+	scripts := LabelScripts(label)
+	if len(scripts) <= 1 {
+		return false
+	}
+	for _, set := range restrictiveScriptSets {
+		allowed := true
+		for _, script := range scripts {
+			if !set[script] {
+				allowed = false
+				break
+			}
+		}
+		if allowed {
+			return false
+		}
+	}
+	return true
+}
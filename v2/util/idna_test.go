@@ -0,0 +1,93 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIsReservedLDHLabel(t *testing.T) {
+	testCases := map[string]bool{
+		"xn--bcher-kva": true,
+		"ab--cd":        true,
+		"a--b":          false,
+		"ab-cd":         false,
+		"ab--":          true,
+		"ab-":           false,
+	}
+	for label, expected := range testCases {
+		if got := IsReservedLDHLabel(label); got != expected {
+			t.Errorf("IsReservedLDHLabel(%q) = %v, expected %v", label, got, expected)
+		}
+	}
+}
+
+func TestToIDNA2008ULabel(t *testing.T) {
+	testCases := []struct {
+		label    string
+		expected string
+		wantErr  bool
+	}{
+		{label: "xn--bcher-kva", expected: "bücher"},
+		{label: "xn--80ak6aa92e", expected: "аррӏе"},
+		{label: "XN--bcher-kva", wantErr: true},
+		{label: "xn--Bcher-kva", wantErr: true},
+		{label: "xn--n3h", wantErr: true},
+		{label: "xn--ls8h", wantErr: true},
+		{label: "xn--zz", wantErr: true},
+		{label: "xn--", wantErr: true},
+		{label: "xn--abc-", wantErr: true},
+		{label: "bucher", wantErr: true},
+	}
+	for _, tc := range testCases {
+		got, err := ToIDNA2008ULabel(tc.label)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("ToIDNA2008ULabel(%q) = %q, expected an error", tc.label, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ToIDNA2008ULabel(%q) returned unexpected error: %v", tc.label, err)
+		} else if got != tc.expected {
+			t.Errorf("ToIDNA2008ULabel(%q) = %q, expected %q", tc.label, got, tc.expected)
+		}
+	}
+}
+
+func TestLabelScripts(t *testing.T) {
+	if got, expected := LabelScripts("аpple-1"), []string{"Cyrillic", "Latin"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("LabelScripts() = %v, expected %v", got, expected)
+	}
+}
+
+func TestIsMixedScriptLabel(t *testing.T) {
+	testCases := map[string]bool{
+		"bücher":  false,
+		"аррӏе":   false,
+		"аpple":   true,
+		"東京tokyo": false,
+		"ひらがな漢字":  false,
+		"한국漢字":    false,
+		"ελλάδαx": true,
+		"123-abc": false,
+	}
+	for label, expected := range testCases {
+		if got := IsMixedScriptLabel(label); got != expected {
+			t.Errorf("IsMixedScriptLabel(%q) = %v, expected %v", label, got, expected)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"encoding/asn1"
+
+	"github.com/zmap/zcrypto/x509"
+)
+
+// IGTFPolicyArc is the arc under which the IGTF assigns its authentication
+// profile and assurance level policy OIDs, e.g. 1.2.840.113612.5.2.2.1 for
+// the Classic authentication profile.
+var IGTFPolicyArc = asn1.ObjectIdentifier{1, 2, 840, 113612, 5, 2}
+
+// IsIGTFCert returns true if c asserts any IGTF policy OID.
+func IsIGTFCert(c *x509.Certificate) bool {
+	for _, policy := range c.PolicyIdentifiers {
+		if len(policy) > len(IGTFPolicyArc) && policy[:len(IGTFPolicyArc)].Equal(IGTFPolicyArc) {
+			return true
+		}
+	}
+	return false
+}
@@ -62,3 +62,18 @@ func NotAllNameFieldsAreEmpty(name *pkix.Name) bool {
 	//Return true if at least one field is non-empty
 	return len(name.Names) >= 1
 }
+
+// GetNameAttributeValues returns the string values of every attribute of the
+// given type in name, in the order they appear.
+func GetNameAttributeValues(name *pkix.Name, oid asn1.ObjectIdentifier) []string {
+	var values []string
+	for _, attr := range name.Names {
+		if !attr.Type.Equal(oid) {
+			continue
+		}
+		if value, ok := attr.Value.(string); ok {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -34,6 +34,7 @@ var (
 	FreshCRLOID             = asn1.ObjectIdentifier{2, 5, 29, 46}                     // Freshest CRL
 	InhibitAnyPolicyOID     = asn1.ObjectIdentifier{2, 5, 29, 54}                     // Inhibit Any Policy
 	IssuerAlternateNameOID  = asn1.ObjectIdentifier{2, 5, 29, 18}                     // Issuer Alt Name
+	IssuingDistPointOID     = asn1.ObjectIdentifier{2, 5, 29, 28}                     // Issuing Distribution Point (CRL extension)
 	KeyUsageOID             = asn1.ObjectIdentifier{2, 5, 29, 15}                     // Key Usage
 	LogoTypeOID             = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 12}       // Logo Type Ext
 	NameConstOID            = asn1.ObjectIdentifier{2, 5, 29, 30}                     // Name Constraints
@@ -43,6 +44,7 @@ var (
 	PrivKeyUsageOID         = asn1.ObjectIdentifier{2, 5, 29, 16}                     // Private Key Usage Period
 	QcStateOid              = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 3}        // QC Statements
 	TimestampOID            = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 4, 2} // Signed Certificate Timestamp List
+	TLSFeatureOID           = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 24}       // TLS Feature
 	SmimeOID                = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 15}      // Smime Capabilities
 	SubjectAlternateNameOID = asn1.ObjectIdentifier{2, 5, 29, 17}                     // Subject Alt Name
 	SubjectDirAttrOID       = asn1.ObjectIdentifier{2, 5, 29, 9}                      // Subject Directory Attributes
@@ -63,9 +65,15 @@ var (
 	StreetAddressOID          = asn1.ObjectIdentifier{2, 5, 4, 9}
 	OrganizationNameOID       = asn1.ObjectIdentifier{2, 5, 4, 10}
 	OrganizationalUnitNameOID = asn1.ObjectIdentifier{2, 5, 4, 11}
+	TitleOID                  = asn1.ObjectIdentifier{2, 5, 4, 12}
 	BusinessOID               = asn1.ObjectIdentifier{2, 5, 4, 15}
 	PostalCodeOID             = asn1.ObjectIdentifier{2, 5, 4, 17}
 	GivenNameOID              = asn1.ObjectIdentifier{2, 5, 4, 42}
+	EmailAddressOID           = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 1}
+	// EV Guidelines jurisdiction of incorporation or registration attribute types
+	JurisdictionLocalityOID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 311, 60, 2, 1, 1}
+	JurisdictionProvinceOID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 311, 60, 2, 1, 2}
+	JurisdictionCountryOID  = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 311, 60, 2, 1, 3}
 	// Hash algorithms - see https://golang.org/src/crypto/x509/x509.go
 	SHA256OID = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}
 	SHA384OID = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 2}
@@ -83,6 +91,7 @@ var (
 	AnyPolicyOID               = asn1.ObjectIdentifier{2, 5, 29, 32, 0}
 	UserNoticeOID              = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 2, 2}
 	CpsOID                     = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 2, 1}
+	SmtpUTF8MailboxOID         = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 8, 9}
 	IdEtsiQcsQcCompliance      = asn1.ObjectIdentifier{0, 4, 0, 1862, 1, 1}
 	IdEtsiQcsQcLimitValue      = asn1.ObjectIdentifier{0, 4, 0, 1862, 1, 2}
 	IdEtsiQcsQcRetentionPeriod = asn1.ObjectIdentifier{0, 4, 0, 1862, 1, 3}
@@ -0,0 +1,87 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"bytes"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	// onionV2AddressLen is the length of a version 2 onion address label: the
+	// base32 encoding of 80 bits of a hash of the service's RSA key.
+	onionV2AddressLen = 16
+	// onionV3AddressLen is the length of a version 3 onion address label: the
+	// base32 encoding of a 32 byte ed25519 public key, a 2 byte checksum and
+	// a version byte.
+	onionV3AddressLen = 56
+	onionV3Version    = 3
+)
+
+var onionEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// OnionAddressVersion returns the version (2 or 3) of the onion service address
+// in the given .onion domain name, using the label immediately to the left of
+// .onion. An error is returned if the name isn't a .onion name or if the label
+// isn't a syntactically valid address, including a v3 address whose checksum
+// or version byte is wrong.
+func OnionAddressVersion(name string) (int, error) {
+	name = strings.ToLower(name)
+	if !strings.HasSuffix(name, OnionTLD) {
+		return 0, fmt.Errorf("%q is not a %s name", name, OnionTLD)
+	}
+	labels := strings.Split(strings.TrimSuffix(name, OnionTLD), ".")
+	address := labels[len(labels)-1]
+	decoded, err := onionEncoding.DecodeString(address)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not base32 encoded", address)
+	}
+	switch len(address) {
+	case onionV2AddressLen:
+		return 2, nil
+	case onionV3AddressLen:
+		pub, checksum, version := decoded[:32], decoded[32:34], decoded[34]
+		if version != onionV3Version {
+			return 0, fmt.Errorf("%q has version byte %d, expected %d", address, version, onionV3Version)
+		}
+		h := sha3.New256()
+		h.Write([]byte(".onion checksum"))
+		h.Write(pub)
+		h.Write([]byte{version})
+		if !bytes.Equal(h.Sum(nil)[:2], checksum) {
+			return 0, fmt.Errorf("%q has an invalid checksum", address)
+		}
+		return 3, nil
+	}
+	return 0, errors.New("onion address has an invalid length")
+}
+
+// GetOnionNames returns the DNS names and subject common name of the
+// certificate that end in .onion.
+func GetOnionNames(c *x509.Certificate) []string {
+	var names []string
+	for _, name := range append([]string{c.Subject.CommonName}, c.DNSNames...) {
+		if strings.HasSuffix(strings.ToLower(name), OnionTLD) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
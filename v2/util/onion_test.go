@@ -0,0 +1,46 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package util
+
+import "testing"
+
+func TestOnionAddressVersion(t *testing.T) {
+	testCases := []struct {
+		name            string
+		expectedVersion int
+		expectErr       bool
+	}{
+		{name: "zlintzlintzlint2.onion", expectedVersion: 2},
+		{name: "www.yqcupfm5ggt5mew3xfxsdikmd4k5hjeykrzhahe45bej4s4baqvqhcyd.onion", expectedVersion: 3},
+		{name: "YQCUPFM5GGT5MEW3XFXSDIKMD4K5HJEYKRZHAHE45BEJ4S4BAQVQHCYD.onion", expectedVersion: 3},
+		{name: "i3pqunhvebvzwla24l6zzyizn7a2jezjtkl2vuw3dtopo2szjddoenid.onion", expectErr: true},
+		{name: "zmap.onion", expectErr: true},
+		{name: "zlint1zlint1zlint.onion", expectErr: true},
+		{name: "example.com", expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			version, err := OnionAddressVersion(tc.name)
+			if tc.expectErr && err == nil {
+				t.Errorf("expected error, got version %d", version)
+			} else if !tc.expectErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			} else if version != tc.expectedVersion {
+				t.Errorf("expected version %d, got %d", tc.expectedVersion, version)
+			}
+		})
+	}
+}
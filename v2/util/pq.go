@@ -0,0 +1,114 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"encoding/asn1"
+	"errors"
+
+	"github.com/zmap/zcrypto/x509"
+	"golang.org/x/crypto/cryptobyte"
+	cryptobyte_asn1 "golang.org/x/crypto/cryptobyte/asn1"
+)
+
+var (
+	// ML-DSA (FIPS 204) signature and public key algorithm identifiers.
+	MLDSA44OID = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 3, 17}
+	MLDSA65OID = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 3, 18}
+	MLDSA87OID = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 3, 19}
+
+	// ML-KEM (FIPS 203) public key algorithm identifiers.
+	MLKEM512OID  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 4, 1}
+	MLKEM768OID  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 4, 2}
+	MLKEM1024OID = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 4, 3}
+)
+
+// PQPublicKeySizes maps the OID of each ML-DSA and ML-KEM parameter set to
+// the length in bytes of its encoded public key.
+var PQPublicKeySizes = map[string]int{
+	MLDSA44OID.String():   1312,
+	MLDSA65OID.String():   1952,
+	MLDSA87OID.String():   2592,
+	MLKEM512OID.String():  800,
+	MLKEM768OID.String():  1184,
+	MLKEM1024OID.String(): 1568,
+}
+
+// compositeSignatureArc is id-alg under the PKIX arc. The composite ML-DSA
+// algorithms of draft-ietf-lamps-pq-composite-sigs are assigned
+// id-alg 37 through 54.
+var compositeSignatureArc = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 6}
+
+// IsMLDSAOID returns true if oid identifies an ML-DSA parameter set.
+func IsMLDSAOID(oid asn1.ObjectIdentifier) bool {
+	return oid.Equal(MLDSA44OID) || oid.Equal(MLDSA65OID) || oid.Equal(MLDSA87OID)
+}
+
+// IsMLKEMOID returns true if oid identifies an ML-KEM parameter set.
+func IsMLKEMOID(oid asn1.ObjectIdentifier) bool {
+	return oid.Equal(MLKEM512OID) || oid.Equal(MLKEM768OID) || oid.Equal(MLKEM1024OID)
+}
+
+// IsCompositeOID returns true if oid identifies a composite ML-DSA algorithm.
+func IsCompositeOID(oid asn1.ObjectIdentifier) bool {
+	if len(oid) != len(compositeSignatureArc)+1 {
+		return false
+	}
+	if !oid[:len(compositeSignatureArc)].Equal(compositeSignatureArc) {
+		return false
+	}
+	id := oid[len(compositeSignatureArc)]
+	return id >= 37 && id <= 54
+}
+
+// IsPQOID returns true if oid identifies a post-quantum or composite
+// algorithm.
+func IsPQOID(oid asn1.ObjectIdentifier) bool {
+	return IsMLDSAOID(oid) || IsMLKEMOID(oid) || IsCompositeOID(oid)
+}
+
+// GetPublicKeyBytes returns the contents of the subjectPublicKey BIT STRING
+// of the certificate.
+func GetPublicKeyBytes(c *x509.Certificate) ([]byte, error) {
+	input := cryptobyte.String(c.RawSubjectPublicKeyInfo)
+
+	var spki cryptobyte.String
+	if !input.ReadASN1(&spki, cryptobyte_asn1.SEQUENCE) {
+		return nil, errors.New("error reading pkixPublicKey")
+	}
+	if !spki.SkipASN1(cryptobyte_asn1.SEQUENCE) {
+		return nil, errors.New("error reading public key algorithm identifier")
+	}
+	var key asn1.BitString
+	if !spki.ReadASN1BitString(&key) {
+		return nil, errors.New("error reading subjectPublicKey")
+	}
+	return key.RightAlign(), nil
+}
+
+// AlgorithmIDHasParams returns true if the encoded AlgorithmIdentifier
+// contains a parameters field, including an explicit NULL.
+func AlgorithmIDHasParams(algorithmIdentifier []byte) (bool, error) {
+	input := cryptobyte.String(algorithmIdentifier)
+
+	var aid cryptobyte.String
+	if !input.ReadASN1(&aid, cryptobyte_asn1.SEQUENCE) {
+		return false, errors.New("error reading algorithm identifier")
+	}
+	if !aid.SkipASN1(cryptobyte_asn1.OBJECT_IDENTIFIER) {
+		return false, errors.New("error reading algorithm OID")
+	}
+	return !aid.Empty(), nil
+}
@@ -0,0 +1,34 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"crypto/ecdsa"
+
+	"github.com/zmap/zcrypto/x509"
+)
+
+// GetECDSAPublicKey returns the ECDSA public key of c, or nil if c doesn't
+// have one. zcrypto wraps ECDSA keys in an x509.AugmentedECDSA when it parses
+// a certificate, so lints should use this rather than a type assertion.
+func GetECDSAPublicKey(c *x509.Certificate) *ecdsa.PublicKey {
+	switch key := c.PublicKey.(type) {
+	case *x509.AugmentedECDSA:
+		return key.Pub
+	case *ecdsa.PublicKey:
+		return key
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"crypto/rsa"
+	"math/big"
+)
+
+// rocaPrimes are the small primes used by the ROCA fingerprint. The Infineon
+// RSALib generated primes of the form k*M + (65537^a mod M), where M is
+// a primorial, so the modulus of an affected key reduced modulo each of these
+// primes always lies in the subgroup generated by 65537.
+var rocaPrimes = []int64{3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37, 41, 43, 47, 53, 59, 61, 67, 71, 73, 79, 83, 89, 97, 101, 103, 107, 109, 113, 127, 131, 137, 139, 149, 151, 157, 163, 167}
+
+// rocaMarkers holds, for each of the rocaPrimes, a bitmask with bit r set if
+// r is a power of 65537 modulo that prime.
+var rocaMarkers = func() []*big.Int {
+	markers := make([]*big.Int, len(rocaPrimes))
+	for i, p := range rocaPrimes {
+		m := new(big.Int)
+		for r := int64(1); m.Bit(int(r)) == 0; r = r * 65537 % p {
+			m.SetBit(m, int(r), 1)
+		}
+		markers[i] = m
+	}
+	return markers
+}()
+
+// IsROCAVulnerable returns true if the RSA public key has the structure of
+// a key generated by the Infineon RSALib affected by the ROCA vulnerability
+// (CVE-2017-15361). Such keys can be factored in practice.
+func IsROCAVulnerable(key *rsa.PublicKey) bool {
+	if key.N == nil || key.N.Sign() <= 0 {
+		return false
+	}
+	r := new(big.Int)
+	for i, p := range rocaPrimes {
+		r.Mod(key.N, big.NewInt(p))
+		if rocaMarkers[i].Bit(int(r.Int64())) == 0 {
+			return false
+		}
+	}
+	return true
+}
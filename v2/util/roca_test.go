@@ -0,0 +1,70 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"crypto/rsa"
+	"math/big"
+	"testing"
+)
+
+func TestIsROCAVulnerable(t *testing.T) {
+	// m is the product of the ROCA primes, so k*m + 65537^a has the
+	// structure of a prime generated by the Infineon RSALib.
+	m := big.NewInt(1)
+	for _, p := range rocaPrimes {
+		m.Mul(m, big.NewInt(p))
+	}
+	rocaFactor := func(k, a int64) *big.Int {
+		f := new(big.Int).Exp(big.NewInt(65537), big.NewInt(a), m)
+		return f.Add(f, new(big.Int).Mul(big.NewInt(k), m))
+	}
+
+	testCases := []struct {
+		name     string
+		modulus  *big.Int
+		expected bool
+	}{
+		{
+			name:     "RSALib structured modulus",
+			modulus:  new(big.Int).Mul(rocaFactor(12345, 67), rocaFactor(54321, 89)),
+			expected: true,
+		},
+		{
+			name:     "modulus divisible by a fingerprint prime",
+			modulus:  new(big.Int).Mul(rocaFactor(12345, 67), big.NewInt(3)),
+			expected: false,
+		},
+		{
+			name:     "unstructured modulus",
+			modulus:  new(big.Int).Add(new(big.Int).Mul(rocaFactor(12345, 67), rocaFactor(54321, 89)), big.NewInt(2)),
+			expected: false,
+		},
+		{
+			name:     "zero modulus",
+			modulus:  new(big.Int),
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			key := &rsa.PublicKey{N: tc.modulus, E: 65537}
+			if got := IsROCAVulnerable(key); got != tc.expected {
+				t.Errorf("expected %v got %v", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"math"
+	"math/big"
+)
+
+// FermatFactorRounds is the number of iterations of Fermat's factorization
+// method attempted by FermatFactor. Moduli whose prime factors share their
+// upper half of bits are factored within the first round or two, so a small
+// bound keeps the check cheap for well-formed keys.
+const FermatFactorRounds = 100
+
+var one = big.NewInt(1)
+
+// FermatFactor attempts to factor n as a difference of squares, which
+// succeeds quickly when n is the product of two primes that are close to
+// each other. It returns the two factors, or nil if none were found within
+// rounds iterations.
+func FermatFactor(n *big.Int, rounds int) (*big.Int, *big.Int) {
+	if n.Sign() <= 0 || n.Bit(0) == 0 {
+		return nil, nil
+	}
+	a := new(big.Int).Sqrt(n)
+	if new(big.Int).Mul(a, a).Cmp(n) < 0 {
+		a.Add(a, one)
+	}
+	b2 := new(big.Int)
+	b := new(big.Int)
+	for i := 0; i < rounds; i++ {
+		b2.Mul(a, a).Sub(b2, n)
+		b.Sqrt(b2)
+		if new(big.Int).Mul(b, b).Cmp(b2) == 0 {
+			p := new(big.Int).Sub(a, b)
+			if p.Cmp(one) == 0 {
+				return nil, nil
+			}
+			return p, new(big.Int).Add(a, b)
+		}
+		a.Add(a, one)
+	}
+	return nil, nil
+}
+
+// IsPrimePower returns true if n = p^k for some prime p and k > 1. Only
+// exponents for which p could exceed 751 are tried, since moduli with
+// smaller factors are already caught by PrimeNoSmallerThan752.
+func IsPrimePower(n *big.Int) bool {
+	if n.Sign() <= 0 {
+		return false
+	}
+	bk := new(big.Int)
+	power := new(big.Int)
+	for k := 2; k <= n.BitLen()/9; k++ {
+		r := nthRoot(n, k)
+		if power.Exp(r, bk.SetInt64(int64(k)), nil).Cmp(n) == 0 && r.ProbablyPrime(20) {
+			return true
+		}
+	}
+	return false
+}
+
+// nthRoot returns the largest integer r such that r^k <= n, using Newton's
+// method.
+func nthRoot(n *big.Int, k int) *big.Int {
+	bk := big.NewInt(int64(k))
+	bk1 := big.NewInt(int64(k - 1))
+	x := nthRootEstimate(n, k)
+	y := new(big.Int)
+	t := new(big.Int)
+	for {
+		// y = ((k-1)*x + n/x^(k-1)) / k
+		y.Exp(x, bk1, nil)
+		y.Div(n, y)
+		y.Add(y, t.Mul(bk1, x))
+		y.Div(y, bk)
+		if y.Cmp(x) >= 0 {
+			return x
+		}
+		x, y = y, x
+	}
+}
+
+// nthRootEstimate returns a starting point for nthRoot that is slightly larger
+// than the k-th root of n, computed in floating point from the leading bits of
+// n. Starting this close to the root means Newton's method converges in a few
+// iterations rather than the O(k) needed from the nearest power of two.
+func nthRootEstimate(n *big.Int, k int) *big.Int {
+	shift := n.BitLen() - 64
+	if shift < 0 {
+		shift = 0
+	}
+	top := new(big.Int).Rsh(n, uint(shift)).Uint64()
+	log2 := (math.Log2(float64(top)) + float64(shift)) / float64(k)
+	exp := math.Floor(log2)
+	// The margin more than covers the rounding error of the float64
+	// arithmetic, so the estimate is never below the root.
+	mant := math.Exp2(log2-exp) * (1 + 1e-9)
+	x, _ := new(big.Float).SetMantExp(big.NewFloat(mant), int(exp)).Int(nil)
+	return x.Add(x, one)
+}
@@ -0,0 +1,68 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestFermatFactor(t *testing.T) {
+	// 1000003 and 1000033 are close primes, 1000003 and 2000003 are not.
+	testCases := []struct {
+		name  string
+		n     int64
+		found bool
+	}{
+		{name: "close factors", n: 1000003 * 1000033, found: true},
+		{name: "distant factors", n: 1000003 * 2000003, found: false},
+		{name: "prime", n: 1000003, found: false},
+		{name: "even", n: 1000003 * 2, found: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			n := big.NewInt(tc.n)
+			p, q := FermatFactor(n, 10)
+			if (p != nil) != tc.found {
+				t.Fatalf("expected found %v got factors %v, %v", tc.found, p, q)
+			}
+			if p != nil && new(big.Int).Mul(p, q).Cmp(n) != 0 {
+				t.Errorf("%v * %v != %v", p, q, n)
+			}
+		})
+	}
+}
+
+func TestIsPrimePower(t *testing.T) {
+	p := big.NewInt(1000003)
+	testCases := []struct {
+		name     string
+		n        *big.Int
+		expected bool
+	}{
+		{name: "square", n: new(big.Int).Exp(p, big.NewInt(2), nil), expected: true},
+		{name: "fifth power", n: new(big.Int).Exp(p, big.NewInt(5), nil), expected: true},
+		{name: "prime", n: p, expected: false},
+		{name: "product of distinct primes", n: new(big.Int).Mul(p, big.NewInt(1000033)), expected: false},
+		{name: "square of composite", n: new(big.Int).Exp(big.NewInt(1000003*1000033), big.NewInt(2), nil), expected: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := IsPrimePower(tc.n); got != tc.expected {
+				t.Errorf("expected %v got %v", tc.expected, got)
+			}
+		})
+	}
+}
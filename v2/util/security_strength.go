@@ -0,0 +1,95 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"crypto/dsa"
+	"crypto/rsa"
+
+	"github.com/zmap/zcrypto/x509"
+)
+
+// DefaultTargetSecurityStrength is the minimum security strength in bits, as
+// defined in NIST SP 800-57 Part 1, that the security strength lints expect
+// a certificate to provide unless lint.Config.TargetSecurityStrength raises
+// it: 112 bits, the minimum NIST allows for applying cryptographic protection
+// after 2013.
+const DefaultTargetSecurityStrength = 112
+
+// ifcStrengths maps RSA and DSA key sizes to security strength (NIST SP 800-57
+// Part 1 Rev. 5, Table 2), in descending order.
+var ifcStrengths = []struct{ bits, strength int }{
+	{15360, 256},
+	{7680, 192},
+	{3072, 128},
+	{2048, 112},
+	{1024, 80},
+}
+
+// eccStrengths maps elliptic curve orders to security strength (NIST SP 800-57
+// Part 1 Rev. 5, Table 2), in descending order.
+var eccStrengths = []struct{ bits, strength int }{
+	{512, 256},
+	{384, 192},
+	{256, 128},
+	{224, 112},
+	{160, 80},
+}
+
+// PublicKeySecurityStrength returns the security strength in bits of the
+// certificate's public key. Keys smaller than the smallest size NIST assigns
+// a strength to have a strength of 0. The second return value is false if the
+// key type isn't supported.
+func PublicKeySecurityStrength(c *x509.Certificate) (int, bool) {
+	var bits int
+	table := ifcStrengths
+	switch key := c.PublicKey.(type) {
+	case *rsa.PublicKey:
+		bits = key.N.BitLen()
+	case *dsa.PublicKey:
+		bits = key.P.BitLen()
+	default:
+		ecKey := GetECDSAPublicKey(c)
+		if ecKey == nil {
+			return 0, false
+		}
+		bits = ecKey.Params().N.BitLen()
+		table = eccStrengths
+	}
+	for _, s := range table {
+		if bits >= s.bits {
+			return s.strength, true
+		}
+	}
+	return 0, true
+}
+
+// SignatureHashSecurityStrength returns the collision resistance in bits of
+// the hash function used by the signature algorithm (NIST SP 800-57 Part 1
+// Rev. 5, Table 3). Broken hash functions (MD2, MD5 and SHA-1) have a strength
+// of 0. The second return value is false if the algorithm isn't supported.
+func SignatureHashSecurityStrength(alg x509.SignatureAlgorithm) (int, bool) {
+	switch alg {
+	case x509.MD2WithRSA, x509.MD5WithRSA, x509.SHA1WithRSA, x509.DSAWithSHA1, x509.ECDSAWithSHA1:
+		return 0, true
+	case x509.SHA256WithRSA, x509.DSAWithSHA256, x509.ECDSAWithSHA256, x509.SHA256WithRSAPSS:
+		return 128, true
+	case x509.SHA384WithRSA, x509.ECDSAWithSHA384, x509.SHA384WithRSAPSS:
+		return 192, true
+	case x509.SHA512WithRSA, x509.ECDSAWithSHA512, x509.SHA512WithRSAPSS:
+		return 256, true
+	}
+	return 0, false
+}
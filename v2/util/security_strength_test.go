@@ -0,0 +1,43 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"testing"
+
+	"github.com/zmap/zcrypto/x509"
+)
+
+func TestSignatureHashSecurityStrength(t *testing.T) {
+	testCases := []struct {
+		alg      x509.SignatureAlgorithm
+		expected int
+		ok       bool
+	}{
+		{x509.MD5WithRSA, 0, true},
+		{x509.SHA1WithRSA, 0, true},
+		{x509.SHA256WithRSA, 128, true},
+		{x509.ECDSAWithSHA384, 192, true},
+		{x509.SHA512WithRSAPSS, 256, true},
+		{x509.UnknownSignatureAlgorithm, 0, false},
+	}
+
+	for _, tc := range testCases {
+		strength, ok := SignatureHashSecurityStrength(tc.alg)
+		if strength != tc.expected || ok != tc.ok {
+			t.Errorf("%v: expected (%d, %v), got (%d, %v)", tc.alg, tc.expected, tc.ok, strength, ok)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"errors"
+
+	"github.com/zmap/zcrypto/x509"
+	"golang.org/x/crypto/cryptobyte"
+	cryptobyte_asn1 "golang.org/x/crypto/cryptobyte/asn1"
+)
+
+// GetSerialNumberEncoded returns the content octets of the serialNumber
+// INTEGER in the certificate's tbsCertificate exactly as they are encoded, or
+// an error if the serial number could not be extracted.
+func GetSerialNumberEncoded(c *x509.Certificate) ([]byte, error) {
+	input := cryptobyte.String(c.RawTBSCertificate)
+
+	var tbsCert cryptobyte.String
+	if !input.ReadASN1(&tbsCert, cryptobyte_asn1.SEQUENCE) {
+		return nil, errors.New("error reading tbsCertificate")
+	}
+	if !tbsCert.SkipOptionalASN1(cryptobyte_asn1.Tag(0).Constructed().ContextSpecific()) {
+		return nil, errors.New("error reading tbsCertificate.version")
+	}
+	var serial cryptobyte.String
+	if !tbsCert.ReadASN1(&serial, cryptobyte_asn1.INTEGER) {
+		return nil, errors.New("error reading tbsCertificate.serialNumber")
+	}
+	return serial, nil
+}
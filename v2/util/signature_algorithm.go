@@ -0,0 +1,131 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"encoding/asn1"
+	"errors"
+	"fmt"
+)
+
+var (
+	OidPublicKeyDSA = asn1.ObjectIdentifier{1, 2, 840, 10040, 4, 1}
+	OidMGF1         = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 8}
+	SHA1OID         = asn1.ObjectIdentifier{1, 3, 14, 3, 2, 26}
+)
+
+// signatureKeyAlgorithms maps signature algorithm OIDs to the public key
+// algorithm OIDs of the keys that can produce them.
+var signatureKeyAlgorithms = map[string][]asn1.ObjectIdentifier{
+	// RSASSA-PKCS1-v1_5
+	OidMD2WithRSAEncryption.String():    {OidRSAEncryption},
+	OidMD5WithRSAEncryption.String():    {OidRSAEncryption},
+	OidSHA1WithRSAEncryption.String():   {OidRSAEncryption},
+	OidSHA224WithRSAEncryption.String(): {OidRSAEncryption},
+	OidSHA256WithRSAEncryption.String(): {OidRSAEncryption},
+	OidSHA384WithRSAEncryption.String(): {OidRSAEncryption},
+	OidSHA512WithRSAEncryption.String(): {OidRSAEncryption},
+	// RSASSA-PSS may be produced by an rsaEncryption key or by a key
+	// restricted to RSASSA-PSS.
+	OidRSASSAPSS.String(): {OidRSAEncryption, OidRSASSAPSS},
+	// ECDSA
+	"1.2.840.10045.4.1":                  {OidPublicKeyECDSA},
+	OidSignatureSHA224withECDSA.String(): {OidPublicKeyECDSA},
+	"1.2.840.10045.4.3.2":                {OidPublicKeyECDSA},
+	"1.2.840.10045.4.3.3":                {OidPublicKeyECDSA},
+	"1.2.840.10045.4.3.4":                {OidPublicKeyECDSA},
+	// DSA
+	"1.2.840.10040.4.3":      {OidPublicKeyDSA},
+	"2.16.840.1.101.3.4.3.1": {OidPublicKeyDSA},
+	"2.16.840.1.101.3.4.3.2": {OidPublicKeyDSA},
+}
+
+// SignatureKeyAlgorithms returns the public key algorithm OIDs of the keys
+// that can produce signatures with the given signature algorithm, or nil if
+// the signature algorithm is unknown. EdDSA and ML-DSA use the same OID for
+// keys and signatures.
+func SignatureKeyAlgorithms(signatureAlgorithm asn1.ObjectIdentifier) []asn1.ObjectIdentifier {
+	if IsEdDSAOID(signatureAlgorithm) || IsMLDSAOID(signatureAlgorithm) {
+		return []asn1.ObjectIdentifier{signatureAlgorithm}
+	}
+	return signatureKeyAlgorithms[signatureAlgorithm.String()]
+}
+
+// RSASSAPSSParams holds the parameters of an RSASSA-PSS algorithm identifier
+// with the defaults of RFC 4055, Section 3.1 applied.
+type RSASSAPSSParams struct {
+	Hash         asn1.ObjectIdentifier
+	MGF          asn1.ObjectIdentifier
+	MGFHash      asn1.ObjectIdentifier
+	SaltLength   int
+	TrailerField int
+}
+
+type rawAlgorithmIdentifier struct {
+	Algorithm  asn1.ObjectIdentifier
+	Parameters asn1.RawValue `asn1:"optional"`
+}
+
+type rsassaPSSParams struct {
+	Hash         rawAlgorithmIdentifier `asn1:"optional,explicit,tag:0"`
+	MGF          rawAlgorithmIdentifier `asn1:"optional,explicit,tag:1"`
+	SaltLength   int                    `asn1:"optional,explicit,tag:2,default:20"`
+	TrailerField int                    `asn1:"optional,explicit,tag:3,default:1"`
+}
+
+// ParseRSASSAPSSParams parses the parameters of a DER encoded RSASSA-PSS
+// algorithm identifier. The parameters MUST be present when the algorithm
+// identifier is associated with a signature value.
+func ParseRSASSAPSSParams(algorithmIdentifier []byte) (*RSASSAPSSParams, error) {
+	var aid rawAlgorithmIdentifier
+	if rest, err := asn1.Unmarshal(algorithmIdentifier, &aid); err != nil {
+		return nil, err
+	} else if len(rest) != 0 {
+		return nil, errors.New("trailing data after algorithm identifier")
+	}
+	if !aid.Algorithm.Equal(OidRSASSAPSS) {
+		return nil, fmt.Errorf("algorithm %s is not RSASSA-PSS", aid.Algorithm)
+	}
+	if len(aid.Parameters.FullBytes) == 0 {
+		return nil, errors.New("RSASSA-PSS parameters are absent")
+	}
+
+	var raw rsassaPSSParams
+	if rest, err := asn1.Unmarshal(aid.Parameters.FullBytes, &raw); err != nil {
+		return nil, fmt.Errorf("error parsing RSASSA-PSS parameters: %v", err)
+	} else if len(rest) != 0 {
+		return nil, errors.New("trailing data after RSASSA-PSS parameters")
+	}
+
+	params := &RSASSAPSSParams{
+		Hash:         SHA1OID,
+		MGF:          OidMGF1,
+		MGFHash:      SHA1OID,
+		SaltLength:   raw.SaltLength,
+		TrailerField: raw.TrailerField,
+	}
+	if raw.Hash.Algorithm != nil {
+		params.Hash = raw.Hash.Algorithm
+	}
+	if raw.MGF.Algorithm != nil {
+		params.MGF = raw.MGF.Algorithm
+		params.MGFHash = nil
+		var mgfHash rawAlgorithmIdentifier
+		if _, err := asn1.Unmarshal(raw.MGF.Parameters.FullBytes, &mgfHash); err == nil {
+			params.MGFHash = mgfHash.Algorithm
+		}
+	}
+	return params, nil
+}
@@ -0,0 +1,98 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"encoding/asn1"
+	"encoding/hex"
+	"testing"
+)
+
+func TestSignatureKeyAlgorithms(t *testing.T) {
+	testCases := []struct {
+		name      string
+		signature asn1.ObjectIdentifier
+		expected  []asn1.ObjectIdentifier
+	}{
+		{name: "sha256WithRSAEncryption", signature: OidSHA256WithRSAEncryption, expected: []asn1.ObjectIdentifier{OidRSAEncryption}},
+		{name: "RSASSA-PSS", signature: OidRSASSAPSS, expected: []asn1.ObjectIdentifier{OidRSAEncryption, OidRSASSAPSS}},
+		{name: "ecdsa-with-SHA224", signature: OidSignatureSHA224withECDSA, expected: []asn1.ObjectIdentifier{OidPublicKeyECDSA}},
+		{name: "Ed25519", signature: Ed25519OID, expected: []asn1.ObjectIdentifier{Ed25519OID}},
+		{name: "unknown", signature: asn1.ObjectIdentifier{1, 2, 3}, expected: nil},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := SignatureKeyAlgorithms(tc.signature)
+			if len(got) != len(tc.expected) {
+				t.Fatalf("expected %v got %v", tc.expected, got)
+			}
+			for i := range got {
+				if !got[i].Equal(tc.expected[i]) {
+					t.Errorf("expected %v got %v", tc.expected, got)
+				}
+			}
+		})
+	}
+}
+
+func TestParseRSASSAPSSParams(t *testing.T) {
+	testCases := []struct {
+		name     string
+		aid      string
+		expected *RSASSAPSSParams
+	}{
+		{
+			name: "SHA-256",
+			aid:  "304106092a864886f70d01010a3034a00f300d06096086480165030402010500a11c301a06092a864886f70d010108300d06096086480165030402010500a203020120",
+			expected: &RSASSAPSSParams{
+				Hash: SHA256OID, MGF: OidMGF1, MGFHash: SHA256OID, SaltLength: 32, TrailerField: 1,
+			},
+		},
+		{
+			name: "defaults",
+			aid:  "300d06092a864886f70d01010a3000",
+			expected: &RSASSAPSSParams{
+				Hash: SHA1OID, MGF: OidMGF1, MGFHash: SHA1OID, SaltLength: 20, TrailerField: 1,
+			},
+		},
+		{
+			name: "absent",
+			aid:  "300b06092a864886f70d01010a",
+		},
+		{
+			name: "not RSASSA-PSS",
+			aid:  "300d06092a864886f70d01010b0500",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			aid, _ := hex.DecodeString(tc.aid)
+			got, err := ParseRSASSAPSSParams(aid)
+			if tc.expected == nil {
+				if err == nil {
+					t.Errorf("expected error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !got.Hash.Equal(tc.expected.Hash) || !got.MGF.Equal(tc.expected.MGF) || !got.MGFHash.Equal(tc.expected.MGFHash) ||
+				got.SaltLength != tc.expected.SaltLength || got.TrailerField != tc.expected.TrailerField {
+				t.Errorf("expected %+v got %+v", tc.expected, got)
+			}
+		})
+	}
+}
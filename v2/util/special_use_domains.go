@@ -0,0 +1,58 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package util
+
+import "strings"
+
+// specialUseDomains maps the special-use domain names that are not globally
+// unique within the public DNS to the document reserving them. It is a
+// snapshot of the IANA Special-Use Domain Names registry[0] plus the
+// ICANN-reserved "internal" TLD[1]. "onion" is omitted since the BRs permit
+// Tor onion service names (see OnionAddressVersion), as are example.com,
+// example.net and example.org, which are delegated.
+//
+// [0]: https://www.iana.org/assignments/special-use-domain-names/special-use-domain-names.xhtml
+// [1]: https://www.icann.org/en/board-activities-and-meetings/materials/approved-resolutions-special-meeting-of-the-icann-board-29-07-2024-en
+var specialUseDomains = map[string]string{
+	"alt":           "RFC 9476",
+	"example":       "RFC 2606",
+	"home.arpa":     "RFC 8375",
+	"internal":      "ICANN Board Resolution 2024.07.29.06",
+	"invalid":       "RFC 2606",
+	"ipv4only.arpa": "RFC 8880",
+	"local":         "RFC 6762",
+	"localhost":     "RFC 6761",
+	"resolver.arpa": "RFC 9462",
+	"service.arpa":  "RFC 9665",
+	"test":          "RFC 6761",
+}
+
+// SpecialUseDomain returns the special-use domain that domain is equal to or
+// a subdomain of, and the document reserving it. ok is false if domain is not
+// within a special-use domain. The comparison ignores case, a leading wildcard
+// label and a trailing dot.
+func SpecialUseDomain(domain string) (suffix, reference string, ok bool) {
+	domain = strings.ToLower(strings.TrimSuffix(RemovePrependedWildcard(domain), "."))
+	for {
+		if reference, ok := specialUseDomains[domain]; ok {
+			return domain, reference, true
+		}
+		i := strings.IndexByte(domain, '.')
+		if i < 0 {
+			return "", "", false
+		}
+		domain = domain[i+1:]
+	}
+}
@@ -0,0 +1,38 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package util
+
+import "testing"
+
+func TestSpecialUseDomain(t *testing.T) {
+	testCases := []struct {
+		domain string
+		suffix string
+		ok     bool
+	}{
+		{domain: "printer.home.arpa", suffix: "home.arpa", ok: true},
+		{domain: "*.Corp.Internal.", suffix: "internal", ok: true},
+		{domain: "localhost", suffix: "localhost", ok: true},
+		{domain: "www.example.com", ok: false},
+		{domain: "1.0.168.192.in-addr.arpa", ok: false},
+		{domain: "notlocal", ok: false},
+	}
+	for _, tc := range testCases {
+		suffix, _, ok := SpecialUseDomain(tc.domain)
+		if suffix != tc.suffix || ok != tc.ok {
+			t.Errorf("SpecialUseDomain(%q) = %q, %v, expected %q, %v", tc.domain, suffix, ok, tc.suffix, tc.ok)
+		}
+	}
+}
@@ -27,11 +27,15 @@ var (
 	RFC2459Date                 = time.Date(1999, time.January, 1, 0, 0, 0, 0, time.UTC)
 	RFC3280Date                 = time.Date(2002, time.April, 1, 0, 0, 0, 0, time.UTC)
 	RFC3490Date                 = time.Date(2003, time.March, 1, 0, 0, 0, 0, time.UTC)
+	RFC5891Date                 = time.Date(2010, time.August, 1, 0, 0, 0, 0, time.UTC)
 	RFC8399Date                 = time.Date(2018, time.May, 1, 0, 0, 0, 0, time.UTC)
+	DirectoryStringUTF8Date     = time.Date(2004, time.January, 1, 0, 0, 0, 0, time.UTC)
 	RFC4325Date                 = time.Date(2005, time.December, 1, 0, 0, 0, 0, time.UTC)
 	RFC4630Date                 = time.Date(2006, time.August, 1, 0, 0, 0, 0, time.UTC)
 	RFC5280Date                 = time.Date(2008, time.May, 1, 0, 0, 0, 0, time.UTC)
 	RFC6818Date                 = time.Date(2013, time.January, 1, 0, 0, 0, 0, time.UTC)
+	RFC5480Date                 = time.Date(2009, time.March, 1, 0, 0, 0, 0, time.UTC)
+	RFC8410Date                 = time.Date(2018, time.August, 1, 0, 0, 0, 0, time.UTC)
 	CABEffectiveDate            = time.Date(2012, time.July, 1, 0, 0, 0, 0, time.UTC)
 	CABReservedIPDate           = time.Date(2016, time.October, 1, 0, 0, 0, 0, time.UTC)
 	CABGivenNameDate            = time.Date(2016, time.September, 7, 0, 0, 0, 0, time.UTC)
@@ -49,17 +53,55 @@ var (
 	NoReservedIP                = time.Date(2015, time.November, 1, 0, 0, 0, 0, time.UTC)
 	SubCert39Month              = time.Date(2016, time.July, 2, 0, 0, 0, 0, time.UTC)
 	SubCert825Days              = time.Date(2018, time.March, 2, 0, 0, 0, 0, time.UTC)
+	SubCert398Days              = time.Date(2020, time.September, 1, 0, 0, 0, 0, time.UTC)
+	SubCert200Days              = time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC)
+	SubCert100Days              = time.Date(2027, time.March, 15, 0, 0, 0, 0, time.UTC)
+	SubCert47Days               = time.Date(2029, time.March, 15, 0, 0, 0, 0, time.UTC)
+	SC62EffectiveDate           = time.Date(2023, time.September, 15, 0, 0, 0, 0, time.UTC)
+	ShortLivedCertDate          = time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC)
+	ShortLivedCert7DaysDate     = time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC)
 	CABV148Date                 = time.Date(2017, time.June, 8, 0, 0, 0, 0, time.UTC)
+	EVOrganizationIDDate        = time.Date(2020, time.January, 31, 0, 0, 0, 0, time.UTC)
+	EtsiEn319_412_1_V1_1_1_Date = time.Date(2016, time.February, 1, 0, 0, 0, 0, time.UTC)
+	EtsiEn319_412_2_V2_1_1_Date = time.Date(2016, time.February, 1, 0, 0, 0, 0, time.UTC)
+	EtsiEn319_412_3_V1_1_1_Date = time.Date(2016, time.February, 1, 0, 0, 0, 0, time.UTC)
 	EtsiEn319_412_5_V2_2_1_Date = time.Date(2017, time.November, 1, 0, 0, 0, 0, time.UTC)
+	EtsiEn319_411_2_V2_1_1_Date = time.Date(2016, time.February, 1, 0, 0, 0, 0, time.UTC)
+	EtsiTs119_495_V1_1_2_Date   = time.Date(2018, time.July, 1, 0, 0, 0, 0, time.UTC)
 	OnionOnlyEVDate             = time.Date(2015, time.May, 1, 0, 0, 0, 0, time.UTC)
+	OnionV2SunsetDate           = time.Date(2021, time.July, 1, 0, 0, 0, 0, time.UTC)
 	CABV201Date                 = time.Date(2017, time.July, 28, 0, 0, 0, 0, time.UTC)
 	AppleCTPolicyDate           = time.Date(2018, time.October, 15, 0, 0, 0, 0, time.UTC)
+	ChromeCTPolicyDate          = time.Date(2022, time.April, 15, 0, 0, 0, 0, time.UTC)
 	MozillaPolicy22Date         = time.Date(2013, time.July, 26, 0, 0, 0, 0, time.UTC)
 	MozillaPolicy24Date         = time.Date(2017, time.February, 28, 0, 0, 0, 0, time.UTC)
 	MozillaPolicy27Date         = time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	AppleTLSRequirementsDate    = time.Date(2019, time.July, 1, 0, 0, 0, 0, time.UTC)
+	RFC6149Date                 = time.Date(2011, time.March, 1, 0, 0, 0, 0, time.UTC)
+	RFC6151Date                 = time.Date(2011, time.March, 1, 0, 0, 0, 0, time.UTC)
+	BSIRSA3000BitDate           = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	BSIForecastEndDate          = time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
 	AppleReducedLifetimeDate    = time.Date(2020, time.September, 1, 0, 0, 0, 0, time.UTC)
 )
 
+// NoWellDefinedExpiration is the notAfter RFC 5280 assigns to certificates
+// that have no well-defined expiration date, 99991231235959Z.
+var NoWellDefinedExpiration = time.Date(9999, time.December, 31, 23, 59, 59, 0, time.UTC)
+
+// HasNoWellDefinedExpiration returns true if the certificate's notAfter is the
+// special value indicating it has no well-defined expiration date. Lints that
+// measure the validity period should treat such certificates as having
+// a period of unbounded length rather than one of several thousand years.
+func HasNoWellDefinedExpiration(c *x509.Certificate) bool {
+	return c.NotAfter.Equal(NoWellDefinedExpiration)
+}
+
+// DefaultMaxNotBeforeBackdate is the longest period by which the notBefore of
+// a certificate with embedded SCTs may precede the earliest SCT timestamp
+// before w_not_before_backdated_beyond_window reports it, unless
+// lint.Config.MaxNotBeforeBackdate changes it.
+const DefaultMaxNotBeforeBackdate = 48 * time.Hour
+
 func FindTimeType(firstDate, secondDate asn1.RawValue) (int, int) {
 	return firstDate.Tag, secondDate.Tag
 }
@@ -68,6 +110,14 @@ func FindTimeType(firstDate, secondDate asn1.RawValue) (int, int) {
 // after my quick fixes for the ineffassigns) and would be a good candidate for
 // clean-up/refactoring.
 func GetTimes(cert *x509.Certificate) (asn1.RawValue, asn1.RawValue) {
+	times := cached(cert, "times", func() interface{} {
+		notBefore, notAfter := getTimes(cert)
+		return [2]asn1.RawValue{notBefore, notAfter}
+	}).([2]asn1.RawValue)
+	return times[0], times[1]
+}
+
+func getTimes(cert *x509.Certificate) (asn1.RawValue, asn1.RawValue) {
 	var outSeq, firstDate, secondDate asn1.RawValue
 	// Unmarshal into the sequence
 	_, err := asn1.Unmarshal(cert.RawTBSCertificate, &outSeq)
@@ -0,0 +1,64 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"encoding/asn1"
+	"errors"
+
+	"github.com/zmap/zcrypto/x509"
+)
+
+// TLSFeatureStatusRequest is the TLS extension number of status_request, the
+// TLS feature asserted by "OCSP Must-Staple" certificates. See RFC 7633.
+const TLSFeatureStatusRequest = 5
+
+// TLSFeatureStatusRequestV2 is the TLS extension number of status_request_v2
+// (RFC 6961), which can also be asserted in the TLS Feature extension.
+const TLSFeatureStatusRequestV2 = 17
+
+// GetTLSFeatures returns the TLS extension numbers listed in the certificate's
+// TLS Feature extension (RFC 7633), or nil if the extension is absent. An
+// error is returned if the extension can not be parsed.
+func GetTLSFeatures(c *x509.Certificate) ([]int, error) {
+	ext := GetExtFromCert(c, TLSFeatureOID)
+	if ext == nil {
+		return nil, nil
+	}
+	var features []int
+	rest, err := asn1.Unmarshal(ext.Value, &features)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("trailing data after TLS Feature extension")
+	}
+	return features, nil
+}
+
+// IsMustStaple returns true if the certificate's TLS Feature extension asserts
+// the status_request feature.
+func IsMustStaple(c *x509.Certificate) bool {
+	features, err := GetTLSFeatures(c)
+	if err != nil {
+		return false
+	}
+	for _, f := range features {
+		if f == TLSFeatureStatusRequest {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/zmap/zcrypto/x509"
+)
+
+// ValidityPeriod is a maximum certificate validity period expressed either in
+// calendar months or in days.
+//
+// The Baseline Requirements define the validity period of a certificate as
+// the period from notBefore through notAfter, inclusive, and measure a day as
+// 86,400 seconds. A certificate valid for exactly one day therefore has
+// a notAfter one second less than 24 hours after its notBefore.
+type ValidityPeriod struct {
+	Months int
+	Days   int
+}
+
+// End returns the latest notAfter a certificate with the given notBefore may
+// have without exceeding the period.
+func (p ValidityPeriod) End(notBefore time.Time) time.Time {
+	end := AddMonths(notBefore, p.Months).Add(time.Duration(p.Days) * 24 * time.Hour)
+	return end.Add(-time.Second)
+}
+
+// ExceededBy returns true if a certificate valid from notBefore to notAfter
+// is valid for longer than the period.
+func (p ValidityPeriod) ExceededBy(notBefore, notAfter time.Time) bool {
+	return notAfter.After(p.End(notBefore))
+}
+
+// ValidityDuration returns the validity period of c as defined by the
+// Baseline Requirements: the time from notBefore through notAfter, inclusive.
+// Periods too long to represent, such as those of certificates with no
+// well-defined expiration date, are clamped to the maximum time.Duration.
+func ValidityDuration(c *x509.Certificate) time.Duration {
+	d := c.NotAfter.Sub(c.NotBefore)
+	if d == math.MaxInt64 {
+		return d
+	}
+	return d + time.Second
+}
+
+// AddMonths returns t plus the given number of calendar months. Unlike
+// time.AddDate it doesn't overflow into the following month when t falls on
+// a day the target month doesn't have: one month after January 31st is the
+// last day of February rather than the 2nd or 3rd of March.
+func AddMonths(t time.Time, months int) time.Time {
+	year, month, day := t.Date()
+	first := time.Date(year, month+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	if last := first.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+func (p ValidityPeriod) String() string {
+	if p.Months > 0 {
+		return fmt.Sprintf("%d months", p.Months)
+	}
+	return fmt.Sprintf("%d days", p.Days)
+}
+
+// ValidityLimit is a maximum validity period that applies to certificates
+// issued on or after a given date.
+type ValidityLimit struct {
+	IssuedOnOrAfter time.Time
+	Max             ValidityPeriod
+}
+
+// ValiditySchedule is a list of validity limits sorted by the date they take
+// effect. Each limit replaces the one before it.
+type ValiditySchedule []ValidityLimit
+
+// LimitFor returns the limit that applies to a certificate issued at the given
+// time. The second return value is false if the certificate predates every
+// limit in the schedule.
+func (s ValiditySchedule) LimitFor(issued time.Time) (ValidityLimit, bool) {
+	for i := len(s) - 1; i >= 0; i-- {
+		if !issued.Before(s[i].IssuedOnOrAfter) {
+			return s[i], true
+		}
+	}
+	return ValidityLimit{}, false
+}
+
+// SubscriberValiditySchedule is the maximum validity of subscriber
+// certificates required by the CA/Browser Forum Baseline Requirements over
+// time, including the reductions that have been adopted but are not yet in
+// effect.
+var SubscriberValiditySchedule = ValiditySchedule{
+	{IssuedOnOrAfter: CABEffectiveDate, Max: ValidityPeriod{Months: 60}},
+	{IssuedOnOrAfter: SubCert39Month, Max: ValidityPeriod{Months: 39}},
+	{IssuedOnOrAfter: SubCert825Days, Max: ValidityPeriod{Days: 825}},
+	{IssuedOnOrAfter: SubCert398Days, Max: ValidityPeriod{Days: 398}},
+	{IssuedOnOrAfter: SubCert200Days, Max: ValidityPeriod{Days: 200}},
+	{IssuedOnOrAfter: SubCert100Days, Max: ValidityPeriod{Days: 100}},
+	{IssuedOnOrAfter: SubCert47Days, Max: ValidityPeriod{Days: 47}},
+}
+
+// ShortLivedValiditySchedule is the maximum validity of a Short-lived
+// Subscriber Certificate as defined by the CA/Browser Forum Baseline
+// Requirements. Short-lived certificates are not required to contain CRL
+// distribution points or OCSP responder URLs.
+var ShortLivedValiditySchedule = ValiditySchedule{
+	{IssuedOnOrAfter: ShortLivedCertDate, Max: ValidityPeriod{Days: 10}},
+	{IssuedOnOrAfter: ShortLivedCert7DaysDate, Max: ValidityPeriod{Days: 7}},
+}
+
+// IsShortLivedCert returns true if the certificate was issued after the
+// Short-lived Subscriber Certificate category was introduced and its validity
+// period is within the maximum allowed for that category.
+func IsShortLivedCert(c *x509.Certificate) bool {
+	limit, ok := ShortLivedValiditySchedule.LimitFor(c.NotBefore)
+	return ok && !limit.Max.ExceededBy(c.NotBefore, c.NotAfter)
+}
@@ -0,0 +1,137 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/zmap/zcrypto/x509"
+)
+
+func TestValidityScheduleLimitFor(t *testing.T) {
+	testCases := []struct {
+		name     string
+		issued   time.Time
+		expected ValidityPeriod
+		found    bool
+	}{
+		{
+			name:   "before the BRs",
+			issued: time.Date(2010, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "first day of a limit",
+			issued:   SubCert825Days,
+			expected: ValidityPeriod{Days: 825},
+			found:    true,
+		},
+		{
+			name:     "last second of a limit",
+			issued:   SubCert398Days.Add(-time.Second),
+			expected: ValidityPeriod{Days: 825},
+			found:    true,
+		},
+		{
+			name:     "latest limit",
+			issued:   time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC),
+			expected: ValidityPeriod{Days: 47},
+			found:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			limit, found := SubscriberValiditySchedule.LimitFor(tc.issued)
+			if found != tc.found {
+				t.Fatalf("expected found %v, got %v", tc.found, found)
+			}
+			if limit.Max != tc.expected {
+				t.Errorf("expected limit %v, got %v", tc.expected, limit.Max)
+			}
+		})
+	}
+}
+
+func TestValidityPeriodExceededBy(t *testing.T) {
+	notBefore := time.Date(2020, time.January, 31, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		period   ValidityPeriod
+		notAfter time.Time
+		expected bool
+	}{
+		{ValidityPeriod{Days: 398}, notBefore.AddDate(0, 0, 398).Add(-time.Second), false},
+		{ValidityPeriod{Days: 398}, notBefore.AddDate(0, 0, 398), true},
+		// 39 months after January 31st is April 30th, not May 1st.
+		{ValidityPeriod{Months: 39}, time.Date(2023, time.April, 29, 23, 59, 59, 0, time.UTC), false},
+		{ValidityPeriod{Months: 39}, time.Date(2023, time.April, 30, 0, 0, 0, 0, time.UTC), true},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.period.ExceededBy(notBefore, tc.notAfter); got != tc.expected {
+			t.Errorf("%v exceeded by notAfter %s: expected %v, got %v", tc.period, tc.notAfter, tc.expected, got)
+		}
+	}
+}
+
+func TestAddMonths(t *testing.T) {
+	testCases := []struct {
+		start    time.Time
+		months   int
+		expected time.Time
+	}{
+		{
+			start:    time.Date(2020, time.January, 15, 12, 0, 0, 0, time.UTC),
+			months:   1,
+			expected: time.Date(2020, time.February, 15, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			start:    time.Date(2020, time.January, 31, 0, 0, 0, 0, time.UTC),
+			months:   1,
+			expected: time.Date(2020, time.February, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			start:    time.Date(2021, time.January, 31, 0, 0, 0, 0, time.UTC),
+			months:   1,
+			expected: time.Date(2021, time.February, 28, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			start:    time.Date(2020, time.October, 31, 0, 0, 0, 0, time.UTC),
+			months:   15,
+			expected: time.Date(2022, time.January, 31, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := AddMonths(tc.start, tc.months); !got.Equal(tc.expected) {
+			t.Errorf("%s plus %d months: expected %s, got %s", tc.start, tc.months, tc.expected, got)
+		}
+	}
+}
+
+func TestValidityDurationNoWellDefinedExpiration(t *testing.T) {
+	c := &x509.Certificate{
+		NotBefore: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:  NoWellDefinedExpiration,
+	}
+	if d := ValidityDuration(c); d != math.MaxInt64 {
+		t.Errorf("expected validity of %d, got %d", int64(math.MaxInt64), d)
+	}
+	c.NotAfter = c.NotBefore.Add(24*time.Hour - time.Second)
+	if d := ValidityDuration(c); d != 24*time.Hour {
+		t.Errorf("expected validity of %s, got %s", 24*time.Hour, d)
+	}
+}
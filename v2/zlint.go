@@ -17,28 +17,119 @@
 package zlint
 
 import (
+	"context"
+	stdx509 "crypto/x509"
+	"fmt"
+	"runtime"
+	"sync"
 	"time"
 
 	"github.com/zmap/zcrypto/x509"
 	"github.com/zmap/zlint/v2/lint"
 	_ "github.com/zmap/zlint/v2/lints/apple"
+	_ "github.com/zmap/zlint/v2/lints/bsi"
 	_ "github.com/zmap/zlint/v2/lints/cabf_br"
 	_ "github.com/zmap/zlint/v2/lints/cabf_ev"
+	_ "github.com/zmap/zlint/v2/lints/chrome"
 	_ "github.com/zmap/zlint/v2/lints/community"
 	_ "github.com/zmap/zlint/v2/lints/etsi"
+	_ "github.com/zmap/zlint/v2/lints/fpki"
+	_ "github.com/zmap/zlint/v2/lints/icao"
+	_ "github.com/zmap/zlint/v2/lints/igtf"
+	_ "github.com/zmap/zlint/v2/lints/microsoft"
 	_ "github.com/zmap/zlint/v2/lints/mozilla"
 	_ "github.com/zmap/zlint/v2/lints/rfc"
 )
 
 const Version int64 = 3
 
-// LintCertificate runs all registered lints on c using default options,
-// producing a ResultSet.
+// LintCertificate runs all registered lints on c, producing a ResultSet. The
+// defaults can be changed with opts, e.g. WithConcurrency.
 //
 // Using LintCertificate(c) is equivalent to calling LintCertificateEx(c, nil).
-func LintCertificate(c *x509.Certificate) *ResultSet {
+func LintCertificate(c *x509.Certificate, opts ...Option) *ResultSet {
 	// Run all lints from the global registry
-	return LintCertificateEx(c, nil)
+	return LintCertificateEx(c, nil, opts...)
+}
+
+// Options configures how LintCertificateWithOptions lints a certificate.
+type Options struct {
+	// Registry is the registry of lints to run. If nil the global registry of
+	// all lints is used.
+	Registry lint.Registry
+	// Concurrency is the maximum number of lints run in parallel for the
+	// certificate. Values less than 2 run the lints one at a time.
+	Concurrency int
+	// Fast skips lints tagged lint.TagExpensive, for callers with a tight
+	// latency budget. Skipped lints have the lint.Skipped status in the
+	// ResultSet unless they are known not to apply to the certificate.
+	Fast bool
+	// Tracer, if set, is notified as the certificate is linted, e.g. to record
+	// a trace span or metrics for it.
+	Tracer Tracer
+	// Context, if set, stops linting early once it is done. Lints that haven't
+	// started by then have the lint.Canceled status in the ResultSet, so they
+	// can be told apart from lints skipped by Fast.
+	Context context.Context
+	// ReferenceTime, if set, is recorded as the ResultSet's Timestamp instead
+	// of the time linting finished, and lints that compare against the current
+	// time evaluate the certificate at it, e.g. to make output reproducible.
+	// It takes precedence over Config.ReferenceTime.
+	ReferenceTime time.Time
+	// Config, if set, holds the settings of the lint run that the results of
+	// some lints depend on. If nil the defaults are used.
+	Config *lint.Config
+}
+
+// Option sets one of the Options used to lint a certificate, as an argument
+// to LintCertificate or LintCertificateEx.
+type Option func(*Options)
+
+// WithRegistry sets the registry of lints to run.
+func WithRegistry(registry lint.Registry) Option {
+	return func(o *Options) { o.Registry = registry }
+}
+
+// WithConcurrency sets the maximum number of lints run in parallel.
+func WithConcurrency(concurrency int) Option {
+	return func(o *Options) { o.Concurrency = concurrency }
+}
+
+// WithFast skips lints tagged lint.TagExpensive.
+func WithFast() Option {
+	return func(o *Options) { o.Fast = true }
+}
+
+// WithTracer sets the Tracer notified as the certificate is linted.
+func WithTracer(tracer Tracer) Option {
+	return func(o *Options) { o.Tracer = tracer }
+}
+
+// WithContext sets the context that stops linting early once it is done.
+func WithContext(ctx context.Context) Option {
+	return func(o *Options) { o.Context = ctx }
+}
+
+// WithReferenceTime sets the time recorded as the ResultSet's Timestamp and
+// that lints comparing against the current time evaluate the certificate at.
+func WithReferenceTime(t time.Time) Option {
+	return func(o *Options) { o.ReferenceTime = t }
+}
+
+// WithConfig sets the settings of the lint run that the results of some lints
+// depend on.
+func WithConfig(config *lint.Config) Option {
+	return func(o *Options) { o.Config = config }
+}
+
+// Tracer is notified as certificates are linted, letting embedders record
+// traces and metrics with the observability library of their choice without
+// zlint depending on it. See the zlintotel module for an OpenTelemetry
+// implementation.
+type Tracer interface {
+	// StartCertificate is called before c is linted. The returned function
+	// is called with the results once linting c has finished.
+	StartCertificate(c *x509.Certificate) (end func(*ResultSet))
 }
 
 // LintCertificateEx runs lints from the provided registry on c producing
@@ -46,17 +137,151 @@ func LintCertificate(c *x509.Certificate) *ResultSet {
 // lints that will be run. (See lint.Registry.Filter())
 //
 // If registry is nil then the global registry of all lints is used and this
-// function is equivalent to calling LintCertificate(c).
-func LintCertificateEx(c *x509.Certificate, registry lint.Registry) *ResultSet {
+// function is equivalent to calling LintCertificate(c). The remaining defaults
+// can be changed with opts; a WithRegistry option takes precedence over
+// registry.
+func LintCertificateEx(c *x509.Certificate, registry lint.Registry, opts ...Option) *ResultSet {
+	return LintCertificateWithOptions(c, newOptions(registry, opts))
+}
+
+// LintCertificateWithOptions runs lints on c as configured by opts, producing
+// a ResultSet. Since lints are independent of each other, setting
+// opts.Concurrency reduces the time taken to lint a single certificate on
+// multi-core machines without changing the result.
+func LintCertificateWithOptions(c *x509.Certificate, opts Options) *ResultSet {
 	if c == nil {
 		return nil
 	}
-	if registry == nil {
-		registry = lint.GlobalRegistry()
+	return lintCertificate(c, newLintPlan(opts.registry(), opts.Fast), opts)
+}
+
+// newOptions returns the Options for linting with registry and opts.
+func newOptions(registry lint.Registry, opts []Option) Options {
+	options := Options{Registry: registry}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// registry returns the registry of lints to run, which is never nil.
+func (opts Options) registry() lint.Registry {
+	if opts.Registry == nil {
+		return lint.GlobalRegistry()
+	}
+	return opts.Registry
+}
+
+// LintStdCertificate runs all registered lints on a certificate parsed by the
+// standard library's crypto/x509 package, producing a ResultSet. The
+// certificate is re-parsed from c.Raw with zcrypto, so callers holding
+// standard library certificates need not import zcrypto themselves.
+//
+// An error wrapping ErrNotACertificate is returned if c is nil or zcrypto
+// can't parse its DER. The defaults can be changed with opts, as for
+// LintCertificate. Use LintStdCertificateEx to lint with a filtered registry.
+func LintStdCertificate(c *stdx509.Certificate, opts ...Option) (*ResultSet, error) {
+	return LintStdCertificateEx(c, nil, opts...)
+}
+
+// LintStdCertificateEx is like LintStdCertificate but runs lints from the
+// provided registry, or the global registry if it is nil.
+func LintStdCertificateEx(c *stdx509.Certificate, registry lint.Registry, opts ...Option) (*ResultSet, error) {
+	if c == nil {
+		return nil, fmt.Errorf("%w: nil certificate", ErrNotACertificate)
+	}
+	zc, err := x509.ParseCertificate(c.Raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrNotACertificate, err)
+	}
+	return LintCertificateEx(zc, registry, opts...), nil
+}
+
+// lintCertificate lints c with the lints in plan. The Registry and Fast fields
+// of opts are ignored in favour of plan.
+func lintCertificate(c *x509.Certificate, plan *lintPlan, opts Options) *ResultSet {
+	var end func(*ResultSet)
+	if opts.Tracer != nil {
+		end = opts.Tracer.StartCertificate(c)
 	}
+	config := opts.lintConfig()
 	res := new(ResultSet)
-	res.execute(c, registry)
+	res.execute(opts.Context, c, plan, opts.Concurrency, config)
 	res.Version = Version
-	res.Timestamp = time.Now().Unix()
+	if config.ReferenceTime.IsZero() {
+		res.Timestamp = time.Now().Unix()
+	} else {
+		res.Timestamp = config.ReferenceTime.Unix()
+	}
+	if end != nil {
+		end(res)
+	}
 	return res
 }
+
+// lintConfig returns the lint.Config to lint with, which is never nil:
+// opts.Config with opts.ReferenceTime applied.
+func (opts Options) lintConfig() *lint.Config {
+	var config lint.Config
+	if opts.Config != nil {
+		config = *opts.Config
+	}
+	if !opts.ReferenceTime.IsZero() {
+		config.ReferenceTime = opts.ReferenceTime
+	}
+	return &config
+}
+
+// CertificateResult is the result of linting one of the certificates given to
+// LintCertificates.
+type CertificateResult struct {
+	// Index is the index of the certificate in the slice given to
+	// LintCertificates.
+	Index       int
+	Certificate *x509.Certificate
+	// ResultSet is nil if the certificate was nil.
+	ResultSet *ResultSet
+}
+
+// LintCertificates runs lints from the provided registry on each of certs,
+// producing a CertificateResult for each on the returned channel. The lints
+// to run are looked up in the registry once rather than once per certificate,
+// and certificates are linted in parallel, so embedders linting batches of
+// certificates should prefer it to calling LintCertificateEx in a loop.
+//
+// Results are delivered in the order the certificates finish linting, which
+// may differ from their order in certs. The channel is closed once every
+// certificate has been linted and must be drained by the caller.
+//
+// If registry is nil then the global registry of all lints is used. The
+// remaining defaults can be changed with opts, which apply to every
+// certificate, as for LintCertificateEx.
+func LintCertificates(certs []*x509.Certificate, registry lint.Registry, opts ...Option) <-chan CertificateResult {
+	options := newOptions(registry, opts)
+	plan := newLintPlan(options.registry(), options.Fast)
+	results := make(chan CertificateResult)
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < runtime.GOMAXPROCS(0) && w < len(certs); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				result := CertificateResult{Index: i, Certificate: certs[i]}
+				if certs[i] != nil {
+					result.ResultSet = lintCertificate(certs[i], plan, options)
+				}
+				results <- result
+			}
+		}()
+	}
+	go func() {
+		for i := range certs {
+			indexes <- i
+		}
+		close(indexes)
+		wg.Wait()
+		close(results)
+	}()
+	return results
+}
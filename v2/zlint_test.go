@@ -1,9 +1,19 @@
 package zlint
 
 import (
+	"context"
+	stdx509 "crypto/x509"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"runtime"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/zmap/zcrypto/x509"
 	"github.com/zmap/zlint/v2/lint"
 )
 
@@ -28,3 +38,249 @@ func TestLintNames(t *testing.T) {
 		}
 	}
 }
+
+func TestLintCertificateConcurrency(t *testing.T) {
+	block, _ := pem.Decode([]byte(bigCertificatePem))
+	c, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("unable to parse certificate: %v", err)
+	}
+
+	expected := LintCertificate(c)
+	for _, concurrency := range []int{2, runtime.NumCPU(), 1000} {
+		actual := LintCertificateWithOptions(c, Options{Concurrency: concurrency})
+		if !reflect.DeepEqual(actual.Results, expected.Results) {
+			t.Errorf("concurrency %d: results differ from sequential linting", concurrency)
+		}
+		if actual.NoticesPresent != expected.NoticesPresent ||
+			actual.WarningsPresent != expected.WarningsPresent ||
+			actual.ErrorsPresent != expected.ErrorsPresent ||
+			actual.FatalsPresent != expected.FatalsPresent {
+			t.Errorf("concurrency %d: result summary differs from sequential linting", concurrency)
+		}
+	}
+}
+
+func TestLintStdCertificate(t *testing.T) {
+	block, _ := pem.Decode([]byte(bigCertificatePem))
+	std, err := stdx509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("unable to parse certificate: %v", err)
+	}
+	c, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("unable to parse certificate: %v", err)
+	}
+
+	actual, err := LintStdCertificate(std)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := LintCertificate(c); !reflect.DeepEqual(actual.Results, expected.Results) {
+		t.Error("results differ from linting the zcrypto certificate")
+	}
+	reference := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	if rs, err := LintStdCertificate(std, WithReferenceTime(reference)); err != nil || rs.Timestamp != reference.Unix() {
+		t.Errorf("expected timestamp %d, got %v (%v)", reference.Unix(), rs, err)
+	}
+
+	if _, err := LintStdCertificate(nil); !errors.Is(err, ErrNotACertificate) {
+		t.Errorf("expected ErrNotACertificate for a nil certificate, got %v", err)
+	}
+	if _, err := LintStdCertificate(&stdx509.Certificate{Raw: []byte("not a certificate")}); !errors.Is(err, ErrNotACertificate) {
+		t.Errorf("expected ErrNotACertificate for a certificate with invalid DER, got %v", err)
+	}
+}
+
+func TestLintCertificateOptions(t *testing.T) {
+	block, _ := pem.Decode([]byte(bigCertificatePem))
+	c, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("unable to parse certificate: %v", err)
+	}
+	registry, err := lint.GlobalRegistry().Filter(lint.FilterOptions{
+		IncludeSources: lint.SourceList{lint.RFC5280},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := LintCertificateEx(c, registry)
+	reference := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	actual := LintCertificate(c, WithRegistry(registry), WithConcurrency(4), WithReferenceTime(reference))
+	if !reflect.DeepEqual(actual.Results, expected.Results) {
+		t.Error("results differ from LintCertificateEx")
+	}
+	if actual.Timestamp != reference.Unix() {
+		t.Errorf("expected timestamp %d, got %d", reference.Unix(), actual.Timestamp)
+	}
+	configured := LintCertificate(c, WithRegistry(registry), WithConfig(&lint.Config{ReferenceTime: reference}))
+	if configured.Timestamp != reference.Unix() {
+		t.Errorf("expected the configured reference time %d as the timestamp, got %d", reference.Unix(), configured.Timestamp)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, concurrency := range []int{0, 4} {
+		canceled := LintCertificateEx(c, registry, WithContext(ctx), WithConcurrency(concurrency))
+		if len(canceled.Results) != len(expected.Results) {
+			t.Fatalf("concurrency %d: expected %d results, got %d", concurrency, len(expected.Results), len(canceled.Results))
+		}
+		for name, result := range canceled.Results {
+			if result.Status != lint.Canceled && result.Status != lint.NA {
+				t.Errorf("concurrency %d: %s: expected a canceled lint, got %v", concurrency, name, result.Status)
+			}
+		}
+	}
+}
+
+func TestLintCertificateFast(t *testing.T) {
+	block, _ := pem.Decode([]byte(bigCertificatePem))
+	c, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("unable to parse certificate: %v", err)
+	}
+
+	expected := LintCertificate(c)
+	actual := LintCertificateWithOptions(c, Options{Fast: true})
+	var skipped int
+	for name, result := range actual.Results {
+		if !lint.GlobalRegistry().ByName(name).HasTag(lint.TagExpensive) {
+			if !reflect.DeepEqual(result, expected.Results[name]) {
+				t.Errorf("%s: expected %v, got %v", name, expected.Results[name].Status, result.Status)
+			}
+			continue
+		}
+		if result.Status == lint.Skipped {
+			skipped++
+		} else if result.Status != lint.NA {
+			t.Errorf("%s: expected expensive lint to be skipped, got %v", name, result.Status)
+		}
+	}
+	if skipped == 0 {
+		t.Error("expected at least one expensive lint to be skipped")
+	}
+}
+
+// testTracer records the certificates it was notified of.
+type testTracer struct {
+	started []*x509.Certificate
+	ended   []*ResultSet
+}
+
+func (t *testTracer) StartCertificate(c *x509.Certificate) func(*ResultSet) {
+	t.started = append(t.started, c)
+	return func(rs *ResultSet) { t.ended = append(t.ended, rs) }
+}
+
+func TestLintCertificateTracer(t *testing.T) {
+	block, _ := pem.Decode([]byte(bigCertificatePem))
+	c, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("unable to parse certificate: %v", err)
+	}
+
+	tracer := &testTracer{}
+	rs := LintCertificateWithOptions(c, Options{Tracer: tracer})
+	if len(tracer.started) != 1 || tracer.started[0] != c {
+		t.Errorf("expected the tracer to be started once for the certificate, got %v", tracer.started)
+	}
+	if len(tracer.ended) != 1 || tracer.ended[0] != rs {
+		t.Errorf("expected the tracer to be ended once with the results, got %v", tracer.ended)
+	}
+}
+
+func TestLintCertificates(t *testing.T) {
+	paths, err := filepath.Glob("testdata/ev*.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var certs []*x509.Certificate
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		block, _ := pem.Decode(data)
+		c, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			t.Fatalf("unable to parse %s: %v", path, err)
+		}
+		certs = append(certs, c)
+	}
+	certs = append(certs, nil)
+
+	registry, err := lint.GlobalRegistry().Filter(lint.FilterOptions{
+		IncludeSources: lint.SourceList{lint.CABFEVGuidelines},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := make(map[int]bool)
+	for result := range LintCertificates(certs, registry) {
+		if seen[result.Index] {
+			t.Errorf("certificate %d: linted more than once", result.Index)
+		}
+		seen[result.Index] = true
+		if result.Certificate != certs[result.Index] {
+			t.Errorf("certificate %d: result is for the wrong certificate", result.Index)
+		}
+		expected := LintCertificateEx(certs[result.Index], registry)
+		if expected == nil {
+			if result.ResultSet != nil {
+				t.Errorf("certificate %d: expected no results for a nil certificate", result.Index)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(result.ResultSet.Results, expected.Results) {
+			t.Errorf("certificate %d: results differ from LintCertificateEx", result.Index)
+		}
+	}
+	if len(seen) != len(certs) {
+		t.Errorf("expected results for %d certificates, got %d", len(certs), len(seen))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for result := range LintCertificates(certs, registry, WithContext(ctx)) {
+		if result.ResultSet == nil {
+			continue
+		}
+		for name, lintResult := range result.ResultSet.Results {
+			if lintResult.Status != lint.Canceled && lintResult.Status != lint.NA {
+				t.Errorf("certificate %d: %s: expected a canceled lint, got %v", result.Index, name, lintResult.Status)
+			}
+		}
+	}
+}
+
+// TestApplicabilityMatchesCheckApplies checks that no lint's Applicability is
+// stricter than its CheckApplies for any of the test certificates, so that
+// skipping unmatched applicability groups never changes a result.
+func TestApplicabilityMatchesCheckApplies(t *testing.T) {
+	paths, err := filepath.Glob("testdata/*.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry := lint.GlobalRegistry()
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		block, _ := pem.Decode(data)
+		if block == nil {
+			continue
+		}
+		c, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			continue
+		}
+		for _, name := range registry.Names() {
+			l := registry.ByName(name)
+			if !l.Applicability.Matches(c) && l.Lint.CheckApplies(c) {
+				t.Errorf("%s: %s applies but its Applicability does not match", path, name)
+			}
+		}
+	}
+}
@@ -0,0 +1,83 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+// Package acm lists the certificates managed by AWS Certificate Manager for
+// zlintcloud.
+package acm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/acm"
+	"github.com/aws/aws-sdk-go-v2/service/acm/types"
+	"github.com/zmap/zlint/v2/zlintcloud"
+)
+
+// API is the part of *acm.Client used by a Source.
+type API interface {
+	acm.ListCertificatesAPIClient
+	GetCertificate(ctx context.Context, params *acm.GetCertificateInput, optFns ...func(*acm.Options)) (*acm.GetCertificateOutput, error)
+}
+
+// Source lists the issued certificates of an account in a region.
+type Source struct {
+	client  API
+	account string
+}
+
+// New returns a Source listing certificates with client, which must be
+// configured for a region. account names the account and region in reports,
+// e.g. "aws:123456789012/us-east-1".
+func New(client API, account string) *Source {
+	return &Source{client: client, account: account}
+}
+
+// Account implements zlintcloud.Source.
+func (s *Source) Account() string {
+	return s.account
+}
+
+// Certificates implements zlintcloud.Source. Only the leaf certificate of
+// each issued or imported certificate is listed, not its chain.
+func (s *Source) Certificates(ctx context.Context, fn func(zlintcloud.Certificate) error) error {
+	// ListCertificates only returns RSA_2048 certificates unless other key
+	// types are asked for.
+	keyTypes := types.KeyAlgorithm("").Values()
+	pages := acm.NewListCertificatesPaginator(s.client, &acm.ListCertificatesInput{
+		CertificateStatuses: []types.CertificateStatus{types.CertificateStatusIssued},
+		Includes:            &types.Filters{KeyTypes: keyTypes},
+	})
+	for pages.HasMorePages() {
+		page, err := pages.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("unable to list certificates: %v", err)
+		}
+		for _, summary := range page.CertificateSummaryList {
+			arn := aws.ToString(summary.CertificateArn)
+			c := zlintcloud.Certificate{ID: arn}
+			out, err := s.client.GetCertificate(ctx, &acm.GetCertificateInput{CertificateArn: summary.CertificateArn})
+			if err != nil {
+				c.Err = err
+			} else {
+				c.DER, c.Err = zlintcloud.DecodePEM(aws.ToString(out.Certificate))
+			}
+			if err := fn(c); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package acm
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/acm"
+	"github.com/aws/aws-sdk-go-v2/service/acm/types"
+	"github.com/zmap/zlint/v2/zlintcloud"
+)
+
+type fakeACM struct {
+	pem   string
+	input *acm.ListCertificatesInput
+}
+
+func (f *fakeACM) ListCertificates(ctx context.Context, in *acm.ListCertificatesInput, optFns ...func(*acm.Options)) (*acm.ListCertificatesOutput, error) {
+	f.input = in
+	if in.NextToken == nil {
+		return &acm.ListCertificatesOutput{
+			CertificateSummaryList: []types.CertificateSummary{{CertificateArn: aws.String("arn:1")}},
+			NextToken:              aws.String("page2"),
+		}, nil
+	}
+	return &acm.ListCertificatesOutput{
+		CertificateSummaryList: []types.CertificateSummary{{CertificateArn: aws.String("arn:2")}},
+	}, nil
+}
+
+func (f *fakeACM) GetCertificate(ctx context.Context, in *acm.GetCertificateInput, optFns ...func(*acm.Options)) (*acm.GetCertificateOutput, error) {
+	if aws.ToString(in.CertificateArn) == "arn:2" {
+		return nil, errors.New("access denied")
+	}
+	return &acm.GetCertificateOutput{Certificate: aws.String(f.pem)}, nil
+}
+
+func TestCertificates(t *testing.T) {
+	pemBytes, err := ioutil.ReadFile("../../testdata/badRsaExp.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &fakeACM{pem: string(pemBytes)}
+	s := New(client, "aws:1/us-east-1")
+	var certs []zlintcloud.Certificate
+	if err := s.Certificates(context.Background(), func(c zlintcloud.Certificate) error {
+		certs = append(certs, c)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(certs) != 2 || certs[0].ID != "arn:1" || certs[1].ID != "arn:2" {
+		t.Fatalf("expected arn:1 and arn:2 from both pages, got %+v", certs)
+	}
+	if len(certs[0].DER) == 0 || certs[0].Err != nil {
+		t.Errorf("expected arn:1's DER, got %+v", certs[0])
+	}
+	if certs[1].Err == nil {
+		t.Error("expected arn:2's error")
+	}
+	if len(client.input.Includes.KeyTypes) < 2 {
+		t.Errorf("expected all key types to be listed, got %v", client.input.Includes.KeyTypes)
+	}
+}
@@ -0,0 +1,68 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+// Package cas lists the certificates issued by a Google Cloud Certificate
+// Authority Service CA pool for zlintcloud.
+package cas
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	privateca "cloud.google.com/go/security/privateca/apiv1"
+	"cloud.google.com/go/security/privateca/apiv1/privatecapb"
+	"github.com/zmap/zlint/v2/zlintcloud"
+	"google.golang.org/api/iterator"
+)
+
+// Source lists the certificates issued by a CA pool.
+type Source struct {
+	client *privateca.CertificateAuthorityClient
+	pool   string
+}
+
+// New returns a Source listing the certificates of pool, the resource name of
+// a CA pool, e.g. projects/my-project/locations/us-central1/caPools/my-pool.
+func New(client *privateca.CertificateAuthorityClient, pool string) *Source {
+	return &Source{client: client, pool: pool}
+}
+
+// Account implements zlintcloud.Source, naming the pool's project, e.g.
+// "gcp:my-project".
+func (s *Source) Account() string {
+	parts := strings.Split(s.pool, "/")
+	if len(parts) > 1 && parts[0] == "projects" {
+		return "gcp:" + parts[1]
+	}
+	return "gcp:" + s.pool
+}
+
+// Certificates implements zlintcloud.Source.
+func (s *Source) Certificates(ctx context.Context, fn func(zlintcloud.Certificate) error) error {
+	it := s.client.ListCertificates(ctx, &privatecapb.ListCertificatesRequest{Parent: s.pool})
+	for {
+		cert, err := it.Next()
+		if err == iterator.Done {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("unable to list certificates of %s: %v", s.pool, err)
+		}
+		c := zlintcloud.Certificate{ID: cert.Name}
+		c.DER, c.Err = zlintcloud.DecodePEM(cert.PemCertificate)
+		if err := fn(c); err != nil {
+			return err
+		}
+	}
+}
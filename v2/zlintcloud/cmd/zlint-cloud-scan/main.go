@@ -0,0 +1,136 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+// zlint-cloud-scan lints the certificates managed by AWS Certificate Manager,
+// Google Cloud Certificate Authority Service and Azure Key Vault, using the
+// ambient credentials of each provider, and writes a JSON report of the
+// findings by account.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"strings"
+
+	privateca "cloud.google.com/go/security/privateca/apiv1"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azcertificates"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	awsacm "github.com/aws/aws-sdk-go-v2/service/acm"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	log "github.com/sirupsen/logrus"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/zlintcloud"
+	"github.com/zmap/zlint/v2/zlintcloud/acm"
+	"github.com/zmap/zlint/v2/zlintcloud/cas"
+	"github.com/zmap/zlint/v2/zlintcloud/keyvault"
+)
+
+func main() {
+	scanAWS := flag.Bool("aws", false, "Scan AWS Certificate Manager, with credentials from the default AWS configuration")
+	awsRegions := flag.String("aws-regions", "", "Comma-separated list of AWS regions to scan (default the configured region)")
+	gcpPools := flag.String("gcp-ca-pools", "", "Comma-separated list of Certificate Authority Service CA pools to scan, e.g. projects/p/locations/us-central1/caPools/pool")
+	azureVaults := flag.String("azure-vaults", "", "Comma-separated list of Azure Key Vault URLs to scan, e.g. https://myvault.vault.azure.net")
+	excludeSources := flag.String("excludeSources", "", "Comma-separated list of lint sources to exclude")
+	pretty := flag.Bool("pretty", false, "Pretty-print output")
+	flag.Parse()
+
+	ctx := context.Background()
+	var sources []zlintcloud.Source
+	if *scanAWS {
+		sources = append(sources, awsSources(ctx, list(*awsRegions))...)
+	}
+	if pools := list(*gcpPools); len(pools) > 0 {
+		client, err := privateca.NewCertificateAuthorityClient(ctx)
+		if err != nil {
+			log.Fatalf("unable to create Certificate Authority Service client: %s", err)
+		}
+		defer client.Close()
+		for _, pool := range pools {
+			sources = append(sources, cas.New(client, pool))
+		}
+	}
+	if vaults := list(*azureVaults); len(vaults) > 0 {
+		cred, err := azidentity.NewDefaultAzureCredential(nil)
+		if err != nil {
+			log.Fatalf("unable to load Azure credentials: %s", err)
+		}
+		for _, vaultURL := range vaults {
+			client, err := azcertificates.NewClient(vaultURL, cred, nil)
+			if err != nil {
+				log.Fatalf("unable to create Key Vault client for %s: %s", vaultURL, err)
+			}
+			sources = append(sources, keyvault.New(client, vaultURL))
+		}
+	}
+	if len(sources) == 0 {
+		log.Fatal("nothing to scan: use -aws, -gcp-ca-pools or -azure-vaults")
+	}
+
+	registry := lint.GlobalRegistry()
+	if *excludeSources != "" {
+		var opts lint.FilterOptions
+		if err := opts.ExcludeSources.FromString(*excludeSources); err != nil {
+			log.Fatalf("invalid -excludeSources: %s", err)
+		}
+		var err error
+		if registry, err = registry.Filter(opts); err != nil {
+			log.Fatalf("unable to filter lints: %s", err)
+		}
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	if *pretty {
+		enc.SetIndent("", " ")
+	}
+	if err := enc.Encode(zlintcloud.Scan(ctx, sources, registry)); err != nil {
+		log.Fatalf("unable to write report: %s", err)
+	}
+}
+
+// awsSources returns an ACM source for each of regions, or for the configured
+// region if there are none.
+func awsSources(ctx context.Context, regions []string) []zlintcloud.Source {
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		log.Fatalf("unable to load AWS configuration: %s", err)
+	}
+	identity, err := sts.NewFromConfig(cfg).GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		log.Fatalf("unable to identify AWS account: %s", err)
+	}
+	if len(regions) == 0 {
+		regions = []string{cfg.Region}
+	}
+	var sources []zlintcloud.Source
+	for _, region := range regions {
+		client := awsacm.NewFromConfig(cfg, func(o *awsacm.Options) { o.Region = region })
+		sources = append(sources, acm.New(client, "aws:"+aws.ToString(identity.Account)+"/"+region))
+	}
+	return sources
+}
+
+// list splits a comma-separated flag value.
+func list(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,74 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+// Package keyvault lists the certificates stored in an Azure Key Vault for
+// zlintcloud.
+package keyvault
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azcertificates"
+	"github.com/zmap/zlint/v2/zlintcloud"
+)
+
+// Source lists the current version of each certificate in a vault.
+type Source struct {
+	client   *azcertificates.Client
+	vaultURL string
+}
+
+// New returns a Source listing certificates with client, which must be for
+// the vault at vaultURL.
+func New(client *azcertificates.Client, vaultURL string) *Source {
+	return &Source{client: client, vaultURL: vaultURL}
+}
+
+// Account implements zlintcloud.Source, naming the vault, e.g.
+// "azure:myvault.vault.azure.net".
+func (s *Source) Account() string {
+	if u, err := url.Parse(s.vaultURL); err == nil && u.Host != "" {
+		return "azure:" + u.Host
+	}
+	return "azure:" + s.vaultURL
+}
+
+// Certificates implements zlintcloud.Source.
+func (s *Source) Certificates(ctx context.Context, fn func(zlintcloud.Certificate) error) error {
+	pager := s.client.NewListCertificatePropertiesPager(nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("unable to list certificates: %v", err)
+		}
+		for _, props := range page.Value {
+			if props.ID == nil {
+				continue
+			}
+			c := zlintcloud.Certificate{ID: string(*props.ID)}
+			resp, err := s.client.GetCertificate(ctx, props.ID.Name(), "", nil)
+			if err != nil {
+				c.Err = err
+			} else {
+				c.DER = resp.CER
+			}
+			if err := fn(c); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+// Package zlintcloud lints the certificates managed by cloud certificate
+// services and reports the findings by account. The acm, cas and keyvault
+// packages provide Sources for AWS Certificate Manager, Google Cloud
+// Certificate Authority Service and Azure Key Vault.
+package zlintcloud
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2"
+	"github.com/zmap/zlint/v2/lint"
+)
+
+// Certificate is a certificate listed by a Source.
+type Certificate struct {
+	// ID identifies the certificate within the service, e.g. its ARN.
+	ID string
+	// DER is the certificate's DER encoding.
+	DER []byte
+	// Err is set instead of DER if the certificate couldn't be read.
+	Err error
+}
+
+// Source lists the certificates of an account with a cloud service.
+type Source interface {
+	// Account names the account, e.g. "aws:123456789012/us-east-1".
+	Account() string
+	// Certificates calls fn with each certificate, stopping at the first
+	// error returned by fn.
+	Certificates(ctx context.Context, fn func(Certificate) error) error
+}
+
+// Report is the result of a Scan, by account.
+type Report struct {
+	Accounts map[string]*AccountReport `json:"accounts"`
+}
+
+// AccountReport describes the certificates of an account.
+type AccountReport struct {
+	// Error is set if listing the account's certificates failed part way,
+	// in which case Certificates is incomplete.
+	Error string `json:"error,omitempty"`
+	// Counts is the number of certificates by their worst lint status, e.g.
+	// "error" or "pass".
+	Counts       map[string]int       `json:"counts"`
+	Certificates []*CertificateReport `json:"certificates"`
+}
+
+// CertificateReport describes a linted certificate. Its Findings are the lint
+// results with a status of info or worse.
+type CertificateReport struct {
+	ID          string                      `json:"id"`
+	Subject     string                      `json:"subject,omitempty"`
+	NotAfter    time.Time                   `json:"not_after"`
+	Error       string                      `json:"error,omitempty"`
+	WorstStatus lint.LintStatus             `json:"worst_status,omitempty"`
+	Findings    map[string]*lint.LintResult `json:"findings,omitempty"`
+}
+
+// Scan lints the certificates listed by each of sources using registry, or
+// the global registry if it is nil. A failure to list one account's
+// certificates is recorded in its AccountReport rather than ending the scan.
+func Scan(ctx context.Context, sources []Source, registry lint.Registry) *Report {
+	if registry == nil {
+		registry = lint.GlobalRegistry()
+	}
+	report := &Report{Accounts: make(map[string]*AccountReport)}
+	for _, source := range sources {
+		account := report.Accounts[source.Account()]
+		if account == nil {
+			account = &AccountReport{Counts: make(map[string]int)}
+			report.Accounts[source.Account()] = account
+		}
+		err := source.Certificates(ctx, func(c Certificate) error {
+			cr := lintCertificate(c, registry)
+			if cr.Error == "" {
+				account.Counts[cr.WorstStatus.String()]++
+			}
+			account.Certificates = append(account.Certificates, cr)
+			return ctx.Err()
+		})
+		if err != nil {
+			account.Error = err.Error()
+		}
+	}
+	return report
+}
+
+// lintCertificate lints c and summarizes its results.
+func lintCertificate(c Certificate, registry lint.Registry) *CertificateReport {
+	cr := &CertificateReport{ID: c.ID}
+	if c.Err != nil {
+		cr.Error = fmt.Sprintf("unable to read certificate: %v", c.Err)
+		return cr
+	}
+	parsed, err := x509.ParseCertificate(c.DER)
+	if err != nil {
+		cr.Error = fmt.Sprintf("unable to parse certificate: %v", err)
+		return cr
+	}
+	cr.Subject = parsed.Subject.String()
+	cr.NotAfter = parsed.NotAfter
+	cr.WorstStatus = lint.Pass
+	rs := zlint.LintCertificateEx(parsed, registry)
+	cr.Findings = rs.Failing(lint.Notice)
+	if worst := rs.MaxStatus(); worst > lint.Pass {
+		cr.WorstStatus = worst
+	}
+	return cr
+}
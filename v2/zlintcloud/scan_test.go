@@ -0,0 +1,85 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package zlintcloud
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/zmap/zlint/v2/lint"
+)
+
+type fakeSource struct {
+	account string
+	certs   []Certificate
+	err     error
+}
+
+func (s *fakeSource) Account() string { return s.account }
+
+func (s *fakeSource) Certificates(ctx context.Context, fn func(Certificate) error) error {
+	for _, c := range s.certs {
+		if err := fn(c); err != nil {
+			return err
+		}
+	}
+	return s.err
+}
+
+func TestScan(t *testing.T) {
+	pemBytes, err := ioutil.ReadFile("../testdata/badRsaExp.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := DecodePEM(string(pemBytes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry, err := lint.GlobalRegistry().Filter(lint.FilterOptions{IncludeNames: []string{"e_rsa_public_exponent_not_odd"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sources := []Source{
+		&fakeSource{account: "aws:1/us-east-1", certs: []Certificate{
+			{ID: "arn:1", DER: der},
+			{ID: "arn:2", Err: errors.New("access denied")},
+		}},
+		&fakeSource{account: "gcp:project", certs: []Certificate{{ID: "cert", DER: der}}, err: errors.New("quota exceeded")},
+		&fakeSource{account: "aws:1/us-east-1", certs: []Certificate{{ID: "arn:3", DER: []byte("not a certificate")}}},
+	}
+
+	report := Scan(context.Background(), sources, registry)
+	if len(report.Accounts) != 2 {
+		t.Fatalf("expected 2 accounts, got %d", len(report.Accounts))
+	}
+	aws := report.Accounts["aws:1/us-east-1"]
+	if len(aws.Certificates) != 3 || aws.Error != "" {
+		t.Fatalf("expected 3 certificates for the AWS account, got %+v", aws)
+	}
+	if c := aws.Certificates[0]; c.WorstStatus != lint.Error || c.Findings["e_rsa_public_exponent_not_odd"] == nil || c.Subject == "" {
+		t.Errorf("expected arn:1 to have an error finding, got %+v", c)
+	}
+	if aws.Certificates[1].Error == "" || aws.Certificates[2].Error == "" {
+		t.Errorf("expected errors for arn:2 and arn:3, got %+v and %+v", aws.Certificates[1], aws.Certificates[2])
+	}
+	if aws.Counts["error"] != 1 || len(aws.Counts) != 1 {
+		t.Errorf("unexpected counts %v", aws.Counts)
+	}
+	if gcp := report.Accounts["gcp:project"]; gcp.Error != "quota exceeded" || len(gcp.Certificates) != 1 {
+		t.Errorf("expected the listing error and the certificates listed before it, got %+v", gcp)
+	}
+}
@@ -0,0 +1,57 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+// zlint-grpc serves the ZLint gRPC service (see zlintpb/zlint.proto).
+package main
+
+import (
+	"context"
+	"flag"
+	"net"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/zmap/zlint/v2"
+	"github.com/zmap/zlint/v2/zlintgrpc"
+	"github.com/zmap/zlint/v2/zlintgrpc/zlintpb"
+	"github.com/zmap/zlint/v2/zlintotel"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+)
+
+func main() {
+	listen := flag.String("listen", ":9090", "Address to listen on")
+	workers := flag.Int("workers", 0, "Certificates linted in parallel for each streaming client (default GOMAXPROCS)")
+	useOTel := flag.Bool("otel", false, "Export OpenTelemetry traces and metrics over OTLP/HTTP, configured by the OTEL_EXPORTER_OTLP_* environment variables")
+	flag.Parse()
+
+	l, err := net.Listen("tcp", *listen)
+	if err != nil {
+		log.Fatalf("unable to listen: %s", err)
+	}
+	zlintServer := zlintgrpc.NewServer(nil, *workers)
+	var serverOpts []grpc.ServerOption
+	if *useOTel {
+		tracer, shutdown, err := zlintotel.Setup(context.Background(), "zlint-grpc")
+		if err != nil {
+			log.Fatalf("unable to set up OpenTelemetry: %s", err)
+		}
+		defer func() { _ = shutdown(context.Background()) }()
+		zlintServer.SetTracer(func(ctx context.Context) zlint.Tracer { return tracer.WithContext(ctx) })
+		serverOpts = append(serverOpts, grpc.StatsHandler(otelgrpc.NewServerHandler()))
+	}
+	server := grpc.NewServer(serverOpts...)
+	zlintpb.RegisterZLintServer(server, zlintServer)
+	log.Infof("listening on %s", l.Addr())
+	log.Fatal(server.Serve(l))
+}
@@ -0,0 +1,227 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+// Package zlintgrpc serves lints over gRPC using the ZLint service defined in
+// the zlintpb package.
+package zlintgrpc
+
+import (
+	"context"
+	"io"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/zlintgrpc/zlintpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Server implements zlintpb.ZLintServer using the lints in a registry.
+type Server struct {
+	zlintpb.UnimplementedZLintServer
+
+	registry lint.Registry
+	workers  int
+	tracer   func(context.Context) zlint.Tracer
+}
+
+// NewServer returns a Server running lints from registry, or from the global
+// registry if registry is nil. Each LintStream call lints up to workers
+// certificates in parallel; values less than 1 use runtime.GOMAXPROCS(0).
+func NewServer(registry lint.Registry, workers int) *Server {
+	if registry == nil {
+		registry = lint.GlobalRegistry()
+	}
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	return &Server{registry: registry, workers: workers}
+}
+
+// SetTracer sets a function returning the zlint.Tracer to notify as
+// certificates are linted, given the context of the request they were sent
+// in, e.g. to record spans that are children of the request's span.
+func (s *Server) SetTracer(tracer func(ctx context.Context) zlint.Tracer) {
+	s.tracer = tracer
+}
+
+// Lint lints the certificate in req. Requests with an invalid filter or
+// certificate fail with codes.InvalidArgument.
+func (s *Server) Lint(ctx context.Context, req *zlintpb.LintRequest) (*zlintpb.LintResponse, error) {
+	registry, err := s.filter(req.GetFilter())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	resp, err := s.lintRequest(ctx, req, registry)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return resp, nil
+}
+
+// LintStream lints the certificates sent on stream, linting up to the
+// server's number of workers in parallel while sending the responses in
+// request order. Requests that can't be linted get a response with the error
+// field set rather than ending the stream.
+func (s *Server) LintStream(stream zlintpb.ZLint_LintStreamServer) error {
+	ctx := stream.Context()
+	// pending holds the results of the requests being linted in request
+	// order. Its capacity bounds how many certificates are linted at once, and
+	// so how far the client can get ahead of the responses.
+	pending := make(chan chan *zlintpb.LintResponse, s.workers)
+	recvErr := make(chan error, 1)
+	go func() {
+		defer close(pending)
+		// Bulk clients usually send the same filter with every request, so
+		// the filtered registry is reused until the filter changes.
+		var filter *zlintpb.Filter
+		var registry lint.Registry
+		var filterErr error
+		for i := 0; ; i++ {
+			req, err := stream.Recv()
+			if err != nil {
+				if err != io.EOF {
+					recvErr <- err
+				}
+				return
+			}
+			if i == 0 || !proto.Equal(req.GetFilter(), filter) {
+				filter = req.GetFilter()
+				registry, filterErr = s.filter(filter)
+			}
+			result := make(chan *zlintpb.LintResponse, 1)
+			select {
+			case pending <- result:
+			case <-ctx.Done():
+				return
+			}
+			go func(registry lint.Registry, filterErr error) {
+				err := filterErr
+				var resp *zlintpb.LintResponse
+				if err == nil {
+					resp, err = s.lintRequest(ctx, req, registry)
+				}
+				if err != nil {
+					resp = &zlintpb.LintResponse{Id: req.GetId(), Error: err.Error()}
+				}
+				result <- resp
+			}(registry, filterErr)
+		}
+	}()
+	for result := range pending {
+		if err := stream.Send(<-result); err != nil {
+			return err
+		}
+	}
+	select {
+	case err := <-recvErr:
+		return err
+	default:
+		return nil
+	}
+}
+
+// ListLints lists the lints selected by the filter in req, sorted by name.
+func (s *Server) ListLints(ctx context.Context, req *zlintpb.ListLintsRequest) (*zlintpb.ListLintsResponse, error) {
+	registry, err := s.filter(req.GetFilter())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	names := registry.Names()
+	resp := &zlintpb.ListLintsResponse{Lints: make([]*zlintpb.LintInfo, 0, len(names))}
+	for _, name := range names {
+		l := registry.ByName(name)
+		resp.Lints = append(resp.Lints, &zlintpb.LintInfo{
+			Name:        l.Name,
+			Description: l.Description,
+			Citation:    l.Citation,
+			Source:      string(l.Source),
+			Tags:        l.Tags,
+			Online:      l.Online,
+		})
+	}
+	return resp, nil
+}
+
+// filter returns the server's registry filtered with f, or the registry
+// itself if f is empty.
+func (s *Server) filter(f *zlintpb.Filter) (lint.Registry, error) {
+	var opts lint.FilterOptions
+	if f.GetNameFilter() != "" {
+		r, err := regexp.Compile(f.GetNameFilter())
+		if err != nil {
+			return nil, err
+		}
+		opts.NameFilter = r
+	}
+	if len(f.GetIncludeSources()) > 0 {
+		if err := opts.IncludeSources.FromString(strings.Join(f.GetIncludeSources(), ",")); err != nil {
+			return nil, err
+		}
+	}
+	if len(f.GetExcludeSources()) > 0 {
+		if err := opts.ExcludeSources.FromString(strings.Join(f.GetExcludeSources(), ",")); err != nil {
+			return nil, err
+		}
+	}
+	opts.IncludeNames = f.GetIncludeNames()
+	opts.ExcludeNames = f.GetExcludeNames()
+	opts.IncludeTags = f.GetIncludeTags()
+	opts.ExcludeTags = f.GetExcludeTags()
+	if opts.Empty() {
+		return s.registry, nil
+	}
+	return s.registry.Filter(opts)
+}
+
+// lintRequest parses the certificate in req, sent in the request with
+// context ctx, and lints it with the lints in registry. Lints that haven't
+// run by the time ctx is done are reported as canceled.
+func (s *Server) lintRequest(ctx context.Context, req *zlintpb.LintRequest, registry lint.Registry) (*zlintpb.LintResponse, error) {
+	c, err := x509.ParseCertificate(req.GetCertificate())
+	if err != nil {
+		return nil, err
+	}
+	opts := zlint.Options{
+		Registry: registry,
+		Fast:     req.GetFast(),
+		Context:  ctx,
+	}
+	if s.tracer != nil {
+		opts.Tracer = s.tracer(ctx)
+	}
+	rs := zlint.LintCertificateWithOptions(c, opts)
+	resp := &zlintpb.LintResponse{
+		Id:              req.GetId(),
+		Version:         rs.Version,
+		Timestamp:       rs.Timestamp,
+		Results:         make(map[string]*zlintpb.LintResult, len(rs.Results)),
+		NoticesPresent:  rs.NoticesPresent,
+		WarningsPresent: rs.WarningsPresent,
+		ErrorsPresent:   rs.ErrorsPresent,
+		FatalsPresent:   rs.FatalsPresent,
+	}
+	for name, result := range rs.Results {
+		resp.Results[name] = &zlintpb.LintResult{
+			Status:  zlintpb.Status(result.Status),
+			Details: result.Details,
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,257 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package zlintgrpc
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2"
+	"github.com/zmap/zlint/v2/zlintgrpc/zlintpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestClient returns a client connected to a Server using the global
+// registry over an in-memory connection. The Server is passed to configure,
+// if any, before it starts serving.
+func newTestClient(t *testing.T, configure ...func(*Server)) zlintpb.ZLintClient {
+	l := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	zlintServer := NewServer(nil, 4)
+	for _, f := range configure {
+		f(zlintServer)
+	}
+	zlintpb.RegisterZLintServer(server, zlintServer)
+	go func() { _ = server.Serve(l) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return l.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return zlintpb.NewZLintClient(conn)
+}
+
+func testCertificate(t *testing.T) []byte {
+	data, err := ioutil.ReadFile("../testdata/evAllGood.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(data)
+	return block.Bytes
+}
+
+var evFilter = &zlintpb.Filter{IncludeSources: []string{"CABF_EV"}}
+
+func TestLint(t *testing.T) {
+	client := newTestClient(t)
+	resp, err := client.Lint(context.Background(), &zlintpb.LintRequest{
+		Id:          "ev",
+		Certificate: testCertificate(t),
+		Filter:      evFilter,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetId() != "ev" {
+		t.Errorf("expected id ev, got %q", resp.GetId())
+	}
+	if result := resp.GetResults()["e_ev_jurisdiction_country_missing"]; result.GetStatus() != zlintpb.Status_STATUS_PASS {
+		t.Errorf("expected e_ev_jurisdiction_country_missing to pass, got %v", result.GetStatus())
+	}
+	if _, ok := resp.GetResults()["e_sub_cert_aia_missing"]; ok {
+		t.Error("expected no results for lints from other sources")
+	}
+}
+
+func TestLintErrors(t *testing.T) {
+	client := newTestClient(t)
+	testCases := []struct {
+		name string
+		req  *zlintpb.LintRequest
+	}{
+		{"bad certificate", &zlintpb.LintRequest{Certificate: []byte("not a certificate")}},
+		{"bad source", &zlintpb.LintRequest{Certificate: testCertificate(t), Filter: &zlintpb.Filter{IncludeSources: []string{"nope"}}}},
+		{"bad name filter", &zlintpb.LintRequest{Certificate: testCertificate(t), Filter: &zlintpb.Filter{NameFilter: "("}}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := client.Lint(context.Background(), tc.req)
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("expected InvalidArgument, got %v", err)
+			}
+		})
+	}
+}
+
+func TestLintCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	resp, err := NewServer(nil, 4).Lint(ctx, &zlintpb.LintRequest{
+		Certificate: testCertificate(t),
+		Filter:      evFilter,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.GetResults()) == 0 {
+		t.Fatal("expected results for the EV lints")
+	}
+	for name, result := range resp.GetResults() {
+		if result.GetStatus() != zlintpb.Status_STATUS_CANCELED {
+			t.Errorf("expected %s to be canceled, got %v", name, result.GetStatus())
+		}
+	}
+}
+
+func TestLintStream(t *testing.T) {
+	client := newTestClient(t)
+	stream, err := client.LintStream(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert := testCertificate(t)
+	const n = 20
+	go func() {
+		for i := 0; i < n; i++ {
+			req := &zlintpb.LintRequest{Id: fmt.Sprint(i), Certificate: cert, Filter: evFilter}
+			switch i {
+			case 3:
+				req.Certificate = []byte("not a certificate")
+			case 7:
+				req.Filter = &zlintpb.Filter{IncludeNames: []string{"e_sub_cert_aia_missing"}}
+			}
+			if err := stream.Send(req); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+		_ = stream.CloseSend()
+	}()
+
+	for i := 0; ; i++ {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			if i != n {
+				t.Errorf("expected %d responses, got %d", n, i)
+			}
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.GetId() != fmt.Sprint(i) {
+			t.Fatalf("expected response %d, got %q", i, resp.GetId())
+		}
+		switch i {
+		case 3:
+			if resp.GetError() == "" {
+				t.Error("expected an error for an invalid certificate")
+			}
+		case 7:
+			if len(resp.GetResults()) != 1 {
+				t.Errorf("expected 1 result with an include filter, got %d", len(resp.GetResults()))
+			}
+		default:
+			if _, ok := resp.GetResults()["e_ev_jurisdiction_country_missing"]; !ok || resp.GetError() != "" {
+				t.Errorf("expected EV results for response %d, got error %q", i, resp.GetError())
+			}
+		}
+	}
+}
+
+func TestListLints(t *testing.T) {
+	client := newTestClient(t)
+	resp, err := client.ListLints(context.Background(), &zlintpb.ListLintsRequest{Filter: evFilter})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.GetLints()) == 0 {
+		t.Fatal("expected CABF EV lints")
+	}
+	for _, l := range resp.GetLints() {
+		if l.GetSource() != "CABF_EV" {
+			t.Errorf("expected only CABF_EV lints, got %s from %s", l.GetName(), l.GetSource())
+		}
+	}
+}
+
+// testTracer counts the certificates it is notified of.
+type testTracer struct {
+	mu     sync.Mutex
+	linted int
+}
+
+func (t *testTracer) StartCertificate(c *x509.Certificate) func(*zlint.ResultSet) {
+	return func(*zlint.ResultSet) {
+		t.mu.Lock()
+		t.linted++
+		t.mu.Unlock()
+	}
+}
+
+func TestSetTracer(t *testing.T) {
+	tracer := &testTracer{}
+	client := newTestClient(t, func(s *Server) {
+		s.SetTracer(func(ctx context.Context) zlint.Tracer {
+			if ctx == nil {
+				t.Error("expected the request context")
+			}
+			return tracer
+		})
+	})
+	req := &zlintpb.LintRequest{Certificate: testCertificate(t), Filter: evFilter}
+	if _, err := client.Lint(context.Background(), req); err != nil {
+		t.Fatal(err)
+	}
+	stream, err := client.LintStream(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := stream.Send(req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := stream.CloseSend(); err != nil {
+		t.Fatal(err)
+	}
+	for {
+		if _, err := stream.Recv(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+	}
+	tracer.mu.Lock()
+	defer tracer.mu.Unlock()
+	if tracer.linted != 4 {
+		t.Errorf("expected the tracer to be notified of 4 certificates, got %d", tracer.linted)
+	}
+}
@@ -0,0 +1,745 @@
+//
+// ZLint Copyright 2020 Regents of the University of Michigan
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: zlint.proto
+
+package zlintpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Status mirrors lint.LintStatus.
+type Status int32
+
+const (
+	Status_STATUS_RESERVED Status = 0
+	Status_STATUS_NA       Status = 1
+	Status_STATUS_NE       Status = 2
+	Status_STATUS_PASS     Status = 3
+	Status_STATUS_NOTICE   Status = 4
+	Status_STATUS_WARN     Status = 5
+	Status_STATUS_ERROR    Status = 6
+	Status_STATUS_FATAL    Status = 7
+	Status_STATUS_SKIPPED  Status = 8
+	Status_STATUS_CANCELED Status = 9
+)
+
+// Enum value maps for Status.
+var (
+	Status_name = map[int32]string{
+		0: "STATUS_RESERVED",
+		1: "STATUS_NA",
+		2: "STATUS_NE",
+		3: "STATUS_PASS",
+		4: "STATUS_NOTICE",
+		5: "STATUS_WARN",
+		6: "STATUS_ERROR",
+		7: "STATUS_FATAL",
+		8: "STATUS_SKIPPED",
+		9: "STATUS_CANCELED",
+	}
+	Status_value = map[string]int32{
+		"STATUS_RESERVED": 0,
+		"STATUS_NA":       1,
+		"STATUS_NE":       2,
+		"STATUS_PASS":     3,
+		"STATUS_NOTICE":   4,
+		"STATUS_WARN":     5,
+		"STATUS_ERROR":    6,
+		"STATUS_FATAL":    7,
+		"STATUS_SKIPPED":  8,
+		"STATUS_CANCELED": 9,
+	}
+)
+
+func (x Status) Enum() *Status {
+	p := new(Status)
+	*p = x
+	return p
+}
+
+func (x Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_zlint_proto_enumTypes[0].Descriptor()
+}
+
+func (Status) Type() protoreflect.EnumType {
+	return &file_zlint_proto_enumTypes[0]
+}
+
+func (x Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Status.Descriptor instead.
+func (Status) EnumDescriptor() ([]byte, []int) {
+	return file_zlint_proto_rawDescGZIP(), []int{0}
+}
+
+// Filter selects lints from the server's registry like the zlint command's
+// flags of the same names. An empty filter selects every lint.
+type Filter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// name_filter is a regular expression matched against lint names. It can't
+	// be used with include_names or exclude_names.
+	NameFilter     string   `protobuf:"bytes,1,opt,name=name_filter,json=nameFilter,proto3" json:"name_filter,omitempty"`
+	IncludeNames   []string `protobuf:"bytes,2,rep,name=include_names,json=includeNames,proto3" json:"include_names,omitempty"`
+	ExcludeNames   []string `protobuf:"bytes,3,rep,name=exclude_names,json=excludeNames,proto3" json:"exclude_names,omitempty"`
+	IncludeSources []string `protobuf:"bytes,4,rep,name=include_sources,json=includeSources,proto3" json:"include_sources,omitempty"`
+	ExcludeSources []string `protobuf:"bytes,5,rep,name=exclude_sources,json=excludeSources,proto3" json:"exclude_sources,omitempty"`
+	IncludeTags    []string `protobuf:"bytes,6,rep,name=include_tags,json=includeTags,proto3" json:"include_tags,omitempty"`
+	ExcludeTags    []string `protobuf:"bytes,7,rep,name=exclude_tags,json=excludeTags,proto3" json:"exclude_tags,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Filter) Reset() {
+	*x = Filter{}
+	mi := &file_zlint_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Filter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Filter) ProtoMessage() {}
+
+func (x *Filter) ProtoReflect() protoreflect.Message {
+	mi := &file_zlint_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Filter.ProtoReflect.Descriptor instead.
+func (*Filter) Descriptor() ([]byte, []int) {
+	return file_zlint_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Filter) GetNameFilter() string {
+	if x != nil {
+		return x.NameFilter
+	}
+	return ""
+}
+
+func (x *Filter) GetIncludeNames() []string {
+	if x != nil {
+		return x.IncludeNames
+	}
+	return nil
+}
+
+func (x *Filter) GetExcludeNames() []string {
+	if x != nil {
+		return x.ExcludeNames
+	}
+	return nil
+}
+
+func (x *Filter) GetIncludeSources() []string {
+	if x != nil {
+		return x.IncludeSources
+	}
+	return nil
+}
+
+func (x *Filter) GetExcludeSources() []string {
+	if x != nil {
+		return x.ExcludeSources
+	}
+	return nil
+}
+
+func (x *Filter) GetIncludeTags() []string {
+	if x != nil {
+		return x.IncludeTags
+	}
+	return nil
+}
+
+func (x *Filter) GetExcludeTags() []string {
+	if x != nil {
+		return x.ExcludeTags
+	}
+	return nil
+}
+
+type LintRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id is copied to the response, letting streaming clients match responses
+	// to requests.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// certificate is the DER encoded certificate to lint.
+	Certificate []byte  `protobuf:"bytes,2,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	Filter      *Filter `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	// fast skips lints tagged "expensive".
+	Fast          bool `protobuf:"varint,4,opt,name=fast,proto3" json:"fast,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LintRequest) Reset() {
+	*x = LintRequest{}
+	mi := &file_zlint_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LintRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LintRequest) ProtoMessage() {}
+
+func (x *LintRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zlint_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LintRequest.ProtoReflect.Descriptor instead.
+func (*LintRequest) Descriptor() ([]byte, []int) {
+	return file_zlint_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LintRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *LintRequest) GetCertificate() []byte {
+	if x != nil {
+		return x.Certificate
+	}
+	return nil
+}
+
+func (x *LintRequest) GetFilter() *Filter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *LintRequest) GetFast() bool {
+	if x != nil {
+		return x.Fast
+	}
+	return false
+}
+
+type LintResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        Status                 `protobuf:"varint,1,opt,name=status,proto3,enum=zlint.v1.Status" json:"status,omitempty"`
+	Details       string                 `protobuf:"bytes,2,opt,name=details,proto3" json:"details,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LintResult) Reset() {
+	*x = LintResult{}
+	mi := &file_zlint_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LintResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LintResult) ProtoMessage() {}
+
+func (x *LintResult) ProtoReflect() protoreflect.Message {
+	mi := &file_zlint_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LintResult.ProtoReflect.Descriptor instead.
+func (*LintResult) Descriptor() ([]byte, []int) {
+	return file_zlint_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LintResult) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_STATUS_RESERVED
+}
+
+func (x *LintResult) GetDetails() string {
+	if x != nil {
+		return x.Details
+	}
+	return ""
+}
+
+type LintResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// error describes why the certificate couldn't be linted. It is only set by
+	// LintStream; Lint returns an error status instead.
+	Error   string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Version int64  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	// timestamp is the time the certificate was linted, in seconds since the
+	// Unix epoch.
+	Timestamp int64 `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// results maps lint names to their results.
+	Results         map[string]*LintResult `protobuf:"bytes,5,rep,name=results,proto3" json:"results,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	NoticesPresent  bool                   `protobuf:"varint,6,opt,name=notices_present,json=noticesPresent,proto3" json:"notices_present,omitempty"`
+	WarningsPresent bool                   `protobuf:"varint,7,opt,name=warnings_present,json=warningsPresent,proto3" json:"warnings_present,omitempty"`
+	ErrorsPresent   bool                   `protobuf:"varint,8,opt,name=errors_present,json=errorsPresent,proto3" json:"errors_present,omitempty"`
+	FatalsPresent   bool                   `protobuf:"varint,9,opt,name=fatals_present,json=fatalsPresent,proto3" json:"fatals_present,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *LintResponse) Reset() {
+	*x = LintResponse{}
+	mi := &file_zlint_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LintResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LintResponse) ProtoMessage() {}
+
+func (x *LintResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zlint_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LintResponse.ProtoReflect.Descriptor instead.
+func (*LintResponse) Descriptor() ([]byte, []int) {
+	return file_zlint_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LintResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *LintResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *LintResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *LintResponse) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *LintResponse) GetResults() map[string]*LintResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *LintResponse) GetNoticesPresent() bool {
+	if x != nil {
+		return x.NoticesPresent
+	}
+	return false
+}
+
+func (x *LintResponse) GetWarningsPresent() bool {
+	if x != nil {
+		return x.WarningsPresent
+	}
+	return false
+}
+
+func (x *LintResponse) GetErrorsPresent() bool {
+	if x != nil {
+		return x.ErrorsPresent
+	}
+	return false
+}
+
+func (x *LintResponse) GetFatalsPresent() bool {
+	if x != nil {
+		return x.FatalsPresent
+	}
+	return false
+}
+
+type ListLintsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        *Filter                `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLintsRequest) Reset() {
+	*x = ListLintsRequest{}
+	mi := &file_zlint_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLintsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLintsRequest) ProtoMessage() {}
+
+func (x *ListLintsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zlint_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLintsRequest.ProtoReflect.Descriptor instead.
+func (*ListLintsRequest) Descriptor() ([]byte, []int) {
+	return file_zlint_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListLintsRequest) GetFilter() *Filter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type LintInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Citation      string                 `protobuf:"bytes,3,opt,name=citation,proto3" json:"citation,omitempty"`
+	Source        string                 `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	Tags          []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	Online        bool                   `protobuf:"varint,6,opt,name=online,proto3" json:"online,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LintInfo) Reset() {
+	*x = LintInfo{}
+	mi := &file_zlint_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LintInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LintInfo) ProtoMessage() {}
+
+func (x *LintInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_zlint_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LintInfo.ProtoReflect.Descriptor instead.
+func (*LintInfo) Descriptor() ([]byte, []int) {
+	return file_zlint_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *LintInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LintInfo) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *LintInfo) GetCitation() string {
+	if x != nil {
+		return x.Citation
+	}
+	return ""
+}
+
+func (x *LintInfo) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *LintInfo) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *LintInfo) GetOnline() bool {
+	if x != nil {
+		return x.Online
+	}
+	return false
+}
+
+type ListLintsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lints         []*LintInfo            `protobuf:"bytes,1,rep,name=lints,proto3" json:"lints,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLintsResponse) Reset() {
+	*x = ListLintsResponse{}
+	mi := &file_zlint_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLintsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLintsResponse) ProtoMessage() {}
+
+func (x *ListLintsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zlint_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLintsResponse.ProtoReflect.Descriptor instead.
+func (*ListLintsResponse) Descriptor() ([]byte, []int) {
+	return file_zlint_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListLintsResponse) GetLints() []*LintInfo {
+	if x != nil {
+		return x.Lints
+	}
+	return nil
+}
+
+var File_zlint_proto protoreflect.FileDescriptor
+
+const file_zlint_proto_rawDesc = "" +
+	"\n" +
+	"\vzlint.proto\x12\bzlint.v1\"\x8b\x02\n" +
+	"\x06Filter\x12\x1f\n" +
+	"\vname_filter\x18\x01 \x01(\tR\n" +
+	"nameFilter\x12#\n" +
+	"\rinclude_names\x18\x02 \x03(\tR\fincludeNames\x12#\n" +
+	"\rexclude_names\x18\x03 \x03(\tR\fexcludeNames\x12'\n" +
+	"\x0finclude_sources\x18\x04 \x03(\tR\x0eincludeSources\x12'\n" +
+	"\x0fexclude_sources\x18\x05 \x03(\tR\x0eexcludeSources\x12!\n" +
+	"\finclude_tags\x18\x06 \x03(\tR\vincludeTags\x12!\n" +
+	"\fexclude_tags\x18\a \x03(\tR\vexcludeTags\"}\n" +
+	"\vLintRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12 \n" +
+	"\vcertificate\x18\x02 \x01(\fR\vcertificate\x12(\n" +
+	"\x06filter\x18\x03 \x01(\v2\x10.zlint.v1.FilterR\x06filter\x12\x12\n" +
+	"\x04fast\x18\x04 \x01(\bR\x04fast\"P\n" +
+	"\n" +
+	"LintResult\x12(\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x10.zlint.v1.StatusR\x06status\x12\x18\n" +
+	"\adetails\x18\x02 \x01(\tR\adetails\"\x9f\x03\n" +
+	"\fLintResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x03R\aversion\x12\x1c\n" +
+	"\ttimestamp\x18\x04 \x01(\x03R\ttimestamp\x12=\n" +
+	"\aresults\x18\x05 \x03(\v2#.zlint.v1.LintResponse.ResultsEntryR\aresults\x12'\n" +
+	"\x0fnotices_present\x18\x06 \x01(\bR\x0enoticesPresent\x12)\n" +
+	"\x10warnings_present\x18\a \x01(\bR\x0fwarningsPresent\x12%\n" +
+	"\x0eerrors_present\x18\b \x01(\bR\rerrorsPresent\x12%\n" +
+	"\x0efatals_present\x18\t \x01(\bR\rfatalsPresent\x1aP\n" +
+	"\fResultsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12*\n" +
+	"\x05value\x18\x02 \x01(\v2\x14.zlint.v1.LintResultR\x05value:\x028\x01\"<\n" +
+	"\x10ListLintsRequest\x12(\n" +
+	"\x06filter\x18\x01 \x01(\v2\x10.zlint.v1.FilterR\x06filter\"\xa0\x01\n" +
+	"\bLintInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bcitation\x18\x03 \x01(\tR\bcitation\x12\x16\n" +
+	"\x06source\x18\x04 \x01(\tR\x06source\x12\x12\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x12\x16\n" +
+	"\x06online\x18\x06 \x01(\bR\x06online\"=\n" +
+	"\x11ListLintsResponse\x12(\n" +
+	"\x05lints\x18\x01 \x03(\v2\x12.zlint.v1.LintInfoR\x05lints*\xbd\x01\n" +
+	"\x06Status\x12\x13\n" +
+	"\x0fSTATUS_RESERVED\x10\x00\x12\r\n" +
+	"\tSTATUS_NA\x10\x01\x12\r\n" +
+	"\tSTATUS_NE\x10\x02\x12\x0f\n" +
+	"\vSTATUS_PASS\x10\x03\x12\x11\n" +
+	"\rSTATUS_NOTICE\x10\x04\x12\x0f\n" +
+	"\vSTATUS_WARN\x10\x05\x12\x10\n" +
+	"\fSTATUS_ERROR\x10\x06\x12\x10\n" +
+	"\fSTATUS_FATAL\x10\a\x12\x12\n" +
+	"\x0eSTATUS_SKIPPED\x10\b\x12\x13\n" +
+	"\x0fSTATUS_CANCELED\x10\t2\xc5\x01\n" +
+	"\x05ZLint\x125\n" +
+	"\x04Lint\x12\x15.zlint.v1.LintRequest\x1a\x16.zlint.v1.LintResponse\x12?\n" +
+	"\n" +
+	"LintStream\x12\x15.zlint.v1.LintRequest\x1a\x16.zlint.v1.LintResponse(\x010\x01\x12D\n" +
+	"\tListLints\x12\x1a.zlint.v1.ListLintsRequest\x1a\x1b.zlint.v1.ListLintsResponseB,Z*github.com/zmap/zlint/v2/zlintgrpc/zlintpbb\x06proto3"
+
+var (
+	file_zlint_proto_rawDescOnce sync.Once
+	file_zlint_proto_rawDescData []byte
+)
+
+func file_zlint_proto_rawDescGZIP() []byte {
+	file_zlint_proto_rawDescOnce.Do(func() {
+		file_zlint_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_zlint_proto_rawDesc), len(file_zlint_proto_rawDesc)))
+	})
+	return file_zlint_proto_rawDescData
+}
+
+var file_zlint_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_zlint_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_zlint_proto_goTypes = []any{
+	(Status)(0),               // 0: zlint.v1.Status
+	(*Filter)(nil),            // 1: zlint.v1.Filter
+	(*LintRequest)(nil),       // 2: zlint.v1.LintRequest
+	(*LintResult)(nil),        // 3: zlint.v1.LintResult
+	(*LintResponse)(nil),      // 4: zlint.v1.LintResponse
+	(*ListLintsRequest)(nil),  // 5: zlint.v1.ListLintsRequest
+	(*LintInfo)(nil),          // 6: zlint.v1.LintInfo
+	(*ListLintsResponse)(nil), // 7: zlint.v1.ListLintsResponse
+	nil,                       // 8: zlint.v1.LintResponse.ResultsEntry
+}
+var file_zlint_proto_depIdxs = []int32{
+	1, // 0: zlint.v1.LintRequest.filter:type_name -> zlint.v1.Filter
+	0, // 1: zlint.v1.LintResult.status:type_name -> zlint.v1.Status
+	8, // 2: zlint.v1.LintResponse.results:type_name -> zlint.v1.LintResponse.ResultsEntry
+	1, // 3: zlint.v1.ListLintsRequest.filter:type_name -> zlint.v1.Filter
+	6, // 4: zlint.v1.ListLintsResponse.lints:type_name -> zlint.v1.LintInfo
+	3, // 5: zlint.v1.LintResponse.ResultsEntry.value:type_name -> zlint.v1.LintResult
+	2, // 6: zlint.v1.ZLint.Lint:input_type -> zlint.v1.LintRequest
+	2, // 7: zlint.v1.ZLint.LintStream:input_type -> zlint.v1.LintRequest
+	5, // 8: zlint.v1.ZLint.ListLints:input_type -> zlint.v1.ListLintsRequest
+	4, // 9: zlint.v1.ZLint.Lint:output_type -> zlint.v1.LintResponse
+	4, // 10: zlint.v1.ZLint.LintStream:output_type -> zlint.v1.LintResponse
+	7, // 11: zlint.v1.ZLint.ListLints:output_type -> zlint.v1.ListLintsResponse
+	9, // [9:12] is the sub-list for method output_type
+	6, // [6:9] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_zlint_proto_init() }
+func file_zlint_proto_init() {
+	if File_zlint_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_zlint_proto_rawDesc), len(file_zlint_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_zlint_proto_goTypes,
+		DependencyIndexes: file_zlint_proto_depIdxs,
+		EnumInfos:         file_zlint_proto_enumTypes,
+		MessageInfos:      file_zlint_proto_msgTypes,
+	}.Build()
+	File_zlint_proto = out.File
+	file_zlint_proto_goTypes = nil
+	file_zlint_proto_depIdxs = nil
+}
@@ -0,0 +1,221 @@
+//
+// ZLint Copyright 2020 Regents of the University of Michigan
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: zlint.proto
+
+package zlintpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ZLint_Lint_FullMethodName       = "/zlint.v1.ZLint/Lint"
+	ZLint_LintStream_FullMethodName = "/zlint.v1.ZLint/LintStream"
+	ZLint_ListLints_FullMethodName  = "/zlint.v1.ZLint/ListLints"
+)
+
+// ZLintClient is the client API for ZLint service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ZLint lints X.509 certificates.
+type ZLintClient interface {
+	// Lint lints a single certificate.
+	Lint(ctx context.Context, in *LintRequest, opts ...grpc.CallOption) (*LintResponse, error)
+	// LintStream lints each certificate sent by the client, sending one
+	// response per request in the order the requests were received. A
+	// certificate that can't be parsed doesn't end the stream: its response has
+	// the error field set instead.
+	LintStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[LintRequest, LintResponse], error)
+	// ListLints lists the lints selected by a filter.
+	ListLints(ctx context.Context, in *ListLintsRequest, opts ...grpc.CallOption) (*ListLintsResponse, error)
+}
+
+type zLintClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewZLintClient(cc grpc.ClientConnInterface) ZLintClient {
+	return &zLintClient{cc}
+}
+
+func (c *zLintClient) Lint(ctx context.Context, in *LintRequest, opts ...grpc.CallOption) (*LintResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LintResponse)
+	err := c.cc.Invoke(ctx, ZLint_Lint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *zLintClient) LintStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[LintRequest, LintResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ZLint_ServiceDesc.Streams[0], ZLint_LintStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[LintRequest, LintResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ZLint_LintStreamClient = grpc.BidiStreamingClient[LintRequest, LintResponse]
+
+func (c *zLintClient) ListLints(ctx context.Context, in *ListLintsRequest, opts ...grpc.CallOption) (*ListLintsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLintsResponse)
+	err := c.cc.Invoke(ctx, ZLint_ListLints_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ZLintServer is the server API for ZLint service.
+// All implementations must embed UnimplementedZLintServer
+// for forward compatibility.
+//
+// ZLint lints X.509 certificates.
+type ZLintServer interface {
+	// Lint lints a single certificate.
+	Lint(context.Context, *LintRequest) (*LintResponse, error)
+	// LintStream lints each certificate sent by the client, sending one
+	// response per request in the order the requests were received. A
+	// certificate that can't be parsed doesn't end the stream: its response has
+	// the error field set instead.
+	LintStream(grpc.BidiStreamingServer[LintRequest, LintResponse]) error
+	// ListLints lists the lints selected by a filter.
+	ListLints(context.Context, *ListLintsRequest) (*ListLintsResponse, error)
+	mustEmbedUnimplementedZLintServer()
+}
+
+// UnimplementedZLintServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedZLintServer struct{}
+
+func (UnimplementedZLintServer) Lint(context.Context, *LintRequest) (*LintResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Lint not implemented")
+}
+func (UnimplementedZLintServer) LintStream(grpc.BidiStreamingServer[LintRequest, LintResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method LintStream not implemented")
+}
+func (UnimplementedZLintServer) ListLints(context.Context, *ListLintsRequest) (*ListLintsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLints not implemented")
+}
+func (UnimplementedZLintServer) mustEmbedUnimplementedZLintServer() {}
+func (UnimplementedZLintServer) testEmbeddedByValue()               {}
+
+// UnsafeZLintServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ZLintServer will
+// result in compilation errors.
+type UnsafeZLintServer interface {
+	mustEmbedUnimplementedZLintServer()
+}
+
+func RegisterZLintServer(s grpc.ServiceRegistrar, srv ZLintServer) {
+	// If the following call pancis, it indicates UnimplementedZLintServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ZLint_ServiceDesc, srv)
+}
+
+func _ZLint_Lint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LintRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ZLintServer).Lint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ZLint_Lint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ZLintServer).Lint(ctx, req.(*LintRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ZLint_LintStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ZLintServer).LintStream(&grpc.GenericServerStream[LintRequest, LintResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ZLint_LintStreamServer = grpc.BidiStreamingServer[LintRequest, LintResponse]
+
+func _ZLint_ListLints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLintsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ZLintServer).ListLints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ZLint_ListLints_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ZLintServer).ListLints(ctx, req.(*ListLintsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ZLint_ServiceDesc is the grpc.ServiceDesc for ZLint service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ZLint_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "zlint.v1.ZLint",
+	HandlerType: (*ZLintServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Lint",
+			Handler:    _ZLint_Lint_Handler,
+		},
+		{
+			MethodName: "ListLints",
+			Handler:    _ZLint_ListLints_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "LintStream",
+			Handler:       _ZLint_LintStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "zlint.proto",
+}
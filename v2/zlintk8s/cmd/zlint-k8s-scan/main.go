@@ -0,0 +1,85 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+// zlint-k8s-scan lints the certificates in the TLS Secrets of a Kubernetes
+// cluster, writing a JSON report of the findings by namespace.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/zlintk8s"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+func main() {
+	defaultKubeconfig := ""
+	if home, err := os.UserHomeDir(); err == nil {
+		defaultKubeconfig = filepath.Join(home, ".kube", "config")
+	}
+	kubeconfig := flag.String("kubeconfig", defaultKubeconfig, "Path to the kubeconfig file (also read from $KUBECONFIG)")
+	kubeContext := flag.String("context", "", "kubeconfig context to use (default the current context)")
+	namespace := flag.String("namespace", "", "Namespace to scan (default all namespaces)")
+	excludeSources := flag.String("excludeSources", "", "Comma-separated list of lint sources to exclude")
+	pretty := flag.Bool("pretty", false, "Pretty-print output")
+	flag.Parse()
+
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if os.Getenv(clientcmd.RecommendedConfigPathEnvVar) == "" {
+		rules.ExplicitPath = *kubeconfig
+	}
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{
+		CurrentContext: *kubeContext,
+	}).ClientConfig()
+	if err != nil {
+		log.Fatalf("unable to load kubeconfig: %s", err)
+	}
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		log.Fatalf("unable to create Kubernetes client: %s", err)
+	}
+
+	registry := lint.GlobalRegistry()
+	if *excludeSources != "" {
+		var opts lint.FilterOptions
+		if err := opts.ExcludeSources.FromString(*excludeSources); err != nil {
+			log.Fatalf("invalid -excludeSources: %s", err)
+		}
+		if registry, err = registry.Filter(opts); err != nil {
+			log.Fatalf("unable to filter lints: %s", err)
+		}
+	}
+
+	report, err := zlintk8s.Scan(context.Background(), client, zlintk8s.Options{
+		Registry:  registry,
+		Namespace: *namespace,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	enc := json.NewEncoder(os.Stdout)
+	if *pretty {
+		enc.SetIndent("", " ")
+	}
+	if err := enc.Encode(report); err != nil {
+		log.Fatalf("unable to write report: %s", err)
+	}
+}
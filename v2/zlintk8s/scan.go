@@ -0,0 +1,180 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+// Package zlintk8s audits the TLS certificates stored in a Kubernetes cluster.
+package zlintk8s
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2"
+	"github.com/zmap/zlint/v2/lint"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// listPageSize is the number of Secrets requested from the API server at a
+// time.
+const listPageSize = 500
+
+// Options configures a Scan.
+type Options struct {
+	// Registry is the set of lints to run. If nil the global registry is used.
+	Registry lint.Registry
+	// Namespace limits the scan to a single namespace. If empty, the Secrets
+	// in all namespaces are scanned.
+	Namespace string
+}
+
+// Report is the result of a Scan, by namespace.
+type Report struct {
+	Namespaces map[string]*NamespaceReport `json:"namespaces"`
+}
+
+// NamespaceReport describes the TLS Secrets in a namespace.
+type NamespaceReport struct {
+	// Counts is the number of certificates in the namespace by their worst
+	// lint status, e.g. "error" or "pass".
+	Counts  map[string]int  `json:"counts"`
+	Secrets []*SecretReport `json:"secrets"`
+}
+
+// SecretReport describes the certificates in a TLS Secret's tls.crt key.
+type SecretReport struct {
+	Name string `json:"name"`
+	// Error is set instead of Certificates if tls.crt couldn't be parsed.
+	Error        string               `json:"error,omitempty"`
+	Certificates []*CertificateReport `json:"certificates,omitempty"`
+}
+
+// CertificateReport describes a linted certificate. Its Findings are the lint
+// results with a status of info or worse.
+type CertificateReport struct {
+	SHA256      string                      `json:"sha256"`
+	Subject     string                      `json:"subject"`
+	Issuer      string                      `json:"issuer"`
+	NotAfter    time.Time                   `json:"not_after"`
+	WorstStatus lint.LintStatus             `json:"worst_status"`
+	Findings    map[string]*lint.LintResult `json:"findings,omitempty"`
+}
+
+// Scan lists the Secrets of type kubernetes.io/tls the client can see and
+// lints the certificates in each, leaf first.
+func Scan(ctx context.Context, client kubernetes.Interface, opts Options) (*Report, error) {
+	registry := opts.Registry
+	if registry == nil {
+		registry = lint.GlobalRegistry()
+	}
+	report := &Report{Namespaces: make(map[string]*NamespaceReport)}
+	list := metav1.ListOptions{
+		FieldSelector: "type=" + string(corev1.SecretTypeTLS),
+		Limit:         listPageSize,
+	}
+	for {
+		secrets, err := client.CoreV1().Secrets(opts.Namespace).List(ctx, list)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list Secrets: %v", err)
+		}
+		for i := range secrets.Items {
+			secret := &secrets.Items[i]
+			if secret.Type != corev1.SecretTypeTLS {
+				// Not all API servers support the field selector.
+				continue
+			}
+			ns := report.Namespaces[secret.Namespace]
+			if ns == nil {
+				ns = &NamespaceReport{Counts: make(map[string]int)}
+				report.Namespaces[secret.Namespace] = ns
+			}
+			sr := lintSecret(secret, registry)
+			for _, cr := range sr.Certificates {
+				ns.Counts[cr.WorstStatus.String()]++
+			}
+			ns.Secrets = append(ns.Secrets, sr)
+		}
+		if secrets.Continue == "" {
+			break
+		}
+		list.Continue = secrets.Continue
+	}
+	for _, ns := range report.Namespaces {
+		sort.Slice(ns.Secrets, func(i, j int) bool { return ns.Secrets[i].Name < ns.Secrets[j].Name })
+	}
+	return report, nil
+}
+
+// lintSecret lints the certificates in secret's tls.crt key.
+func lintSecret(secret *corev1.Secret, registry lint.Registry) *SecretReport {
+	sr := &SecretReport{Name: secret.Name}
+	chain, err := parseChain(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		sr.Error = err.Error()
+		return sr
+	}
+	for _, c := range chain {
+		sr.Certificates = append(sr.Certificates, lintCertificate(c, registry))
+	}
+	return sr
+}
+
+// lintCertificate lints c and summarizes its results.
+func lintCertificate(c *x509.Certificate, registry lint.Registry) *CertificateReport {
+	fingerprint := sha256.Sum256(c.Raw)
+	cr := &CertificateReport{
+		SHA256:      hex.EncodeToString(fingerprint[:]),
+		Subject:     c.Subject.String(),
+		Issuer:      c.Issuer.String(),
+		NotAfter:    c.NotAfter,
+		WorstStatus: lint.Pass,
+	}
+	rs := zlint.LintCertificateEx(c, registry)
+	cr.Findings = rs.Failing(lint.Notice)
+	if worst := rs.MaxStatus(); worst > lint.Pass {
+		cr.WorstStatus = worst
+	}
+	return cr
+}
+
+// parseChain parses the CERTIFICATE blocks in a PEM certificate chain.
+func parseChain(chainPEM []byte) ([]*x509.Certificate, error) {
+	var chain []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, chainPEM = pem.Decode(chainPEM)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		c, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse certificate %d in %s: %v", len(chain), corev1.TLSCertKey, err)
+		}
+		chain = append(chain, c)
+	}
+	if len(chain) == 0 {
+		return nil, errors.New(corev1.TLSCertKey + " contains no PEM certificates")
+	}
+	return chain, nil
+}
@@ -0,0 +1,90 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package zlintk8s
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/zmap/zlint/v2/lint"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestScan(t *testing.T) {
+	var chain []byte
+	for _, file := range []string{"badRsaExp.pem", "evAllGood.pem"} {
+		data, err := ioutil.ReadFile("../testdata/" + file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		chain = append(chain, data...)
+	}
+	secret := func(namespace, name string, secretType corev1.SecretType, crt []byte) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Type:       secretType,
+			Data:       map[string][]byte{corev1.TLSCertKey: crt},
+		}
+	}
+	client := fake.NewClientset(
+		secret("web", "www-tls", corev1.SecretTypeTLS, chain),
+		secret("web", "api-tls", corev1.SecretTypeTLS, []byte("not a certificate")),
+		secret("web", "opaque", corev1.SecretTypeOpaque, chain),
+		secret("db", "db-tls", corev1.SecretTypeTLS, chain),
+	)
+	registry, err := lint.GlobalRegistry().Filter(lint.FilterOptions{IncludeNames: []string{"e_rsa_public_exponent_not_odd"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := Scan(context.Background(), client, Options{Registry: registry})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Namespaces) != 2 {
+		t.Fatalf("expected reports for 2 namespaces, got %d", len(report.Namespaces))
+	}
+	web := report.Namespaces["web"]
+	if len(web.Secrets) != 2 || web.Secrets[0].Name != "api-tls" || web.Secrets[1].Name != "www-tls" {
+		t.Fatalf("expected the api-tls and www-tls Secrets in web, got %+v", web.Secrets)
+	}
+	if web.Secrets[0].Error == "" {
+		t.Error("expected an error for a Secret without PEM certificates")
+	}
+	certs := web.Secrets[1].Certificates
+	if len(certs) != 2 {
+		t.Fatalf("expected 2 certificates in www-tls, got %d", len(certs))
+	}
+	if certs[0].WorstStatus != lint.Error || certs[0].Findings["e_rsa_public_exponent_not_odd"] == nil {
+		t.Errorf("expected the leaf to fail e_rsa_public_exponent_not_odd, got %+v", certs[0])
+	}
+	if certs[1].WorstStatus != lint.Pass || certs[1].Findings != nil {
+		t.Errorf("expected the second certificate to pass, got %+v", certs[1])
+	}
+	if web.Counts["error"] != 1 || web.Counts["pass"] != 1 {
+		t.Errorf("unexpected counts %v", web.Counts)
+	}
+
+	report, err = Scan(context.Background(), client, Options{Registry: registry, Namespace: "db"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Namespaces) != 1 || report.Namespaces["db"] == nil {
+		t.Errorf("expected a report for only the db namespace, got %v", report.Namespaces)
+	}
+}
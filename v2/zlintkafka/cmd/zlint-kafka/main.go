@@ -0,0 +1,97 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+// zlint-kafka lints base64 encoded DER certificates consumed from a Kafka
+// topic and produces the results to another topic.
+package main
+
+import (
+	"context"
+	"flag"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	log "github.com/sirupsen/logrus"
+	"github.com/zmap/zlint/v2/metrics"
+	"github.com/zmap/zlint/v2/zlintkafka"
+	"github.com/zmap/zlint/v2/zlintotel"
+)
+
+func main() {
+	brokers := flag.String("brokers", "localhost:9092", "Comma-separated list of Kafka brokers")
+	inputTopic := flag.String("input-topic", "", "Topic to consume certificates from")
+	outputTopic := flag.String("output-topic", "", "Topic to produce results to")
+	group := flag.String("group", "zlint", "Consumer group sharing the partitions of -input-topic")
+	batchSize := flag.Int("batch-size", 100, "Maximum number of certificates linted, produced and committed together")
+	batchTimeout := flag.Duration("batch-timeout", time.Second, "How long to wait for a batch to fill up")
+	workers := flag.Int("workers", 0, "Number of certificates linted in parallel (default GOMAXPROCS)")
+	metricsListen := flag.String("metrics-listen", "", "Address to serve Prometheus metrics on at /metrics, e.g. :9100")
+	useOTel := flag.Bool("otel", false, "Export OpenTelemetry traces and metrics over OTLP/HTTP, configured by the OTEL_EXPORTER_OTLP_* environment variables")
+	flag.Parse()
+	if *inputTopic == "" || *outputTopic == "" {
+		log.Fatal("-input-topic and -output-topic are required")
+	}
+
+	brokerList := strings.Split(*brokers, ",")
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers: brokerList,
+		GroupID: *group,
+		Topic:   *inputTopic,
+	})
+	defer reader.Close()
+	writer := &kafka.Writer{
+		Addr:         kafka.TCP(brokerList...),
+		Topic:        *outputTopic,
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+		BatchSize:    *batchSize,
+	}
+	defer writer.Close()
+
+	m := metrics.New()
+	if *metricsListen != "" {
+		go func() {
+			mux := http.NewServeMux()
+			mux.Handle("/metrics", m)
+			log.Fatal(http.ListenAndServe(*metricsListen, mux))
+		}()
+	}
+
+	opts := zlintkafka.Options{
+		BatchSize:    *batchSize,
+		BatchTimeout: *batchTimeout,
+		Workers:      *workers,
+		Metrics:      m,
+	}
+	if *useOTel {
+		tracer, shutdown, err := zlintotel.Setup(context.Background(), "zlint-kafka")
+		if err != nil {
+			log.Fatalf("unable to set up OpenTelemetry: %s", err)
+		}
+		defer func() { _ = shutdown(context.Background()) }()
+		opts.Tracer = tracer
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	pipeline := zlintkafka.NewPipeline(reader, writer, opts)
+	log.Infof("linting certificates from %s to %s", *inputTopic, *outputTopic)
+	if err := pipeline.Run(ctx); err != nil && ctx.Err() == nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,206 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+// Package zlintkafka lints certificates consumed from a Kafka topic,
+// producing the results to another topic.
+package zlintkafka
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/metrics"
+)
+
+// MessageReader is the part of *kafka.Reader used by a Pipeline. The reader
+// must belong to a consumer group so that offsets can be committed.
+type MessageReader interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, msgs ...kafka.Message) error
+}
+
+// MessageWriter is the part of *kafka.Writer used by a Pipeline.
+type MessageWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+}
+
+// Options configures a Pipeline.
+type Options struct {
+	// Registry is the registry of lints to run. If nil the global registry of
+	// all lints is used.
+	Registry lint.Registry
+	// BatchSize is the maximum number of certificates linted, produced and
+	// committed together. Values less than 1 use 100.
+	BatchSize int
+	// BatchTimeout is how long to wait for a batch to fill up once its first
+	// message has arrived. Values less than 1 use one second.
+	BatchTimeout time.Duration
+	// Workers is the number of certificates in a batch linted in parallel.
+	// Values less than 1 use runtime.GOMAXPROCS(0).
+	Workers int
+	// Metrics, if set, counts the certificates linted.
+	Metrics *metrics.Metrics
+	// Tracer, if set, is notified as each certificate is linted.
+	Tracer zlint.Tracer
+}
+
+// Result is the JSON value of the message produced for each consumed
+// message. The produced message has the same key as the consumed one.
+type Result struct {
+	Partition int    `json:"partition"`
+	Offset    int64  `json:"offset"`
+	Error     string `json:"error,omitempty"`
+	// ResultSet is nil if the certificate couldn't be decoded or parsed.
+	ResultSet *zlint.ResultSet `json:"results,omitempty"`
+}
+
+// Pipeline consumes base64 encoded DER certificates from a MessageReader and
+// produces a Result for each to a MessageWriter.
+//
+// Delivery is at-least-once: the offsets of a batch are only committed after
+// the results of the whole batch have been produced, so a batch interrupted
+// by a failure or restart is linted again by the next consumer of its
+// partitions, and its results may be produced twice.
+type Pipeline struct {
+	reader MessageReader
+	writer MessageWriter
+	opts   Options
+}
+
+// NewPipeline returns a Pipeline reading from r and writing to w.
+func NewPipeline(r MessageReader, w MessageWriter, opts Options) *Pipeline {
+	if opts.Registry == nil {
+		opts.Registry = lint.GlobalRegistry()
+	}
+	if opts.BatchSize < 1 {
+		opts.BatchSize = 100
+	}
+	if opts.BatchTimeout < 1 {
+		opts.BatchTimeout = time.Second
+	}
+	if opts.Workers < 1 {
+		opts.Workers = runtime.GOMAXPROCS(0)
+	}
+	return &Pipeline{reader: r, writer: w, opts: opts}
+}
+
+// Run processes batches of messages until ctx is done or reading, writing or
+// committing fails. It returns ctx.Err() if ctx is done.
+func (p *Pipeline) Run(ctx context.Context) error {
+	for {
+		batch, err := p.fetchBatch(ctx)
+		if err != nil {
+			return err
+		}
+		if err := p.writer.WriteMessages(ctx, p.lintBatch(batch)...); err != nil {
+			return fmt.Errorf("unable to produce results: %v", err)
+		}
+		if err := p.reader.CommitMessages(ctx, batch...); err != nil {
+			return fmt.Errorf("unable to commit offsets: %v", err)
+		}
+	}
+}
+
+// fetchBatch waits for a message and then reads more until the batch is full
+// or the batch timeout passes.
+func (p *Pipeline) fetchBatch(ctx context.Context) ([]kafka.Message, error) {
+	msg, err := p.reader.FetchMessage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	batch := append(make([]kafka.Message, 0, p.opts.BatchSize), msg)
+	fillCtx, cancel := context.WithTimeout(ctx, p.opts.BatchTimeout)
+	defer cancel()
+	for len(batch) < p.opts.BatchSize {
+		msg, err := p.reader.FetchMessage(fillCtx)
+		if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		batch = append(batch, msg)
+	}
+	return batch, nil
+}
+
+// lintBatch returns the result messages to produce for batch, in order.
+func (p *Pipeline) lintBatch(batch []kafka.Message) []kafka.Message {
+	results := make([]kafka.Message, len(batch))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < p.opts.Workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = p.lintMessage(batch[i])
+			}
+		}()
+	}
+	for i := range batch {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return results
+}
+
+// lintMessage lints the certificate in msg, returning the result message.
+func (p *Pipeline) lintMessage(msg kafka.Message) kafka.Message {
+	result := Result{Partition: msg.Partition, Offset: msg.Offset}
+	if c, err := parseMessage(msg.Value); err != nil {
+		result.Error = err.Error()
+		if p.opts.Metrics != nil {
+			p.opts.Metrics.ObserveFailure()
+		}
+	} else {
+		start := time.Now()
+		result.ResultSet = zlint.LintCertificateWithOptions(c, zlint.Options{
+			Registry: p.opts.Registry,
+			Tracer:   p.opts.Tracer,
+		})
+		if p.opts.Metrics != nil {
+			p.opts.Metrics.Observe(result.ResultSet, time.Since(start))
+		}
+	}
+	value, err := json.Marshal(result)
+	if err != nil {
+		// Not all certificates can be marshalled to JSON, but the results
+		// of linting them can be; this only guards against the unexpected.
+		value, _ = json.Marshal(Result{Partition: msg.Partition, Offset: msg.Offset, Error: err.Error()})
+	}
+	return kafka.Message{Key: msg.Key, Value: value}
+}
+
+// parseMessage parses the base64 encoded DER certificate in value.
+func parseMessage(value []byte) (*x509.Certificate, error) {
+	value = bytes.TrimSpace(value)
+	der := make([]byte, base64.StdEncoding.DecodedLen(len(value)))
+	n, err := base64.StdEncoding.Decode(der, value)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode base64 certificate: %v", err)
+	}
+	return x509.ParseCertificate(der[:n])
+}
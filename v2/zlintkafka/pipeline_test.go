@@ -0,0 +1,162 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package zlintkafka
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/zmap/zlint/v2/lint"
+	"github.com/zmap/zlint/v2/metrics"
+)
+
+// testReader serves messages and records the committed offsets. Once the
+// messages run out FetchMessage blocks until its context is done.
+type testReader struct {
+	messages  chan kafka.Message
+	committed []int64
+}
+
+func (r *testReader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	select {
+	case msg := <-r.messages:
+		return msg, nil
+	case <-ctx.Done():
+		return kafka.Message{}, ctx.Err()
+	}
+}
+
+func (r *testReader) CommitMessages(ctx context.Context, msgs ...kafka.Message) error {
+	for _, msg := range msgs {
+		r.committed = append(r.committed, msg.Offset)
+	}
+	return nil
+}
+
+// testWriter records the produced messages, failing once err is set.
+type testWriter struct {
+	written []kafka.Message
+	batches int
+	err     error
+	// done is called after each batch is written.
+	done func()
+}
+
+func (w *testWriter) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
+	if w.err != nil {
+		return w.err
+	}
+	w.written = append(w.written, msgs...)
+	w.batches++
+	if w.done != nil {
+		w.done()
+	}
+	return nil
+}
+
+func newTestReader(t *testing.T, n int) *testReader {
+	data, err := ioutil.ReadFile("../testdata/evAllGood.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(data)
+	r := &testReader{messages: make(chan kafka.Message, n)}
+	for i := 0; i < n; i++ {
+		value := []byte(base64.StdEncoding.EncodeToString(block.Bytes) + "\n")
+		if i == 2 {
+			value = []byte("not base64")
+		}
+		r.messages <- kafka.Message{Key: []byte(fmt.Sprint(i)), Offset: int64(i), Value: value}
+	}
+	return r
+}
+
+func TestPipeline(t *testing.T) {
+	registry, err := lint.GlobalRegistry().Filter(lint.FilterOptions{IncludeSources: lint.SourceList{lint.CABFEVGuidelines}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reader := newTestReader(t, 5)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	writer := &testWriter{}
+	writer.done = func() {
+		if len(writer.written) == 5 {
+			cancel()
+		}
+	}
+	m := metrics.New()
+	p := NewPipeline(reader, writer, Options{Registry: registry, BatchSize: 2, BatchTimeout: 10 * time.Millisecond, Metrics: m})
+	if err := p.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the pipeline to run until cancelled, got %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := m.Write(&out); err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{"zlint_certificates_total 4\n", "zlint_certificate_failures_total 1\n"} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("expected metrics to contain %q, got:\n%s", expected, out.String())
+		}
+	}
+	if writer.batches != 3 {
+		t.Errorf("expected 3 batches, got %d", writer.batches)
+	}
+	if len(reader.committed) != 5 {
+		t.Errorf("expected all 5 offsets to be committed, got %v", reader.committed)
+	}
+	for i, msg := range writer.written {
+		if string(msg.Key) != fmt.Sprint(i) {
+			t.Errorf("expected result %d to have key %d, got %q", i, i, msg.Key)
+		}
+		var result Result
+		if err := json.Unmarshal(msg.Value, &result); err != nil {
+			t.Fatal(err)
+		}
+		if result.Offset != int64(i) {
+			t.Errorf("expected result %d for offset %d, got %d", i, i, result.Offset)
+		}
+		if i == 2 {
+			if result.Error == "" || result.ResultSet != nil {
+				t.Errorf("expected an error for invalid base64, got %+v", result)
+			}
+		} else if _, ok := result.ResultSet.Results["e_ev_jurisdiction_country_missing"]; !ok {
+			t.Errorf("expected CABF EV results for offset %d", i)
+		}
+	}
+}
+
+func TestPipelineWriteFailure(t *testing.T) {
+	reader := newTestReader(t, 3)
+	writer := &testWriter{err: errors.New("broker unavailable")}
+	p := NewPipeline(reader, writer, Options{BatchSize: 3})
+	if err := p.Run(context.Background()); err == nil {
+		t.Fatal("expected an error when producing results fails")
+	}
+	if len(reader.committed) != 0 {
+		t.Errorf("expected no offsets to be committed before results are produced, got %v", reader.committed)
+	}
+}
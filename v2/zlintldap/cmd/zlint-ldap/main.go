@@ -0,0 +1,76 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+// zlint-ldap lints the certificates published in the userCertificate and
+// cACertificate attributes of an LDAP directory, writing the results to
+// stdout as NDJSON.
+package main
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"flag"
+	"net/url"
+	"os"
+
+	"github.com/go-ldap/ldap/v3"
+	log "github.com/sirupsen/logrus"
+	"github.com/zmap/zlint/v2/zlintldap"
+)
+
+func main() {
+	directoryURL := flag.String("url", "", "URL of the directory, e.g. ldaps://dc.example.com")
+	startTLS := flag.Bool("starttls", false, "Upgrade an ldap:// connection to TLS with StartTLS before binding")
+	bindDN := flag.String("bind-dn", "", "DN to bind as (default anonymous); the password is read from $ZLINT_LDAP_PASSWORD")
+	baseDN := flag.String("base-dn", "", "DN of the subtree to search, e.g. DC=example,DC=com")
+	filter := flag.String("filter", zlintldap.DefaultFilter, "Filter selecting the entries to lint the certificates of")
+	pageSize := flag.Uint("page-size", 500, "Number of entries requested at a time")
+	flag.Parse()
+	if *directoryURL == "" || *baseDN == "" {
+		log.Fatal("-url and -base-dn are required")
+	}
+	u, err := url.Parse(*directoryURL)
+	if err != nil {
+		log.Fatalf("invalid -url: %s", err)
+	}
+
+	conn, err := ldap.DialURL(*directoryURL)
+	if err != nil {
+		log.Fatalf("unable to connect to %s: %s", *directoryURL, err)
+	}
+	defer conn.Close()
+	if *startTLS {
+		if err := conn.StartTLS(&tls.Config{ServerName: u.Hostname()}); err != nil {
+			log.Fatalf("unable to start TLS: %s", err)
+		}
+	}
+	if *bindDN != "" {
+		err = conn.Bind(*bindDN, os.Getenv("ZLINT_LDAP_PASSWORD"))
+	} else {
+		err = conn.UnauthenticatedBind("")
+	}
+	if err != nil {
+		log.Fatalf("unable to bind: %s", err)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	err = zlintldap.Scan(conn, zlintldap.Options{
+		BaseDN:   *baseDN,
+		Filter:   *filter,
+		PageSize: uint32(*pageSize),
+	}, func(result zlintldap.Result) error { return enc.Encode(result) })
+	if err != nil {
+		log.Fatal(err)
+	}
+}
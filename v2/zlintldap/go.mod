@@ -0,0 +1,21 @@
+module github.com/zmap/zlint/v2/zlintldap
+
+go 1.26.0
+
+require (
+	github.com/go-ldap/ldap/v3 v3.4.14
+	github.com/sirupsen/logrus v1.10.2
+	github.com/zmap/zcrypto v0.0.0-20200513165325-16679db567ff
+	github.com/zmap/zlint/v2 v2.3.0
+)
+
+require (
+	github.com/Azure/go-ntlmssp v0.1.1 // indirect
+	github.com/go-asn1-ber/asn1-ber v1.5.8 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/weppos/publicsuffix-go v0.4.0 // indirect
+	golang.org/x/crypto v0.54.0 // indirect
+	golang.org/x/net v0.57.0 // indirect
+	golang.org/x/sys v0.47.0 // indirect
+	golang.org/x/text v0.40.0 // indirect
+)
@@ -0,0 +1,112 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+// Package zlintldap lints the certificates published in an LDAP directory,
+// such as Active Directory, in userCertificate and cACertificate attributes.
+package zlintldap
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2"
+	"github.com/zmap/zlint/v2/lint"
+)
+
+// DefaultFilter selects the entries with a userCertificate or cACertificate.
+const DefaultFilter = "(|(userCertificate=*)(cACertificate=*))"
+
+// certificateAttributes are the attributes requested from the directory.
+// Some directories, such as OpenLDAP, only return certificates when they are
+// requested with the ;binary option.
+var certificateAttributes = []string{
+	"userCertificate", "userCertificate;binary",
+	"cACertificate", "cACertificate;binary",
+}
+
+// Searcher is the part of *ldap.Conn used by Scan.
+type Searcher interface {
+	SearchWithPaging(req *ldap.SearchRequest, pagingSize uint32) (*ldap.SearchResult, error)
+}
+
+// Options configures a Scan.
+type Options struct {
+	// Registry is the registry of lints to run. If nil the global registry of
+	// all lints is used.
+	Registry lint.Registry
+	// BaseDN is the DN of the subtree searched.
+	BaseDN string
+	// Filter selects the entries searched for certificates. Defaults to
+	// DefaultFilter.
+	Filter string
+	// PageSize is the number of entries requested at a time. Values less
+	// than 1 use 500.
+	PageSize uint32
+}
+
+// Result is the result of linting a certificate found in the directory.
+type Result struct {
+	DN string `json:"dn"`
+	// Attribute is the attribute holding the certificate, without options.
+	Attribute string `json:"attribute"`
+	// Index is the index of the certificate among the attribute's values.
+	Index int    `json:"index"`
+	Error string `json:"error,omitempty"`
+	// ResultSet is nil if the certificate couldn't be parsed.
+	ResultSet *zlint.ResultSet `json:"results,omitempty"`
+}
+
+// Scan searches the subtree at opts.BaseDN and lints each certificate in the
+// userCertificate and cACertificate attributes of the entries found, passing
+// the results to write. It stops at the first error searching or returned by
+// write.
+func Scan(s Searcher, opts Options, write func(Result) error) error {
+	if opts.Registry == nil {
+		opts.Registry = lint.GlobalRegistry()
+	}
+	if opts.Filter == "" {
+		opts.Filter = DefaultFilter
+	}
+	if opts.PageSize < 1 {
+		opts.PageSize = 500
+	}
+	req := ldap.NewSearchRequest(opts.BaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases,
+		0, 0, false, opts.Filter, certificateAttributes, nil)
+	res, err := s.SearchWithPaging(req, opts.PageSize)
+	if err != nil {
+		return fmt.Errorf("unable to search %s: %v", opts.BaseDN, err)
+	}
+	for _, entry := range res.Entries {
+		for _, attr := range entry.Attributes {
+			name := strings.SplitN(attr.Name, ";", 2)[0]
+			if !strings.EqualFold(name, "userCertificate") && !strings.EqualFold(name, "cACertificate") {
+				continue
+			}
+			for i, der := range attr.ByteValues {
+				result := Result{DN: entry.DN, Attribute: name, Index: i}
+				if c, err := x509.ParseCertificate(der); err != nil {
+					result.Error = fmt.Sprintf("unable to parse certificate: %v", err)
+				} else {
+					result.ResultSet = zlint.LintCertificateEx(c, opts.Registry)
+				}
+				if err := write(result); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
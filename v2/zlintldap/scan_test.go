@@ -0,0 +1,101 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package zlintldap
+
+import (
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/zmap/zlint/v2/lint"
+)
+
+type fakeDirectory struct {
+	req     *ldap.SearchRequest
+	entries []*ldap.Entry
+	err     error
+}
+
+func (d *fakeDirectory) SearchWithPaging(req *ldap.SearchRequest, pagingSize uint32) (*ldap.SearchResult, error) {
+	d.req = req
+	return &ldap.SearchResult{Entries: d.entries}, d.err
+}
+
+func TestScan(t *testing.T) {
+	pemBytes, err := ioutil.ReadFile("../testdata/badRsaExp.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(pemBytes)
+	dir := &fakeDirectory{entries: []*ldap.Entry{
+		{
+			DN: "CN=Alice,OU=Users,DC=example,DC=com",
+			Attributes: []*ldap.EntryAttribute{
+				{Name: "userCertificate", ByteValues: [][]byte{block.Bytes, []byte("not a certificate")}},
+			},
+		},
+		{
+			DN: "CN=Issuing CA,CN=AIA,CN=Public Key Services,DC=example,DC=com",
+			Attributes: []*ldap.EntryAttribute{
+				{Name: "cACertificate;binary", ByteValues: [][]byte{block.Bytes}},
+				{Name: "description", ByteValues: [][]byte{[]byte("ignored")}},
+			},
+		},
+	}}
+	registry, err := lint.GlobalRegistry().Filter(lint.FilterOptions{IncludeNames: []string{"e_rsa_public_exponent_not_odd"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var results []Result
+	err = Scan(dir, Options{Registry: registry, BaseDN: "DC=example,DC=com"}, func(r Result) error {
+		results = append(results, r)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dir.req.BaseDN != "DC=example,DC=com" || dir.req.Filter != DefaultFilter || dir.req.Scope != ldap.ScopeWholeSubtree {
+		t.Errorf("unexpected search request %+v", dir.req)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	expected := []struct {
+		attribute string
+		index     int
+		failed    bool
+	}{
+		{"userCertificate", 0, false},
+		{"userCertificate", 1, true},
+		{"cACertificate", 0, false},
+	}
+	for i, e := range expected {
+		r := results[i]
+		if r.Attribute != e.attribute || r.Index != e.index || (r.Error != "") != e.failed {
+			t.Errorf("result %d: expected %s[%d] (error %v), got %+v", i, e.attribute, e.index, e.failed, r)
+		}
+		if !e.failed && r.ResultSet.Results["e_rsa_public_exponent_not_odd"].Status != lint.Error {
+			t.Errorf("result %d: unexpected results %+v", i, r.ResultSet.Results)
+		}
+	}
+
+	dir.err = errors.New("size limit exceeded")
+	if err := Scan(dir, Options{Registry: registry}, func(Result) error { return nil }); err == nil {
+		t.Error("expected the search error to be returned")
+	}
+}
@@ -0,0 +1,64 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+// zlint-nats lints certificates published to a NATS subject, publishing the
+// results to each message's reply subject.
+package main
+
+import (
+	"flag"
+	"os"
+	"os/signal"
+
+	"github.com/nats-io/nats.go"
+	log "github.com/sirupsen/logrus"
+	"github.com/zmap/zlint/v2/zlintnats"
+)
+
+func main() {
+	url := flag.String("server", nats.DefaultURL, "NATS server URL")
+	subject := flag.String("subject", "", "Subject to receive certificates on")
+	queue := flag.String("queue", "zlint", "Queue group sharing the certificates published to -subject")
+	resultSubject := flag.String("result-subject", "", "Subject to publish results to for messages without a reply subject")
+	workers := flag.Int("workers", 0, "Number of certificates linted in parallel (default GOMAXPROCS)")
+	flag.Parse()
+	if *subject == "" {
+		log.Fatal("-subject is required")
+	}
+
+	closed := make(chan struct{})
+	nc, err := nats.Connect(*url, nats.ClosedHandler(func(*nats.Conn) { close(closed) }))
+	if err != nil {
+		log.Fatalf("unable to connect to NATS: %s", err)
+	}
+	_, err = zlintnats.Subscribe(nc, *subject, zlintnats.Options{
+		Queue:         *queue,
+		ResultSubject: *resultSubject,
+		Workers:       *workers,
+	})
+	if err != nil {
+		log.Fatalf("unable to subscribe: %s", err)
+	}
+	log.Infof("linting certificates published to %s", *subject)
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	<-interrupt
+	// Draining the connection publishes the results of the certificates
+	// already received before closing it.
+	if err := nc.Drain(); err != nil {
+		log.Fatalf("unable to drain connection: %s", err)
+	}
+	<-closed
+}
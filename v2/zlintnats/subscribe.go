@@ -0,0 +1,155 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+// Package zlintnats lints certificates published to a NATS subject,
+// publishing the results to the reply subject of each message.
+package zlintnats
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"runtime"
+
+	"github.com/nats-io/nats.go"
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2"
+	"github.com/zmap/zlint/v2/lint"
+)
+
+// Options configures Subscribe.
+type Options struct {
+	// Registry is the registry of lints to run. If nil the global registry of
+	// all lints is used.
+	Registry lint.Registry
+	// Queue is the queue group the subscriptions join, so that each
+	// certificate is linted by only one subscriber. Defaults to "zlint".
+	Queue string
+	// ResultSubject is the subject results are published to for messages
+	// without a reply subject. Such messages are dropped if it is empty.
+	ResultSubject string
+	// Workers is the number of certificates linted in parallel. Values less
+	// than 1 use runtime.GOMAXPROCS(0).
+	Workers int
+}
+
+// Result is the JSON message published for each certificate.
+type Result struct {
+	Error string `json:"error,omitempty"`
+	// ResultSet is nil if the certificate couldn't be parsed.
+	ResultSet *zlint.ResultSet `json:"results,omitempty"`
+}
+
+// Subscription is a set of subscriptions linting certificates in parallel.
+type Subscription struct {
+	subs []*nats.Subscription
+}
+
+// Subscribe subscribes to subject on nc, linting each message as a PEM, DER
+// or base64 encoded certificate and publishing a Result to its reply subject
+// (or opts.ResultSubject). Messages are linted in parallel by opts.Workers
+// subscriptions in the same queue group.
+func Subscribe(nc *nats.Conn, subject string, opts Options) (*Subscription, error) {
+	if opts.Registry == nil {
+		opts.Registry = lint.GlobalRegistry()
+	}
+	if opts.Queue == "" {
+		opts.Queue = "zlint"
+	}
+	if opts.Workers < 1 {
+		opts.Workers = runtime.GOMAXPROCS(0)
+	}
+	handler := func(msg *nats.Msg) {
+		reply := msg.Reply
+		if reply == "" {
+			reply = opts.ResultSubject
+		}
+		if reply == "" {
+			return
+		}
+		// Publish only fails if the connection is closed, in which case
+		// there is no one to tell.
+		_ = nc.Publish(reply, lintMessage(msg.Data, opts.Registry))
+	}
+	s := &Subscription{}
+	for i := 0; i < opts.Workers; i++ {
+		sub, err := nc.QueueSubscribe(subject, opts.Queue, handler)
+		if err != nil {
+			_ = s.Unsubscribe()
+			return nil, err
+		}
+		s.subs = append(s.subs, sub)
+	}
+	return s, nil
+}
+
+// Drain stops receiving new certificates, letting those already received be
+// linted and their results published.
+func (s *Subscription) Drain() error {
+	var firstErr error
+	for _, sub := range s.subs {
+		if err := sub.Drain(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Unsubscribe stops receiving certificates immediately.
+func (s *Subscription) Unsubscribe() error {
+	var firstErr error
+	for _, sub := range s.subs {
+		if err := sub.Unsubscribe(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// lintMessage returns the JSON Result of linting the certificate in data.
+func lintMessage(data []byte, registry lint.Registry) []byte {
+	var result Result
+	if c, err := parseCertificate(data); err != nil {
+		result.Error = err.Error()
+	} else {
+		result.ResultSet = zlint.LintCertificateEx(c, registry)
+	}
+	out, err := json.Marshal(result)
+	if err != nil {
+		out, _ = json.Marshal(Result{Error: err.Error()})
+	}
+	return out
+}
+
+// parseCertificate parses the PEM, DER or base64 encoded certificate in data.
+func parseCertificate(data []byte) (*x509.Certificate, error) {
+	trimmed := bytes.TrimSpace(data)
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("-----BEGIN")) || bytes.Contains(trimmed, []byte("\n-----BEGIN")):
+		block, _ := pem.Decode(trimmed)
+		if block == nil {
+			return nil, errors.New("unable to decode PEM certificate")
+		}
+		return x509.ParseCertificate(block.Bytes)
+	case len(data) > 0 && data[0] == 0x30:
+		return x509.ParseCertificate(data)
+	}
+	der, err := base64.StdEncoding.DecodeString(string(trimmed))
+	if err != nil {
+		return nil, errors.New("unable to decode certificate: expected PEM, DER or base64")
+	}
+	return x509.ParseCertificate(der)
+}
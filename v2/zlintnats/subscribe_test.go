@@ -0,0 +1,120 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package zlintnats
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/zmap/zlint/v2/lint"
+)
+
+// newTestConn returns a connection to an in-process NATS server.
+func newTestConn(t *testing.T) *nats.Conn {
+	ns, err := server.NewServer(&server.Options{Host: "127.0.0.1", Port: -1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	go ns.Start()
+	t.Cleanup(ns.Shutdown)
+	if !ns.ReadyForConnections(5 * time.Second) {
+		t.Fatal("NATS server didn't start")
+	}
+	nc, err := nats.Connect(ns.ClientURL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(nc.Close)
+	return nc
+}
+
+func TestSubscribe(t *testing.T) {
+	nc := newTestConn(t)
+	registry, err := lint.GlobalRegistry().Filter(lint.FilterOptions{IncludeSources: lint.SourceList{lint.CABFEVGuidelines}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Subscribe(nc, "certs", Options{Registry: registry, ResultSubject: "results", Workers: 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	pemBytes, err := ioutil.ReadFile("../testdata/evAllGood.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(pemBytes)
+	testCases := []struct {
+		name  string
+		data  []byte
+		valid bool
+	}{
+		{"pem", pemBytes, true},
+		{"der", block.Bytes, true},
+		{"base64", []byte(base64.StdEncoding.EncodeToString(block.Bytes)), true},
+		{"invalid", []byte("not a certificate"), false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			msg, err := nc.Request("certs", tc.data, 5*time.Second)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkResult(t, msg.Data, tc.valid)
+		})
+	}
+
+	// Results for messages without a reply subject go to the result subject.
+	results, err := nc.SubscribeSync("results")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := nc.Publish("certs", pemBytes); err != nil {
+		t.Fatal(err)
+	}
+	msg, err := results.NextMsg(5 * time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkResult(t, msg.Data, true)
+}
+
+func checkResult(t *testing.T, data []byte, valid bool) {
+	t.Helper()
+	var result Result
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatal(err)
+	}
+	if !valid {
+		if result.Error == "" || result.ResultSet != nil {
+			t.Errorf("expected an error, got %+v", result)
+		}
+		return
+	}
+	if result.Error != "" {
+		t.Fatalf("unexpected error %q", result.Error)
+	}
+	if _, ok := result.ResultSet.Results["e_ev_jurisdiction_country_missing"]; !ok {
+		t.Error("expected results for CABF EV lints")
+	}
+	if _, ok := result.ResultSet.Results["e_sub_cert_aia_missing"]; ok {
+		t.Error("expected no results for lints from other sources")
+	}
+}
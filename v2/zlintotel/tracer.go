@@ -0,0 +1,105 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+// Package zlintotel instruments linting with OpenTelemetry, recording a span
+// and metrics for each certificate linted.
+package zlintotel
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2"
+	"github.com/zmap/zlint/v2/lint"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies the tracer and meter used.
+const instrumentationName = "github.com/zmap/zlint/v2/zlintotel"
+
+// Attribute keys set on spans and metrics.
+const (
+	FingerprintKey = attribute.Key("zlint.certificate.sha256")
+	WorstStatusKey = attribute.Key("zlint.worst_status")
+	LintsKey       = attribute.Key("zlint.lints")
+)
+
+// Tracer implements zlint.Tracer, recording a "zlint.LintCertificate" span for
+// each certificate linted with its SHA-256 fingerprint and the worst status of
+// its results as attributes. It also counts certificates by worst status
+// (zlint.certificates) and records the time taken to lint them
+// (zlint.certificate.duration).
+type Tracer struct {
+	ctx          context.Context
+	tracer       trace.Tracer
+	certificates metric.Int64Counter
+	duration     metric.Float64Histogram
+}
+
+// New returns a Tracer recording spans with tp and metrics with mp.
+func New(tp trace.TracerProvider, mp metric.MeterProvider) (*Tracer, error) {
+	meter := mp.Meter(instrumentationName)
+	certificates, err := meter.Int64Counter("zlint.certificates",
+		metric.WithDescription("Certificates linted, by the worst status of their results."),
+		metric.WithUnit("{certificate}"))
+	if err != nil {
+		return nil, err
+	}
+	duration, err := meter.Float64Histogram("zlint.certificate.duration",
+		metric.WithDescription("Time taken to lint a certificate."),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+	return &Tracer{
+		ctx:          context.Background(),
+		tracer:       tp.Tracer(instrumentationName),
+		certificates: certificates,
+		duration:     duration,
+	}, nil
+}
+
+// WithContext returns a copy of t whose spans are children of the span in
+// ctx, e.g. the span of the request a server is linting a certificate for.
+func (t *Tracer) WithContext(ctx context.Context) *Tracer {
+	t2 := *t
+	t2.ctx = ctx
+	return &t2
+}
+
+// StartCertificate implements zlint.Tracer.
+func (t *Tracer) StartCertificate(c *x509.Certificate) func(*zlint.ResultSet) {
+	fingerprint := sha256.Sum256(c.Raw)
+	fingerprintAttr := FingerprintKey.String(hex.EncodeToString(fingerprint[:]))
+	ctx, span := t.tracer.Start(t.ctx, "zlint.LintCertificate",
+		trace.WithAttributes(fingerprintAttr))
+	start := time.Now()
+	return func(rs *zlint.ResultSet) {
+		worst := rs.MaxStatus()
+		status := WorstStatusKey.String(worst.String())
+		span.SetAttributes(status, LintsKey.Int(len(rs.Results)))
+		if worst >= lint.Error {
+			span.SetStatus(codes.Error, "certificate has "+worst.String()+" results")
+		}
+		span.End()
+		t.certificates.Add(ctx, 1, metric.WithAttributes(status))
+		t.duration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(status))
+	}
+}
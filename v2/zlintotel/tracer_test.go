@@ -0,0 +1,97 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package zlintotel
+
+import (
+	"context"
+	"encoding/pem"
+	"io/ioutil"
+	"testing"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2"
+	"github.com/zmap/zlint/v2/lint"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracer(t *testing.T) {
+	data, err := ioutil.ReadFile("../testdata/badRsaExp.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(data)
+	c, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spans := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	tracer, err := New(tp, mp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The certificate's span is a child of the span in the tracer's context.
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "request")
+	rs := zlint.LintCertificateWithOptions(c, zlint.Options{Tracer: tracer.WithContext(ctx)})
+	parent.End()
+	if rs.Results["e_rsa_public_exponent_not_odd"].Status != lint.Error {
+		t.Fatal("expected badRsaExp.pem to have an error")
+	}
+
+	ended := spans.Ended()
+	if len(ended) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(ended))
+	}
+	span := ended[0]
+	if span.Name() != "zlint.LintCertificate" || span.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("expected a zlint.LintCertificate span under the request span, got %s", span.Name())
+	}
+	attrs := attribute.NewSet(span.Attributes()...)
+	if v, _ := attrs.Value(WorstStatusKey); v.AsString() != rs.MaxStatus().String() {
+		t.Errorf("expected worst status %s, got %s", rs.MaxStatus(), v.AsString())
+	}
+	if v, _ := attrs.Value(FingerprintKey); len(v.AsString()) != 64 {
+		t.Errorf("expected a SHA-256 fingerprint, got %q", v.AsString())
+	}
+	if span.Status().Code != codes.Error {
+		t.Errorf("expected an error span status, got %v", span.Status().Code)
+	}
+
+	var metrics metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &metrics); err != nil {
+		t.Fatal(err)
+	}
+	found := map[string]bool{}
+	for _, sm := range metrics.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			found[m.Name] = true
+			if sum, ok := m.Data.(metricdata.Sum[int64]); ok && (len(sum.DataPoints) != 1 || sum.DataPoints[0].Value != 1) {
+				t.Errorf("expected %s to count one certificate, got %+v", m.Name, sum.DataPoints)
+			}
+		}
+	}
+	if !found["zlint.certificates"] || !found["zlint.certificate.duration"] {
+		t.Errorf("expected certificate count and duration metrics, got %v", found)
+	}
+}
@@ -0,0 +1,78 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+// zlint-sql lints the certificates stored in a SQL database table, writing the
+// results back to a column of the table or to stdout as NDJSON.
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"flag"
+	"os"
+
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	log "github.com/sirupsen/logrus"
+	"github.com/zmap/zlint/v2/zlintsql"
+	_ "modernc.org/sqlite"
+)
+
+func main() {
+	driver := flag.String("driver", "pgx", "Database driver: pgx (PostgreSQL), mysql or sqlite")
+	dsn := flag.String("dsn", "", "Data source name of the database, e.g. postgres://user@host/certs (also read from $ZLINT_SQL_DSN)")
+	table := flag.String("table", "", "Table holding the certificates")
+	idColumn := flag.String("id-column", "id", "Unique key column of -table, which rows are read in order of")
+	column := flag.String("column", "", "Column of -table holding each DER or PEM certificate")
+	where := flag.String("where", "", "SQL condition selecting the rows to lint, e.g. \"issued_at > '2020-01-01'\"")
+	resultColumn := flag.String("result-column", "", "Column of -table to write each row's results to as JSON, rather than writing them to stdout")
+	batchSize := flag.Int("batch-size", 500, "Number of rows read, linted and written back together")
+	workers := flag.Int("workers", 0, "Number of certificates linted in parallel (default GOMAXPROCS)")
+	flag.Parse()
+	if *dsn == "" {
+		*dsn = os.Getenv("ZLINT_SQL_DSN")
+	}
+	if *dsn == "" || *table == "" || *column == "" {
+		log.Fatal("-dsn, -table and -column are required")
+	}
+
+	db, err := sql.Open(*driver, *dsn)
+	if err != nil {
+		log.Fatalf("unable to open database: %s", err)
+	}
+	defer db.Close()
+	scanner, err := zlintsql.NewScanner(db, zlintsql.Options{
+		Table:              *table,
+		IDColumn:           *idColumn,
+		CertificateColumn:  *column,
+		Where:              *where,
+		ResultColumn:       *resultColumn,
+		DollarPlaceholders: *driver == "pgx",
+		BatchSize:          *batchSize,
+		Workers:            *workers,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var write func(zlintsql.Result) error
+	if *resultColumn == "" {
+		enc := json.NewEncoder(os.Stdout)
+		write = func(result zlintsql.Result) error { return enc.Encode(result) }
+	}
+	if err := scanner.Run(context.Background(), write); err != nil {
+		log.Fatal(err)
+	}
+}
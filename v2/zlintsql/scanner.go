@@ -0,0 +1,292 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+// Package zlintsql lints the certificates stored in a SQL database table,
+// writing the results back to the table or to another sink.
+package zlintsql
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v2"
+	"github.com/zmap/zlint/v2/lint"
+)
+
+// identifier matches the table and column names accepted by NewScanner,
+// which are interpolated into queries.
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// Options configures a Scanner.
+type Options struct {
+	// Registry is the registry of lints to run. If nil the global registry of
+	// all lints is used.
+	Registry lint.Registry
+	// Table is the table holding the certificates.
+	Table string
+	// IDColumn is the table's unique, ordered key column. The table is read
+	// in batches in order of this column. Defaults to "id".
+	IDColumn string
+	// CertificateColumn is the column holding each certificate, DER or PEM
+	// encoded.
+	CertificateColumn string
+	// Where, if set, is an SQL condition restricting the rows linted, e.g.
+	// "issued_at > '2020-01-01'". It is included in queries as is.
+	Where string
+	// ResultColumn, if set, is a column each row's results are written back
+	// to, as JSON.
+	ResultColumn string
+	// DollarPlaceholders uses $1-style query placeholders, as PostgreSQL
+	// requires, rather than ?.
+	DollarPlaceholders bool
+	// BatchSize is the number of rows read, linted and written back together.
+	// Values less than 1 use 500.
+	BatchSize int
+	// Workers is the number of certificates in a batch linted in parallel.
+	// Values less than 1 use runtime.GOMAXPROCS(0).
+	Workers int
+}
+
+// Result is the result of linting a row's certificate.
+type Result struct {
+	// ID is the value of the row's IDColumn.
+	ID    interface{} `json:"id"`
+	Error string      `json:"error,omitempty"`
+	// ResultSet is nil if the certificate couldn't be parsed.
+	ResultSet *zlint.ResultSet `json:"results,omitempty"`
+}
+
+// Scanner lints the certificates in a table.
+type Scanner struct {
+	db   *sql.DB
+	opts Options
+	// first and next select the first batch of rows and the batch after a
+	// given ID.
+	first, next string
+	// update writes a row's results back, if Options.ResultColumn is set.
+	update string
+}
+
+// row is a row read from the table.
+type row struct {
+	id          interface{}
+	certificate []byte
+}
+
+// NewScanner returns a Scanner for the table of db described by opts.
+func NewScanner(db *sql.DB, opts Options) (*Scanner, error) {
+	if opts.Registry == nil {
+		opts.Registry = lint.GlobalRegistry()
+	}
+	if opts.IDColumn == "" {
+		opts.IDColumn = "id"
+	}
+	if opts.BatchSize < 1 {
+		opts.BatchSize = 500
+	}
+	if opts.Workers < 1 {
+		opts.Workers = runtime.GOMAXPROCS(0)
+	}
+	for _, name := range []string{opts.Table, opts.IDColumn, opts.CertificateColumn} {
+		if !identifier.MatchString(name) {
+			return nil, fmt.Errorf("invalid table or column name %q", name)
+		}
+	}
+	if opts.ResultColumn != "" && !identifier.MatchString(opts.ResultColumn) {
+		return nil, fmt.Errorf("invalid column name %q", opts.ResultColumn)
+	}
+
+	s := &Scanner{db: db, opts: opts}
+	where := ""
+	if opts.Where != "" {
+		where = "(" + opts.Where + ") AND "
+	}
+	query := func(cond string) string {
+		if cond = strings.TrimSuffix(where+cond, " AND "); cond != "" {
+			cond = " WHERE " + cond
+		}
+		return fmt.Sprintf("SELECT %s, %s FROM %s%s ORDER BY %s LIMIT %d",
+			opts.IDColumn, opts.CertificateColumn, opts.Table, cond, opts.IDColumn, opts.BatchSize)
+	}
+	s.first = query("")
+	s.next = query(fmt.Sprintf("%s > %s", opts.IDColumn, s.placeholder(1)))
+	if opts.ResultColumn != "" {
+		s.update = fmt.Sprintf("UPDATE %s SET %s = %s WHERE %s = %s",
+			opts.Table, opts.ResultColumn, s.placeholder(1), opts.IDColumn, s.placeholder(2))
+	}
+	return s, nil
+}
+
+// placeholder returns the nth query placeholder.
+func (s *Scanner) placeholder(n int) string {
+	if s.opts.DollarPlaceholders {
+		return fmt.Sprintf("$%d", n)
+	}
+	return "?"
+}
+
+// Run lints every selected row in order of ID, passing each result to write
+// if it isn't nil and, if Options.ResultColumn is set, writing the results of
+// each batch back to the table in a transaction. It stops at the first error
+// reading or updating the table, or returned by write.
+func (s *Scanner) Run(ctx context.Context, write func(Result) error) error {
+	var last interface{}
+	for {
+		rows, err := s.fetchBatch(ctx, last)
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		results := s.lintBatch(rows)
+		if s.update != "" {
+			if err := s.writeBack(ctx, results); err != nil {
+				return err
+			}
+		}
+		if write != nil {
+			for _, result := range results {
+				if err := write(result); err != nil {
+					return err
+				}
+			}
+		}
+		if len(rows) < s.opts.BatchSize {
+			return nil
+		}
+		last = rows[len(rows)-1].id
+	}
+}
+
+// fetchBatch reads the batch of rows following the row with ID last, or the
+// first batch if last is nil.
+func (s *Scanner) fetchBatch(ctx context.Context, last interface{}) ([]row, error) {
+	var rs *sql.Rows
+	var err error
+	if last == nil {
+		rs, err = s.db.QueryContext(ctx, s.first)
+	} else {
+		rs, err = s.db.QueryContext(ctx, s.next, last)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to select certificates: %v", err)
+	}
+	defer rs.Close()
+	var rows []row
+	for rs.Next() {
+		var r row
+		if err := rs.Scan(&r.id, &r.certificate); err != nil {
+			return nil, fmt.Errorf("unable to read certificate row: %v", err)
+		}
+		// Some drivers return text and numeric columns as bytes, which
+		// would be encoded in results as base64.
+		if b, ok := r.id.([]byte); ok {
+			r.id = string(b)
+		}
+		rows = append(rows, r)
+	}
+	if err := rs.Err(); err != nil {
+		return nil, fmt.Errorf("unable to select certificates: %v", err)
+	}
+	return rows, nil
+}
+
+// lintBatch returns the results for rows, in order.
+func (s *Scanner) lintBatch(rows []row) []Result {
+	results := make([]Result, len(rows))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < s.opts.Workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = s.lintRow(rows[i])
+			}
+		}()
+	}
+	for i := range rows {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return results
+}
+
+// lintRow lints the certificate in r.
+func (s *Scanner) lintRow(r row) Result {
+	result := Result{ID: r.id}
+	c, err := parseCertificate(r.certificate)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.ResultSet = zlint.LintCertificateEx(c, s.opts.Registry)
+	return result
+}
+
+// writeBack writes the JSON encoding of each of results to its row's result
+// column in a single transaction.
+func (s *Scanner) writeBack(ctx context.Context, results []Result) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("unable to write results: %v", err)
+	}
+	stmt, err := tx.PrepareContext(ctx, s.update)
+	if err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("unable to write results: %v", err)
+	}
+	for _, result := range results {
+		value, err := json.Marshal(result)
+		if err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("unable to encode results for %v: %v", result.ID, err)
+		}
+		if _, err := stmt.ExecContext(ctx, string(value), result.ID); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("unable to write results for %v: %v", result.ID, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("unable to write results: %v", err)
+	}
+	return nil
+}
+
+// parseCertificate parses a DER or PEM encoded certificate.
+func parseCertificate(data []byte) (*x509.Certificate, error) {
+	if len(data) == 0 {
+		return nil, errors.New("row has no certificate")
+	}
+	if bytes.Contains(data, []byte("-----BEGIN")) {
+		block, _ := pem.Decode(data)
+		if block == nil {
+			return nil, errors.New("unable to decode PEM certificate")
+		}
+		data = block.Bytes
+	}
+	return x509.ParseCertificate(data)
+}
@@ -0,0 +1,150 @@
+/*
+ * ZLint Copyright 2020 Regents of the University of Michigan
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not
+ * use this file except in compliance with the License. You may obtain a copy
+ * of the License at http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+ * implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package zlintsql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/zmap/zlint/v2/lint"
+	_ "modernc.org/sqlite"
+)
+
+func TestScanner(t *testing.T) {
+	pemBytes, err := ioutil.ReadFile("../testdata/badRsaExp.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(pemBytes)
+	dir, err := ioutil.TempDir("", "zlintsql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db, err := sql.Open("sqlite", filepath.Join(dir, "certs.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := db.Exec("CREATE TABLE certs (id INTEGER PRIMARY KEY, cert BLOB, revoked INTEGER, lint_results TEXT)"); err != nil {
+		t.Fatal(err)
+	}
+	// Rows 1-4 are DER, 5 is PEM, 6 isn't a certificate and 7 is revoked.
+	for id := 1; id <= 7; id++ {
+		var cert interface{} = block.Bytes
+		switch id {
+		case 5:
+			cert = string(pemBytes)
+		case 6:
+			cert = []byte("not a certificate")
+		}
+		if _, err := db.Exec("INSERT INTO certs (id, cert, revoked) VALUES (?, ?, ?)", id, cert, id == 7); err != nil {
+			t.Fatal(err)
+		}
+	}
+	registry, err := lint.GlobalRegistry().Filter(lint.FilterOptions{IncludeNames: []string{"e_rsa_public_exponent_not_odd"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := Options{
+		Registry:          registry,
+		Table:             "certs",
+		CertificateColumn: "cert",
+		Where:             "revoked = 0",
+		BatchSize:         2,
+		Workers:           2,
+	}
+
+	t.Run("write", func(t *testing.T) {
+		s, err := NewScanner(db, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var results []Result
+		if err := s.Run(context.Background(), func(r Result) error {
+			results = append(results, r)
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if len(results) != 6 {
+			t.Fatalf("expected 6 results, got %d", len(results))
+		}
+		for i, result := range results {
+			if fmt.Sprint(result.ID) != fmt.Sprint(i+1) {
+				t.Errorf("expected result %d to be for row %d, got %v", i, i+1, result.ID)
+			}
+			if result.ID == int64(6) {
+				if result.Error == "" {
+					t.Error("expected an error for row 6")
+				}
+				continue
+			}
+			if result.ResultSet == nil || result.ResultSet.Results["e_rsa_public_exponent_not_odd"].Status != lint.Error {
+				t.Errorf("row %v: unexpected results %+v (%s)", result.ID, result.ResultSet, result.Error)
+			}
+		}
+	})
+
+	t.Run("write back", func(t *testing.T) {
+		writeBack := opts
+		writeBack.ResultColumn = "lint_results"
+		s, err := NewScanner(db, writeBack)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.Run(context.Background(), nil); err != nil {
+			t.Fatal(err)
+		}
+		rows, err := db.Query("SELECT id, lint_results FROM certs ORDER BY id")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var id int
+			var value sql.NullString
+			if err := rows.Scan(&id, &value); err != nil {
+				t.Fatal(err)
+			}
+			if id == 7 {
+				if value.Valid {
+					t.Errorf("expected no results for the excluded row 7, got %s", value.String)
+				}
+				continue
+			}
+			var result Result
+			if err := json.Unmarshal([]byte(value.String), &result); err != nil {
+				t.Fatalf("row %d: %v", id, err)
+			}
+			if (id == 6) != (result.Error != "") {
+				t.Errorf("row %d: unexpected result %s", id, value.String)
+			}
+		}
+	})
+
+	bad := opts
+	bad.Table = "certs; DROP TABLE certs"
+	if _, err := NewScanner(db, bad); err == nil {
+		t.Error("expected an error for an invalid table name")
+	}
+}